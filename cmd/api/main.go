@@ -4,35 +4,95 @@ import (
 	"computer-management-api/internal/config"
 	"computer-management-api/internal/database"
 	"computer-management-api/internal/handler"
+	"computer-management-api/internal/logging"
+	"computer-management-api/internal/metrics"
 	"computer-management-api/internal/middleware"
+	"computer-management-api/internal/model"
 	"computer-management-api/internal/notification"
-	"computer-management-api/internal/repository"
 	"computer-management-api/internal/router"
+	"computer-management-api/internal/service"
+	notificationadapter "computer-management-api/internal/service/notification"
+	"computer-management-api/internal/tracing"
+	"computer-management-api/pkg/jwt"
+	"computer-management-api/pkg/netconfig"
+	"computer-management-api/pkg/oui"
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 func main() {
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		fatal("Failed to load configuration", err)
+	}
+
+	// Log JSON records at the configured level; the standard logger is routed through it too
+	logLevel, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		fatal("Invalid log level", err)
+	}
+	logger := logging.New(os.Stdout, logLevel)
+	slog.SetDefault(logger)
+
+	// Export traces of requests, queries and notifications
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("Failed to initialize tracing", err)
 	}
 
 	// Initialize database
-	db, err := database.InitDB(cfg)
+	db, store, err := database.Open(cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		fatal("Failed to initialize database", err)
 	}
 	defer db.Close()
 
-	// Initialize repository
-	repo := repository.NewComputerRepository(db)
+	// Run the migrate subcommand instead of the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if cfg.Database.Driver != config.DatabaseDriverPostgres {
+			db.Close()
+			fatal("Migration failed", fmt.Errorf("migrations apply to PostgreSQL only; the %s schema is created when the database is opened", cfg.Database.Driver))
+		}
+		if err := migrate(db, os.Args[2:], logger); err != nil {
+			db.Close()
+			fatal("Migration failed", err)
+		}
+		return
+	}
+
+	// Bring the schema up to date; replicas starting together take turns
+	if cfg.Database.AutoMigrate && cfg.Database.Driver == config.DatabaseDriverPostgres {
+		if err := migrateUp(db, logger); err != nil {
+			fatal("Failed to apply migrations", err)
+		}
+	}
+
+	// Collect metrics for the internal metrics listener
+	var appMetrics *metrics.Metrics
+	if cfg.Server.EnableMetrics {
+		appMetrics = metrics.New(logger)
+		if err := appMetrics.RegisterDB(db, databaseName(cfg.Database)); err != nil {
+			fatal("Failed to register database metrics", err)
+		}
+	}
+
+	// Initialize repositories
+	repo := store.Computers
+	employeeRepo := store.Employees
+	eventRepo := store.Events
+	outboxRepo := store.Outbox
+	policyRepo := store.Policies
+	interfaceRepo := store.Interfaces
+	subnetRepo := store.Subnets
+	apiKeyRepo := store.APIKeys
+	transactor := store.Transactor
 
 	// Initialize notification client with enhanced configuration
 	notificationConfig := notification.NotificationConfig{
@@ -41,21 +101,110 @@ func main() {
 		RetryAttempts:  cfg.NotificationService.RetryAttempts,
 		RetryDelay:     cfg.NotificationService.RetryDelay,
 		MaxPayloadSize: cfg.NotificationService.MaxPayloadSize,
+		Metrics:        appMetrics,
 	}
 	notifier := notification.NewNotifierWithConfig(notificationConfig)
 
-	// Initialize handler with logger
-	logger := log.Default()
-	h := handler.NewComputerHandler(repo, notifier, logger)
+	// Deliver notifications from the outbox through the notification adapter
+	dispatcherConfig := service.DefaultDispatcherConfig()
+	dispatcherConfig.PollInterval = cfg.NotificationService.OutboxPollInterval
+	dispatcherConfig.BatchSize = cfg.NotificationService.OutboxBatchSize
+	dispatcherConfig.MaxAttempts = cfg.NotificationService.OutboxMaxAttempts
+	dispatcherConfig.RetryBackoff = cfg.NotificationService.OutboxRetryBackoff
+	dispatcher := service.NewOutboxDispatcher(outboxRepo, notificationadapter.NewServiceAdapter(notifier), dispatcherConfig, logger)
+	dispatcher.Start()
+
+	// Check the database, notification service and outbox for the readiness probe
+	healthConfig := service.HealthConfig{Timeout: cfg.Server.HealthCheckTimeout}
+	healthService := service.NewHealthService(db, notifier, outboxRepo, healthConfig, logger)
+
+	// Identify MAC address vendors from the IEEE registry files when configured, or the embedded registry
+	vendors, err := newVendorRegistry(cfg.MACAddresses, logger)
+	if err != nil {
+		fatal("Failed to load OUI registry", err)
+	}
+	rejectSuspiciousMACs := cfg.MACAddresses.Policy == config.MACAddressPolicyReject
+
+	// Initialize service layer
+	computerService := service.NewComputerService(repo, employeeRepo, transactor, logger)
+	computerService.RequireHostnames = cfg.NetworkExports.Enabled
+	computerService.Vendors = vendors
+	computerService.RejectSuspiciousMACs = rejectSuspiciousMACs
+	interfaceService := service.NewNetworkInterfaceService(repo, interfaceRepo, transactor, logger)
+	interfaceService.Vendors = vendors
+	interfaceService.RejectSuspiciousMACs = rejectSuspiciousMACs
+	subnetService := service.NewSubnetService(subnetRepo, transactor, logger)
+	subnetService.Vendors = vendors
+	employeeService := service.NewEmployeeService(employeeRepo, logger)
+	historyService := service.NewHistoryService(eventRepo, repo, employeeRepo, logger)
+	policyService := service.NewPolicyService(policyRepo, employeeRepo, logger)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, logger)
+
+	if appMetrics != nil {
+		inventoryService := service.NewInventoryService(store.Inventory, logger)
+		if err := appMetrics.RegisterInventory(inventoryService); err != nil {
+			fatal("Failed to register inventory metrics", err)
+		}
+	}
+
+	// Initialize handlers with logger
+	computerHandler := handler.NewComputerHandler(computerService, logger)
+	computerHandler.RequireIfMatch = cfg.Security.RequireIfMatch
+	interfaceHandler := handler.NewNetworkInterfaceHandler(interfaceService, logger)
+	interfaceHandler.RequireIfMatch = cfg.Security.RequireIfMatch
+	subnetHandler := handler.NewSubnetHandler(subnetService, logger)
+	subnetHandler.RequireIfMatch = cfg.Security.RequireIfMatch
+
+	handlers := router.Handlers{
+		Computer:  computerHandler,
+		Interface: interfaceHandler,
+		Subnet:    subnetHandler,
+		Employee:  handler.NewEmployeeHandler(employeeService, logger),
+		History:   handler.NewHistoryHandler(historyService, logger),
+		Policy:    handler.NewPolicyHandler(policyService, logger),
+		APIKey:    handler.NewAPIKeyHandler(apiKeyService, logger),
+		Health:    handler.NewHealthHandler(healthService, logger),
+	}
+
+	// Generate DHCP and DNS configuration from the inventory when enabled
+	if cfg.NetworkExports.Enabled {
+		zone := netconfig.Zone{
+			NameServer: cfg.NetworkExports.NameServer,
+			Hostmaster: cfg.NetworkExports.Hostmaster,
+			TTL:        cfg.NetworkExports.TTL,
+		}
+		networkConfigService := service.NewNetworkConfigService(repo, interfaceRepo, eventRepo, logger)
+		handlers.NetworkConfig = handler.NewNetworkConfigHandler(networkConfigService, zone, logger)
+	}
+
+	// Wake computers with Wake-on-LAN magic packets when enabled
+	if cfg.WakeOnLAN.Enabled {
+		wakeService := service.NewWakeService(transactor, logger)
+		wakeService.Port = cfg.WakeOnLAN.Port
+		wakeService.MinInterval = cfg.WakeOnLAN.MinInterval
+		handlers.Wake = handler.NewWakeHandler(wakeService, logger)
+	}
+
+	// Accept identity provider tokens when configured
+	var tokens middleware.TokenVerifier
+	if cfg.Security.OIDC.Enabled() {
+		oidcAuthenticator, err := newOIDCAuthenticator(cfg.Security.OIDC, logger)
+		if err != nil {
+			fatal("Failed to initialize OIDC authentication", err)
+		}
+		tokens = oidcAuthenticator
+	}
 
 	// Setup router with security configuration
-	r := router.NewRouter(h, cfg)
+	r := router.NewRouter(handlers, apiKeyService, tokens, appMetrics, cfg)
 
-	// Initialize logging middleware
+	// Initialize logging and tracing middleware
 	loggingMW := middleware.NewLoggingMiddleware(logger)
+	tracingMW := middleware.NewTracingMiddleware(nil, nil)
 
-	// Wrap router with logging middleware
-	finalHandler := loggingMW.LogRequests(r)
+	// Wrap router with logging middleware inside the request span and request ID, so logs carry
+	// both IDs
+	finalHandler := tracingMW.Trace(middleware.RequestID(loggingMW.LogRequests(r)))
 
 	// Configure server with security settings
 	server := &http.Server{
@@ -67,28 +216,58 @@ func main() {
 		MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
 	}
 
+	// Serve metrics and profiling on a separate internal port
+	var metricsServer *http.Server
+	if cfg.Server.EnableMetrics || cfg.Server.EnableProfiling {
+		metricsServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.Server.MetricsPort),
+			Handler:           metrics.NewHandler(appMetrics, cfg.Server.EnableProfiling),
+			ReadHeaderTimeout: cfg.Server.ReadTimeout,
+		}
+		go func() {
+			logger.Info("Starting metrics server", "port", cfg.Server.MetricsPort,
+				"metrics", cfg.Server.EnableMetrics, "profiling", cfg.Server.EnableProfiling)
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("Metrics server failed", "error", err)
+			}
+		}()
+	}
+
 	// Channel to listen for interrupt signal to gracefully shutdown
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
 	// Start server in a goroutine
 	go func() {
-		log.Printf("Starting server on port %d with security features enabled", cfg.Port)
-		log.Printf("Security: Rate limit=%d RPS, Burst=%d, CORS=%v, Timeout=%v",
-			cfg.Security.RateLimitRPS,
-			cfg.Security.RateLimitBurst,
-			cfg.Security.EnableCORS,
-			cfg.Security.RequestTimeout,
+		logger.Info("Starting server with security features enabled", "port", cfg.Port, "log_level", logLevel.String())
+		logger.Info("Security settings",
+			"rate_limit_rps", cfg.Security.RateLimitRPS,
+			"rate_limit_burst", cfg.Security.RateLimitBurst,
+			"cors", cfg.Security.EnableCORS,
+			"timeout", cfg.Security.RequestTimeout,
+			"require_auth", cfg.Security.RequireAuth,
 		)
+		logger.Info("Tracing settings", "exporter", cfg.Tracing.Exporter, "sample_ratio", cfg.Tracing.SampleRatio)
+		if cfg.Security.OIDC.Enabled() {
+			logger.Info("OIDC settings", "issuer", cfg.Security.OIDC.Issuer, "audience", cfg.Security.OIDC.Audience)
+		}
 
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+			fatal("Failed to start server", err)
 		}
 	}()
 
 	// Block until we receive a signal
 	<-done
-	log.Println("Server is shutting down...")
+	logger.Info("Server is shutting down")
+
+	// Fail readiness first and keep serving while load balancers take the server out of
+	// rotation; a second signal skips the wait
+	healthService.BeginShutdown()
+	select {
+	case <-time.After(cfg.Server.ShutdownDrainDelay):
+	case <-done:
+	}
 
 	// Create a deadline for shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Security.ShutdownTimeout)
@@ -96,8 +275,88 @@ func main() {
 
 	// Attempt graceful shutdown
 	if err := server.Shutdown(ctx); err != nil {
-		log.Printf("Server forced to shutdown: %v", err)
+		logger.Error("Server forced to shutdown", "error", err)
 	} else {
-		log.Println("Server exited gracefully")
+		logger.Info("Server exited gracefully")
+	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			logger.Error("Metrics server forced to shutdown", "error", err)
+		}
+	}
+
+	// Deliver the notifications written by the last requests before exiting
+	if err := dispatcher.Shutdown(ctx); err != nil {
+		logger.Error("Notification dispatcher did not drain before shutdown deadline", "error", err)
+	}
+
+	// Export the remaining spans
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("Failed to flush traces", "error", err)
+	}
+}
+
+// fatal logs an error that keeps the server from running and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// newOIDCAuthenticator creates the bearer token authenticator from the identity provider settings
+func newOIDCAuthenticator(cfg config.OIDCConfig, logger *slog.Logger) (*service.OIDCAuthenticator, error) {
+	var keys jwt.KeyProvider
+	if cfg.JWKSFile != "" {
+		keySet, err := jwt.LoadKeySetFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = keySet
+	} else {
+		keys = jwt.NewRemoteKeySet(cfg.JWKSURL, nil, cfg.JWKSRefreshInterval)
+	}
+
+	validator := &jwt.Validator{
+		Keys:     keys,
+		Issuer:   cfg.Issuer,
+		Audience: cfg.Audience,
+		Leeway:   cfg.ClockSkew,
+	}
+
+	oidcConfig := service.OIDCConfig{
+		RolesClaim:    cfg.RolesClaim,
+		RoleMapping:   make(map[string]model.Role, len(cfg.RoleMapping)),
+		EmployeeClaim: cfg.EmployeeClaim,
+		UsernameClaim: cfg.UsernameClaim,
+	}
+	for value, role := range cfg.RoleMapping {
+		oidcConfig.RoleMapping[value] = model.Role(role)
+	}
+
+	return service.NewOIDCAuthenticator(validator, oidcConfig, logger), nil
+}
+
+// newVendorRegistry loads the configured OUI registry files, which are reloaded when they
+// change, or returns the embedded registry
+func newVendorRegistry(cfg config.MACAddressConfig, logger *slog.Logger) (service.VendorRegistry, error) {
+	if len(cfg.OUIFiles) == 0 {
+		return oui.Embedded(), nil
+	}
+
+	registry, err := oui.LoadFiles(cfg.OUIRefreshInterval, cfg.OUIFiles...)
+	if err != nil {
+		return nil, err
+	}
+	registry.OnReloadError = func(err error) {
+		logger.Error("Failed to reload OUI registry, keeping the previous one", "error", err)
+	}
+	logger.Info("Loaded OUI registry", "files", cfg.OUIFiles, "assignments", registry.Len())
+	return registry, nil
+}
+
+// databaseName labels the connection pool metrics with the database in use
+func databaseName(database config.DatabaseConfig) string {
+	if database.Driver == config.DatabaseDriverSQLite {
+		return database.SQLitePath
 	}
+	return database.Name
 }
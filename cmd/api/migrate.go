@@ -0,0 +1,101 @@
+package main
+
+import (
+	"computer-management-api/internal/database"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// migrate runs the migrate subcommand against the configured database:
+//
+//	api migrate up
+//	api migrate down [-steps N]
+//	api migrate status
+func migrate(db *sql.DB, args []string, logger *slog.Logger) error {
+	if len(args) == 0 {
+		migrateUsage()
+	}
+
+	migrator, err := database.NewMigrator(db, logger)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migration(s)\n", applied)
+
+	case "down":
+		flags := flag.NewFlagSet("down", flag.ContinueOnError)
+		steps := flags.Int("steps", 1, "number of migrations to revert")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *steps < 1 {
+			return fmt.Errorf("steps must be at least 1")
+		}
+
+		reverted, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %d migration(s)\n", reverted)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED")
+		for _, status := range statuses {
+			state, appliedAt := "pending", ""
+			if status.AppliedAt != nil {
+				state, appliedAt = "applied", status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Unknown {
+				state = "unknown"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		migrateUsage()
+	}
+	return nil
+}
+
+// migrateUp applies the pending migrations on startup
+func migrateUp(db *sql.DB, logger *slog.Logger) error {
+	migrator, err := database.NewMigrator(db, logger)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		return err
+	}
+	logger.Info("Database schema is up to date", "applied_migrations", applied)
+	return nil
+}
+
+func migrateUsage() {
+	fmt.Fprintf(os.Stderr, "usage: api migrate up\n")
+	fmt.Fprintf(os.Stderr, "       api migrate down [-steps N]\n")
+	fmt.Fprintf(os.Stderr, "       api migrate status\n")
+	os.Exit(2)
+}
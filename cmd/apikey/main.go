@@ -0,0 +1,153 @@
+// Command apikey issues, lists and revokes API keys directly in the database. It reads the same
+// environment variables as the API server and is used to create the first key with the
+// api_keys:manage scope; further keys can be managed through the API.
+//
+//	apikey issue -name inventory-sync -scopes computers:read,computers:write [-expires 720h]
+//	apikey list
+//	apikey revoke -id 3
+package main
+
+import (
+	"computer-management-api/internal/config"
+	"computer-management-api/internal/database"
+	"computer-management-api/internal/logging"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/service"
+	apperrors "computer-management-api/pkg/errors"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	db, store, err := database.Open(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	svc := service.NewAPIKeyService(store.APIKeys, logging.Discard())
+	ctx := service.WithActor(context.Background(), "cli:"+os.Getenv("USER"))
+
+	switch os.Args[1] {
+	case "issue":
+		err = issue(ctx, svc, os.Args[2:])
+	case "list":
+		err = list(ctx, svc)
+	case "revoke":
+		err = revoke(ctx, svc, os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		db.Close()
+		log.Fatal(err)
+	}
+}
+
+// issue creates a key and prints its secret
+func issue(ctx context.Context, svc *service.APIKeyService, args []string) error {
+	flags := flag.NewFlagSet("issue", flag.ExitOnError)
+	name := flags.String("name", "", "name of the client the key is for")
+	scopes := flags.String("scopes", "", "comma-separated scopes to grant")
+	expires := flags.Duration("expires", 0, "lifetime of the key, e.g. 720h (default: never expires)")
+	flags.Parse(args)
+
+	key := model.APIKey{Name: *name}
+	for _, scope := range strings.Split(*scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			key.Scopes = append(key.Scopes, model.Scope(scope))
+		}
+	}
+	if *expires > 0 {
+		expiresAt := time.Now().Add(*expires)
+		key.ExpiresAt = &expiresAt
+	}
+
+	issued, err := svc.IssueAPIKey(ctx, key)
+	if err != nil {
+		return describeError(err)
+	}
+
+	fmt.Printf("Issued API key %d (%s) with scopes %v\n", issued.ID, issued.Name, issued.Scopes)
+	fmt.Println("Store the key now, it cannot be shown again:")
+	fmt.Println(issued.Key)
+	return nil
+}
+
+// list prints every key without its secret
+func list(ctx context.Context, svc *service.APIKeyService) error {
+	keys, err := svc.GetAllAPIKeys(ctx)
+	if err != nil {
+		return describeError(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tSTATUS\tCREATED")
+	for _, key := range keys {
+		status := "active"
+		switch {
+		case key.RevokedAt != nil:
+			status = "revoked"
+		case !key.IsActive(time.Now()):
+			status = "expired"
+		}
+		scopes := make([]string, len(key.Scopes))
+		for i, scope := range key.Scopes {
+			scopes[i] = string(scope)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", key.ID, key.Name, key.Prefix, strings.Join(scopes, ","), status, key.CreatedAt.Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+// revoke revokes a key by ID
+func revoke(ctx context.Context, svc *service.APIKeyService, args []string) error {
+	flags := flag.NewFlagSet("revoke", flag.ExitOnError)
+	id := flags.Int64("id", 0, "ID of the key to revoke")
+	flags.Parse(args)
+
+	if err := svc.RevokeAPIKey(ctx, *id); err != nil {
+		return describeError(err)
+	}
+
+	fmt.Printf("Revoked API key %d\n", *id)
+	return nil
+}
+
+// describeError includes the validation details of an application error
+func describeError(err error) error {
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok || len(appErr.Details) == 0 {
+		return err
+	}
+
+	details := make([]string, 0, len(appErr.Details))
+	for _, detail := range appErr.Details {
+		details = append(details, fmt.Sprint(detail))
+	}
+	sort.Strings(details)
+	return fmt.Errorf("%s: %s", appErr.Message, strings.Join(details, "; "))
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: apikey issue -name NAME -scopes SCOPE[,SCOPE...] [-expires DURATION]\n")
+	fmt.Fprintf(os.Stderr, "       apikey list\n")
+	fmt.Fprintf(os.Stderr, "       apikey revoke -id ID\n")
+	os.Exit(2)
+}
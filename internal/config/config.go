@@ -1,8 +1,11 @@
 package config
 
 import (
+	"computer-management-api/internal/model"
 	"fmt"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -25,10 +28,33 @@ type Config struct {
 
 	// Performance settings
 	Server ServerConfig `validate:"required"`
+
+	// Observability settings
+	Tracing TracingConfig
+
+	// DHCP and DNS configuration exports
+	NetworkExports NetworkExportConfig
+
+	// MAC address vendor lookups and checks
+	MACAddresses MACAddressConfig
+
+	// Waking computers with Wake-on-LAN magic packets
+	WakeOnLAN WakeOnLANConfig
 }
 
+// Database drivers
+const (
+	DatabaseDriverPostgres = "postgres"
+	DatabaseDriverSQLite   = "sqlite"
+)
+
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
+	// Driver is postgres or sqlite. SQLite is meant for development without a database server.
+	Driver string `validate:"oneof=postgres sqlite"`
+	// SQLitePath is the database file of the sqlite driver, or :memory: for a throwaway database
+	SQLitePath string
+
 	Host            string `validate:"required"`
 	Port            int    `validate:"required,min=1,max=65535"`
 	User            string `validate:"required"`
@@ -39,6 +65,8 @@ type DatabaseConfig struct {
 	MaxIdleConns    int    `validate:"min=1"`
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
+	// AutoMigrate applies pending schema migrations on startup
+	AutoMigrate bool
 }
 
 // NotificationConfig holds notification service configuration
@@ -48,6 +76,12 @@ type NotificationConfig struct {
 	RetryAttempts  int           `validate:"min=0,max=10"`
 	RetryDelay     time.Duration
 	MaxPayloadSize int64 `validate:"min=1024"`
+
+	// Outbox dispatcher settings
+	OutboxPollInterval time.Duration `validate:"required"`
+	OutboxBatchSize    int           `validate:"min=1"`
+	OutboxMaxAttempts  int           `validate:"min=1"`
+	OutboxRetryBackoff time.Duration `validate:"required"`
 }
 
 // SecurityConfig holds security-related configuration
@@ -59,6 +93,40 @@ type SecurityConfig struct {
 	EnableCORS      bool
 	AllowedOrigins  []string
 	TrustedProxies  []string
+
+	// RequireIfMatch rejects computer changes without an If-Match header (428 Precondition Required)
+	RequireIfMatch bool
+
+	// RequireAuth rejects requests to protected routes that carry no credentials (401 Unauthorized)
+	RequireAuth bool
+
+	// OIDC enables bearer tokens issued by an OpenID Connect identity provider
+	OIDC OIDCConfig
+}
+
+// OIDCConfig holds the identity provider configuration. Tokens are accepted when an issuer is set.
+type OIDCConfig struct {
+	Issuer string
+	// Audience is required with an issuer, so tokens the identity provider issued to other
+	// clients are not accepted
+	Audience string
+
+	// The signing keys are read from a JWKS file or fetched from a URL, but not both
+	JWKSFile            string
+	JWKSURL             string
+	JWKSRefreshInterval time.Duration
+
+	// RolesClaim is the claim listing roles or groups; RoleMapping maps its values to roles
+	RolesClaim    string
+	RoleMapping   map[string]string
+	EmployeeClaim string
+	UsernameClaim string
+	ClockSkew     time.Duration
+}
+
+// Enabled reports whether bearer tokens are accepted
+func (c OIDCConfig) Enabled() bool {
+	return c.Issuer != ""
 }
 
 // ServerConfig holds server performance configuration
@@ -70,6 +138,65 @@ type ServerConfig struct {
 	EnableMetrics   bool
 	MetricsPort     int `validate:"min=1,max=65535"`
 	EnableProfiling bool
+
+	// HealthCheckTimeout bounds each dependency check of the readiness probe
+	HealthCheckTimeout time.Duration
+	// ShutdownDrainDelay is how long readiness fails before the server stops accepting
+	// requests, so load balancers notice and route traffic elsewhere first
+	ShutdownDrainDelay time.Duration
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration
+type TracingConfig struct {
+	// Exporter is none, stdout, file or otlp
+	Exporter    string `validate:"oneof=none stdout file otlp"`
+	ServiceName string
+	// SampleRatio is the share of new traces that are recorded; incoming sampling decisions are kept
+	SampleRatio float64 `validate:"min=0,max=1"`
+
+	// FilePath receives the spans of the file exporter as JSON
+	FilePath string
+	// OTLPEndpoint is the URL of the OTLP/HTTP collector; the OTEL_EXPORTER_OTLP_* defaults when empty
+	OTLPEndpoint string
+}
+
+// NetworkExportConfig holds the settings of the DHCP and DNS configuration exports
+type NetworkExportConfig struct {
+	// Enabled serves the exports and requires computer names to be DNS hostnames
+	Enabled bool
+	// NameServer and Hostmaster fill the SOA record of zone files, relative to the zone unless
+	// they end with a dot
+	NameServer string
+	Hostmaster string
+	// TTL is the default TTL of the records in zone files
+	TTL time.Duration
+}
+
+// MAC address policies for multicast and locally administered addresses
+const (
+	MACAddressPolicyWarn   = "warn"
+	MACAddressPolicyReject = "reject"
+)
+
+// MACAddressConfig holds the settings of MAC address vendor lookups and checks
+type MACAddressConfig struct {
+	// Policy is warn to accept multicast and locally administered addresses with warnings, or
+	// reject to refuse them
+	Policy string `validate:"oneof=warn reject"`
+	// OUIFiles are IEEE registry CSV files used instead of the embedded registry. They are checked
+	// for changes every OUIRefreshInterval, or never if it is zero.
+	OUIFiles           []string
+	OUIRefreshInterval time.Duration
+}
+
+// WakeOnLANConfig holds the settings of waking computers with Wake-on-LAN magic packets
+type WakeOnLANConfig struct {
+	// Enabled serves the wake endpoint, which sends magic packets from the API server's host
+	Enabled bool
+	// Port is the UDP port magic packets are sent to, usually 9 (discard) or 7 (echo)
+	Port int `validate:"min=1,max=65535"`
+	// MinInterval is how long a computer cannot be woken again after a magic packet was sent to it
+	MinInterval time.Duration
 }
 
 // LoadConfig loads and validates the configuration from environment variables
@@ -80,6 +207,8 @@ func LoadConfig() (*Config, error) {
 		LogLevel: getEnv("LOG_LEVEL", "info"),
 
 		Database: DatabaseConfig{
+			Driver:          getEnv("DB_DRIVER", DatabaseDriverPostgres),
+			SQLitePath:      getEnv("DB_SQLITE_PATH", "computers.db"),
 			Host:            getEnv("DB_HOST", "localhost"),
 			Port:            getEnvAsInt("DB_PORT", 5432),
 			User:            getEnv("DB_USER", ""),
@@ -90,6 +219,7 @@ func LoadConfig() (*Config, error) {
 			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 25),
 			ConnMaxLifetime: getEnvAsDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
 			ConnMaxIdleTime: getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			AutoMigrate:     getEnvAsBool("DB_AUTO_MIGRATE", false),
 		},
 
 		NotificationService: NotificationConfig{
@@ -98,6 +228,11 @@ func LoadConfig() (*Config, error) {
 			RetryAttempts:  getEnvAsInt("NOTIFIER_RETRY_ATTEMPTS", 3),
 			RetryDelay:     getEnvAsDuration("NOTIFIER_RETRY_DELAY", time.Second),
 			MaxPayloadSize: getEnvAsInt64("NOTIFIER_MAX_PAYLOAD_SIZE", 1024*1024),
+
+			OutboxPollInterval: getEnvAsDuration("NOTIFIER_OUTBOX_POLL_INTERVAL", time.Second),
+			OutboxBatchSize:    getEnvAsInt("NOTIFIER_OUTBOX_BATCH_SIZE", 50),
+			OutboxMaxAttempts:  getEnvAsInt("NOTIFIER_OUTBOX_MAX_ATTEMPTS", 5),
+			OutboxRetryBackoff: getEnvAsDuration("NOTIFIER_OUTBOX_RETRY_BACKOFF", 30*time.Second),
 		},
 
 		Security: SecurityConfig{
@@ -108,6 +243,21 @@ func LoadConfig() (*Config, error) {
 			EnableCORS:      getEnvAsBool("ENABLE_CORS", true),
 			AllowedOrigins:  getEnvAsSlice("ALLOWED_ORIGINS", []string{"*"}),
 			TrustedProxies:  getEnvAsSlice("TRUSTED_PROXIES", []string{}),
+			RequireIfMatch:  getEnvAsBool("REQUIRE_IF_MATCH", false),
+			RequireAuth:     getEnvAsBool("REQUIRE_AUTH", false),
+
+			OIDC: OIDCConfig{
+				Issuer:              getEnv("OIDC_ISSUER", ""),
+				Audience:            getEnv("OIDC_AUDIENCE", ""),
+				JWKSFile:            getEnv("OIDC_JWKS_FILE", ""),
+				JWKSURL:             getEnv("OIDC_JWKS_URL", ""),
+				JWKSRefreshInterval: getEnvAsDuration("OIDC_JWKS_REFRESH_INTERVAL", time.Hour),
+				RolesClaim:          getEnv("OIDC_ROLES_CLAIM", "roles"),
+				RoleMapping:         getEnvAsMap("OIDC_ROLE_MAPPING", map[string]string{}),
+				EmployeeClaim:       getEnv("OIDC_EMPLOYEE_CLAIM", "employee_abbreviation"),
+				UsernameClaim:       getEnv("OIDC_USERNAME_CLAIM", "preferred_username"),
+				ClockSkew:           getEnvAsDuration("OIDC_CLOCK_SKEW", time.Minute),
+			},
 		},
 
 		Server: ServerConfig{
@@ -118,6 +268,36 @@ func LoadConfig() (*Config, error) {
 			EnableMetrics:   getEnvAsBool("ENABLE_METRICS", true),
 			MetricsPort:     getEnvAsInt("METRICS_PORT", 9090),
 			EnableProfiling: getEnvAsBool("ENABLE_PROFILING", false),
+
+			HealthCheckTimeout: getEnvAsDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+			ShutdownDrainDelay: getEnvAsDuration("SHUTDOWN_DRAIN_DELAY", 5*time.Second),
+		},
+
+		Tracing: TracingConfig{
+			Exporter:     getEnv("TRACING_EXPORTER", "none"),
+			ServiceName:  getEnv("OTEL_SERVICE_NAME", "computer-management-api"),
+			SampleRatio:  getEnvAsFloat("TRACING_SAMPLE_RATIO", 1),
+			FilePath:     getEnv("TRACING_FILE", ""),
+			OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "")),
+		},
+
+		NetworkExports: NetworkExportConfig{
+			Enabled:    getEnvAsBool("ENABLE_NETWORK_EXPORTS", false),
+			NameServer: getEnv("DNS_NAME_SERVER", "ns1"),
+			Hostmaster: getEnv("DNS_HOSTMASTER", "hostmaster"),
+			TTL:        getEnvAsDuration("DNS_TTL", time.Hour),
+		},
+
+		MACAddresses: MACAddressConfig{
+			Policy:             getEnv("MAC_ADDRESS_POLICY", MACAddressPolicyWarn),
+			OUIFiles:           getEnvAsSlice("OUI_FILES", nil),
+			OUIRefreshInterval: getEnvAsDuration("OUI_REFRESH_INTERVAL", time.Hour),
+		},
+
+		WakeOnLAN: WakeOnLANConfig{
+			Enabled:     getEnvAsBool("ENABLE_WAKE_ON_LAN", false),
+			Port:        getEnvAsInt("WOL_PORT", 9),
+			MinInterval: getEnvAsDuration("WOL_MIN_INTERVAL", time.Minute),
 		},
 	}
 
@@ -132,28 +312,58 @@ func LoadConfig() (*Config, error) {
 func validateConfig(config *Config) error {
 	var errors []string
 
-	// Validate required database fields
-	if config.Database.User == "" {
-		errors = append(errors, "database user is required")
-	}
-	if config.Database.Password == "" {
-		errors = append(errors, "database password is required in production")
-	}
-	if config.Database.Name == "" {
-		errors = append(errors, "database name is required")
-	}
+	// Validate database settings
+	errors = append(errors, validateDatabaseConfig(config.Database)...)
 
 	// Validate notification URL
 	if config.NotificationService.URL == "" {
 		errors = append(errors, "notification service URL is required")
 	}
 
+	// Validate outbox dispatcher settings
+	if config.NotificationService.OutboxPollInterval <= 0 {
+		errors = append(errors, "outbox poll interval must be positive")
+	}
+	if config.NotificationService.OutboxBatchSize < 1 {
+		errors = append(errors, "outbox batch size must be at least 1")
+	}
+	if config.NotificationService.OutboxMaxAttempts < 1 {
+		errors = append(errors, "outbox max attempts must be at least 1")
+	}
+
+	// Validate health check settings
+	if config.Server.HealthCheckTimeout <= 0 {
+		errors = append(errors, "health check timeout must be positive")
+	}
+	if config.Server.ShutdownDrainDelay < 0 {
+		errors = append(errors, "shutdown drain delay must not be negative")
+	}
+
+	// Validate identity provider settings
+	errors = append(errors, validateOIDCConfig(config.Security.OIDC)...)
+
+	// Validate tracing settings
+	errors = append(errors, validateTracingConfig(config.Tracing)...)
+
+	// Validate DHCP and DNS export settings
+	errors = append(errors, validateNetworkExportConfig(config.NetworkExports)...)
+
+	// Validate MAC address settings
+	errors = append(errors, validateMACAddressConfig(config.MACAddresses)...)
+
+	// Validate Wake-on-LAN settings
+	errors = append(errors, validateWakeOnLANConfig(config.WakeOnLAN)...)
+
 	// Validate port ranges
 	if config.Port < 1 || config.Port > 65535 {
 		errors = append(errors, "port must be between 1 and 65535")
 	}
-	if config.Database.Port < 1 || config.Database.Port > 65535 {
-		errors = append(errors, "database port must be between 1 and 65535")
+	if config.Server.EnableMetrics || config.Server.EnableProfiling {
+		if config.Server.MetricsPort < 1 || config.Server.MetricsPort > 65535 {
+			errors = append(errors, "metrics port must be between 1 and 65535")
+		} else if config.Server.MetricsPort == config.Port {
+			errors = append(errors, "metrics port must differ from the server port")
+		}
 	}
 
 	if len(errors) > 0 {
@@ -163,6 +373,157 @@ func validateConfig(config *Config) error {
 	return nil
 }
 
+// validateDatabaseConfig validates the settings of the selected driver
+func validateDatabaseConfig(database DatabaseConfig) []string {
+	var errors []string
+	switch database.Driver {
+	case DatabaseDriverPostgres:
+		if database.User == "" {
+			errors = append(errors, "database user is required")
+		}
+		if database.Password == "" {
+			errors = append(errors, "database password is required in production")
+		}
+		if database.Name == "" {
+			errors = append(errors, "database name is required")
+		}
+		if database.Port < 1 || database.Port > 65535 {
+			errors = append(errors, "database port must be between 1 and 65535")
+		}
+	case DatabaseDriverSQLite:
+		if database.SQLitePath == "" {
+			errors = append(errors, "SQLite path is required for the sqlite driver")
+		}
+	default:
+		errors = append(errors, "database driver must be postgres or sqlite")
+	}
+	return errors
+}
+
+// validateTracingConfig validates the exporter settings
+func validateTracingConfig(tracing TracingConfig) []string {
+	var errors []string
+	switch tracing.Exporter {
+	case "none", "stdout", "otlp":
+	case "file":
+		if tracing.FilePath == "" {
+			errors = append(errors, "tracing file is required for the file exporter")
+		}
+	default:
+		errors = append(errors, "tracing exporter must be none, stdout, file or otlp")
+	}
+
+	if tracing.OTLPEndpoint != "" && tracing.Exporter == "otlp" {
+		if u, err := url.Parse(tracing.OTLPEndpoint); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			errors = append(errors, "OTLP endpoint must be an http(s) URL")
+		}
+	}
+	if tracing.SampleRatio < 0 || tracing.SampleRatio > 1 {
+		errors = append(errors, "tracing sample ratio must be between 0 and 1")
+	}
+	return errors
+}
+
+// validateNetworkExportConfig validates the zone file settings when the exports are enabled
+func validateNetworkExportConfig(exports NetworkExportConfig) []string {
+	if !exports.Enabled {
+		return nil
+	}
+
+	var errors []string
+	if exports.NameServer == "" {
+		errors = append(errors, "DNS name server is required for network exports")
+	}
+	if exports.Hostmaster == "" {
+		errors = append(errors, "DNS hostmaster is required for network exports")
+	}
+	if exports.TTL < time.Second {
+		errors = append(errors, "DNS TTL must be at least one second")
+	}
+	return errors
+}
+
+// validateMACAddressConfig validates the MAC address policy and registry settings
+func validateMACAddressConfig(macAddresses MACAddressConfig) []string {
+	var errors []string
+	if macAddresses.Policy != MACAddressPolicyWarn && macAddresses.Policy != MACAddressPolicyReject {
+		errors = append(errors, "MAC address policy must be warn or reject")
+	}
+	for _, path := range macAddresses.OUIFiles {
+		if strings.TrimSpace(path) == "" {
+			errors = append(errors, "OUI file paths must not be empty")
+			break
+		}
+	}
+	if macAddresses.OUIRefreshInterval < 0 {
+		errors = append(errors, "OUI refresh interval must not be negative")
+	}
+	return errors
+}
+
+// validateWakeOnLANConfig validates the magic packet settings when waking computers is enabled
+func validateWakeOnLANConfig(wakeOnLAN WakeOnLANConfig) []string {
+	if !wakeOnLAN.Enabled {
+		return nil
+	}
+
+	var errors []string
+	if wakeOnLAN.Port < 1 || wakeOnLAN.Port > 65535 {
+		errors = append(errors, "Wake-on-LAN port must be between 1 and 65535")
+	}
+	if wakeOnLAN.MinInterval < 0 {
+		errors = append(errors, "Wake-on-LAN minimum interval must not be negative")
+	}
+	return errors
+}
+
+// validateOIDCConfig validates the identity provider settings when tokens are enabled
+func validateOIDCConfig(oidc OIDCConfig) []string {
+	if !oidc.Enabled() {
+		if oidc.JWKSFile != "" || oidc.JWKSURL != "" {
+			return []string{"OIDC issuer is required when a JWKS is configured"}
+		}
+		return nil
+	}
+
+	var errors []string
+	if oidc.Audience == "" {
+		errors = append(errors, "OIDC audience is required when an issuer is configured")
+	}
+	switch {
+	case oidc.JWKSFile == "" && oidc.JWKSURL == "":
+		errors = append(errors, "OIDC requires a JWKS file or URL")
+	case oidc.JWKSFile != "" && oidc.JWKSURL != "":
+		errors = append(errors, "OIDC JWKS file and URL are mutually exclusive")
+	case oidc.JWKSURL != "":
+		if u, err := url.Parse(oidc.JWKSURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			errors = append(errors, "OIDC JWKS URL must be an http(s) URL")
+		}
+	}
+	if oidc.JWKSRefreshInterval <= 0 {
+		errors = append(errors, "OIDC JWKS refresh interval must be positive")
+	}
+	if oidc.ClockSkew < 0 {
+		errors = append(errors, "OIDC clock skew cannot be negative")
+	}
+	if oidc.RolesClaim == "" {
+		errors = append(errors, "OIDC roles claim is required")
+	}
+
+	values := make([]string, 0, len(oidc.RoleMapping))
+	for value := range oidc.RoleMapping {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		role := oidc.RoleMapping[value]
+		if _, ok := model.RoleScopes[model.Role(role)]; !ok {
+			errors = append(errors, fmt.Sprintf("OIDC role mapping for %q has unknown role %q", value, role))
+		}
+	}
+	return errors
+}
+
 // GetDatabaseDSN returns the database connection string
 func (c *Config) GetDatabaseDSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -197,6 +558,15 @@ func getEnvAsInt64(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolVal, err := strconv.ParseBool(value); err == nil {
@@ -221,3 +591,20 @@ func getEnvAsSlice(key string, defaultValue []string) []string {
 	}
 	return defaultValue
 }
+
+// getEnvAsMap parses comma-separated key=value pairs, such as "admins=it-admin,staff=viewer"
+func getEnvAsMap(key string, defaultValue map[string]string) map[string]string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	result := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		k, v, found := strings.Cut(pair, "=")
+		if k = strings.TrimSpace(k); found && k != "" {
+			result[k] = strings.TrimSpace(v)
+		}
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"computer-management-api/internal/config"
+	"computer-management-api/internal/repository"
 	"database/sql"
 	"fmt"
 
@@ -29,3 +30,21 @@ func InitDB(cfg *config.Config) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// Open connects to the database selected by the configured driver and creates its repositories
+func Open(cfg *config.Config) (*sql.DB, *repository.Store, error) {
+	switch cfg.Database.Driver {
+	case config.DatabaseDriverSQLite:
+		db, err := InitSQLite(cfg.Database.SQLitePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, repository.NewSQLiteStore(db), nil
+	default:
+		db, err := InitDB(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, repository.NewPostgresStore(db), nil
+	}
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	lockQuery    = regexp.QuoteMeta(`SELECT pg_advisory_lock($1)`)
+	unlockQuery  = regexp.QuoteMeta(`SELECT pg_advisory_unlock($1)`)
+	createQuery  = regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS schema_migrations`)
+	appliedQuery = regexp.QuoteMeta(`SELECT version FROM schema_migrations`)
+)
+
+// testMigrator returns a migrator for two test migrations backed by a mock database
+func testMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migrations := []Migration{
+		{Version: 1, Name: "create_things", Up: "CREATE TABLE things (id INT)", Down: "DROP TABLE things"},
+		{Version: 2, Name: "add_name", Up: "ALTER TABLE things ADD COLUMN name TEXT", Down: "ALTER TABLE things DROP COLUMN name"},
+	}
+	return &Migrator{db: db, migrations: migrations, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}, mock
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrator, err := NewMigrator(nil, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrator.migrations)
+
+	assert.Equal(t, "initial_schema", migrator.migrations[0].Name)
+	for i, migration := range migrator.migrations {
+		assert.Equal(t, int64(i+1), migration.Version, "migration versions must be contiguous")
+	}
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing down":  {"0001_init.up.sql": {Data: []byte("SELECT 1")}},
+		"bad file name": {"init.sql": {Data: []byte("SELECT 1")}},
+		"two names": {
+			"0001_init.up.sql":    {Data: []byte("SELECT 1")},
+			"0001_other.down.sql": {Data: []byte("SELECT 1")},
+		},
+	}
+
+	for name, files := range tests {
+		if _, err := LoadMigrations(files); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	migrator, mock := testMigrator(t)
+
+	mock.ExpectExec(lockQuery).WithArgs(migrationLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(createQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(appliedQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`ALTER TABLE things ADD COLUMN name TEXT`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`)).
+		WithArgs(int64(2), "add_name").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(unlockQuery).WithArgs(migrationLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Up_FailureRollsBack(t *testing.T) {
+	migrator, mock := testMigrator(t)
+
+	mock.ExpectExec(lockQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(createQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(appliedQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`CREATE TABLE things (id INT)`)).WillReturnError(errors.New("syntax error"))
+	mock.ExpectRollback()
+	mock.ExpectExec(unlockQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := migrator.Up(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 0, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Down(t *testing.T) {
+	migrator, mock := testMigrator(t)
+
+	mock.ExpectExec(lockQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(createQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(appliedQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1).AddRow(2))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`ALTER TABLE things DROP COLUMN name`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM schema_migrations WHERE version = $1`)).
+		WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(unlockQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	reverted, err := migrator.Down(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reverted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Status(t *testing.T) {
+	migrator, mock := testMigrator(t)
+	appliedAt := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT to_regclass('schema_migrations') IS NOT NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT version, name, applied_at FROM schema_migrations`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).
+			AddRow(1, "create_things", appliedAt).
+			AddRow(3, "from_a_newer_release", appliedAt))
+
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.False(t, statuses[0].Unknown)
+	assert.Nil(t, statuses[1].AppliedAt, "migration 2 is pending")
+	assert.True(t, statuses[2].Unknown)
+	assert.Equal(t, "from_a_newer_release", statuses[2].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
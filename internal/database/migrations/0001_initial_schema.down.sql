@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS computers;
+
+DROP FUNCTION IF EXISTS update_updated_at_column();
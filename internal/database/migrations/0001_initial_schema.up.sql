@@ -1,3 +1,7 @@
+-- Initial schema, as created by the former init.sql script. Databases set up by that script
+-- already have it, so every statement is idempotent and the later migrations bring them up to
+-- date.
+
 CREATE TABLE IF NOT EXISTS computers (
     id UUID PRIMARY KEY,
     mac_address VARCHAR(17) NOT NULL UNIQUE,
@@ -24,5 +28,5 @@ BEGIN
 END;
 $$ language 'plpgsql';
 
-CREATE TRIGGER update_computers_updated_at BEFORE UPDATE ON computers
-FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
\ No newline at end of file
+CREATE OR REPLACE TRIGGER update_computers_updated_at BEFORE UPDATE ON computers
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+ALTER TABLE computers DROP CONSTRAINT IF EXISTS computers_employee_abbreviation_fkey;
+
+DROP TABLE IF EXISTS employees;
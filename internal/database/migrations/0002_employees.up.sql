@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS employees (
+    abbreviation VARCHAR(3) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    department VARCHAR(255) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    deactivated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE OR REPLACE TRIGGER update_employees_updated_at BEFORE UPDATE ON employees
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Computers were assigned to free-form abbreviations before; each of them becomes an employee
+-- named after it, so the assignments satisfy the foreign key
+INSERT INTO employees (abbreviation, name)
+SELECT DISTINCT employee_abbreviation, employee_abbreviation
+FROM computers
+WHERE employee_abbreviation IS NOT NULL
+ON CONFLICT (abbreviation) DO NOTHING;
+
+ALTER TABLE computers
+    DROP CONSTRAINT IF EXISTS computers_employee_abbreviation_fkey,
+    ADD CONSTRAINT computers_employee_abbreviation_fkey FOREIGN KEY (employee_abbreviation)
+        REFERENCES employees (abbreviation) ON UPDATE CASCADE ON DELETE RESTRICT;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS computer_events;
+
+DROP FUNCTION IF EXISTS prevent_computer_event_changes();
@@ -0,0 +1,30 @@
+-- Append-only audit trail of every change made to a computer. Rows are kept after the
+-- computer is deleted, so computer_id deliberately has no foreign key.
+CREATE TABLE IF NOT EXISTS computer_events (
+    id BIGSERIAL PRIMARY KEY,
+    computer_id UUID NOT NULL,
+    event_type VARCHAR(20) NOT NULL CHECK (event_type IN ('created', 'updated', 'assigned', 'unassigned', 'deleted')),
+    actor VARCHAR(255) NOT NULL,
+    mac_address VARCHAR(17) NOT NULL,
+    old_employee_abbreviation VARCHAR(3),
+    new_employee_abbreviation VARCHAR(3),
+    old_value JSONB,
+    new_value JSONB,
+    occurred_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_computer_events_computer_id ON computer_events (computer_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_computer_events_old_employee ON computer_events (old_employee_abbreviation, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_computer_events_new_employee ON computer_events (new_employee_abbreviation, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_computer_events_mac_address ON computer_events (mac_address, occurred_at DESC);
+
+-- Reject any attempt to rewrite the audit trail
+CREATE OR REPLACE FUNCTION prevent_computer_event_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'computer_events is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE TRIGGER computer_events_append_only BEFORE UPDATE OR DELETE ON computer_events
+FOR EACH ROW EXECUTE FUNCTION prevent_computer_event_changes();
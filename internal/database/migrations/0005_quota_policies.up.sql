@@ -0,0 +1,20 @@
+-- Computer quota policies. The most specific policy applies to an employee: an employee
+-- override, then their department, then the global default.
+CREATE TABLE IF NOT EXISTS quota_policies (
+    id BIGSERIAL PRIMARY KEY,
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('global', 'department', 'employee')),
+    target VARCHAR(255) NOT NULL DEFAULT '',
+    max_computers INTEGER NOT NULL CHECK (max_computers >= 0),
+    mode VARCHAR(20) NOT NULL DEFAULT 'warn' CHECK (mode IN ('warn', 'enforce')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (scope, target)
+);
+
+CREATE OR REPLACE TRIGGER update_quota_policies_updated_at BEFORE UPDATE ON quota_policies
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Keep the historical behavior: warn once an employee holds three computers
+INSERT INTO quota_policies (scope, target, max_computers, mode)
+VALUES ('global', '', 3, 'warn')
+ON CONFLICT (scope, target) DO NOTHING;
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS increment_computers_version ON computers;
+DROP FUNCTION IF EXISTS increment_version_column();
+
+ALTER TABLE computers DROP COLUMN IF EXISTS version;
@@ -0,0 +1,15 @@
+-- The computer version backs the ETag used for optimistic locking. Existing computers start at
+-- version 1.
+ALTER TABLE computers ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+-- Bump the computer version on every change
+CREATE OR REPLACE FUNCTION increment_version_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE TRIGGER increment_computers_version BEFORE UPDATE ON computers
+FOR EACH ROW EXECUTE FUNCTION increment_version_column();
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_computers_search;
+DROP INDEX IF EXISTS idx_computers_mac_address_prefix;
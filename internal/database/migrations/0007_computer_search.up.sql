@@ -0,0 +1,6 @@
+-- Support MAC address prefix filters regardless of the database collation
+CREATE INDEX IF NOT EXISTS idx_computers_mac_address_prefix ON computers (mac_address varchar_pattern_ops);
+
+-- Full-text search across computer name and description; the expression must match the repository's search query
+CREATE INDEX IF NOT EXISTS idx_computers_search ON computers
+    USING GIN (to_tsvector('simple', computer_name || ' ' || COALESCE(description, '')));
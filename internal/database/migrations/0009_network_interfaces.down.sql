@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS sync_computers_primary_interface ON computers;
+DROP TRIGGER IF EXISTS create_computers_primary_interface ON computers;
+DROP TABLE IF EXISTS network_interfaces;
+
+DROP FUNCTION IF EXISTS sync_primary_network_interface();
+DROP FUNCTION IF EXISTS create_primary_network_interface();
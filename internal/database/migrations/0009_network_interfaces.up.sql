@@ -0,0 +1,56 @@
+-- Network interfaces of computers. MAC addresses are unique across every interface, and the
+-- primary interface of a computer mirrors its mac_address and ip_address columns, which the
+-- triggers below keep in sync.
+CREATE TABLE network_interfaces (
+    id UUID PRIMARY KEY,
+    computer_id UUID NOT NULL REFERENCES computers (id) ON DELETE CASCADE,
+    mac_address VARCHAR(17) NOT NULL UNIQUE,
+    interface_type VARCHAR(20) NOT NULL DEFAULT 'ethernet' CHECK (interface_type IN ('ethernet', 'wifi', 'dock', 'other')),
+    ipv4_address VARCHAR(15),
+    ipv6_address VARCHAR(45),
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_network_interfaces_computer_id ON network_interfaces (computer_id);
+
+-- At most one primary interface per computer
+CREATE UNIQUE INDEX idx_network_interfaces_primary ON network_interfaces (computer_id) WHERE is_primary;
+
+CREATE TRIGGER update_network_interfaces_updated_at BEFORE UPDATE ON network_interfaces
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Every existing computer gets its primary interface
+INSERT INTO network_interfaces (id, computer_id, mac_address, ipv4_address, is_primary, created_at, updated_at)
+SELECT gen_random_uuid(), id, mac_address, ip_address, TRUE, created_at, updated_at
+FROM computers;
+
+-- A new computer starts with its primary interface
+CREATE FUNCTION create_primary_network_interface()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO network_interfaces (id, computer_id, mac_address, ipv4_address, is_primary)
+    VALUES (gen_random_uuid(), NEW.id, NEW.mac_address, NEW.ip_address, TRUE);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER create_computers_primary_interface AFTER INSERT ON computers
+FOR EACH ROW EXECUTE FUNCTION create_primary_network_interface();
+
+-- Changing the MAC or IP address of a computer changes its primary interface
+CREATE FUNCTION sync_primary_network_interface()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE network_interfaces
+    SET mac_address = NEW.mac_address, ipv4_address = NEW.ip_address
+    WHERE computer_id = NEW.id AND is_primary;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER sync_computers_primary_interface AFTER UPDATE OF mac_address, ip_address ON computers
+FOR EACH ROW
+WHEN (OLD.mac_address IS DISTINCT FROM NEW.mac_address OR OLD.ip_address IS DISTINCT FROM NEW.ip_address)
+EXECUTE FUNCTION sync_primary_network_interface();
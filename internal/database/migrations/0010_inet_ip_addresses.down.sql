@@ -0,0 +1,40 @@
+-- Reverting fails while a computer has an IPv6 address longer than the former 15 characters
+DROP TRIGGER IF EXISTS sync_computers_primary_interface ON computers;
+
+DROP INDEX IF EXISTS idx_network_interfaces_ipv6_address;
+DROP INDEX IF EXISTS idx_network_interfaces_ipv4_address;
+DROP INDEX IF EXISTS idx_computers_ip_address;
+
+ALTER TABLE network_interfaces
+    DROP CONSTRAINT IF EXISTS network_interfaces_ipv6_address_family,
+    DROP CONSTRAINT IF EXISTS network_interfaces_ipv4_address_family;
+
+ALTER TABLE network_interfaces
+    ALTER COLUMN ipv4_address TYPE VARCHAR(15) USING host(ipv4_address),
+    ALTER COLUMN ipv6_address TYPE VARCHAR(45) USING host(ipv6_address);
+
+ALTER TABLE computers ALTER COLUMN ip_address TYPE VARCHAR(15) USING host(ip_address);
+
+CREATE OR REPLACE FUNCTION create_primary_network_interface()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO network_interfaces (id, computer_id, mac_address, ipv4_address, is_primary)
+    VALUES (gen_random_uuid(), NEW.id, NEW.mac_address, NEW.ip_address, TRUE);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION sync_primary_network_interface()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE network_interfaces
+    SET mac_address = NEW.mac_address, ipv4_address = NEW.ip_address
+    WHERE computer_id = NEW.id AND is_primary;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER sync_computers_primary_interface AFTER UPDATE OF mac_address, ip_address ON computers
+FOR EACH ROW
+WHEN (OLD.mac_address IS DISTINCT FROM NEW.mac_address OR OLD.ip_address IS DISTINCT FROM NEW.ip_address)
+EXECUTE FUNCTION sync_primary_network_interface();
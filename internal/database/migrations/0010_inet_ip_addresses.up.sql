@@ -0,0 +1,53 @@
+-- IP addresses are stored as inet, so IPv6 addresses fit and networks can be matched with the
+-- containment operators. The trigger that reads ip_address is dropped while its type changes.
+DROP TRIGGER sync_computers_primary_interface ON computers;
+
+ALTER TABLE computers ALTER COLUMN ip_address TYPE inet USING ip_address::inet;
+
+ALTER TABLE network_interfaces
+    ALTER COLUMN ipv4_address TYPE inet USING ipv4_address::inet,
+    ALTER COLUMN ipv6_address TYPE inet USING ipv6_address::inet;
+
+-- Primary interfaces of IPv6 computers got the computer's address as their IPv4 address
+UPDATE network_interfaces
+SET ipv6_address = ipv4_address, ipv4_address = NULL
+WHERE family(ipv4_address) = 6;
+
+ALTER TABLE network_interfaces
+    ADD CONSTRAINT network_interfaces_ipv4_address_family CHECK (family(ipv4_address) = 4),
+    ADD CONSTRAINT network_interfaces_ipv6_address_family CHECK (family(ipv6_address) = 6);
+
+-- Support network containment filters such as ip_address <<= '10.0.0.0/8'
+CREATE INDEX idx_computers_ip_address ON computers USING GIST (ip_address inet_ops);
+CREATE INDEX idx_network_interfaces_ipv4_address ON network_interfaces USING GIST (ipv4_address inet_ops);
+CREATE INDEX idx_network_interfaces_ipv6_address ON network_interfaces USING GIST (ipv6_address inet_ops);
+
+-- The IP address of a computer is the primary interface's address of the same family
+CREATE OR REPLACE FUNCTION create_primary_network_interface()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO network_interfaces (id, computer_id, mac_address, ipv4_address, ipv6_address, is_primary)
+    VALUES (gen_random_uuid(), NEW.id, NEW.mac_address,
+        CASE WHEN family(NEW.ip_address) = 4 THEN NEW.ip_address END,
+        CASE WHEN family(NEW.ip_address) = 6 THEN NEW.ip_address END,
+        TRUE);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION sync_primary_network_interface()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE network_interfaces
+    SET mac_address = NEW.mac_address,
+        ipv4_address = CASE WHEN family(NEW.ip_address) = 4 THEN NEW.ip_address ELSE ipv4_address END,
+        ipv6_address = CASE WHEN family(NEW.ip_address) = 6 THEN NEW.ip_address ELSE ipv6_address END
+    WHERE computer_id = NEW.id AND is_primary;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER sync_computers_primary_interface AFTER UPDATE OF mac_address, ip_address ON computers
+FOR EACH ROW
+WHEN (OLD.mac_address IS DISTINCT FROM NEW.mac_address OR OLD.ip_address IS DISTINCT FROM NEW.ip_address)
+EXECUTE FUNCTION sync_primary_network_interface();
@@ -0,0 +1,15 @@
+-- IP networks computers are addressed from. Subnets cannot overlap, so every address belongs
+-- to at most one of them, and the gateway has to be a host address of its subnet.
+CREATE TABLE subnets (
+    id UUID PRIMARY KEY,
+    cidr cidr NOT NULL,
+    gateway inet CHECK (gateway << cidr AND family(gateway) = family(cidr)),
+    vlan INTEGER CHECK (vlan BETWEEN 1 AND 4094),
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT subnets_no_overlap EXCLUDE USING GIST (cidr inet_ops WITH &&)
+);
+
+CREATE TRIGGER update_subnets_updated_at BEFORE UPDATE ON subnets
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- The audit trail is append-only, so recorded wake-ups are kept and only new ones are rejected
+ALTER TABLE computer_events DROP CONSTRAINT IF EXISTS computer_events_event_type_check;
+ALTER TABLE computer_events ADD CONSTRAINT computer_events_event_type_check
+    CHECK (event_type IN ('created', 'updated', 'assigned', 'unassigned', 'deleted')) NOT VALID;
+
+ALTER TABLE subnets DROP COLUMN IF EXISTS broadcast_address;
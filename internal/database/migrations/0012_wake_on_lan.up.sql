@@ -0,0 +1,10 @@
+-- Wake-on-LAN magic packets for the computers of an IPv4 subnet are sent to its broadcast
+-- address, which defaults to the directed broadcast address when not set. It may lie outside
+-- the subnet, such as the limited broadcast address or a relay forwarding the packets.
+ALTER TABLE subnets
+    ADD COLUMN broadcast_address inet CHECK (family(broadcast_address) = 4 AND family(cidr) = 4);
+
+-- Record who woke which computer in the audit trail
+ALTER TABLE computer_events DROP CONSTRAINT computer_events_event_type_check;
+ALTER TABLE computer_events ADD CONSTRAINT computer_events_event_type_check
+    CHECK (event_type IN ('created', 'updated', 'assigned', 'unassigned', 'deleted', 'woken'));
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_computer_events_event_type;
@@ -0,0 +1,3 @@
+-- Finds the latest event of a type across all computers, such as the last deletion that
+-- advances the serial of DNS zone files
+CREATE INDEX IF NOT EXISTS idx_computer_events_event_type ON computer_events (event_type, occurred_at DESC);
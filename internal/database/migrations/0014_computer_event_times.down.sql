@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_computer_events_occurred_at;
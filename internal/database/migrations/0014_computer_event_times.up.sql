@@ -0,0 +1,3 @@
+-- Searches the audit trail of all computers by time, such as the state of the inventory at a
+-- date; searches by MAC address use idx_computer_events_mac_address
+CREATE INDEX IF NOT EXISTS idx_computer_events_occurred_at ON computer_events (occurred_at DESC);
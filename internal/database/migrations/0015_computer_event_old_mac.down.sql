@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_computer_events_old_mac_address;
+ALTER TABLE computer_events DROP COLUMN IF EXISTS old_mac_address;
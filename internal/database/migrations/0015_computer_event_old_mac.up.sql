@@ -0,0 +1,11 @@
+-- The MAC address a computer had before a change, so that a change of the MAC address is found
+-- in the history of both addresses
+ALTER TABLE computer_events ADD COLUMN IF NOT EXISTS old_mac_address VARCHAR(17);
+
+-- Earlier events keep it in their old snapshot. The append-only trigger is suspended for the
+-- backfill only.
+ALTER TABLE computer_events DISABLE TRIGGER computer_events_append_only;
+UPDATE computer_events SET old_mac_address = old_value->>'mac_address' WHERE old_value IS NOT NULL;
+ALTER TABLE computer_events ENABLE TRIGGER computer_events_append_only;
+
+CREATE INDEX IF NOT EXISTS idx_computer_events_old_mac_address ON computer_events (old_mac_address, occurred_at DESC);
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"computer-management-api/internal/repository"
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"net/url"
+)
+
+// sqliteSchema creates the tables of the SQLite backend
+//
+//go:embed sqlite/schema.sql
+var sqliteSchema string
+
+// InitSQLite opens the SQLite database at path, creating it and its schema if needed. The path
+// :memory: opens a private in-memory database that lives as long as the returned pool.
+//
+// The pool holds a single connection: SQLite serializes writers anyway, and an in-memory
+// database is dropped with the last connection to it.
+func InitSQLite(path string) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	// Take the write lock when a transaction begins, as reads made before a write in the same
+	// transaction rely on it
+	params.Set("_txlock", "immediate")
+	if path != ":memory:" {
+		params.Add("_pragma", "journal_mode(WAL)")
+	}
+
+	db, err := sql.Open(repository.SQLiteDriver, "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if _, err := db.ExecContext(context.Background(), sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create SQLite schema: %w", err)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,181 @@
+-- SQLite schema of the development backend. It mirrors the PostgreSQL migrations and is
+-- idempotent, so it is applied every time a database is opened. The repositories write every
+-- timestamp themselves as fixed-width UTC text, and the defaults use the same format.
+
+CREATE TABLE IF NOT EXISTS employees (
+    abbreviation TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    department TEXT NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    deactivated_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS computers (
+    id TEXT PRIMARY KEY,
+    mac_address TEXT NOT NULL UNIQUE,
+    computer_name TEXT NOT NULL,
+    ip_address TEXT NOT NULL,
+    employee_abbreviation TEXT REFERENCES employees (abbreviation) ON UPDATE CASCADE ON DELETE RESTRICT,
+    description TEXT,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_computers_employee_abbreviation ON computers (employee_abbreviation);
+
+-- Network interfaces of computers. MAC addresses are unique across every interface, and the
+-- primary interface of a computer mirrors its mac_address and ip_address columns, which the
+-- triggers below keep in sync.
+CREATE TABLE IF NOT EXISTS network_interfaces (
+    id TEXT PRIMARY KEY,
+    computer_id TEXT NOT NULL REFERENCES computers (id) ON DELETE CASCADE,
+    mac_address TEXT NOT NULL UNIQUE,
+    interface_type TEXT NOT NULL DEFAULT 'ethernet' CHECK (interface_type IN ('ethernet', 'wifi', 'dock', 'other')),
+    ipv4_address TEXT,
+    ipv6_address TEXT,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_network_interfaces_computer_id ON network_interfaces (computer_id);
+
+-- At most one primary interface per computer
+CREATE UNIQUE INDEX IF NOT EXISTS idx_network_interfaces_primary ON network_interfaces (computer_id) WHERE is_primary;
+
+-- Computers of databases created before interfaces existed get their primary interface. IP
+-- addresses are stored in their canonical text form, so IPv6 addresses are the ones with a colon.
+INSERT INTO network_interfaces (id, computer_id, mac_address, ipv4_address, ipv6_address, is_primary, created_at, updated_at)
+SELECT gen_random_uuid(), id, mac_address,
+    CASE WHEN instr(ip_address, ':') = 0 THEN ip_address END,
+    CASE WHEN instr(ip_address, ':') > 0 THEN ip_address END,
+    TRUE, created_at, updated_at
+FROM computers
+WHERE NOT EXISTS (SELECT 1 FROM network_interfaces WHERE computer_id = computers.id AND is_primary);
+
+-- Primary interfaces of IPv6 computers used to get the computer's address as their IPv4 address
+UPDATE network_interfaces
+SET ipv6_address = ipv4_address, ipv4_address = NULL
+WHERE instr(ipv4_address, ':') > 0;
+
+-- The triggers are recreated so databases opened before their last change get the current ones
+
+-- A new computer starts with its primary interface
+DROP TRIGGER IF EXISTS create_computers_primary_interface;
+CREATE TRIGGER create_computers_primary_interface AFTER INSERT ON computers
+BEGIN
+    INSERT INTO network_interfaces (id, computer_id, mac_address, ipv4_address, ipv6_address, is_primary, created_at, updated_at)
+    VALUES (gen_random_uuid(), NEW.id, NEW.mac_address,
+        CASE WHEN instr(NEW.ip_address, ':') = 0 THEN NEW.ip_address END,
+        CASE WHEN instr(NEW.ip_address, ':') > 0 THEN NEW.ip_address END,
+        TRUE, NEW.created_at, NEW.updated_at);
+END;
+
+-- Changing the MAC or IP address of a computer changes its primary interface; the IP address
+-- replaces the interface's address of the same family
+DROP TRIGGER IF EXISTS sync_computers_primary_interface;
+CREATE TRIGGER sync_computers_primary_interface AFTER UPDATE OF mac_address, ip_address ON computers
+WHEN OLD.mac_address IS NOT NEW.mac_address OR OLD.ip_address IS NOT NEW.ip_address
+BEGIN
+    UPDATE network_interfaces
+    SET mac_address = NEW.mac_address,
+        ipv4_address = CASE WHEN instr(NEW.ip_address, ':') = 0 THEN NEW.ip_address ELSE ipv4_address END,
+        ipv6_address = CASE WHEN instr(NEW.ip_address, ':') > 0 THEN NEW.ip_address ELSE ipv6_address END,
+        updated_at = NEW.updated_at
+    WHERE computer_id = NEW.id AND is_primary;
+END;
+
+-- Append-only audit trail of every change made to a computer. Rows are kept after the
+-- computer is deleted, so computer_id deliberately has no foreign key.
+CREATE TABLE IF NOT EXISTS computer_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    computer_id TEXT NOT NULL,
+    event_type TEXT NOT NULL CHECK (event_type IN ('created', 'updated', 'assigned', 'unassigned', 'deleted', 'woken')),
+    actor TEXT NOT NULL,
+    mac_address TEXT NOT NULL,
+    old_mac_address TEXT,
+    old_employee_abbreviation TEXT,
+    new_employee_abbreviation TEXT,
+    old_value TEXT,
+    new_value TEXT,
+    occurred_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_computer_events_computer_id ON computer_events (computer_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_computer_events_mac_address ON computer_events (mac_address, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_computer_events_old_mac_address ON computer_events (old_mac_address, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_computer_events_old_employee ON computer_events (old_employee_abbreviation, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_computer_events_new_employee ON computer_events (new_employee_abbreviation, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_computer_events_event_type ON computer_events (event_type, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_computer_events_occurred_at ON computer_events (occurred_at DESC);
+
+-- Reject any attempt to rewrite the audit trail
+CREATE TRIGGER IF NOT EXISTS computer_events_no_update BEFORE UPDATE ON computer_events
+BEGIN
+    SELECT RAISE(ABORT, 'computer_events is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS computer_events_no_delete BEFORE DELETE ON computer_events
+BEGIN
+    SELECT RAISE(ABORT, 'computer_events is append-only');
+END;
+
+-- Transactional outbox for notifications
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    notification_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now')),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now')),
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_pending ON notification_outbox (next_attempt_at, id) WHERE status = 'pending';
+
+-- Computer quota policies
+CREATE TABLE IF NOT EXISTS quota_policies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    scope TEXT NOT NULL CHECK (scope IN ('global', 'department', 'employee')),
+    target TEXT NOT NULL DEFAULT '',
+    max_computers INTEGER NOT NULL CHECK (max_computers >= 0),
+    mode TEXT NOT NULL DEFAULT 'warn' CHECK (mode IN ('warn', 'enforce')),
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now')),
+    UNIQUE (scope, target)
+);
+
+-- Keep the historical behavior: warn once an employee holds three computers
+INSERT OR IGNORE INTO quota_policies (scope, target, max_computers, mode)
+VALUES ('global', '', 3, 'warn');
+
+-- API keys. Only the SHA-256 hash of a key is stored; scopes are a JSON array.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT NOT NULL DEFAULT '[]',
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now'))
+);
+
+-- IP networks computers are addressed from, stored in their canonical text form. The
+-- repository keeps subnets from overlapping.
+CREATE TABLE IF NOT EXISTS subnets (
+    id TEXT PRIMARY KEY,
+    cidr TEXT NOT NULL UNIQUE,
+    gateway TEXT,
+    broadcast_address TEXT,
+    vlan INTEGER CHECK (vlan BETWEEN 1 AND 4094),
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%dT%H:%M:%f000Z', 'now'))
+);
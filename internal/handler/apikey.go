@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/service"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// APIKeyHandler handles the HTTP requests for managing API keys.
+type APIKeyHandler struct {
+	Service service.APIKeyServiceInterface
+	Logger  *slog.Logger
+
+	// Helper components for cleaner code organization
+	ErrorHandler   *ErrorHandler
+	ResponseHelper *ResponseHelper
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler with dependencies and helpers
+func NewAPIKeyHandler(svc service.APIKeyServiceInterface, logger *slog.Logger) *APIKeyHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &APIKeyHandler{
+		Service:        svc,
+		Logger:         logger,
+		ErrorHandler:   NewErrorHandler(logger),
+		ResponseHelper: NewResponseHelper(),
+	}
+}
+
+// CreateAPIKeyHandler handles issuing a new API key. The secret key is only part of this response.
+func (h *APIKeyHandler) CreateAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	var key model.APIKey
+	if err := json.NewDecoder(r.Body).Decode(&key); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	issued, err := h.Service.IssueAPIKey(ctx, key)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "create api key")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusCreated, "API key created successfully; store the key now, it cannot be retrieved again", issued)
+}
+
+// GetAllAPIKeysHandler handles the retrieval of all API keys, without their secrets.
+func (h *APIKeyHandler) GetAllAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	keys, err := h.Service.GetAllAPIKeys(ctx)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve api keys")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"api_keys": keys,
+	})
+}
+
+// RevokeAPIKeyHandler handles revoking an API key.
+func (h *APIKeyHandler) RevokeAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateID(w, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	if err := h.Service.RevokeAPIKey(ctx, id); err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "revoke api key")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "API key revoked successfully", map[string]interface{}{
+		"id": id,
+	})
+}
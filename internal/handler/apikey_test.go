@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// MockAPIKeyRepository is an in-memory implementation of APIKeyRepository
+type MockAPIKeyRepository struct {
+	Keys   map[int64]model.APIKey
+	Hashes map[string]int64
+}
+
+func (m *MockAPIKeyRepository) CreateAPIKey(ctx context.Context, key model.APIKey, keyHash string) (int64, error) {
+	key.ID = int64(len(m.Keys) + 1)
+	key.CreatedAt = time.Now()
+	m.Keys[key.ID] = key
+	m.Hashes[keyHash] = key.ID
+	return key.ID, nil
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyByID(ctx context.Context, id int64) (*model.APIKey, error) {
+	key, ok := m.Keys[id]
+	if !ok {
+		return nil, repository.ErrAPIKeyNotFound
+	}
+	return &key, nil
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	id, ok := m.Hashes[keyHash]
+	if !ok {
+		return nil, repository.ErrAPIKeyNotFound
+	}
+	return m.GetAPIKeyByID(ctx, id)
+}
+
+func (m *MockAPIKeyRepository) GetAllAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	keys := []model.APIKey{}
+	for _, key := range m.Keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m *MockAPIKeyRepository) RevokeAPIKey(ctx context.Context, id int64) error {
+	key, ok := m.Keys[id]
+	if !ok {
+		return repository.ErrAPIKeyNotFound
+	}
+	now := time.Now()
+	key.RevokedAt = &now
+	m.Keys[id] = key
+	return nil
+}
+
+func createTestAPIKeyHandler() (*APIKeyHandler, *MockAPIKeyRepository) {
+	keys := &MockAPIKeyRepository{Keys: map[int64]model.APIKey{}, Hashes: map[string]int64{}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+
+	handler := NewAPIKeyHandler(service.NewAPIKeyService(keys, logger), logger)
+	return handler, keys
+}
+
+func TestCreateAPIKeyHandler_Success(t *testing.T) {
+	handler, keys := createTestAPIKeyHandler()
+
+	body := `{"name": "inventory-sync", "scopes": ["computers:read", "computers:write"]}`
+	req, _ := http.NewRequest("POST", "/api-keys", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateAPIKeyHandler(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Data service.IssuedAPIKey `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	issued := response.Data
+	if !strings.HasPrefix(issued.Key, service.APIKeyPrefix) || !strings.HasPrefix(issued.Key, issued.Prefix) {
+		t.Errorf("Expected a key starting with its prefix, got %q and %q", issued.Key, issued.Prefix)
+	}
+	if _, stored := keys.Hashes[issued.Key]; stored {
+		t.Error("Expected the key to be stored hashed")
+	}
+	if _, stored := keys.Hashes[service.HashAPIKey(issued.Key)]; !stored {
+		t.Error("Expected the hash of the key to be stored")
+	}
+	if rr.Header().Get("Cache-Control") != "no-store" {
+		t.Error("Expected the response with the key not to be cached")
+	}
+}
+
+func TestCreateAPIKeyHandler_ValidationError(t *testing.T) {
+	handler, _ := createTestAPIKeyHandler()
+
+	body := `{"name": "", "scopes": ["computers:delete"], "expires_at": "2001-01-01T00:00:00Z"}`
+	req, _ := http.NewRequest("POST", "/api-keys", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateAPIKeyHandler(rr, req)
+
+	var response ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if rr.Code != http.StatusBadRequest || len(response.Details) != 3 {
+		t.Errorf("Expected three validation errors, got %d: %v", rr.Code, response.Details)
+	}
+}
+
+func TestRevokeAPIKeyHandler(t *testing.T) {
+	handler, keys := createTestAPIKeyHandler()
+	id, _ := keys.CreateAPIKey(context.Background(), model.APIKey{Name: "reporting", Scopes: []model.Scope{model.ScopeComputersRead}}, "hash")
+
+	tests := []struct {
+		id             string
+		expectedStatus int
+	}{
+		{"1", http.StatusOK},
+		{"2", http.StatusNotFound},
+		{"abc", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest("DELETE", "/api-keys/"+tt.id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": tt.id})
+		rr := httptest.NewRecorder()
+
+		handler.RevokeAPIKeyHandler(rr, req)
+
+		if rr.Code != tt.expectedStatus {
+			t.Errorf("Revoking %s: expected status code %d, got %d", tt.id, tt.expectedStatus, rr.Code)
+		}
+	}
+
+	if keys.Keys[id].RevokedAt == nil {
+		t.Error("Expected the key to be revoked")
+	}
+}
+
+func TestGetAllAPIKeysHandler(t *testing.T) {
+	handler, keys := createTestAPIKeyHandler()
+	keys.CreateAPIKey(context.Background(), model.APIKey{Name: "reporting", Scopes: []model.Scope{model.ScopeComputersRead}}, "hash")
+
+	req, _ := http.NewRequest("GET", "/api-keys", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetAllAPIKeysHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	if strings.Contains(rr.Body.String(), "hash") || !strings.Contains(rr.Body.String(), `"name":"reporting"`) {
+		t.Errorf("Unexpected response %s", rr.Body.String())
+	}
+}
@@ -2,33 +2,46 @@ package handler
 
 import (
 	"computer-management-api/internal/model"
-	"computer-management-api/internal/notification"
 	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	apperrors "computer-management-api/pkg/errors"
+	"computer-management-api/pkg/patch"
 	"computer-management-api/pkg/validation"
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
-// Constants for timeouts and validation
+// Constants for request timeouts
 const (
-	DefaultTimeout        = 10 * time.Second
-	LongRunningTimeout    = 15 * time.Second
-	NotificationTimeout   = 5 * time.Second
-	EmployeeAbbrevLength  = 3
-	MaxComputersThreshold = 3
+	DefaultTimeout     = 10 * time.Second
+	LongRunningTimeout = 15 * time.Second
+	ImportTimeout      = 30 * time.Second
+	ExportTimeout      = 5 * time.Minute
 )
 
+// MaxSearchQueryLength limits the full-text search query of a computer listing
+const MaxSearchQueryLength = 200
+
+// MaxVendorFilterLength limits the vendor filter of a computer listing
+const MaxVendorFilterLength = 100
+
 // Error response structure for consistent JSON error responses
 type ErrorResponse struct {
-	Error   string            `json:"error"`
-	Code    string            `json:"code,omitempty"`
-	Details map[string]string `json:"details,omitempty"`
+	Error     string            `json:"error"`
+	Code      string            `json:"code,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	TraceID   string            `json:"trace_id,omitempty"`
 }
 
 // Success response structure for consistent JSON success responses
@@ -38,10 +51,13 @@ type SuccessResponse struct {
 }
 
 // ComputerHandler handles the HTTP requests for computers.
+// All business rules are delegated to the service layer.
 type ComputerHandler struct {
-	Repo     repository.ComputerRepository
-	Notifier notification.Notifier
-	Logger   *log.Logger
+	Service service.ComputerServiceInterface
+	Logger  *slog.Logger
+
+	// RequireIfMatch rejects changes to a computer that are not conditional on its ETag
+	RequireIfMatch bool
 
 	// Helper components for cleaner code organization
 	ErrorHandler   *ErrorHandler
@@ -49,14 +65,13 @@ type ComputerHandler struct {
 }
 
 // NewComputerHandler creates a new ComputerHandler with dependencies and helpers
-func NewComputerHandler(repo repository.ComputerRepository, notifier notification.Notifier, logger *log.Logger) *ComputerHandler {
+func NewComputerHandler(svc service.ComputerServiceInterface, logger *slog.Logger) *ComputerHandler {
 	if logger == nil {
-		logger = log.Default()
+		logger = slog.Default()
 	}
 
 	return &ComputerHandler{
-		Repo:           repo,
-		Notifier:       notifier,
+		Service:        svc,
 		Logger:         logger,
 		ErrorHandler:   NewErrorHandler(logger),
 		ResponseHelper: NewResponseHelper(),
@@ -70,60 +85,51 @@ func (h *ComputerHandler) CreateComputerHandler(w http.ResponseWriter, r *http.R
 
 	var computer model.Computer
 	if err := json.NewDecoder(r.Body).Decode(&computer); err != nil {
-		h.ErrorHandler.HandleJSONDecodeError(w, err)
-		return
-	}
-
-	// Validate input using validation package
-	if validationErrors := validation.ValidateComputerInput(&computer); len(validationErrors) > 0 {
-		// Convert []string to map[string]string for ErrorHandler
-		errorMap := make(map[string]string)
-		for i, err := range validationErrors {
-			errorMap[fmt.Sprintf("error_%d", i)] = err
-		}
-		h.ErrorHandler.HandleValidationErrors(w, errorMap)
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
 		return
 	}
 
-	// Generate ID if not provided
-	if computer.ID == uuid.Nil {
-		computer.ID = uuid.New()
-	}
-
-	// Create computer
-	if err := h.Repo.CreateComputer(ctx, computer); err != nil {
-		h.ErrorHandler.HandleRepositoryError(w, err, "create")
+	// Create computer (validation, normalization and notifications happen in the service)
+	created, err := h.Service.CreateComputer(ctx, computer)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "create computer")
 		return
 	}
 
-	// Async notification (non-blocking)
-	go h.checkAndNotify(computer.EmployeeAbbreviation)
-
 	// Send success response with helper
-	successData := h.ResponseHelper.CreateComputerSuccessData(computer.ID.String(), computer.MACAddress)
+	successData := h.ResponseHelper.CreateComputerSuccessData(created.ID.String(), created.MACAddress)
+	addMACWarnings(successData, created.MACWarnings)
 	h.ErrorHandler.SendSuccessResponse(w, http.StatusCreated, "Computer created successfully", successData)
 }
 
-// GetAllComputersHandler handles the retrieval of all computers with pagination.
+// GetAllComputersHandler handles the retrieval of all computers with filtering, sorting,
+// full-text search and pagination.
 func (h *ComputerHandler) GetAllComputersHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, LongRunningTimeout)
 	defer cancel()
 
+	filter, filterErrors := parseComputerFilter(r)
+
 	// Parse pagination parameters
 	paginationParams := h.ResponseHelper.ParsePaginationParams(r)
+	repositoryParams, paginationErrors := parseCursorPagination(paginationParams)
+	for name, message := range paginationErrors {
+		filterErrors[name] = message
+	}
+	if len(filterErrors) > 0 {
+		h.ErrorHandler.HandleValidationErrors(w, filterErrors)
+		return
+	}
 
 	// Always use paginated endpoint for list operations
-	result, err := h.Repo.GetAllComputersPaginated(ctx, repository.PaginationParams{
-		Offset: paginationParams.Offset,
-		Limit:  paginationParams.Limit,
-	})
+	result, err := h.Service.GetAllComputers(ctx, filter, repositoryParams)
 	if err != nil {
-		h.ErrorHandler.HandleRepositoryError(w, err, "retrieve")
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve computers")
 		return
 	}
 
 	// Calculate pagination metadata
-	paginationMeta := h.ResponseHelper.CalculatePaginationMeta(paginationParams, result.TotalCount)
+	paginationMeta := h.ResponseHelper.CalculateCursorPaginationMeta(paginationParams, result)
 
 	// Create paginated response
 	responseData := h.ResponseHelper.CreatePaginatedListResponseData(result.Items, paginationMeta, map[string]interface{}{
@@ -131,7 +137,135 @@ func (h *ComputerHandler) GetAllComputersHandler(w http.ResponseWriter, r *http.
 	})
 	delete(responseData, "items") // Remove generic "items" key since we have "computers"
 
-	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, responseData)
+	h.ErrorHandler.SendConditionalJSONResponse(w, r, "", responseData)
+}
+
+// parseComputerFilter parses the filter, search and sort query parameters of a computer listing.
+// It returns the problems with each invalid parameter keyed by parameter name.
+func parseComputerFilter(r *http.Request) (repository.ComputerFilter, map[string]string) {
+	query := r.URL.Query()
+	var filter repository.ComputerFilter
+	errs := make(map[string]string)
+
+	if value := query.Get("mac_prefix"); value != "" {
+		prefix, err := validation.ValidateMACPrefix(value)
+		if err != nil {
+			errs["mac_prefix"] = err.Error()
+		}
+		filter.MACPrefix = prefix
+	}
+
+	if value := strings.TrimSpace(query.Get("vendor")); value != "" {
+		if len(value) > MaxVendorFilterLength {
+			errs["vendor"] = fmt.Sprintf("vendor cannot exceed %d characters", MaxVendorFilterLength)
+		}
+		filter.Vendor = value
+	}
+
+	if value := query.Get("subnet"); value != "" {
+		subnet, err := validation.ValidateSubnet(value)
+		if err != nil {
+			errs["subnet"] = err.Error()
+		}
+		filter.Subnet = subnet
+	}
+
+	if value := query.Get("ip_in"); value != "" {
+		network, err := validation.ValidateSubnet(value)
+		if err != nil {
+			errs["ip_in"] = err.Error()
+		}
+		filter.IPIn = network
+	}
+
+	if value := query.Get("employee"); value != "" {
+		if err := validation.ValidateEmployeeAbbreviation(value); err != nil {
+			errs["employee"] = err.Error()
+		}
+		filter.EmployeeAbbreviation = value
+	}
+
+	if value := query.Get("assigned"); value != "" {
+		assigned, err := strconv.ParseBool(value)
+		if err != nil {
+			errs["assigned"] = "assigned must be true or false"
+		}
+		filter.Assigned = &assigned
+	}
+
+	for name, target := range map[string]*time.Time{
+		"created_after":  &filter.CreatedAfter,
+		"created_before": &filter.CreatedBefore,
+		"updated_after":  &filter.UpdatedAfter,
+		"updated_before": &filter.UpdatedBefore,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseFilterTime(value)
+		if err != nil {
+			errs[name] = fmt.Sprintf("%s must be an RFC 3339 timestamp or a YYYY-MM-DD date", name)
+		}
+		*target = parsed
+	}
+
+	if value := strings.TrimSpace(query.Get("q")); value != "" {
+		if len(value) > MaxSearchQueryLength {
+			errs["q"] = fmt.Sprintf("search query cannot exceed %d characters", MaxSearchQueryLength)
+		}
+		filter.Query = value
+	}
+
+	if value := query.Get("sort"); value != "" {
+		sort, err := repository.ParseComputerSort(value)
+		if err != nil {
+			errs["sort"] = err.Error()
+		}
+		filter.Sort = sort
+	}
+
+	return filter, errs
+}
+
+// parseCursorPagination translates pagination parameters into repository parameters, decoding the
+// cursor and count mode. Without a cursor the exact total is counted as before; listings paginated
+// by cursor skip counting unless it is requested.
+func parseCursorPagination(params PaginationParams) (repository.PaginationParams, map[string]string) {
+	result := repository.PaginationParams{
+		Offset: params.Offset,
+		Limit:  params.Limit,
+		Count:  repository.CountExact,
+	}
+	errs := make(map[string]string)
+
+	if params.Cursor != "" {
+		cursor, err := repository.DecodeCursor(params.Cursor)
+		if err != nil {
+			errs["cursor"] = "cursor is invalid or has expired"
+		}
+		result.After = cursor
+		result.Count = repository.CountNone
+	}
+
+	if params.Count != "" {
+		mode, ok := repository.ParseCountMode(params.Count)
+		if !ok {
+			errs["count"] = "count must be exact, estimated or none"
+		} else {
+			result.Count = mode
+		}
+	}
+
+	return result, errs
+}
+
+// parseFilterTime parses an RFC 3339 timestamp or a date, which is taken as midnight UTC
+func parseFilterTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
 }
 
 // GetComputerHandler handles the retrieval of a single computer by ID.
@@ -140,18 +274,18 @@ func (h *ComputerHandler) GetComputerHandler(w http.ResponseWriter, r *http.Requ
 	defer cancel()
 
 	vars := mux.Vars(r)
-	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, vars["id"])
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, vars["id"])
 	if !valid {
 		return
 	}
 
-	computer, err := h.Repo.GetComputerByID(ctx, id)
+	computer, err := h.Service.GetComputerByID(ctx, id)
 	if err != nil {
-		h.ErrorHandler.HandleRepositoryError(w, err, "retrieve")
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve computer")
 		return
 	}
 
-	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, computer)
+	h.ErrorHandler.SendConditionalJSONResponse(w, r, h.ResponseHelper.ComputerETag(computer), computer)
 }
 
 // UpdateComputerHandler handles the update of a computer.
@@ -160,39 +294,130 @@ func (h *ComputerHandler) UpdateComputerHandler(w http.ResponseWriter, r *http.R
 	defer cancel()
 
 	vars := mux.Vars(r)
-	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, vars["id"])
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, vars["id"])
+	if !valid {
+		return
+	}
+
+	ctx, valid = h.withPreconditions(ctx, w, r)
 	if !valid {
 		return
 	}
 
 	var computer model.Computer
 	if err := json.NewDecoder(r.Body).Decode(&computer); err != nil {
-		h.ErrorHandler.HandleJSONDecodeError(w, err)
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
 		return
 	}
 
-	// Validate input using validation package
-	if validationErrors := validation.ValidateComputerInputForUpdate(&computer); len(validationErrors) > 0 {
-		// Convert []string to map[string]string for ErrorHandler
-		errorMap := make(map[string]string)
-		for i, err := range validationErrors {
-			errorMap[fmt.Sprintf("error_%d", i)] = err
-		}
-		h.ErrorHandler.HandleValidationErrors(w, errorMap)
+	updated, err := h.Service.UpdateComputer(ctx, id, computer)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "update computer")
 		return
 	}
 
-	if err := h.Repo.UpdateComputer(ctx, id, computer); err != nil {
-		h.ErrorHandler.HandleRepositoryError(w, err, "update")
+	// Send success response with the new ETag so the client can make its next change conditional
+	w.Header().Set("ETag", h.ResponseHelper.ComputerETag(updated))
+	successData := h.ResponseHelper.CreateComputerSuccessData(id.String(), "")
+	addMACWarnings(successData, updated.MACWarnings)
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Computer updated successfully", successData)
+}
+
+// PatchComputerHandler handles a partial update of a computer. The body is an RFC 7396 merge
+// patch (application/merge-patch+json or application/json) or an RFC 6902 JSON Patch
+// (application/json-patch+json).
+func (h *ComputerHandler) PatchComputerHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	vars := mux.Vars(r)
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, vars["id"])
+	if !valid {
 		return
 	}
 
-	// Async notification (non-blocking)
-	go h.checkAndNotify(computer.EmployeeAbbreviation)
+	ctx, valid = h.withPreconditions(ctx, w, r)
+	if !valid {
+		return
+	}
 
-	// Send success response
-	successData := h.ResponseHelper.CreateComputerSuccessData(id.String(), "")
-	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Computer updated successfully", successData)
+	mediaType, supported := parsePatchMediaType(r.Header.Get("Content-Type"))
+	if !supported {
+		w.Header().Set("Accept-Patch", strings.Join([]string{patch.MergePatchMediaType, patch.JSONPatchMediaType}, ", "))
+		h.ErrorHandler.SendErrorResponse(w, http.StatusUnsupportedMediaType, "Unsupported patch format", "UNSUPPORTED_MEDIA_TYPE", nil)
+		return
+	}
+
+	document, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	updated, err := h.Service.PatchComputer(ctx, id, mediaType, document)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "update computer")
+		return
+	}
+
+	w.Header().Set("ETag", h.ResponseHelper.ComputerETag(updated))
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Computer updated successfully", updated)
+}
+
+// addMACWarnings adds the warnings about an accepted MAC address to the data of a success
+// response, so clients registering a computer learn about them right away
+func addMACWarnings(data map[string]interface{}, warnings []string) {
+	if len(warnings) > 0 {
+		data["mac_warnings"] = warnings
+	}
+}
+
+// parsePatchMediaType returns the patch format of a Content-Type header. Plain JSON and a
+// missing header are treated as a merge patch.
+func parsePatchMediaType(contentType string) (string, bool) {
+	if contentType == "" {
+		return patch.MergePatchMediaType, true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case patch.MergePatchMediaType, "application/json":
+		return patch.MergePatchMediaType, true
+	case patch.JSONPatchMediaType:
+		return patch.JSONPatchMediaType, true
+	default:
+		return "", false
+	}
+}
+
+// withPreconditions makes the changes made with ctx conditional on the request's If-Match
+// header. A wildcard only requires the computer to exist. When RequireIfMatch is set and the
+// header is missing it responds with 428 Precondition Required and returns false.
+func (h *ComputerHandler) withPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool) {
+	return withComputerPreconditions(ctx, w, r, h.ErrorHandler, h.ResponseHelper, h.RequireIfMatch)
+}
+
+// withComputerPreconditions makes the changes made with ctx conditional on the computer version
+// named by the request's If-Match header, for every handler that changes a computer
+func withComputerPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Request, errorHandler *ErrorHandler, responseHelper *ResponseHelper, requireIfMatch bool) (context.Context, bool) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		if requireIfMatch {
+			errorHandler.HandleServiceError(w, r, apperrors.PreconditionRequiredError("If-Match"), "change computer")
+			return ctx, false
+		}
+		return ctx, true
+	}
+
+	versions, wildcard := responseHelper.ParseIfMatch(header)
+	if wildcard {
+		return ctx, true
+	}
+	return service.WithExpectedVersions(ctx, versions...), true
 }
 
 // DeleteComputerHandler handles the deletion of a computer.
@@ -201,13 +426,18 @@ func (h *ComputerHandler) DeleteComputerHandler(w http.ResponseWriter, r *http.R
 	defer cancel()
 
 	vars := mux.Vars(r)
-	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, vars["id"])
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, vars["id"])
+	if !valid {
+		return
+	}
+
+	ctx, valid = h.withPreconditions(ctx, w, r)
 	if !valid {
 		return
 	}
 
-	if err := h.Repo.DeleteComputer(ctx, id); err != nil {
-		h.ErrorHandler.HandleRepositoryError(w, err, "delete")
+	if err := h.Service.DeleteComputer(ctx, id); err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "delete computer")
 		return
 	}
 
@@ -224,26 +454,23 @@ func (h *ComputerHandler) GetEmployeeComputersHandler(w http.ResponseWriter, r *
 	vars := mux.Vars(r)
 	employeeAbbreviation := vars["employee_abbreviation"]
 
-	if err := validation.ValidateEmployeeAbbreviation(employeeAbbreviation); err != nil {
-		h.ErrorHandler.HandleEmployeeAbbreviationError(w, err)
-		return
-	}
-
 	// Parse pagination parameters
 	paginationParams := h.ResponseHelper.ParsePaginationParams(r)
+	repositoryParams, paginationErrors := parseCursorPagination(paginationParams)
+	if len(paginationErrors) > 0 {
+		h.ErrorHandler.HandleValidationErrors(w, paginationErrors)
+		return
+	}
 
 	// Always use paginated endpoint for list operations
-	result, err := h.Repo.GetComputersByEmployeePaginated(ctx, employeeAbbreviation, repository.PaginationParams{
-		Offset: paginationParams.Offset,
-		Limit:  paginationParams.Limit,
-	})
+	result, err := h.Service.GetComputersByEmployee(ctx, employeeAbbreviation, repositoryParams)
 	if err != nil {
-		h.ErrorHandler.HandleRepositoryError(w, err, "retrieve")
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve computers")
 		return
 	}
 
 	// Calculate pagination metadata
-	paginationMeta := h.ResponseHelper.CalculatePaginationMeta(paginationParams, result.TotalCount)
+	paginationMeta := h.ResponseHelper.CalculateCursorPaginationMeta(paginationParams, result)
 
 	// Create paginated response
 	responseData := h.ResponseHelper.CreatePaginatedListResponseData(result.Items, paginationMeta, map[string]interface{}{
@@ -252,47 +479,7 @@ func (h *ComputerHandler) GetEmployeeComputersHandler(w http.ResponseWriter, r *
 	})
 	delete(responseData, "items") // Remove generic "items" key
 
-	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, responseData)
-}
-
-// checkAndNotify performs asynchronous notification checking
-func (h *ComputerHandler) checkAndNotify(employeeAbbreviation string) {
-	if employeeAbbreviation == "" {
-		return
-	}
-
-	ctx, cancel := h.ResponseHelper.CreateRequestContext(&http.Request{}, NotificationTimeout)
-	defer cancel()
-
-	computers, err := h.Repo.GetComputersByEmployee(ctx, employeeAbbreviation)
-	if err != nil {
-		h.Logger.Printf("Failed to check employee computers for notification: %v", err)
-		return
-	}
-
-	if len(computers) >= MaxComputersThreshold {
-		notification := notification.Notification{
-			Level:                notification.LevelWarning,
-			EmployeeAbbreviation: employeeAbbreviation,
-			Message:              fmt.Sprintf("Employee %s has %d computers assigned (threshold: %d)", employeeAbbreviation, len(computers), MaxComputersThreshold),
-			Metadata: map[string]string{
-				"computer_count": fmt.Sprintf("%d", len(computers)),
-				"threshold":      fmt.Sprintf("%d", MaxComputersThreshold),
-			},
-		}
-
-		if err := h.Notifier.SendNotification(notification); err != nil {
-			h.Logger.Printf("Failed to send notification for employee %s: %v", employeeAbbreviation, err)
-		} else {
-			h.Logger.Printf("Notification sent for employee %s (%d computers)", employeeAbbreviation, len(computers))
-		}
-	}
-}
-
-// HealthHandler provides a health check endpoint
-func (h *ComputerHandler) HealthHandler(w http.ResponseWriter, r *http.Request) {
-	healthData := h.ResponseHelper.CreateHealthCheckData()
-	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Service is healthy", healthData)
+	h.ErrorHandler.SendConditionalJSONResponse(w, r, "", responseData)
 }
 
 // RemoveComputerFromEmployeeHandler handles removing a computer from an employee.
@@ -305,29 +492,26 @@ func (h *ComputerHandler) RemoveComputerFromEmployeeHandler(w http.ResponseWrite
 	employeeAbbreviation := vars["employee_abbreviation"]
 	computerIDStr := vars["computer_id"]
 
-	// Validate employee abbreviation
-	if err := validation.ValidateEmployeeAbbreviation(employeeAbbreviation); err != nil {
-		h.ErrorHandler.HandleEmployeeAbbreviationError(w, err)
+	// Parse and validate computer ID
+	computerID, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, computerIDStr)
+	if !valid {
 		return
 	}
 
-	// Parse and validate computer ID
-	computerID, valid := h.ErrorHandler.ParseAndValidateUUID(w, computerIDStr)
+	ctx, valid = h.withPreconditions(ctx, w, r)
 	if !valid {
 		return
 	}
 
 	// Remove computer from employee
-	if err := h.Repo.RemoveComputerFromEmployee(ctx, computerID, employeeAbbreviation); err != nil {
-		if err.Error() == fmt.Sprintf("computer not found or not assigned to employee %s", employeeAbbreviation) {
-			h.ErrorHandler.SendErrorResponse(w, http.StatusNotFound, "Computer not found or not assigned to this employee", "COMPUTER_NOT_FOUND", nil)
-			return
-		}
-		h.ErrorHandler.HandleRepositoryError(w, err, "remove computer from employee")
+	unassigned, err := h.Service.RemoveComputerFromEmployee(ctx, computerID, employeeAbbreviation)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "remove computer from employee")
 		return
 	}
 
-	// Send success response
+	// Send success response with the new ETag so the client can make its next change conditional
+	w.Header().Set("ETag", h.ResponseHelper.ComputerETag(unassigned))
 	successData := h.ResponseHelper.CreateComputerSuccessData(computerID.String(), employeeAbbreviation)
 	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Computer successfully removed from employee", successData)
 }
@@ -342,32 +526,26 @@ func (h *ComputerHandler) AssignComputerToEmployeeHandler(w http.ResponseWriter,
 	employeeAbbreviation := vars["employee_abbreviation"]
 	computerIDStr := vars["computer_id"]
 
-	// Validate employee abbreviation
-	if err := validation.ValidateEmployeeAbbreviation(employeeAbbreviation); err != nil {
-		h.ErrorHandler.HandleEmployeeAbbreviationError(w, err)
+	// Parse and validate computer ID
+	computerID, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, computerIDStr)
+	if !valid {
 		return
 	}
 
-	// Parse and validate computer ID
-	computerID, valid := h.ErrorHandler.ParseAndValidateUUID(w, computerIDStr)
+	ctx, valid = h.withPreconditions(ctx, w, r)
 	if !valid {
 		return
 	}
 
 	// Assign computer to employee
-	if err := h.Repo.AssignComputerToEmployee(ctx, computerID, employeeAbbreviation); err != nil {
-		if err.Error() == fmt.Sprintf("computer with ID %s not found", computerID) {
-			h.ErrorHandler.SendErrorResponse(w, http.StatusNotFound, "Computer not found", "COMPUTER_NOT_FOUND", nil)
-			return
-		}
-		h.ErrorHandler.HandleRepositoryError(w, err, "assign computer to employee")
+	assigned, err := h.Service.AssignComputerToEmployee(ctx, computerID, employeeAbbreviation)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "assign computer to employee")
 		return
 	}
 
-	// Async notification check (non-blocking)
-	go h.checkAndNotify(employeeAbbreviation)
-
-	// Send success response
+	// Send success response with the new ETag so the client can make its next change conditional
+	w.Header().Set("ETag", h.ResponseHelper.ComputerETag(assigned))
 	successData := h.ResponseHelper.CreateComputerSuccessData(computerID.String(), employeeAbbreviation)
 	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Computer successfully assigned to employee", successData)
 }
@@ -2,16 +2,19 @@ package handler
 
 import (
 	"bytes"
+	"computer-management-api/internal/logging"
 	"computer-management-api/internal/model"
-	"computer-management-api/internal/notification"
 	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"computer-management-api/internal/tracing"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -27,13 +30,15 @@ type MockComputerRepository struct {
 	// Function fields to set expectations
 	CreateComputerFunc                  func(ctx context.Context, computer model.Computer) error
 	GetAllComputersFunc                 func(ctx context.Context) ([]model.Computer, error)
-	GetAllComputersPaginatedFunc        func(ctx context.Context, params repository.PaginationParams) (*repository.PaginatedResult, error)
+	GetAllComputersPaginatedFunc        func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error)
 	GetComputerByIDFunc                 func(ctx context.Context, id uuid.UUID) (*model.Computer, error)
 	UpdateComputerFunc                  func(ctx context.Context, id uuid.UUID, computer model.Computer) error
 	DeleteComputerFunc                  func(ctx context.Context, id uuid.UUID) error
 	GetComputersByEmployeeFunc          func(ctx context.Context, employeeAbbreviation string) ([]model.Computer, error)
 	GetComputersByEmployeePaginatedFunc func(ctx context.Context, employeeAbbreviation string, params repository.PaginationParams) (*repository.PaginatedResult, error)
 	ComputerExistsFunc                  func(ctx context.Context, macAddress string) (bool, error)
+	GetComputersByMACsFunc              func(ctx context.Context, macAddresses []string) ([]model.Computer, error)
+	StreamComputersFunc                 func(ctx context.Context, filter repository.ComputerFilter, fn func(model.Computer) error) error
 	AssignComputerToEmployeeFunc        func(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error
 	RemoveComputerFromEmployeeFunc      func(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error
 }
@@ -52,19 +57,33 @@ func (m *MockComputerRepository) GetAllComputers(ctx context.Context) ([]model.C
 	return []model.Computer{}, nil
 }
 
-func (m *MockComputerRepository) GetAllComputersPaginated(ctx context.Context, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+func (m *MockComputerRepository) GetAllComputersPaginated(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
 	if m.GetAllComputersPaginatedFunc != nil {
-		return m.GetAllComputersPaginatedFunc(ctx, params)
+		return m.GetAllComputersPaginatedFunc(ctx, filter, params)
 	}
 	return &repository.PaginatedResult{Items: []model.Computer{}, TotalCount: 0}, nil
 }
 
+func (m *MockComputerRepository) StreamComputers(ctx context.Context, filter repository.ComputerFilter, fn func(model.Computer) error) error {
+	if m.StreamComputersFunc != nil {
+		return m.StreamComputersFunc(ctx, filter, fn)
+	}
+	return nil
+}
+
 func (m *MockComputerRepository) GetComputerByMAC(ctx context.Context, macAddress string) (*model.Computer, error) {
 	// This method is still needed for repository interface compliance
 	// even though the handler endpoint was removed
 	return nil, repository.ErrComputerNotFound
 }
 
+func (m *MockComputerRepository) GetComputersByMACs(ctx context.Context, macAddresses []string) ([]model.Computer, error) {
+	if m.GetComputersByMACsFunc != nil {
+		return m.GetComputersByMACsFunc(ctx, macAddresses)
+	}
+	return []model.Computer{}, nil
+}
+
 func (m *MockComputerRepository) GetComputerByID(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
 	if m.GetComputerByIDFunc != nil {
 		return m.GetComputerByIDFunc(ctx, id)
@@ -72,6 +91,10 @@ func (m *MockComputerRepository) GetComputerByID(ctx context.Context, id uuid.UU
 	return nil, repository.ErrComputerNotFound
 }
 
+func (m *MockComputerRepository) GetComputerByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	return m.GetComputerByID(ctx, id)
+}
+
 func (m *MockComputerRepository) UpdateComputer(ctx context.Context, id uuid.UUID, computer model.Computer) error {
 	if m.UpdateComputerFunc != nil {
 		return m.UpdateComputerFunc(ctx, id, computer)
@@ -121,36 +144,25 @@ func (m *MockComputerRepository) ComputerExists(ctx context.Context, macAddress
 	return false, nil
 }
 
-// MockNotifier is a mock implementation of Notifier
-type MockNotifier struct {
-	SendNotificationFunc            func(notification notification.Notification) error
-	SendNotificationWithContextFunc func(ctx context.Context, notification notification.Notification) error
-	IsHealthyFunc                   func(ctx context.Context) bool
-	// Track calls for verification
-	NotificationsSent []notification.Notification
-}
+// MockOutboxRepository is a mock implementation of OutboxRepository that records enqueued messages
+type MockOutboxRepository struct {
+	repository.OutboxRepository
 
-func (m *MockNotifier) SendNotification(notification notification.Notification) error {
-	m.NotificationsSent = append(m.NotificationsSent, notification)
-	if m.SendNotificationFunc != nil {
-		return m.SendNotificationFunc(notification)
-	}
-	return nil
+	Messages []model.OutboxMessage
 }
 
-func (m *MockNotifier) SendNotificationWithContext(ctx context.Context, notification notification.Notification) error {
-	m.NotificationsSent = append(m.NotificationsSent, notification)
-	if m.SendNotificationWithContextFunc != nil {
-		return m.SendNotificationWithContextFunc(ctx, notification)
-	}
+func (m *MockOutboxRepository) Enqueue(ctx context.Context, message model.OutboxMessage) error {
+	m.Messages = append(m.Messages, message)
 	return nil
 }
 
-func (m *MockNotifier) IsHealthy(ctx context.Context) bool {
-	if m.IsHealthyFunc != nil {
-		return m.IsHealthyFunc(ctx)
+// Types returns the notification types of the enqueued messages in order
+func (m *MockOutboxRepository) Types() []string {
+	types := make([]string, 0, len(m.Messages))
+	for _, message := range m.Messages {
+		types = append(types, message.NotificationType)
 	}
-	return true
+	return types
 }
 
 // Helper functions for tests
@@ -163,20 +175,28 @@ func createTestComputer() model.Computer {
 		IPAddress:            "192.168.1.100",
 		EmployeeAbbreviation: "ABC",
 		Description:          "Test computer",
+		Version:              1,
 		CreatedAt:            time.Now(),
 		UpdatedAt:            time.Now(),
 	}
 }
 
-func createTestHandler() (*ComputerHandler, *MockComputerRepository, *MockNotifier) {
+func createTestHandler() (*ComputerHandler, *MockComputerRepository, *MockOutboxRepository) {
 	mockRepo := &MockComputerRepository{}
-	mockNotifier := &MockNotifier{
-		NotificationsSent: make([]notification.Notification, 0),
-	}
-	logger := log.New(bytes.NewBuffer([]byte{}), "", 0) // Silent logger for tests
-
-	handler := NewComputerHandler(mockRepo, mockNotifier, logger)
-	return handler, mockRepo, mockNotifier
+	mockOutbox := &MockOutboxRepository{}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+
+	transactor := &MockTransactor{Repos: repository.Repositories{
+		Computers: mockRepo,
+		Employees: &MockEmployeeRepository{},
+		Events:    &MockEventRepository{},
+		Outbox:    mockOutbox,
+		Policies:  &MockPolicyRepository{},
+		Subnets:   &MockSubnetRepository{},
+	}}
+	svc := service.NewComputerService(mockRepo, &MockEmployeeRepository{}, transactor, logger)
+	handler := NewComputerHandler(svc, logger)
+	return handler, mockRepo, mockOutbox
 }
 
 func createJSONRequest(method, url string, body interface{}) *http.Request {
@@ -192,7 +212,7 @@ func createJSONRequest(method, url string, body interface{}) *http.Request {
 // Test CreateComputerHandler
 
 func TestCreateComputerHandler_Success(t *testing.T) {
-	handler, mockRepo, mockNotifier := createTestHandler()
+	handler, mockRepo, mockOutbox := createTestHandler()
 
 	computer := createTestComputer()
 	computer.ID = uuid.Nil // ID should be auto-generated
@@ -205,7 +225,7 @@ func TestCreateComputerHandler_Success(t *testing.T) {
 		return nil
 	}
 
-	// Mock the async notification check
+	// Mock the threshold check made inside the transaction
 	mockRepo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
 		if emp != "ABC" {
 			t.Errorf("Expected employee ABC, got %s", emp)
@@ -213,9 +233,6 @@ func TestCreateComputerHandler_Success(t *testing.T) {
 		return []model.Computer{computer}, nil
 	}
 
-	// Ensure mockNotifier is used
-	_ = mockNotifier
-
 	req := createJSONRequest("POST", "/computers", computer)
 	rr := httptest.NewRecorder()
 
@@ -236,6 +253,41 @@ func TestCreateComputerHandler_Success(t *testing.T) {
 	if response.Data == nil {
 		t.Error("Expected response data to be present")
 	}
+
+	// Only the creation notice is queued while the employee is below the threshold
+	if types := mockOutbox.Types(); len(types) != 1 || types[0] != string(service.NotificationTypeComputerCreated) {
+		t.Errorf("Expected a single computer_created notification, got %v", types)
+	}
+}
+
+func TestCreateComputerHandler_MACWarnings(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	computer.MACAddress = "02:00:5E:10:00:01"
+	computer.EmployeeAbbreviation = ""
+	mockRepo.CreateComputerFunc = func(ctx context.Context, c model.Computer) error { return nil }
+
+	req := createJSONRequest("POST", "/computers", computer)
+	rr := httptest.NewRecorder()
+
+	handler.CreateComputerHandler(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Data struct {
+			MACWarnings []string `json:"mac_warnings"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Data.MACWarnings) != 1 || !strings.Contains(response.Data.MACWarnings[0], "locally administered") {
+		t.Errorf("Expected a locally administered warning, got %v", response.Data.MACWarnings)
+	}
 }
 
 func TestCreateComputerHandler_InvalidJSON(t *testing.T) {
@@ -303,6 +355,8 @@ func TestCreateComputerHandler_RepositoryError(t *testing.T) {
 
 	req := createJSONRequest("POST", "/computers", computer)
 	rr := httptest.NewRecorder()
+	rr.Header().Set(logging.RequestIDHeader, "req-1")
+	rr.Header().Set(tracing.TraceIDHeader, "4bf92f3577b34da6a3ce929d0e0e4736")
 
 	handler.CreateComputerHandler(rr, req)
 
@@ -318,6 +372,12 @@ func TestCreateComputerHandler_RepositoryError(t *testing.T) {
 	if !strings.Contains(response.Error, "Failed to create computer") {
 		t.Errorf("Expected repository error message, got %s", response.Error)
 	}
+	if response.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the trace ID in the error response, got %q", response.TraceID)
+	}
+	if response.RequestID != "req-1" {
+		t.Errorf("Expected the request ID in the error response, got %q", response.RequestID)
+	}
 }
 
 // Test GetAllComputersHandler
@@ -331,7 +391,7 @@ func TestGetAllComputersHandler_Success(t *testing.T) {
 		TotalCount: 2,
 	}
 
-	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
 		if params.Offset != 0 || params.Limit != 10 {
 			t.Errorf("Expected default pagination params (offset: 0, limit: 10), got offset: %d, limit: %d", params.Offset, params.Limit)
 		}
@@ -369,7 +429,7 @@ func TestGetAllComputersHandler_WithPagination(t *testing.T) {
 		TotalCount: 25,
 	}
 
-	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
 		if params.Offset != 10 || params.Limit != 5 {
 			t.Errorf("Expected pagination params (offset: 10, limit: 5), got offset: %d, limit: %d", params.Offset, params.Limit)
 		}
@@ -406,7 +466,7 @@ func TestGetAllComputersHandler_WithPagination(t *testing.T) {
 func TestGetAllComputersHandler_RepositoryError(t *testing.T) {
 	handler, mockRepo, _ := createTestHandler()
 
-	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
 		return nil, errors.New("database error")
 	}
 
@@ -420,6 +480,191 @@ func TestGetAllComputersHandler_RepositoryError(t *testing.T) {
 	}
 }
 
+func TestGetAllComputersHandler_Filters(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	var received repository.ComputerFilter
+	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+		received = filter
+		return &repository.PaginatedResult{Items: []model.Computer{}}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/computers?mac_prefix=00-1b-44&subnet=192.168.1.0/24&ip_in=2001:db8::/32&employee=ABC&assigned=true"+
+		"&created_after=2024-01-01&updated_before=2024-06-30T12:00:00Z&q=dell+laptop&sort=-created_at,computer_name", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetAllComputersHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if received.MACPrefix != "00:1B:44" {
+		t.Errorf("Expected normalized MAC prefix 00:1B:44, got %s", received.MACPrefix)
+	}
+	if received.Subnet == nil || received.Subnet.String() != "192.168.1.0/24" {
+		t.Errorf("Expected subnet 192.168.1.0/24, got %v", received.Subnet)
+	}
+	if received.IPIn == nil || received.IPIn.String() != "2001:db8::/32" {
+		t.Errorf("Expected ip_in 2001:db8::/32, got %v", received.IPIn)
+	}
+	if received.EmployeeAbbreviation != "ABC" || received.Assigned == nil || !*received.Assigned {
+		t.Errorf("Expected assigned computers of ABC, got %+v", received)
+	}
+	if !received.CreatedAfter.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected created_after 2024-01-01, got %s", received.CreatedAfter)
+	}
+	if !received.UpdatedBefore.Equal(time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected updated_before 2024-06-30T12:00:00Z, got %s", received.UpdatedBefore)
+	}
+	if received.Query != "dell laptop" {
+		t.Errorf("Expected search query 'dell laptop', got %s", received.Query)
+	}
+	expectedSort := []repository.SortField{{Field: "created_at", Descending: true}, {Field: "computer_name"}}
+	if len(received.Sort) != 2 || received.Sort[0] != expectedSort[0] || received.Sort[1] != expectedSort[1] {
+		t.Errorf("Expected sort %v, got %v", expectedSort, received.Sort)
+	}
+}
+
+func TestGetAllComputersHandler_Vendor(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	vm := createTestComputer()
+	vm.MACAddress = "00:50:56:12:34:56"
+	var received repository.ComputerFilter
+	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+		received = filter
+		return &repository.PaginatedResult{Items: []model.Computer{vm}}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/computers?vendor=vmware", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetAllComputersHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if received.Vendor != "vmware" || !slices.Contains(received.MACPrefixes, "00:50:56") {
+		t.Errorf("Expected the vendor to be resolved into its MAC prefixes, got %+v", received)
+	}
+
+	var response struct {
+		Computers []model.Computer `json:"computers"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Computers) != 1 || response.Computers[0].Vendor != "VMware, Inc." {
+		t.Errorf("Expected the computer with its vendor, got %+v", response.Computers)
+	}
+}
+
+func TestGetAllComputersHandler_InvalidFilters(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+		t.Error("Expected the repository not to be queried")
+		return nil, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/computers?mac_prefix=XY&subnet=10.0.0.0/40&ip_in=2001:db8::/129&assigned=maybe&created_after=yesterday&sort=password&cursor=%21&count=some", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetAllComputersHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	for _, param := range []string{"mac_prefix", "subnet", "ip_in", "assigned", "created_after", "sort", "cursor", "count"} {
+		if _, ok := response.Details[param]; !ok {
+			t.Errorf("Expected an error for %s, got %v", param, response.Details)
+		}
+	}
+}
+
+func TestGetAllComputersHandler_Cursor(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	cursor := repository.Cursor{Sort: "computer_name", Values: []string{"PC-A"}, ID: uuid.New()}
+	next := repository.Cursor{Sort: "computer_name", Values: []string{computer.ComputerName}, ID: computer.ID}
+
+	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+		if params.After == nil || params.After.ID != cursor.ID {
+			t.Errorf("Expected cursor %v, got %v", cursor, params.After)
+		}
+		if params.Count != repository.CountNone {
+			t.Errorf("Expected counting to be skipped, got %s", params.Count)
+		}
+		return &repository.PaginatedResult{
+			Items:      []model.Computer{computer},
+			TotalCount: repository.UnknownTotalCount,
+			NextCursor: &next,
+		}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/computers?page_size=1&cursor="+cursor.Encode(), nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetAllComputersHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Pagination PaginationMeta `json:"pagination"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	pagination := response.Pagination
+	if pagination.NextCursor != next.Encode() || pagination.Cursor != cursor.Encode() {
+		t.Errorf("Expected cursors %s and %s, got %+v", cursor.Encode(), next.Encode(), pagination)
+	}
+	if !pagination.HasNext || pagination.HasPrevious {
+		t.Errorf("Expected a next page and no previous page, got %+v", pagination)
+	}
+	if pagination.TotalItems != nil || pagination.Page != 0 || pagination.TotalPages != 0 {
+		t.Errorf("Expected no page numbers or totals, got %+v", pagination)
+	}
+}
+
+func TestGetAllComputersHandler_EstimatedCount(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+		if params.After != nil || params.Count != repository.CountEstimated {
+			t.Errorf("Expected offset pagination with an estimated count, got %+v", params)
+		}
+		return &repository.PaginatedResult{Items: []model.Computer{}, TotalCount: 200000, TotalCountEstimated: true}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/computers?page=2&count=estimated", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetAllComputersHandler(rr, req)
+
+	var response struct {
+		Pagination PaginationMeta `json:"pagination"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	pagination := response.Pagination
+	if pagination.TotalItems == nil || *pagination.TotalItems != 200000 || !pagination.TotalItemsEstimated {
+		t.Errorf("Expected an estimated total of 200000, got %+v", pagination)
+	}
+	if pagination.Page != 2 || pagination.HasNext || !pagination.HasPrevious {
+		t.Errorf("Expected the last page 2, got %+v", pagination)
+	}
+}
+
 // Test GetComputerHandler
 
 func TestGetComputerHandler_Success(t *testing.T) {
@@ -503,12 +748,18 @@ func TestGetComputerHandler_NotFound(t *testing.T) {
 // Test UpdateComputerHandler
 
 func TestUpdateComputerHandler_Success(t *testing.T) {
-	handler, mockRepo, mockNotifier := createTestHandler()
+	handler, mockRepo, mockOutbox := createTestHandler()
 
 	computerID := uuid.New()
 	computer := createTestComputer()
 	computer.ComputerName = "UPDATED-001"
 
+	mockRepo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		existing := createTestComputer()
+		existing.ID = id
+		return &existing, nil
+	}
+
 	mockRepo.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, c model.Computer) error {
 		if id != computerID {
 			t.Errorf("Expected computer ID %s, got %s", computerID, id)
@@ -519,14 +770,6 @@ func TestUpdateComputerHandler_Success(t *testing.T) {
 		return nil
 	}
 
-	// Mock the async notification check
-	mockRepo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
-		return []model.Computer{computer}, nil
-	}
-
-	// Ensure mockNotifier is used
-	_ = mockNotifier
-
 	req := createJSONRequest("PUT", fmt.Sprintf("/computers/%s", computerID), computer)
 	req = mux.SetURLVars(req, map[string]string{"id": computerID.String()})
 	rr := httptest.NewRecorder()
@@ -545,6 +788,147 @@ func TestUpdateComputerHandler_Success(t *testing.T) {
 	if response.Message != "Computer updated successfully" {
 		t.Errorf("Expected update success message, got %s", response.Message)
 	}
+
+	// The assignment did not change, so nothing is worth notifying about
+	if len(mockOutbox.Messages) != 0 {
+		t.Errorf("Expected no notifications, got %v", mockOutbox.Types())
+	}
+}
+
+// Test PatchComputerHandler
+
+// createPatchRequest builds a PATCH request with a raw body and content type
+func createPatchRequest(id uuid.UUID, contentType, body string) *http.Request {
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf("/computers/%s", id), strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	return mux.SetURLVars(req, map[string]string{"id": id.String()})
+}
+
+// mockStoredComputer makes the mock repository return the last computer written to it
+func mockStoredComputer(mockRepo *MockComputerRepository, computer model.Computer) *model.Computer {
+	stored := computer
+	mockRepo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		current := stored
+		return &current, nil
+	}
+	mockRepo.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, c model.Computer) error {
+		c.Version = stored.Version + 1
+		stored = c
+		return nil
+	}
+	return &stored
+}
+
+func TestPatchComputerHandler_MergePatch(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	stored := mockStoredComputer(mockRepo, computer)
+
+	req := createPatchRequest(computer.ID, "application/merge-patch+json", `{"computer_name":"PATCHED-001","description":null}`)
+	rr := httptest.NewRecorder()
+
+	handler.PatchComputerHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if stored.ComputerName != "PATCHED-001" {
+		t.Errorf("Expected computer name PATCHED-001, got %s", stored.ComputerName)
+	}
+	if stored.Description != "" {
+		t.Errorf("Expected null to clear the description, got %s", stored.Description)
+	}
+	if stored.MACAddress != computer.MACAddress || stored.IPAddress != computer.IPAddress || stored.EmployeeAbbreviation != "ABC" {
+		t.Errorf("Expected fields missing from the patch to be kept, got %+v", *stored)
+	}
+}
+
+func TestPatchComputerHandler_JSONPatch(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	stored := mockStoredComputer(mockRepo, computer)
+
+	req := createPatchRequest(computer.ID, "application/json-patch+json",
+		`[{"op":"test","path":"/employee_abbreviation","value":"ABC"},{"op":"remove","path":"/employee_abbreviation"}]`)
+	rr := httptest.NewRecorder()
+
+	handler.PatchComputerHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if stored.EmployeeAbbreviation != "" {
+		t.Errorf("Expected employee to be unassigned, got %s", stored.EmployeeAbbreviation)
+	}
+}
+
+func TestPatchComputerHandler_JSONPatchTestFailed(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	mockStoredComputer(mockRepo, computer)
+
+	req := createPatchRequest(computer.ID, "application/json-patch+json", `[{"op":"test","path":"/employee_abbreviation","value":"XYZ"}]`)
+	rr := httptest.NewRecorder()
+
+	handler.PatchComputerHandler(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestPatchComputerHandler_ValidatesMergedResult(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	stored := mockStoredComputer(mockRepo, computer)
+
+	req := createPatchRequest(computer.ID, "application/merge-patch+json", `{"ip_address":null}`)
+	rr := httptest.NewRecorder()
+
+	handler.PatchComputerHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+	if stored.IPAddress != computer.IPAddress {
+		t.Error("Expected the invalid patch not to be stored")
+	}
+}
+
+func TestPatchComputerHandler_CannotChangeID(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	mockStoredComputer(mockRepo, computer)
+
+	req := createPatchRequest(computer.ID, "application/merge-patch+json", fmt.Sprintf(`{"id":"%s"}`, uuid.New()))
+	rr := httptest.NewRecorder()
+
+	handler.PatchComputerHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestPatchComputerHandler_UnsupportedMediaType(t *testing.T) {
+	handler, _, _ := createTestHandler()
+
+	req := createPatchRequest(uuid.New(), "text/plain", `computer_name=PATCHED`)
+	rr := httptest.NewRecorder()
+
+	handler.PatchComputerHandler(rr, req)
+
+	if rr.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected status code %d, got %d", http.StatusUnsupportedMediaType, rr.Code)
+	}
+	if rr.Header().Get("Accept-Patch") == "" {
+		t.Error("Expected Accept-Patch header to list the supported formats")
+	}
 }
 
 // Test DeleteComputerHandler
@@ -554,6 +938,12 @@ func TestDeleteComputerHandler_Success(t *testing.T) {
 
 	computerID := uuid.New()
 
+	mockRepo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		existing := createTestComputer()
+		existing.ID = id
+		return &existing, nil
+	}
+
 	mockRepo.DeleteComputerFunc = func(ctx context.Context, id uuid.UUID) error {
 		if id != computerID {
 			t.Errorf("Expected computer ID %s, got %s", computerID, id)
@@ -581,6 +971,277 @@ func TestDeleteComputerHandler_Success(t *testing.T) {
 	}
 }
 
+// Test conditional requests
+
+func TestGetComputerHandler_ETag(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	computer.Version = 7
+	mockStoredComputer(mockRepo, computer)
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/computers/%s", computer.ID), nil)
+	req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.GetComputerHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	if etag := rr.Header().Get("ETag"); etag != `"7"` {
+		t.Errorf("Expected ETag \"7\", got %s", etag)
+	}
+}
+
+func TestGetComputerHandler_IfNoneMatch(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	computer.Version = 7
+	mockStoredComputer(mockRepo, computer)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{"current version", `"7"`, http.StatusNotModified},
+		{"weak comparison", `W/"7"`, http.StatusNotModified},
+		{"one of several", `"5", "7"`, http.StatusNotModified},
+		{"stale version", `"6"`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/computers/%s", computer.ID), nil)
+			req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			rr := httptest.NewRecorder()
+
+			handler.GetComputerHandler(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("Expected status code %d, got %d", tt.wantStatus, rr.Code)
+			}
+			if tt.wantStatus == http.StatusNotModified && rr.Body.Len() != 0 {
+				t.Errorf("Expected an empty body, got %s", rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetAllComputersHandler_IfNoneMatch(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	mockRepo.GetAllComputersPaginatedFunc = func(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+		return &repository.PaginatedResult{Items: []model.Computer{computer}, TotalCount: 1}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/computers", nil)
+	rr := httptest.NewRecorder()
+	handler.GetAllComputersHandler(rr, req)
+
+	etag := rr.Header().Get("ETag")
+	if rr.Code != http.StatusOK || !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("Expected 200 with a weak ETag, got %d and %q", rr.Code, etag)
+	}
+
+	req, _ = http.NewRequest("GET", "/computers", nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	handler.GetAllComputersHandler(rr, req)
+
+	if rr.Code != http.StatusNotModified {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotModified, rr.Code)
+	}
+}
+
+func TestUpdateComputerHandler_IfMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		ifMatch    string
+		wantStatus int
+		wantETag   string
+	}{
+		{"current version", `"3"`, http.StatusOK, `"4"`},
+		{"one of several", `"2", "3"`, http.StatusOK, `"4"`},
+		{"wildcard", "*", http.StatusOK, `"4"`},
+		{"stale version", `"2"`, http.StatusPreconditionFailed, ""},
+		{"weak tag never matches", `W/"3"`, http.StatusPreconditionFailed, ""},
+		{"malformed tag", "3", http.StatusPreconditionFailed, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockRepo, _ := createTestHandler()
+
+			computer := createTestComputer()
+			computer.EmployeeAbbreviation = ""
+			computer.Version = 3
+			stored := mockStoredComputer(mockRepo, computer)
+
+			update := computer
+			update.ComputerName = "RENAMED-001"
+			req := createJSONRequest("PUT", fmt.Sprintf("/computers/%s", computer.ID), update)
+			req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+			req.Header.Set("If-Match", tt.ifMatch)
+			rr := httptest.NewRecorder()
+
+			handler.UpdateComputerHandler(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("Expected status code %d, got %d: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+			if etag := rr.Header().Get("ETag"); etag != tt.wantETag {
+				t.Errorf("Expected ETag %q, got %q", tt.wantETag, etag)
+			}
+			if tt.wantStatus == http.StatusPreconditionFailed {
+				var response ErrorResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if response.Code != "PRECONDITION_FAILED" {
+					t.Errorf("Expected code PRECONDITION_FAILED, got %s", response.Code)
+				}
+				if stored.ComputerName != computer.ComputerName {
+					t.Errorf("Expected the computer to be left unchanged, got name %s", stored.ComputerName)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchComputerHandler_IfMatchStale(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	computer.Version = 3
+	stored := mockStoredComputer(mockRepo, computer)
+
+	req := createPatchRequest(computer.ID, "application/merge-patch+json", `{"computer_name":"PATCHED-001"}`)
+	req.Header.Set("If-Match", `"2"`)
+	rr := httptest.NewRecorder()
+
+	handler.PatchComputerHandler(rr, req)
+
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusPreconditionFailed, rr.Code, rr.Body.String())
+	}
+	if stored.ComputerName != computer.ComputerName {
+		t.Errorf("Expected the computer to be left unchanged, got name %s", stored.ComputerName)
+	}
+}
+
+func TestDeleteComputerHandler_IfMatchStale(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	computer.Version = 3
+	mockStoredComputer(mockRepo, computer)
+
+	deleted := false
+	mockRepo.DeleteComputerFunc = func(ctx context.Context, id uuid.UUID) error {
+		deleted = true
+		return nil
+	}
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/computers/%s", computer.ID), nil)
+	req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+	req.Header.Set("If-Match", `"2"`)
+	rr := httptest.NewRecorder()
+
+	handler.DeleteComputerHandler(rr, req)
+
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status code %d, got %d", http.StatusPreconditionFailed, rr.Code)
+	}
+	if deleted {
+		t.Error("Expected the computer not to be deleted")
+	}
+}
+
+func TestAssignComputerToEmployeeHandler_IfMatchStale(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	computer.EmployeeAbbreviation = ""
+	computer.Version = 3
+	mockStoredComputer(mockRepo, computer)
+
+	assigned := false
+	mockRepo.AssignComputerToEmployeeFunc = func(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+		assigned = true
+		return nil
+	}
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/employees/ABC/computers/%s", computer.ID), nil)
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "ABC", "computer_id": computer.ID.String()})
+	req.Header.Set("If-Match", `"2"`)
+	rr := httptest.NewRecorder()
+
+	handler.AssignComputerToEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status code %d, got %d: %s", http.StatusPreconditionFailed, rr.Code, rr.Body.String())
+	}
+	if assigned {
+		t.Error("Expected the computer not to be assigned")
+	}
+}
+
+func TestRemoveComputerFromEmployeeHandler_IfMatchStale(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	computer.Version = 3
+	mockStoredComputer(mockRepo, computer)
+
+	removed := false
+	mockRepo.RemoveComputerFromEmployeeFunc = func(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+		removed = true
+		return nil
+	}
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/employees/ABC/computers/%s", computer.ID), nil)
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "ABC", "computer_id": computer.ID.String()})
+	req.Header.Set("If-Match", `"2"`)
+	rr := httptest.NewRecorder()
+
+	handler.RemoveComputerFromEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected status code %d, got %d: %s", http.StatusPreconditionFailed, rr.Code, rr.Body.String())
+	}
+	if removed {
+		t.Error("Expected the computer not to be removed")
+	}
+}
+
+func TestUpdateComputerHandler_IfMatchRequired(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+	handler.RequireIfMatch = true
+
+	computer := createTestComputer()
+	stored := mockStoredComputer(mockRepo, computer)
+
+	update := computer
+	update.ComputerName = "RENAMED-001"
+	req := createJSONRequest("PUT", fmt.Sprintf("/computers/%s", computer.ID), update)
+	req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateComputerHandler(rr, req)
+
+	if rr.Code != http.StatusPreconditionRequired {
+		t.Errorf("Expected status code %d, got %d", http.StatusPreconditionRequired, rr.Code)
+	}
+	if stored.ComputerName != computer.ComputerName {
+		t.Errorf("Expected the computer to be left unchanged, got name %s", stored.ComputerName)
+	}
+}
+
 // Test GetEmployeeComputersHandler
 
 func TestGetEmployeeComputersHandler_Success(t *testing.T) {
@@ -650,115 +1311,3 @@ func TestGetEmployeeComputersHandler_InvalidEmployee(t *testing.T) {
 		t.Errorf("Expected employee abbreviation error, got %s", response.Error)
 	}
 }
-
-// Test HealthHandler
-
-func TestHealthHandler_Success(t *testing.T) {
-	handler, _, _ := createTestHandler()
-
-	req, _ := http.NewRequest("GET", "/health", nil)
-	rr := httptest.NewRecorder()
-
-	handler.HealthHandler(rr, req)
-
-	if rr.Code != http.StatusOK {
-		t.Errorf("Expected status code %d, got %d", http.StatusOK, rr.Code)
-	}
-
-	var response SuccessResponse
-	err := json.Unmarshal(rr.Body.Bytes(), &response)
-	if err != nil {
-		t.Errorf("Failed to unmarshal response: %v", err)
-	}
-	if response.Message != "Service is healthy" {
-		t.Errorf("Expected health success message, got %s", response.Message)
-	}
-	if response.Data == nil {
-		t.Error("Expected response data to be present")
-	}
-}
-
-// Test checkAndNotify function (indirectly through async calls)
-
-func TestCheckAndNotify_ThresholdExceeded(t *testing.T) {
-	handler, mockRepo, mockNotifier := createTestHandler()
-
-	// Create enough computers to exceed threshold
-	computers := []model.Computer{
-		createTestComputer(),
-		createTestComputer(),
-		createTestComputer(),
-		createTestComputer(), // 4 computers > 3 threshold
-	}
-
-	mockRepo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
-		if emp != "ABC" {
-			t.Errorf("Expected employee ABC, got %s", emp)
-		}
-		return computers, nil
-	}
-
-	// Call checkAndNotify directly
-	handler.checkAndNotify("ABC")
-
-	// Give some time for the async operation
-	time.Sleep(100 * time.Millisecond)
-
-	// Check that notification was sent
-	if len(mockNotifier.NotificationsSent) == 0 {
-		t.Error("Expected notification to be sent")
-	} else {
-		notification := mockNotifier.NotificationsSent[0]
-		if notification.Level != "warning" {
-			t.Errorf("Expected warning level, got %s", notification.Level)
-		}
-		if notification.EmployeeAbbreviation != "ABC" {
-			t.Errorf("Expected employee ABC, got %s", notification.EmployeeAbbreviation)
-		}
-		if !strings.Contains(notification.Message, "4 computers") {
-			t.Errorf("Expected message to contain '4 computers', got %s", notification.Message)
-		}
-	}
-}
-
-func TestCheckAndNotify_BelowThreshold(t *testing.T) {
-	handler, mockRepo, mockNotifier := createTestHandler()
-
-	computers := []model.Computer{createTestComputer()} // Only 1 computer < 3 threshold
-
-	mockRepo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
-		return computers, nil
-	}
-
-	handler.checkAndNotify("ABC")
-
-	time.Sleep(100 * time.Millisecond)
-
-	// Check that no notification was sent
-	if len(mockNotifier.NotificationsSent) > 0 {
-		t.Error("Expected no notification to be sent for computers below threshold")
-	}
-}
-
-func TestCheckAndNotify_EmptyEmployee(t *testing.T) {
-	handler, mockRepo, mockNotifier := createTestHandler()
-
-	// Track if repository method was called
-	repoCalled := false
-	mockRepo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
-		repoCalled = true
-		return []model.Computer{}, nil
-	}
-
-	// Should return early without calling any methods
-	handler.checkAndNotify("")
-
-	time.Sleep(100 * time.Millisecond)
-
-	if repoCalled {
-		t.Error("Repository method should not be called for empty employee")
-	}
-	if len(mockNotifier.NotificationsSent) > 0 {
-		t.Error("No notification should be sent for empty employee")
-	}
-}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// EmployeeHandler handles the HTTP requests for employees.
+type EmployeeHandler struct {
+	Service service.EmployeeServiceInterface
+	Logger  *slog.Logger
+
+	// Helper components for cleaner code organization
+	ErrorHandler   *ErrorHandler
+	ResponseHelper *ResponseHelper
+}
+
+// NewEmployeeHandler creates a new EmployeeHandler with dependencies and helpers
+func NewEmployeeHandler(svc service.EmployeeServiceInterface, logger *slog.Logger) *EmployeeHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &EmployeeHandler{
+		Service:        svc,
+		Logger:         logger,
+		ErrorHandler:   NewErrorHandler(logger),
+		ResponseHelper: NewResponseHelper(),
+	}
+}
+
+// CreateEmployeeHandler handles the creation of a new employee.
+func (h *EmployeeHandler) CreateEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	var employee model.Employee
+	if err := json.NewDecoder(r.Body).Decode(&employee); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	created, err := h.Service.CreateEmployee(ctx, employee)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "create employee")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusCreated, "Employee created successfully", created)
+}
+
+// GetAllEmployeesHandler handles the retrieval of all employees with pagination.
+func (h *EmployeeHandler) GetAllEmployeesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, LongRunningTimeout)
+	defer cancel()
+
+	paginationParams := h.ResponseHelper.ParsePaginationParams(r)
+
+	result, err := h.Service.GetAllEmployees(ctx, repository.PaginationParams{
+		Offset: paginationParams.Offset,
+		Limit:  paginationParams.Limit,
+	})
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve employees")
+		return
+	}
+
+	paginationMeta := h.ResponseHelper.CalculatePaginationMeta(paginationParams, result.TotalCount)
+
+	responseData := h.ResponseHelper.CreatePaginatedListResponseData(result.Items, paginationMeta, map[string]interface{}{
+		"employees": result.Items,
+	})
+	delete(responseData, "items") // Remove generic "items" key since we have "employees"
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, responseData)
+}
+
+// GetEmployeeHandler handles the retrieval of a single employee by abbreviation.
+func (h *EmployeeHandler) GetEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	employee, err := h.Service.GetEmployee(ctx, mux.Vars(r)["employee_abbreviation"])
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve employee")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, employee)
+}
+
+// UpdateEmployeeHandler handles the update of an employee.
+// Sending "active": false marks the employee as having left the company.
+func (h *EmployeeHandler) UpdateEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	// Employees stay active unless the request explicitly deactivates them
+	employee := model.Employee{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&employee); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	updated, err := h.Service.UpdateEmployee(ctx, mux.Vars(r)["employee_abbreviation"], employee)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "update employee")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Employee updated successfully", updated)
+}
+
+// DeleteEmployeeHandler handles the deletion of an employee without assigned computers.
+func (h *EmployeeHandler) DeleteEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	abbreviation := mux.Vars(r)["employee_abbreviation"]
+	if err := h.Service.DeleteEmployee(ctx, abbreviation); err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "delete employee")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Employee deleted successfully", map[string]interface{}{
+		"abbreviation": abbreviation,
+	})
+}
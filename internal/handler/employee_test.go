@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// MockEmployeeRepository is a mock implementation of EmployeeRepository
+type MockEmployeeRepository struct {
+	CreateEmployeeFunc            func(ctx context.Context, employee model.Employee) error
+	GetEmployeeByAbbreviationFunc func(ctx context.Context, abbreviation string) (*model.Employee, error)
+	GetAllEmployeesPaginatedFunc  func(ctx context.Context, params repository.PaginationParams) (*repository.EmployeePaginatedResult, error)
+	UpdateEmployeeFunc            func(ctx context.Context, abbreviation string, employee model.Employee) error
+	DeleteEmployeeFunc            func(ctx context.Context, abbreviation string) error
+}
+
+func (m *MockEmployeeRepository) CreateEmployee(ctx context.Context, employee model.Employee) error {
+	if m.CreateEmployeeFunc != nil {
+		return m.CreateEmployeeFunc(ctx, employee)
+	}
+	return nil
+}
+
+// GetEmployeeByAbbreviation returns an active employee by default so computer tests can assign freely
+func (m *MockEmployeeRepository) GetEmployeeByAbbreviation(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	if m.GetEmployeeByAbbreviationFunc != nil {
+		return m.GetEmployeeByAbbreviationFunc(ctx, abbreviation)
+	}
+	return &model.Employee{Abbreviation: abbreviation, Name: "Test Employee", Active: true}, nil
+}
+
+func (m *MockEmployeeRepository) GetEmployeeByAbbreviationForUpdate(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	return m.GetEmployeeByAbbreviation(ctx, abbreviation)
+}
+
+func (m *MockEmployeeRepository) GetAllEmployeesPaginated(ctx context.Context, params repository.PaginationParams) (*repository.EmployeePaginatedResult, error) {
+	if m.GetAllEmployeesPaginatedFunc != nil {
+		return m.GetAllEmployeesPaginatedFunc(ctx, params)
+	}
+	return &repository.EmployeePaginatedResult{Items: []model.Employee{}, TotalCount: 0}, nil
+}
+
+func (m *MockEmployeeRepository) UpdateEmployee(ctx context.Context, abbreviation string, employee model.Employee) error {
+	if m.UpdateEmployeeFunc != nil {
+		return m.UpdateEmployeeFunc(ctx, abbreviation, employee)
+	}
+	return nil
+}
+
+func (m *MockEmployeeRepository) DeleteEmployee(ctx context.Context, abbreviation string) error {
+	if m.DeleteEmployeeFunc != nil {
+		return m.DeleteEmployeeFunc(ctx, abbreviation)
+	}
+	return nil
+}
+
+func createTestEmployeeHandler() (*EmployeeHandler, *MockEmployeeRepository) {
+	mockRepo := &MockEmployeeRepository{}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+
+	handler := NewEmployeeHandler(service.NewEmployeeService(mockRepo, logger), logger)
+	return handler, mockRepo
+}
+
+// Test CreateEmployeeHandler
+
+func TestCreateEmployeeHandler_Success(t *testing.T) {
+	handler, mockRepo := createTestEmployeeHandler()
+
+	mockRepo.CreateEmployeeFunc = func(ctx context.Context, e model.Employee) error {
+		if !e.Active {
+			t.Error("Expected new employee to be active")
+		}
+		return nil
+	}
+
+	req := createJSONRequest("POST", "/employees", model.Employee{Abbreviation: "JDO", Name: "John Doe", Email: "john@example.com"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Errorf("Expected status code %d, got %d", http.StatusCreated, rr.Code)
+	}
+}
+
+func TestCreateEmployeeHandler_ValidationError(t *testing.T) {
+	handler, _ := createTestEmployeeHandler()
+
+	req := createJSONRequest("POST", "/employees", model.Employee{Abbreviation: "TOOLONG"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Errorf("Failed to unmarshal response: %v", err)
+	}
+	if response.Details == nil {
+		t.Error("Expected validation details to be present")
+	}
+}
+
+func TestCreateEmployeeHandler_Duplicate(t *testing.T) {
+	handler, mockRepo := createTestEmployeeHandler()
+
+	mockRepo.CreateEmployeeFunc = func(ctx context.Context, e model.Employee) error {
+		return repository.ErrDuplicateEmployee
+	}
+
+	req := createJSONRequest("POST", "/employees", model.Employee{Abbreviation: "JDO", Name: "John Doe"})
+	rr := httptest.NewRecorder()
+
+	handler.CreateEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+// Test GetEmployeeHandler
+
+func TestGetEmployeeHandler_NotFound(t *testing.T) {
+	handler, mockRepo := createTestEmployeeHandler()
+
+	mockRepo.GetEmployeeByAbbreviationFunc = func(ctx context.Context, abbreviation string) (*model.Employee, error) {
+		return nil, repository.ErrEmployeeNotFound
+	}
+
+	req, _ := http.NewRequest("GET", "/employees/JDO", nil)
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "JDO"})
+	rr := httptest.NewRecorder()
+
+	handler.GetEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+// Test UpdateEmployeeHandler
+
+func TestUpdateEmployeeHandler_KeepsActiveByDefault(t *testing.T) {
+	handler, mockRepo := createTestEmployeeHandler()
+
+	mockRepo.UpdateEmployeeFunc = func(ctx context.Context, abbreviation string, e model.Employee) error {
+		if !e.Active {
+			t.Error("Expected employee to stay active when active is omitted")
+		}
+		return nil
+	}
+
+	req := createJSONRequest("PUT", "/employees/JDO", map[string]string{"name": "John Doe"})
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "JDO"})
+	rr := httptest.NewRecorder()
+
+	handler.UpdateEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+// Test DeleteEmployeeHandler
+
+func TestDeleteEmployeeHandler_InUse(t *testing.T) {
+	handler, mockRepo := createTestEmployeeHandler()
+
+	mockRepo.DeleteEmployeeFunc = func(ctx context.Context, abbreviation string) error {
+		return repository.ErrEmployeeInUse
+	}
+
+	req, _ := http.NewRequest("DELETE", "/employees/JDO", nil)
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "JDO"})
+	rr := httptest.NewRecorder()
+
+	handler.DeleteEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+// Test AssignComputerToEmployeeHandler against employee state
+
+func TestAssignComputerToEmployeeHandler_DeactivatedEmployee(t *testing.T) {
+	_, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	mockRepo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		return &computer, nil
+	}
+
+	employees := &MockEmployeeRepository{
+		GetEmployeeByAbbreviationFunc: func(ctx context.Context, abbreviation string) (*model.Employee, error) {
+			return &model.Employee{Abbreviation: abbreviation, Name: "Former Employee", Active: false}, nil
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil))
+	handler := NewComputerHandler(service.NewComputerService(mockRepo, employees, nil, logger), logger)
+
+	req, _ := http.NewRequest("PUT", "/employees/XYZ/computers/"+computer.ID.String(), nil)
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "XYZ", "computer_id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.AssignComputerToEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestAssignComputerToEmployeeHandler_UnknownEmployee(t *testing.T) {
+	_, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	mockRepo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		return &computer, nil
+	}
+
+	employees := &MockEmployeeRepository{
+		GetEmployeeByAbbreviationFunc: func(ctx context.Context, abbreviation string) (*model.Employee, error) {
+			return nil, repository.ErrEmployeeNotFound
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil))
+	handler := NewComputerHandler(service.NewComputerService(mockRepo, employees, nil, logger), logger)
+
+	req, _ := http.NewRequest("PUT", "/employees/XYZ/computers/"+computer.ID.String(), nil)
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "XYZ", "computer_id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.AssignComputerToEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
@@ -1,45 +1,56 @@
 package handler
 
 import (
+	"computer-management-api/internal/logging"
 	"computer-management-api/internal/repository"
+	"computer-management-api/internal/tracing"
+	apperrors "computer-management-api/pkg/errors"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 )
 
 // ErrorHandler provides centralized error handling functionality for handlers
 type ErrorHandler struct {
-	Logger *log.Logger
+	Logger *slog.Logger
 }
 
 // NewErrorHandler creates a new ErrorHandler instance
-func NewErrorHandler(logger *log.Logger) *ErrorHandler {
+func NewErrorHandler(logger *slog.Logger) *ErrorHandler {
 	if logger == nil {
-		logger = log.Default()
+		logger = slog.Default()
 	}
 	return &ErrorHandler{
 		Logger: logger,
 	}
 }
 
-// SendErrorResponse sends a structured error response
+// SendErrorResponse sends a structured error response. The request and trace IDs the
+// middleware returned in the response headers are repeated in the body, so they end up in bug
+// reports.
 func (e *ErrorHandler) SendErrorResponse(w http.ResponseWriter, statusCode int, message, code string, details map[string]string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
 	response := ErrorResponse{
-		Error:   message,
-		Code:    code,
-		Details: details,
+		Error:     message,
+		Code:      code,
+		Details:   details,
+		RequestID: w.Header().Get(logging.RequestIDHeader),
+		TraceID:   w.Header().Get(tracing.TraceIDHeader),
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		e.Logger.Printf("Failed to encode error response: %v", err)
+		e.logWriteError(w, "Failed to encode error response", err)
 	}
 }
 
@@ -54,7 +65,7 @@ func (e *ErrorHandler) SendSuccessResponse(w http.ResponseWriter, statusCode int
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		e.Logger.Printf("Failed to encode success response: %v", err)
+		e.logWriteError(w, "Failed to encode success response", err)
 	}
 }
 
@@ -64,14 +75,58 @@ func (e *ErrorHandler) SendJSONResponse(w http.ResponseWriter, statusCode int, d
 	w.WriteHeader(statusCode)
 
 	if err := json.NewEncoder(w).Encode(data); err != nil {
-		e.Logger.Printf("Failed to encode JSON response: %v", err)
+		e.logWriteError(w, "Failed to encode JSON response", err)
 		e.SendErrorResponse(w, http.StatusInternalServerError, "Failed to encode response", "ENCODING_ERROR", nil)
 	}
 }
 
+// SendConditionalJSONResponse sends a JSON response tagged with etag, or an empty 304 Not Modified
+// when the request's If-None-Match header already names it. Without an etag, a weak one is
+// derived from the encoded body so unchanged lists can be polled cheaply too.
+func (e *ErrorHandler) SendConditionalJSONResponse(w http.ResponseWriter, r *http.Request, etag string, data interface{}) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		e.Logger.ErrorContext(r.Context(), "Failed to encode JSON response", "error", err)
+		e.SendErrorResponse(w, http.StatusInternalServerError, "Failed to encode response", "ENCODING_ERROR", nil)
+		return
+	}
+
+	if etag == "" {
+		sum := sha256.Sum256(body)
+		etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+	w.Header().Set("ETag", etag)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		e.Logger.WarnContext(r.Context(), "Failed to write JSON response", "error", err)
+	}
+}
+
+// etagMatches reports whether an If-None-Match header names etag, using the weak comparison
+// RFC 9110 prescribes for GET requests
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 // HandleRepositoryError handles repository-specific errors and maps them to HTTP responses
-func (e *ErrorHandler) HandleRepositoryError(w http.ResponseWriter, err error, operation string) {
-	e.Logger.Printf("Repository error during %s: %v", operation, err)
+func (e *ErrorHandler) HandleRepositoryError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	e.Logger.ErrorContext(r.Context(), "Repository error", "operation", operation, "error", err)
 
 	switch {
 	case errors.Is(err, repository.ErrComputerNotFound):
@@ -87,6 +142,45 @@ func (e *ErrorHandler) HandleRepositoryError(w http.ResponseWriter, err error, o
 	}
 }
 
+// HandleServiceError maps service layer errors to HTTP responses.
+// Client errors expose the AppError message and code, server errors are logged and replaced
+// with a generic message so internal details never leak to callers.
+func (e *ErrorHandler) HandleServiceError(w http.ResponseWriter, r *http.Request, err error, operation string) {
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		e.Logger.ErrorContext(r.Context(), "Service error", "operation", operation, "error", err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			e.SendErrorResponse(w, http.StatusRequestTimeout, "Operation timed out", "TIMEOUT", nil)
+			return
+		}
+		e.SendErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to %s", operation), "INTERNAL_ERROR", nil)
+		return
+	}
+
+	statusCode := appErr.GetHTTPStatus()
+	if statusCode >= http.StatusInternalServerError {
+		e.Logger.ErrorContext(r.Context(), "Service error", "operation", operation, "error", err)
+		e.SendErrorResponse(w, statusCode, fmt.Sprintf("Failed to %s", operation), "INTERNAL_ERROR", nil)
+		return
+	}
+
+	var details map[string]string
+	if len(appErr.Details) > 0 {
+		details = make(map[string]string, len(appErr.Details))
+		for key, value := range appErr.Details {
+			details[key] = fmt.Sprint(value)
+		}
+	}
+
+	e.SendErrorResponse(w, statusCode, appErr.Message, string(appErr.Code), details)
+}
+
+// logWriteError logs a failure to write a response. Only the response is at hand, so the
+// request is identified by the request ID set in its headers.
+func (e *ErrorHandler) logWriteError(w http.ResponseWriter, msg string, err error) {
+	e.Logger.Warn(msg, "error", err, "request_id", w.Header().Get(logging.RequestIDHeader))
+}
+
 // HandleValidationErrors handles validation errors and sends appropriate response
 func (e *ErrorHandler) HandleValidationErrors(w http.ResponseWriter, validationErrors map[string]string) {
 	if len(validationErrors) > 0 {
@@ -95,19 +189,19 @@ func (e *ErrorHandler) HandleValidationErrors(w http.ResponseWriter, validationE
 }
 
 // HandleJSONDecodeError handles JSON decoding errors
-func (e *ErrorHandler) HandleJSONDecodeError(w http.ResponseWriter, err error) {
-	e.Logger.Printf("JSON decode error: %v", err)
+func (e *ErrorHandler) HandleJSONDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	e.Logger.DebugContext(r.Context(), "JSON decode error", "error", err)
 	e.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON format", "INVALID_JSON", nil)
 }
 
 // HandleUUIDParseError handles UUID parsing errors
-func (e *ErrorHandler) HandleUUIDParseError(w http.ResponseWriter, err error) {
-	e.Logger.Printf("UUID parse error: %v", err)
+func (e *ErrorHandler) HandleUUIDParseError(w http.ResponseWriter, r *http.Request, err error) {
+	e.Logger.DebugContext(r.Context(), "UUID parse error", "error", err)
 	e.SendErrorResponse(w, http.StatusBadRequest, "Invalid UUID format", "INVALID_UUID", nil)
 }
 
 // ParseAndValidateUUID parses and validates UUID from string
-func (e *ErrorHandler) ParseAndValidateUUID(w http.ResponseWriter, idStr string) (uuid.UUID, bool) {
+func (e *ErrorHandler) ParseAndValidateUUID(w http.ResponseWriter, r *http.Request, idStr string) (uuid.UUID, bool) {
 	if idStr == "" {
 		e.SendErrorResponse(w, http.StatusBadRequest, "ID is required", "INVALID_UUID", nil)
 		return uuid.Nil, false
@@ -115,13 +209,24 @@ func (e *ErrorHandler) ParseAndValidateUUID(w http.ResponseWriter, idStr string)
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		e.HandleUUIDParseError(w, err)
+		e.HandleUUIDParseError(w, r, err)
 		return uuid.Nil, false
 	}
 
 	return id, true
 }
 
+// ParseAndValidateID parses and validates a positive numeric ID from string
+func (e *ErrorHandler) ParseAndValidateID(w http.ResponseWriter, idStr string) (int64, bool) {
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id < 1 {
+		e.SendErrorResponse(w, http.StatusBadRequest, "ID must be a positive integer", "INVALID_ID", nil)
+		return 0, false
+	}
+
+	return id, true
+}
+
 // HandleEmployeeAbbreviationError handles employee abbreviation validation errors
 func (e *ErrorHandler) HandleEmployeeAbbreviationError(w http.ResponseWriter, err error) {
 	e.SendErrorResponse(w, http.StatusBadRequest, err.Error(), "INVALID_EMPLOYEE_ABBREV", nil)
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/pkg/xlsx"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportFlushInterval is the number of rows after which an export is flushed to the client
+const exportFlushInterval = 500
+
+// exportColumns are the columns of CSV and XLSX exports. The editable ones match the import
+// columns, so an exported CSV file can be imported again.
+var exportColumns = []string{"id", "mac_address", "vendor", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"}
+
+// computerExporter writes computers in an export format
+type computerExporter interface {
+	WriteComputer(c model.Computer) error
+	Flush() error
+	Close() error
+}
+
+// exportFormat describes an export format
+type exportFormat struct {
+	mediaType   string
+	extension   string
+	newExporter func(w io.Writer) (computerExporter, error)
+}
+
+// exportFormats are the supported export formats by name
+var exportFormats = map[string]exportFormat{
+	"csv":    {CSVMediaType + "; charset=utf-8", "csv", newCSVExporter},
+	"ndjson": {NDJSONMediaType, "ndjson", newNDJSONExporter},
+	"xlsx":   {xlsx.MediaType, "xlsx", newXLSXExporter},
+}
+
+// ExportComputersHandler streams every computer matching the list filters as a CSV, NDJSON or
+// XLSX download. Rows are written as they are read from the database. Problems found before the
+// first row are reported as usual; once streaming has begun a failure can only end the download.
+func (h *ComputerHandler) ExportComputersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, ExportTimeout)
+	defer cancel()
+
+	filter, filterErrors := parseComputerFilter(r)
+	name := strings.ToLower(r.URL.Query().Get("format"))
+	if name == "" {
+		name = "csv"
+	}
+	format, ok := exportFormats[name]
+	if !ok {
+		filterErrors["format"] = "format must be csv, ndjson or xlsx"
+	}
+	if len(filterErrors) > 0 {
+		h.ErrorHandler.HandleValidationErrors(w, filterErrors)
+		return
+	}
+
+	// Large exports outlive the server's default write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Now().Add(ExportTimeout))
+
+	var exporter computerExporter
+	start := func() error {
+		filename := fmt.Sprintf("computers-%s.%s", time.Now().UTC().Format("20060102"), format.extension)
+		w.Header().Set("Content-Type", format.mediaType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+
+		var err error
+		exporter, err = format.newExporter(w)
+		return err
+	}
+
+	rows := 0
+	err := h.Service.ExportComputers(ctx, filter, func(c model.Computer) error {
+		if exporter == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := exporter.WriteComputer(c); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%exportFlushInterval == 0 {
+			if err := exporter.Flush(); err != nil {
+				return err
+			}
+			_ = rc.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if exporter == nil {
+			h.ErrorHandler.HandleServiceError(w, r, err, "export computers")
+			return
+		}
+		h.Logger.WarnContext(r.Context(), "Computer export aborted", "rows", rows, "error", err)
+		return
+	}
+
+	// An empty export still gets its header row
+	if exporter == nil {
+		if err := start(); err != nil {
+			h.Logger.ErrorContext(r.Context(), "Failed to start computer export", "error", err)
+			return
+		}
+	}
+	if err := exporter.Close(); err != nil {
+		h.Logger.ErrorContext(r.Context(), "Failed to complete computer export", "error", err)
+	}
+}
+
+// csvFormulaPrefixes are the leading characters that make spreadsheet applications evaluate a
+// CSV cell as a formula
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// csvCell neutralizes a free-text value that a spreadsheet would evaluate as a formula by
+// prefixing it with a single quote. The import strips the quote again, see csvCellValue.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvCellValue reverses csvCell
+func csvCellValue(cell string) string {
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
+
+// csvExporter writes computers as CSV with a header row. Free-text values that start like a
+// formula are prefixed with a single quote so spreadsheets show them as text; the import removes
+// the quote, so the file can be imported again.
+type csvExporter struct {
+	writer *csv.Writer
+}
+
+func newCSVExporter(w io.Writer) (computerExporter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportColumns); err != nil {
+		return nil, err
+	}
+	return &csvExporter{writer: writer}, nil
+}
+
+func (e *csvExporter) WriteComputer(c model.Computer) error {
+	return e.writer.Write([]string{
+		c.ID.String(),
+		c.MACAddress,
+		csvCell(c.Vendor),
+		csvCell(c.ComputerName),
+		c.IPAddress,
+		csvCell(c.EmployeeAbbreviation),
+		csvCell(c.Description),
+		strconv.FormatInt(c.Version, 10),
+		c.CreatedAt.UTC().Format(time.RFC3339),
+		c.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (e *csvExporter) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvExporter) Close() error {
+	return e.Flush()
+}
+
+// ndjsonExporter writes one JSON computer object per line
+type ndjsonExporter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONExporter(w io.Writer) (computerExporter, error) {
+	return &ndjsonExporter{encoder: json.NewEncoder(w)}, nil
+}
+
+func (e *ndjsonExporter) WriteComputer(c model.Computer) error {
+	return e.encoder.Encode(c)
+}
+
+func (e *ndjsonExporter) Flush() error { return nil }
+
+func (e *ndjsonExporter) Close() error { return nil }
+
+// xlsxExporter writes computers to a single-sheet workbook with a header row
+type xlsxExporter struct {
+	writer *xlsx.Writer
+}
+
+func newXLSXExporter(w io.Writer) (computerExporter, error) {
+	writer, err := xlsx.NewWriter(w, "Computers")
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]interface{}, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column
+	}
+	if err := writer.WriteRow(header...); err != nil {
+		return nil, err
+	}
+	return &xlsxExporter{writer: writer}, nil
+}
+
+func (e *xlsxExporter) WriteComputer(c model.Computer) error {
+	return e.writer.WriteRow(c.ID.String(), c.MACAddress, c.Vendor, c.ComputerName, c.IPAddress,
+		c.EmployeeAbbreviation, c.Description, c.Version, c.CreatedAt, c.UpdatedAt)
+}
+
+func (e *xlsxExporter) Flush() error {
+	return e.writer.Flush()
+}
+
+func (e *xlsxExporter) Close() error {
+	return e.writer.Close()
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// streamComputers returns a StreamComputersFunc serving the given computers
+func streamComputers(computers ...model.Computer) func(ctx context.Context, filter repository.ComputerFilter, fn func(model.Computer) error) error {
+	return func(ctx context.Context, filter repository.ComputerFilter, fn func(model.Computer) error) error {
+		for _, c := range computers {
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestExportComputersHandler_CSV(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	computer.Description = "Desk 1, window"
+	var received repository.ComputerFilter
+	stream := streamComputers(computer)
+	mockRepo.StreamComputersFunc = func(ctx context.Context, filter repository.ComputerFilter, fn func(model.Computer) error) error {
+		received = filter
+		return stream(ctx, filter, fn)
+	}
+
+	req, _ := http.NewRequest("GET", "/computers/export?employee=ABC&sort=-created_at", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportComputersHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if received.EmployeeAbbreviation != "ABC" || len(received.Sort) != 1 {
+		t.Errorf("Expected the list filters to be applied, got %+v", received)
+	}
+	if !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/csv") {
+		t.Errorf("Expected a CSV content type, got %s", rr.Header().Get("Content-Type"))
+	}
+	if disposition := rr.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, `attachment; filename="computers-`) || !strings.HasSuffix(disposition, `.csv"`) {
+		t.Errorf("Unexpected Content-Disposition %s", disposition)
+	}
+
+	records, err := csv.NewReader(rr.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(records) != 2 || records[0][0] != "id" || records[1][0] != computer.ID.String() || records[1][6] != computer.Description {
+		t.Errorf("Unexpected CSV records %v", records)
+	}
+}
+
+func TestExportComputersHandler_CSVNeutralizesFormulas(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	computer.ComputerName = "=HYPERLINK(\"http://example.com\")"
+	computer.Description = "@SUM(A1:A2)"
+	mockRepo.StreamComputersFunc = streamComputers(computer)
+
+	req, _ := http.NewRequest("GET", "/computers/export?format=csv", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportComputersHandler(rr, req)
+
+	records, err := csv.NewReader(rr.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(records) != 2 || records[1][3] != "'"+computer.ComputerName || records[1][6] != "'"+computer.Description {
+		t.Errorf("Expected formulas to be prefixed with a quote, got %v", records)
+	}
+}
+
+func TestCSVCell(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"", ""},
+		{"Desk 1", "Desk 1"},
+		{"=1+1", "'=1+1"},
+		{"+1", "'+1"},
+		{"-1", "'-1"},
+		{"@A1", "'@A1"},
+		{"'quoted", "'quoted"},
+	}
+	for _, tt := range tests {
+		cell := csvCell(tt.value)
+		if cell != tt.expected {
+			t.Errorf("csvCell(%q) = %q, expected %q", tt.value, cell, tt.expected)
+		}
+		if value := csvCellValue(cell); value != tt.value {
+			t.Errorf("csvCellValue(%q) = %q, expected %q", cell, value, tt.value)
+		}
+	}
+}
+
+func TestExportComputersHandler_NDJSON(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	first, second := createTestComputer(), createTestComputer()
+	mockRepo.StreamComputersFunc = streamComputers(first, second)
+
+	req, _ := http.NewRequest("GET", "/computers/export?format=ndjson", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportComputersHandler(rr, req)
+
+	if rr.Header().Get("Content-Type") != NDJSONMediaType {
+		t.Errorf("Expected content type %s, got %s", NDJSONMediaType, rr.Header().Get("Content-Type"))
+	}
+
+	decoder := json.NewDecoder(rr.Body)
+	var ids []string
+	for decoder.More() {
+		var computer model.Computer
+		if err := decoder.Decode(&computer); err != nil {
+			t.Fatalf("Invalid NDJSON: %v", err)
+		}
+		ids = append(ids, computer.ID.String())
+	}
+	if len(ids) != 2 || ids[0] != first.ID.String() || ids[1] != second.ID.String() {
+		t.Errorf("Expected both computers in order, got %v", ids)
+	}
+}
+
+func TestExportComputersHandler_XLSX(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	computer := createTestComputer()
+	mockRepo.StreamComputersFunc = streamComputers(computer)
+
+	req, _ := http.NewRequest("GET", "/computers/export?format=xlsx", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportComputersHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	body := rr.Body.Bytes()
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("Expected a valid workbook: %v", err)
+	}
+	for _, file := range archive.File {
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, _ := file.Open()
+		var sheet bytes.Buffer
+		sheet.ReadFrom(rc)
+		rc.Close()
+		if !strings.Contains(sheet.String(), computer.MACAddress) {
+			t.Errorf("Expected the computer in the worksheet, got %s", sheet.String())
+		}
+		return
+	}
+	t.Error("Expected a worksheet in the workbook")
+}
+
+func TestExportComputersHandler_Empty(t *testing.T) {
+	handler, _, _ := createTestHandler()
+
+	req, _ := http.NewRequest("GET", "/computers/export", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportComputersHandler(rr, req)
+
+	if rr.Code != http.StatusOK || strings.TrimSpace(rr.Body.String()) != strings.Join(exportColumns, ",") {
+		t.Errorf("Expected only the header row, got %d: %q", rr.Code, rr.Body.String())
+	}
+}
+
+func TestExportComputersHandler_Errors(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	req, _ := http.NewRequest("GET", "/computers/export?format=pdf&assigned=maybe", nil)
+	rr := httptest.NewRecorder()
+	handler.ExportComputersHandler(rr, req)
+
+	var response ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if rr.Code != http.StatusBadRequest || response.Details["format"] == "" || response.Details["assigned"] == "" {
+		t.Errorf("Expected format and filter errors, got %d: %v", rr.Code, response.Details)
+	}
+
+	// A failure before the first row is still reported as an error response
+	mockRepo.StreamComputersFunc = func(ctx context.Context, filter repository.ComputerFilter, fn func(model.Computer) error) error {
+		return errors.New("database error")
+	}
+	req, _ = http.NewRequest("GET", "/computers/export", nil)
+	rr = httptest.NewRecorder()
+	handler.ExportComputersHandler(rr, req)
+
+	if rr.Code != http.StatusInternalServerError || rr.Header().Get("Content-Disposition") != "" {
+		t.Errorf("Expected an error response, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"computer-management-api/internal/service"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// HealthHandler handles the liveness and readiness probes of load balancers and orchestrators.
+type HealthHandler struct {
+	Service service.HealthServiceInterface
+	Logger  *slog.Logger
+
+	// Helper components for cleaner code organization
+	ErrorHandler   *ErrorHandler
+	ResponseHelper *ResponseHelper
+}
+
+// NewHealthHandler creates a new HealthHandler with dependencies and helpers
+func NewHealthHandler(svc service.HealthServiceInterface, logger *slog.Logger) *HealthHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &HealthHandler{
+		Service:        svc,
+		Logger:         logger,
+		ErrorHandler:   NewErrorHandler(logger),
+		ResponseHelper: NewResponseHelper(),
+	}
+}
+
+// LiveHandler reports that the process is up and serving requests. It checks no dependencies,
+// so that an unreachable database does not get every instance restarted.
+func (h *HealthHandler) LiveHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"status":    service.HealthStatusUp,
+		"timestamp": time.Now().UTC(),
+	})
+}
+
+// ReadyHandler reports whether the server should receive traffic, with the result of every
+// dependency check. It responds 503 Service Unavailable when a critical dependency is down or
+// the server is shutting down.
+func (h *HealthHandler) ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	report := h.Service.Readiness(r.Context())
+
+	statusCode := http.StatusOK
+	if !report.Ready() {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	h.ErrorHandler.SendJSONResponse(w, statusCode, report)
+}
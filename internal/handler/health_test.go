@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// MockHealthService is a mock implementation of HealthServiceInterface
+type MockHealthService struct {
+	Report *service.HealthReport
+}
+
+func (m *MockHealthService) Readiness(ctx context.Context) *service.HealthReport {
+	return m.Report
+}
+
+func TestHealthHandler_ReadyHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		statusCode int
+	}{
+		{"up", service.HealthStatusUp, http.StatusOK},
+		{"degraded", service.HealthStatusDegraded, http.StatusOK},
+		{"down", service.HealthStatusDown, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &MockHealthService{Report: &service.HealthReport{
+				Status: tt.status,
+				Components: map[string]service.ComponentHealth{
+					service.HealthComponentDatabase: {Status: tt.status, Critical: true},
+				},
+			}}
+			h := NewHealthHandler(svc, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+			rr := httptest.NewRecorder()
+			h.ReadyHandler(rr, httptest.NewRequest("GET", "/health/ready", nil))
+
+			if rr.Code != tt.statusCode {
+				t.Errorf("Expected status %d, got %d", tt.statusCode, rr.Code)
+			}
+			if rr.Header().Get("Cache-Control") != "no-store" {
+				t.Error("Expected probe responses not to be cached")
+			}
+
+			var report service.HealthReport
+			if err := json.NewDecoder(rr.Body).Decode(&report); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if report.Components[service.HealthComponentDatabase].Status != tt.status {
+				t.Errorf("Expected the component breakdown, got %+v", report.Components)
+			}
+		})
+	}
+}
+
+func TestHealthHandler_LiveHandler(t *testing.T) {
+	// Liveness does not consult the readiness checks
+	h := NewHealthHandler(&MockHealthService{Report: &service.HealthReport{Status: service.HealthStatusDown}}, nil)
+
+	rr := httptest.NewRecorder()
+	h.LiveHandler(rr, httptest.NewRequest("GET", "/health/live", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"computer-management-api/pkg/validation"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// HistoryHandler handles the HTTP requests for the computer audit trail.
+type HistoryHandler struct {
+	Service service.HistoryServiceInterface
+	Logger  *slog.Logger
+
+	// Helper components for cleaner code organization
+	ErrorHandler   *ErrorHandler
+	ResponseHelper *ResponseHelper
+}
+
+// NewHistoryHandler creates a new HistoryHandler with dependencies and helpers
+func NewHistoryHandler(svc service.HistoryServiceInterface, logger *slog.Logger) *HistoryHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &HistoryHandler{
+		Service:        svc,
+		Logger:         logger,
+		ErrorHandler:   NewErrorHandler(logger),
+		ResponseHelper: NewResponseHelper(),
+	}
+}
+
+// GetComputerHistoryHandler handles the retrieval of a computer's audit trail, newest first.
+func (h *HistoryHandler) GetComputerHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, LongRunningTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	paginationParams := h.ResponseHelper.ParsePaginationParams(r)
+
+	result, err := h.Service.GetComputerHistory(ctx, id, repository.PaginationParams{
+		Offset: paginationParams.Offset,
+		Limit:  paginationParams.Limit,
+	})
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve computer history")
+		return
+	}
+
+	paginationMeta := h.ResponseHelper.CalculatePaginationMeta(paginationParams, result.TotalCount)
+
+	responseData := h.ResponseHelper.CreatePaginatedListResponseData(result.Items, paginationMeta, map[string]interface{}{
+		"computer_id": id,
+		"events":      result.Items,
+	})
+	delete(responseData, "items") // Remove generic "items" key since we have "events"
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, responseData)
+}
+
+// GetEmployeeHistoryHandler handles the retrieval of every assignment change involving an employee.
+func (h *HistoryHandler) GetEmployeeHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, LongRunningTimeout)
+	defer cancel()
+
+	employeeAbbreviation := mux.Vars(r)["employee_abbreviation"]
+	paginationParams := h.ResponseHelper.ParsePaginationParams(r)
+
+	result, err := h.Service.GetEmployeeHistory(ctx, employeeAbbreviation, repository.PaginationParams{
+		Offset: paginationParams.Offset,
+		Limit:  paginationParams.Limit,
+	})
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve employee history")
+		return
+	}
+
+	paginationMeta := h.ResponseHelper.CalculatePaginationMeta(paginationParams, result.TotalCount)
+
+	responseData := h.ResponseHelper.CreatePaginatedListResponseData(result.Items, paginationMeta, map[string]interface{}{
+		"employee_abbreviation": employeeAbbreviation,
+		"events":                result.Items,
+	})
+	delete(responseData, "items") // Remove generic "items" key since we have "events"
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, responseData)
+}
+
+// SearchHistoryHandler handles a search of the audit trail of all computers, including deleted ones.
+// With at, the first event of a MAC address is its state at that time.
+func (h *HistoryHandler) SearchHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, LongRunningTimeout)
+	defer cancel()
+
+	filter, filterErrors := parseEventFilter(r)
+	if len(filterErrors) > 0 {
+		h.ErrorHandler.HandleValidationErrors(w, filterErrors)
+		return
+	}
+
+	paginationParams := h.ResponseHelper.ParsePaginationParams(r)
+
+	result, err := h.Service.SearchHistory(ctx, filter, repository.PaginationParams{
+		Offset: paginationParams.Offset,
+		Limit:  paginationParams.Limit,
+	})
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "search history")
+		return
+	}
+
+	paginationMeta := h.ResponseHelper.CalculatePaginationMeta(paginationParams, result.TotalCount)
+
+	responseData := h.ResponseHelper.CreatePaginatedListResponseData(result.Items, paginationMeta, map[string]interface{}{
+		"events": result.Items,
+	})
+	delete(responseData, "items") // Remove generic "items" key since we have "events"
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, responseData)
+}
+
+// parseEventFilter parses the query parameters of a history search. The at parameter is an
+// inclusive upper bound like to, so the two cannot be combined.
+func parseEventFilter(r *http.Request) (repository.EventFilter, map[string]string) {
+	query := r.URL.Query()
+	filter := repository.EventFilter{}
+	errs := make(map[string]string)
+
+	if value := query.Get("mac"); value != "" {
+		mac, err := validation.ValidateMAC(value)
+		if err != nil {
+			errs["mac"] = err.Error()
+		}
+		filter.MACAddress = mac
+	}
+
+	if query.Get("at") != "" && query.Get("to") != "" {
+		errs["at"] = "at cannot be combined with to"
+	}
+
+	for name, target := range map[string]*time.Time{
+		"from": &filter.From,
+		"to":   &filter.To,
+		"at":   &filter.To,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseFilterTime(value)
+		if err != nil {
+			errs[name] = fmt.Sprintf("%s must be an RFC 3339 timestamp or a YYYY-MM-DD date", name)
+		}
+		*target = parsed
+	}
+
+	return filter, errs
+}
@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// MockEventRepository is a mock implementation of EventRepository that records events in memory
+type MockEventRepository struct {
+	Events []model.ComputerEvent
+
+	GetEventsByComputerFunc func(ctx context.Context, computerID uuid.UUID, params repository.PaginationParams) (*repository.EventPaginatedResult, error)
+	GetEventsByEmployeeFunc func(ctx context.Context, employeeAbbreviation string, params repository.PaginationParams) (*repository.EventPaginatedResult, error)
+	SearchEventsFunc        func(ctx context.Context, filter repository.EventFilter, params repository.PaginationParams) (*repository.EventPaginatedResult, error)
+}
+
+func (m *MockEventRepository) RecordEvent(ctx context.Context, event model.ComputerEvent) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	m.Events = append(m.Events, event)
+	return nil
+}
+
+func (m *MockEventRepository) GetEventsByComputer(ctx context.Context, computerID uuid.UUID, params repository.PaginationParams) (*repository.EventPaginatedResult, error) {
+	if m.GetEventsByComputerFunc != nil {
+		return m.GetEventsByComputerFunc(ctx, computerID, params)
+	}
+	return &repository.EventPaginatedResult{Items: []model.ComputerEvent{}}, nil
+}
+
+func (m *MockEventRepository) GetEventsByEmployee(ctx context.Context, employeeAbbreviation string, params repository.PaginationParams) (*repository.EventPaginatedResult, error) {
+	if m.GetEventsByEmployeeFunc != nil {
+		return m.GetEventsByEmployeeFunc(ctx, employeeAbbreviation, params)
+	}
+	return &repository.EventPaginatedResult{Items: []model.ComputerEvent{}}, nil
+}
+
+func (m *MockEventRepository) SearchEvents(ctx context.Context, filter repository.EventFilter, params repository.PaginationParams) (*repository.EventPaginatedResult, error) {
+	if m.SearchEventsFunc != nil {
+		return m.SearchEventsFunc(ctx, filter, params)
+	}
+	return &repository.EventPaginatedResult{Items: []model.ComputerEvent{}}, nil
+}
+
+func (m *MockEventRepository) GetLatestEvent(ctx context.Context, computerID uuid.UUID, eventType model.ComputerEventType) (*model.ComputerEvent, error) {
+	for i := len(m.Events) - 1; i >= 0; i-- {
+		if m.Events[i].ComputerID == computerID && m.Events[i].EventType == eventType {
+			return &m.Events[i], nil
+		}
+	}
+	return nil, repository.ErrEventNotFound
+}
+
+func (m *MockEventRepository) GetLatestEventByType(ctx context.Context, eventType model.ComputerEventType) (*model.ComputerEvent, error) {
+	for i := len(m.Events) - 1; i >= 0; i-- {
+		if m.Events[i].EventType == eventType {
+			return &m.Events[i], nil
+		}
+	}
+	return nil, repository.ErrEventNotFound
+}
+
+// MockTransactor runs the unit of work directly against the mock repositories
+type MockTransactor struct {
+	Repos repository.Repositories
+}
+
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	return fn(m.Repos)
+}
+
+func createTestHistoryHandler() (*HistoryHandler, *MockEventRepository, *MockComputerRepository) {
+	events := &MockEventRepository{}
+	computers := &MockComputerRepository{}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+	handler := NewHistoryHandler(service.NewHistoryService(events, computers, &MockEmployeeRepository{}, logger), logger)
+	return handler, events, computers
+}
+
+// Test GetComputerHistoryHandler
+
+func TestGetComputerHistoryHandler_Success(t *testing.T) {
+	handler, events, _ := createTestHistoryHandler()
+
+	computerID := uuid.New()
+	events.GetEventsByComputerFunc = func(ctx context.Context, id uuid.UUID, params repository.PaginationParams) (*repository.EventPaginatedResult, error) {
+		return &repository.EventPaginatedResult{
+			Items: []model.ComputerEvent{
+				{ID: 2, ComputerID: id, EventType: model.ComputerEventAssigned, Actor: "admin", OldEmployeeAbbreviation: "ABC", NewEmployeeAbbreviation: "XYZ"},
+				{ID: 1, ComputerID: id, EventType: model.ComputerEventCreated, Actor: "admin", NewEmployeeAbbreviation: "ABC"},
+			},
+			TotalCount: 2,
+		}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/computers/"+computerID.String()+"/history", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": computerID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.GetComputerHistoryHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response map[string]interface{}
+	json.Unmarshal(rr.Body.Bytes(), &response)
+
+	items, ok := response["events"].([]interface{})
+	if !ok || len(items) != 2 {
+		t.Fatalf("Expected 2 events, got %v", response["events"])
+	}
+	first := items[0].(map[string]interface{})
+	if first["old_employee_abbreviation"] != "ABC" || first["new_employee_abbreviation"] != "XYZ" {
+		t.Errorf("Expected reassignment from ABC to XYZ, got %v", first)
+	}
+}
+
+func TestGetComputerHistoryHandler_DeletedComputerKeepsHistory(t *testing.T) {
+	handler, events, _ := createTestHistoryHandler()
+
+	computerID := uuid.New()
+	events.GetEventsByComputerFunc = func(ctx context.Context, id uuid.UUID, params repository.PaginationParams) (*repository.EventPaginatedResult, error) {
+		return &repository.EventPaginatedResult{
+			Items:      []model.ComputerEvent{{ID: 3, ComputerID: id, EventType: model.ComputerEventDeleted, Actor: "admin"}},
+			TotalCount: 1,
+		}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/computers/"+computerID.String()+"/history", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": computerID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.GetComputerHistoryHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+}
+
+func TestGetComputerHistoryHandler_UnknownComputer(t *testing.T) {
+	handler, _, _ := createTestHistoryHandler()
+
+	computerID := uuid.New()
+	req, _ := http.NewRequest("GET", "/computers/"+computerID.String()+"/history", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": computerID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.GetComputerHistoryHandler(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+// Test GetEmployeeHistoryHandler
+
+func TestGetEmployeeHistoryHandler_InvalidAbbreviation(t *testing.T) {
+	handler, _, _ := createTestHistoryHandler()
+
+	req, _ := http.NewRequest("GET", "/employees/TOOLONG/history", nil)
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "TOOLONG"})
+	rr := httptest.NewRecorder()
+
+	handler.GetEmployeeHistoryHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+// Test SearchHistoryHandler
+
+func TestSearchHistoryHandler_MACAt(t *testing.T) {
+	handler, events, _ := createTestHistoryHandler()
+
+	var got repository.EventFilter
+	events.SearchEventsFunc = func(ctx context.Context, filter repository.EventFilter, params repository.PaginationParams) (*repository.EventPaginatedResult, error) {
+		got = filter
+		return &repository.EventPaginatedResult{
+			Items:      []model.ComputerEvent{{ID: 7, ComputerID: uuid.New(), EventType: model.ComputerEventAssigned, MACAddress: filter.MACAddress, NewEmployeeAbbreviation: "ABC"}},
+			TotalCount: 1,
+		}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/history?mac=aa-bb-cc-dd-ee-ff&at=2026-03-01", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SearchHistoryHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if got.MACAddress != "AA:BB:CC:DD:EE:FF" {
+		t.Errorf("Expected the normalized MAC address, got %q", got.MACAddress)
+	}
+	if !got.To.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || !got.From.IsZero() {
+		t.Errorf("Expected events up to 2026-03-01, got %v to %v", got.From, got.To)
+	}
+
+	var response map[string]interface{}
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	items, ok := response["events"].([]interface{})
+	if !ok || len(items) != 1 {
+		t.Fatalf("Expected 1 event, got %v", response["events"])
+	}
+	if items[0].(map[string]interface{})["new_employee_abbreviation"] != "ABC" {
+		t.Errorf("Expected the MAC address to be held by ABC, got %v", items[0])
+	}
+}
+
+func TestSearchHistoryHandler_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"invalid MAC address", "mac=not-a-mac"},
+		{"invalid time", "from=yesterday"},
+		{"at combined with to", "at=2026-03-01&to=2026-04-01"},
+		{"from after to", "from=2026-04-01&to=2026-03-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _, _ := createTestHistoryHandler()
+
+			req, _ := http.NewRequest("GET", "/history?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.SearchHistoryHandler(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+			}
+		})
+	}
+}
+
+// Test audit events recorded by computer mutations
+
+func TestAssignComputerToEmployeeHandler_RecordsActor(t *testing.T) {
+	mockRepo := &MockComputerRepository{}
+	events := &MockEventRepository{}
+	transactor := &MockTransactor{Repos: repository.Repositories{Computers: mockRepo, Employees: &MockEmployeeRepository{}, Events: events, Outbox: &MockOutboxRepository{}, Policies: &MockPolicyRepository{},
+		Subnets: &MockSubnetRepository{}}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil))
+	handler := NewComputerHandler(service.NewComputerService(mockRepo, &MockEmployeeRepository{}, transactor, logger), logger)
+
+	computer := createTestComputer()
+	computer.EmployeeAbbreviation = "ABC"
+	mockRepo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		stored := computer
+		return &stored, nil
+	}
+	mockRepo.AssignComputerToEmployeeFunc = func(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+		computer.EmployeeAbbreviation = employeeAbbreviation
+		computer.Version++
+		return nil
+	}
+
+	req, _ := http.NewRequest("PUT", "/employees/XYZ/computers/"+computer.ID.String(), nil)
+	req = req.WithContext(service.WithPrincipal(req.Context(), &model.Principal{Actor: "user:jane.admin"}))
+	req.Header.Set("X-Actor", "mallory") // Never overrides the authenticated principal
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "XYZ", "computer_id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.AssignComputerToEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	if len(events.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events.Events))
+	}
+
+	event := events.Events[0]
+	if event.EventType != model.ComputerEventAssigned {
+		t.Errorf("Expected assigned event, got %s", event.EventType)
+	}
+	if event.Actor != "user:jane.admin" {
+		t.Errorf("Expected actor user:jane.admin, got %s", event.Actor)
+	}
+	if event.OldEmployeeAbbreviation != "ABC" || event.NewEmployeeAbbreviation != "XYZ" {
+		t.Errorf("Expected reassignment from ABC to XYZ, got %s -> %s", event.OldEmployeeAbbreviation, event.NewEmployeeAbbreviation)
+	}
+	if etag := rr.Header().Get("ETag"); etag != handler.ResponseHelper.ComputerETag(&computer) {
+		t.Errorf("Expected the ETag of the assigned computer, got %s", etag)
+	}
+}
+
+func TestAssignComputerToEmployeeHandler_IgnoresActorHeader(t *testing.T) {
+	mockRepo := &MockComputerRepository{}
+	events := &MockEventRepository{}
+	transactor := &MockTransactor{Repos: repository.Repositories{Computers: mockRepo, Employees: &MockEmployeeRepository{}, Events: events, Outbox: &MockOutboxRepository{}, Policies: &MockPolicyRepository{},
+		Subnets: &MockSubnetRepository{}}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil))
+	handler := NewComputerHandler(service.NewComputerService(mockRepo, &MockEmployeeRepository{}, transactor, logger), logger)
+
+	computer := createTestComputer()
+	mockRepo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		return &computer, nil
+	}
+
+	req, _ := http.NewRequest("PUT", "/employees/XYZ/computers/"+computer.ID.String(), nil)
+	req.Header.Set("X-Actor", "jane.admin")
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "XYZ", "computer_id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.AssignComputerToEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	if len(events.Events) != 1 || events.Events[0].Actor != service.AnonymousActor {
+		t.Errorf("Expected an anonymous event, got %+v", events.Events)
+	}
+}
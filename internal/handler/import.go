@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/service"
+	"encoding/csv"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MaxImportBodySize limits the size of an import file
+const MaxImportBodySize = 10 << 20
+
+// Media types accepted by the import endpoint
+const (
+	CSVMediaType    = "text/csv"
+	NDJSONMediaType = "application/x-ndjson"
+)
+
+// importColumns are the CSV columns of an import; the header row names them in any order. The
+// read-only columns of an export are accepted and ignored, so exports can be imported again.
+var importColumns = map[string]func(c *model.Computer, value string){
+	"mac_address":           func(c *model.Computer, value string) { c.MACAddress = value },
+	"computer_name":         func(c *model.Computer, value string) { c.ComputerName = value },
+	"ip_address":            func(c *model.Computer, value string) { c.IPAddress = value },
+	"employee_abbreviation": func(c *model.Computer, value string) { c.EmployeeAbbreviation = value },
+	"description":           func(c *model.Computer, value string) { c.Description = value },
+	"id":                    nil,
+	"vendor":                nil,
+	"version":               nil,
+	"created_at":            nil,
+	"updated_at":            nil,
+}
+
+// ImportComputersHandler imports computers from a CSV or NDJSON file in a single transaction.
+// With dry_run=true the per-row report is returned without committing anything, and mode=upsert
+// updates the computers whose MAC address is already registered.
+func (h *ComputerHandler) ImportComputersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, ImportTimeout)
+	defer cancel()
+
+	query := r.URL.Query()
+	opts := service.ImportOptions{Mode: service.ImportMode(query.Get("mode"))}
+	if value := query.Get("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			h.ErrorHandler.HandleValidationErrors(w, map[string]string{"dry_run": "dry_run must be true or false"})
+			return
+		}
+		opts.DryRun = dryRun
+	}
+
+	mediaType, supported := parseImportMediaType(r.Header.Get("Content-Type"))
+	if !supported {
+		w.Header().Set("Accept", strings.Join([]string{CSVMediaType, NDJSONMediaType}, ", "))
+		h.ErrorHandler.SendErrorResponse(w, http.StatusUnsupportedMediaType, "Unsupported import format", "UNSUPPORTED_MEDIA_TYPE", nil)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, MaxImportBodySize)
+	var rows []service.ImportRow
+	var err error
+	if mediaType == CSVMediaType {
+		rows, err = parseCSVImport(body)
+	} else {
+		rows, err = parseNDJSONImport(body)
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if stderrors.As(err, &maxBytesErr) {
+			h.ErrorHandler.SendErrorResponse(w, http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("Import file cannot exceed %d bytes", MaxImportBodySize), "REQUEST_TOO_LARGE", nil)
+			return
+		}
+		h.ErrorHandler.SendErrorResponse(w, http.StatusBadRequest, "Invalid import file", "INVALID_IMPORT_FILE", map[string]string{"file": err.Error()})
+		return
+	}
+
+	report, err := h.Service.ImportComputers(ctx, rows, opts)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "import computers")
+		return
+	}
+
+	switch {
+	case report.Failed > 0 && !report.DryRun:
+		h.ErrorHandler.SendJSONResponse(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"error":  fmt.Sprintf("Import rejected: %d of %d rows are invalid", report.Failed, report.Total),
+			"code":   "IMPORT_REJECTED",
+			"report": report,
+		})
+	case report.DryRun:
+		h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Import validated (dry run)", report)
+	default:
+		h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Computers imported successfully", report)
+	}
+}
+
+// parseImportMediaType returns the import format of a Content-Type header
+func parseImportMediaType(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case CSVMediaType:
+		return CSVMediaType, true
+	case NDJSONMediaType, "application/ndjson", "application/jsonl":
+		return NDJSONMediaType, true
+	default:
+		return "", false
+	}
+}
+
+// parseCSVImport reads computers from a CSV file whose first row names the columns. Rows with
+// the wrong number of fields are reported per row; a malformed header fails the whole file.
+// Values escaped against formula evaluation by the export are read back unescaped.
+func parseCSVImport(body io.Reader) ([]service.ImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, stderrors.New("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	setters := make([]func(c *model.Computer, value string), len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		setter, ok := importColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column %q", name)
+		}
+		seen[name] = true
+		setters[i] = setter
+	}
+	for _, required := range []string{"mac_address", "computer_name", "ip_address"} {
+		if !seen[required] {
+			return nil, fmt.Errorf("missing column %q", required)
+		}
+	}
+
+	var rows []service.ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+
+		var parseErr *csv.ParseError
+		if stderrors.As(err, &parseErr) && stderrors.Is(parseErr.Err, csv.ErrFieldCount) {
+			rows = append(rows, service.ImportRow{
+				Line:  parseErr.StartLine,
+				Error: fmt.Sprintf("expected %d fields, got %d", len(header), len(record)),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := service.ImportRow{Line: line}
+		for i, value := range record {
+			if setters[i] != nil {
+				setters[i](&row.Computer, csvCellValue(strings.TrimSpace(value)))
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// parseNDJSONImport reads computers from newline-delimited JSON, one computer object per line.
+// Blank lines are skipped and lines that are not a JSON object are reported per row.
+func parseNDJSONImport(body io.Reader) ([]service.ImportRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxImportBodySize)
+
+	var rows []service.ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := service.ImportRow{Line: line}
+		if err := json.Unmarshal(data, &row.Computer); err != nil {
+			row.Error = "line is not a valid computer JSON object"
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, stderrors.New("file is empty")
+	}
+	return rows, nil
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// importResponse is the body of a successful import
+type importResponse struct {
+	Message string               `json:"message"`
+	Data    service.ImportReport `json:"data"`
+}
+
+func createImportRequest(url, contentType, body string) *http.Request {
+	req, _ := http.NewRequest("POST", url, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	return req
+}
+
+func TestImportComputersHandler_CSV(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	var created []model.Computer
+	mockRepo.CreateComputerFunc = func(ctx context.Context, computer model.Computer) error {
+		created = append(created, computer)
+		return nil
+	}
+
+	body := "computer_name,mac_address,ip_address,description\n" +
+		"PC-1,00-1b-44-11-3a-b7,10.0.0.1,\"Desk 1, window\"\n" +
+		"PC-2,AA:BB:CC:DD:EE:FF,10.0.0.2,'=spare\n"
+	rr := httptest.NewRecorder()
+	handler.ImportComputersHandler(rr, createImportRequest("/computers:import", "text/csv; charset=utf-8", body))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var response importResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if !response.Data.Applied || response.Data.Created != 2 {
+		t.Errorf("Expected 2 computers to be created, got %+v", response.Data)
+	}
+	if len(created) != 2 || created[0].MACAddress != "00:1B:44:11:3A:B7" || created[0].Description != "Desk 1, window" || created[1].Description != "=spare" {
+		t.Errorf("Expected the CSV rows to be stored, got %+v", created)
+	}
+	if response.Data.Rows[1].Line != 3 {
+		t.Errorf("Expected the second row on line 3, got %d", response.Data.Rows[1].Line)
+	}
+}
+
+func TestImportComputersHandler_InvalidRows(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+
+	mockRepo.CreateComputerFunc = func(ctx context.Context, computer model.Computer) error {
+		t.Error("Expected no computer to be created")
+		return nil
+	}
+
+	body := `{"computer_name":"PC-1","mac_address":"00:1B:44:11:3A:B7","ip_address":"10.0.0.1"}` + "\n\n" +
+		`{"computer_name":"PC-2","mac_address":"00:1B:44:11:3A:B7","ip_address":"10.0.0.2"}` + "\n" +
+		`not json` + "\n"
+	rr := httptest.NewRecorder()
+	handler.ImportComputersHandler(rr, createImportRequest("/computers:import", "application/x-ndjson", body))
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Code   string               `json:"code"`
+		Report service.ImportReport `json:"report"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != "IMPORT_REJECTED" || response.Report.Failed != 2 {
+		t.Errorf("Expected 2 rejected rows, got %+v", response)
+	}
+	if response.Report.Rows[1].Line != 3 || response.Report.Rows[2].Line != 4 {
+		t.Errorf("Expected errors on lines 3 and 4, got %+v", response.Report.Rows)
+	}
+}
+
+func TestImportComputersHandler_DryRun(t *testing.T) {
+	handler, _, _ := createTestHandler()
+
+	body := "mac_address,computer_name,ip_address\n00:1B:44:11:3A:B7,PC-1\n"
+	rr := httptest.NewRecorder()
+	handler.ImportComputersHandler(rr, createImportRequest("/computers:import?dry_run=true", "text/csv", body))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var response importResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if !response.Data.DryRun || response.Data.Applied || response.Data.Failed != 1 {
+		t.Errorf("Expected a dry run report with 1 failed row, got %+v", response.Data)
+	}
+}
+
+func TestImportComputersHandler_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{"unsupported format", "/computers:import", "application/json", `[]`, http.StatusUnsupportedMediaType},
+		{"unknown column", "/computers:import", "text/csv", "mac_address,computer_name,ip_address,owner\n", http.StatusBadRequest},
+		{"missing column", "/computers:import", "text/csv", "mac_address,computer_name\n", http.StatusBadRequest},
+		{"empty file", "/computers:import", "application/x-ndjson", "\n", http.StatusBadRequest},
+		{"no rows", "/computers:import", "text/csv", "mac_address,computer_name,ip_address\n", http.StatusBadRequest},
+		{"unknown mode", "/computers:import?mode=replace", "text/csv", "mac_address,computer_name,ip_address\n00:1B:44:11:3A:B7,PC-1,10.0.0.1\n", http.StatusBadRequest},
+		{"invalid dry run", "/computers:import?dry_run=maybe", "text/csv", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _, _ := createTestHandler()
+
+			rr := httptest.NewRecorder()
+			handler.ImportComputersHandler(rr, createImportRequest(tt.url, tt.contentType, tt.body))
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
@@ -12,16 +12,99 @@ type ComputerHandlerInterface interface {
 	GetAllComputersHandler(w http.ResponseWriter, r *http.Request)
 	GetComputerHandler(w http.ResponseWriter, r *http.Request)
 	UpdateComputerHandler(w http.ResponseWriter, r *http.Request)
+	PatchComputerHandler(w http.ResponseWriter, r *http.Request)
 	DeleteComputerHandler(w http.ResponseWriter, r *http.Request)
+	ImportComputersHandler(w http.ResponseWriter, r *http.Request)
+	ExportComputersHandler(w http.ResponseWriter, r *http.Request)
 
 	// Employee-specific operations
 	GetEmployeeComputersHandler(w http.ResponseWriter, r *http.Request)
 	RemoveComputerFromEmployeeHandler(w http.ResponseWriter, r *http.Request)
 	AssignComputerToEmployeeHandler(w http.ResponseWriter, r *http.Request)
+}
+
+// EmployeeHandlerInterface defines the contract for employee HTTP handlers.
+type EmployeeHandlerInterface interface {
+	CreateEmployeeHandler(w http.ResponseWriter, r *http.Request)
+	GetAllEmployeesHandler(w http.ResponseWriter, r *http.Request)
+	GetEmployeeHandler(w http.ResponseWriter, r *http.Request)
+	UpdateEmployeeHandler(w http.ResponseWriter, r *http.Request)
+	DeleteEmployeeHandler(w http.ResponseWriter, r *http.Request)
+}
+
+// NetworkInterfaceHandlerInterface defines the contract for network interface HTTP handlers.
+type NetworkInterfaceHandlerInterface interface {
+	GetInterfacesHandler(w http.ResponseWriter, r *http.Request)
+	CreateInterfaceHandler(w http.ResponseWriter, r *http.Request)
+	GetInterfaceHandler(w http.ResponseWriter, r *http.Request)
+	UpdateInterfaceHandler(w http.ResponseWriter, r *http.Request)
+	DeleteInterfaceHandler(w http.ResponseWriter, r *http.Request)
+	GetInterfaceByMACHandler(w http.ResponseWriter, r *http.Request)
+}
+
+// SubnetHandlerInterface defines the contract for subnet and address allocation HTTP handlers.
+type SubnetHandlerInterface interface {
+	CreateSubnetHandler(w http.ResponseWriter, r *http.Request)
+	GetAllSubnetsHandler(w http.ResponseWriter, r *http.Request)
+	GetSubnetHandler(w http.ResponseWriter, r *http.Request)
+	UpdateSubnetHandler(w http.ResponseWriter, r *http.Request)
+	DeleteSubnetHandler(w http.ResponseWriter, r *http.Request)
+	GetSubnetUtilizationHandler(w http.ResponseWriter, r *http.Request)
+	GetAllSubnetUtilizationHandler(w http.ResponseWriter, r *http.Request)
+	AllocateAddressHandler(w http.ResponseWriter, r *http.Request)
+}
+
+// NetworkConfigHandlerInterface defines the contract for DHCP and DNS configuration HTTP handlers.
+type NetworkConfigHandlerInterface interface {
+	ExportDHCPHandler(w http.ResponseWriter, r *http.Request)
+	ExportDNSHandler(w http.ResponseWriter, r *http.Request)
+}
 
-	// Health and monitoring
-	HealthHandler(w http.ResponseWriter, r *http.Request)
+// WakeHandlerInterface defines the contract for Wake-on-LAN HTTP handlers.
+type WakeHandlerInterface interface {
+	WakeComputerHandler(w http.ResponseWriter, r *http.Request)
 }
 
-// Ensure ComputerHandler implements ComputerHandlerInterface at compile time
-var _ ComputerHandlerInterface = (*ComputerHandler)(nil)
+// HistoryHandlerInterface defines the contract for audit trail HTTP handlers.
+type HistoryHandlerInterface interface {
+	GetComputerHistoryHandler(w http.ResponseWriter, r *http.Request)
+	GetEmployeeHistoryHandler(w http.ResponseWriter, r *http.Request)
+	SearchHistoryHandler(w http.ResponseWriter, r *http.Request)
+}
+
+// PolicyHandlerInterface defines the contract for quota policy HTTP handlers.
+type PolicyHandlerInterface interface {
+	CreatePolicyHandler(w http.ResponseWriter, r *http.Request)
+	GetAllPoliciesHandler(w http.ResponseWriter, r *http.Request)
+	GetPolicyHandler(w http.ResponseWriter, r *http.Request)
+	UpdatePolicyHandler(w http.ResponseWriter, r *http.Request)
+	DeletePolicyHandler(w http.ResponseWriter, r *http.Request)
+	GetEmployeePolicyHandler(w http.ResponseWriter, r *http.Request)
+}
+
+// APIKeyHandlerInterface defines the contract for API key management HTTP handlers.
+type APIKeyHandlerInterface interface {
+	CreateAPIKeyHandler(w http.ResponseWriter, r *http.Request)
+	GetAllAPIKeysHandler(w http.ResponseWriter, r *http.Request)
+	RevokeAPIKeyHandler(w http.ResponseWriter, r *http.Request)
+}
+
+// HealthHandlerInterface defines the contract for liveness and readiness probe handlers.
+type HealthHandlerInterface interface {
+	LiveHandler(w http.ResponseWriter, r *http.Request)
+	ReadyHandler(w http.ResponseWriter, r *http.Request)
+}
+
+// Ensure handlers implement their interfaces at compile time
+var (
+	_ ComputerHandlerInterface         = (*ComputerHandler)(nil)
+	_ EmployeeHandlerInterface         = (*EmployeeHandler)(nil)
+	_ NetworkInterfaceHandlerInterface = (*NetworkInterfaceHandler)(nil)
+	_ SubnetHandlerInterface           = (*SubnetHandler)(nil)
+	_ NetworkConfigHandlerInterface    = (*NetworkConfigHandler)(nil)
+	_ WakeHandlerInterface             = (*WakeHandler)(nil)
+	_ HistoryHandlerInterface          = (*HistoryHandler)(nil)
+	_ PolicyHandlerInterface           = (*PolicyHandler)(nil)
+	_ APIKeyHandlerInterface           = (*APIKeyHandler)(nil)
+	_ HealthHandlerInterface           = (*HealthHandler)(nil)
+)
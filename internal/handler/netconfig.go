@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/service"
+	"computer-management-api/pkg/netconfig"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NetworkConfigHandler serves DHCP reservations and DNS zone files generated from the inventory.
+type NetworkConfigHandler struct {
+	Service service.NetworkConfigServiceInterface
+	Logger  *slog.Logger
+
+	// Zone holds the SOA settings and TTL of zone files; the zone itself is named by the request
+	Zone netconfig.Zone
+
+	// Helper components for cleaner code organization
+	ErrorHandler   *ErrorHandler
+	ResponseHelper *ResponseHelper
+}
+
+// NewNetworkConfigHandler creates a new NetworkConfigHandler with dependencies and helpers
+func NewNetworkConfigHandler(svc service.NetworkConfigServiceInterface, zone netconfig.Zone, logger *slog.Logger) *NetworkConfigHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &NetworkConfigHandler{
+		Service:        svc,
+		Logger:         logger,
+		Zone:           zone,
+		ErrorHandler:   NewErrorHandler(logger),
+		ResponseHelper: NewResponseHelper(),
+	}
+}
+
+// ExportDHCPHandler handles rendering the static DHCP reservations of all computers as ISC dhcpd
+// host declarations, dnsmasq dhcp-host options or Kea reservations.
+func (h *NetworkConfigHandler) ExportDHCPHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, LongRunningTimeout)
+	defer cancel()
+
+	format := netconfig.DHCPFormat(strings.ToLower(r.URL.Query().Get("format")))
+	if format == "" {
+		format = netconfig.DHCPFormatISC
+	}
+	if !isDHCPFormat(format) {
+		h.ErrorHandler.HandleValidationErrors(w, map[string]string{"format": "format must be isc, dnsmasq or kea"})
+		return
+	}
+
+	hosts, err := h.Service.GetHosts(ctx)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "export DHCP configuration")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := netconfig.WriteDHCP(&buf, format, hosts); err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "export DHCP configuration")
+		return
+	}
+	h.sendFile(w, format.MediaType(), format.Filename(), buf.Bytes())
+}
+
+// ExportDNSHandler handles rendering a BIND zone file: A and AAAA records of all computers for a
+// forward zone, or PTR records of the addresses within a reverse zone, which point into the
+// domain given with it.
+func (h *NetworkConfigHandler) ExportDNSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, LongRunningTimeout)
+	defer cancel()
+
+	query := r.URL.Query()
+	zone := h.Zone
+	validationErrors := make(map[string]string)
+
+	name, err := netconfig.ParseZoneName(query.Get("zone"))
+	if err != nil {
+		validationErrors["zone"] = "zone must be a domain name, such as example.com or 1.168.192.in-addr.arpa"
+	}
+	zone.Name = name
+
+	if domain := query.Get("domain"); domain != "" {
+		if zone.Domain, err = netconfig.ParseZoneName(domain); err != nil {
+			validationErrors["domain"] = "domain must be a domain name"
+		}
+	} else if name != "" && netconfig.IsReverseZone(name) {
+		validationErrors["domain"] = "domain is required for reverse zones"
+	}
+
+	if value := query.Get("serial"); value != "" {
+		serial, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || serial == 0 {
+			validationErrors["serial"] = "serial must be between 1 and 4294967295"
+		}
+		zone.Serial = uint32(serial)
+	}
+
+	if len(validationErrors) > 0 {
+		h.ErrorHandler.HandleValidationErrors(w, validationErrors)
+		return
+	}
+
+	hosts, err := h.Service.GetHosts(ctx)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "export DNS zone")
+		return
+	}
+	if zone.Serial == 0 {
+		if zone.RemovedAt, err = h.Service.GetLastDeletion(ctx); err != nil {
+			h.ErrorHandler.HandleServiceError(w, r, err, "export DNS zone")
+			return
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := netconfig.WriteZone(&buf, zone, hosts); err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "export DNS zone")
+		return
+	}
+	h.sendFile(w, netconfig.TextMediaType, fmt.Sprintf("db.%s", zone.Name), buf.Bytes())
+}
+
+// sendFile sends a generated configuration file as a download
+func (h *NetworkConfigHandler) sendFile(w http.ResponseWriter, mediaType, filename string, content []byte) {
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(content); err != nil {
+		h.ErrorHandler.logWriteError(w, "Failed to write network configuration", err)
+	}
+}
+
+// isDHCPFormat reports whether format is a supported DHCP format
+func isDHCPFormat(format netconfig.DHCPFormat) bool {
+	for _, supported := range netconfig.DHCPFormats {
+		if format == supported {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"computer-management-api/pkg/netconfig"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// createTestNetworkConfigHandler returns a handler over an in-memory store holding a dual-stack
+// laptop with a Wi-Fi interface and a computer whose name is not a DNS hostname
+func createTestNetworkConfigHandler(t *testing.T) (*NetworkConfigHandler, *MockEventRepository) {
+	t.Helper()
+
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	laptop := model.Computer{ID: uuid.New(), ComputerName: "LAPTOP-01", MACAddress: "00:1B:44:11:3A:B7", IPAddress: "192.168.1.100"}
+	legacy := model.Computer{ID: uuid.New(), ComputerName: "Reception PC", MACAddress: "00:1B:44:11:3A:B8", IPAddress: "192.168.1.101"}
+	for _, c := range []model.Computer{laptop, legacy} {
+		if err := store.Computers().CreateComputer(ctx, c); err != nil {
+			t.Fatalf("Failed to create computer: %v", err)
+		}
+	}
+
+	interfaces, err := store.Interfaces().GetInterfacesByComputer(ctx, laptop.ID)
+	if err != nil {
+		t.Fatalf("Failed to get interfaces: %v", err)
+	}
+	primary := interfaces[0]
+	primary.IPv6Address = "2001:db8::100"
+	if err := store.Interfaces().UpdateInterface(ctx, primary); err != nil {
+		t.Fatalf("Failed to update interface: %v", err)
+	}
+	wifi := model.NetworkInterface{ID: uuid.New(), ComputerID: laptop.ID, MACAddress: "00:1B:44:11:3A:C0", Type: model.NetworkInterfaceTypeWiFi, IPv4Address: "192.168.1.150"}
+	if err := store.Interfaces().CreateInterface(ctx, wifi); err != nil {
+		t.Fatalf("Failed to create interface: %v", err)
+	}
+
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+	events := &MockEventRepository{}
+	svc := service.NewNetworkConfigService(store.Computers(), store.Interfaces(), events, logger)
+	zone := netconfig.Zone{NameServer: "ns1", Hostmaster: "hostmaster", TTL: time.Hour}
+	return NewNetworkConfigHandler(svc, zone, logger), events
+}
+
+func TestExportDHCPHandler_Dnsmasq(t *testing.T) {
+	handler, _ := createTestNetworkConfigHandler(t)
+
+	req, _ := http.NewRequest("GET", "/exports/dhcp?format=dnsmasq", nil)
+	rr := httptest.NewRecorder()
+	handler.ExportDHCPHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != netconfig.TextMediaType {
+		t.Errorf("Expected Content-Type %s, got %s", netconfig.TextMediaType, contentType)
+	}
+	if disposition := rr.Header().Get("Content-Disposition"); !strings.Contains(disposition, "dnsmasq-hosts.conf") {
+		t.Errorf("Unexpected Content-Disposition %s", disposition)
+	}
+
+	expected := "# Generated from the computer inventory. Do not edit.\n" +
+		"dhcp-host=00:1b:44:11:3a:b7,192.168.1.100,laptop-01\n" +
+		"dhcp-host=00:1b:44:11:3a:c0,192.168.1.150\n"
+	if rr.Body.String() != expected {
+		t.Errorf("Unexpected output:\n%s", rr.Body.String())
+	}
+}
+
+func TestExportDHCPHandler_InvalidFormat(t *testing.T) {
+	handler, _ := createTestNetworkConfigHandler(t)
+
+	req, _ := http.NewRequest("GET", "/exports/dhcp?format=bootp", nil)
+	rr := httptest.NewRecorder()
+	handler.ExportDHCPHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestExportDNSHandler_ForwardZone(t *testing.T) {
+	handler, _ := createTestNetworkConfigHandler(t)
+
+	req, _ := http.NewRequest("GET", "/exports/dns?zone=example.com&serial=42", nil)
+	rr := httptest.NewRecorder()
+	handler.ExportDNSHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	body := rr.Body.String()
+	for _, line := range []string{"$ORIGIN example.com.\n", "\t\t\t42\t; serial\n", "laptop-01\tIN\tA\t192.168.1.100\n", "laptop-01\tIN\tAAAA\t2001:db8::100\n"} {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected %q in zone file:\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, "192.168.1.150") || strings.Contains(body, "192.168.1.101") {
+		t.Errorf("Only primary addresses of computers named as hostnames belong in the zone:\n%s", body)
+	}
+}
+
+func TestExportDNSHandler_SerialAdvancedByDeletion(t *testing.T) {
+	handler, events := createTestNetworkConfigHandler(t)
+	deletedAt := time.Now().Add(time.Hour)
+	events.Events = append(events.Events, model.ComputerEvent{ComputerID: uuid.New(), EventType: model.ComputerEventDeleted, OccurredAt: deletedAt})
+
+	req, _ := http.NewRequest("GET", "/exports/dns?zone=example.com", nil)
+	rr := httptest.NewRecorder()
+	handler.ExportDNSHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if serial := fmt.Sprintf("\t\t\t%d\t; serial\n", deletedAt.Unix()); !strings.Contains(rr.Body.String(), serial) {
+		t.Errorf("Expected the deletion to advance the serial to %d, got:\n%s", deletedAt.Unix(), rr.Body.String())
+	}
+}
+
+func TestExportDNSHandler_ReverseZone(t *testing.T) {
+	handler, _ := createTestNetworkConfigHandler(t)
+
+	req, _ := http.NewRequest("GET", "/exports/dns?zone=1.168.192.in-addr.arpa&domain=example.com", nil)
+	rr := httptest.NewRecorder()
+	handler.ExportDNSHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if !strings.HasSuffix(rr.Body.String(), "\n100\tIN\tPTR\tlaptop-01.example.com.\n") {
+		t.Errorf("Unexpected zone file:\n%s", rr.Body.String())
+	}
+}
+
+func TestExportDNSHandler_InvalidParameters(t *testing.T) {
+	handler, _ := createTestNetworkConfigHandler(t)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing zone", ""},
+		{"invalid zone", "zone=exa_mple.com"},
+		{"reverse zone without domain", "zone=1.168.192.in-addr.arpa"},
+		{"invalid serial", "zone=example.com&serial=0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/exports/dns?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			handler.ExportDNSHandler(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// NetworkInterfaceHandler handles the HTTP requests for the network interfaces of computers.
+type NetworkInterfaceHandler struct {
+	Service service.NetworkInterfaceServiceInterface
+	Logger  *slog.Logger
+
+	// RequireIfMatch rejects changes to interfaces that are not conditional on their computer's ETag
+	RequireIfMatch bool
+
+	// Helper components for cleaner code organization
+	ErrorHandler   *ErrorHandler
+	ResponseHelper *ResponseHelper
+}
+
+// NewNetworkInterfaceHandler creates a new NetworkInterfaceHandler with dependencies and helpers
+func NewNetworkInterfaceHandler(svc service.NetworkInterfaceServiceInterface, logger *slog.Logger) *NetworkInterfaceHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &NetworkInterfaceHandler{
+		Service:        svc,
+		Logger:         logger,
+		ErrorHandler:   NewErrorHandler(logger),
+		ResponseHelper: NewResponseHelper(),
+	}
+}
+
+// GetInterfacesHandler handles the retrieval of a computer's network interfaces, the primary one first.
+func (h *NetworkInterfaceHandler) GetInterfacesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	computerID, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	interfaces, err := h.Service.GetInterfaces(ctx, computerID)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve network interfaces")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"computer_id": computerID,
+		"interfaces":  interfaces,
+	})
+}
+
+// CreateInterfaceHandler handles adding a network interface to a computer.
+func (h *NetworkInterfaceHandler) CreateInterfaceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	computerID, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	ctx, valid = h.withPreconditions(ctx, w, r)
+	if !valid {
+		return
+	}
+
+	var iface model.NetworkInterface
+	if err := json.NewDecoder(r.Body).Decode(&iface); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	created, err := h.Service.CreateInterface(ctx, computerID, iface)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "create network interface")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusCreated, "Network interface created successfully", created)
+}
+
+// GetInterfaceHandler handles the retrieval of a single network interface of a computer.
+func (h *NetworkInterfaceHandler) GetInterfaceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	computerID, id, valid := h.parseInterfaceIDs(w, r)
+	if !valid {
+		return
+	}
+
+	iface, err := h.Service.GetInterface(ctx, computerID, id)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve network interface")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, iface)
+}
+
+// UpdateInterfaceHandler handles the replacement of a network interface of a computer.
+func (h *NetworkInterfaceHandler) UpdateInterfaceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	computerID, id, valid := h.parseInterfaceIDs(w, r)
+	if !valid {
+		return
+	}
+
+	ctx, valid = h.withPreconditions(ctx, w, r)
+	if !valid {
+		return
+	}
+
+	var iface model.NetworkInterface
+	if err := json.NewDecoder(r.Body).Decode(&iface); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	updated, err := h.Service.UpdateInterface(ctx, computerID, id, iface)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "update network interface")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Network interface updated successfully", updated)
+}
+
+// DeleteInterfaceHandler handles the deletion of a secondary network interface of a computer.
+func (h *NetworkInterfaceHandler) DeleteInterfaceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	computerID, id, valid := h.parseInterfaceIDs(w, r)
+	if !valid {
+		return
+	}
+
+	ctx, valid = h.withPreconditions(ctx, w, r)
+	if !valid {
+		return
+	}
+
+	if err := h.Service.DeleteInterface(ctx, computerID, id); err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "delete network interface")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Network interface deleted successfully", map[string]interface{}{
+		"computer_id": computerID,
+		"id":          id,
+	})
+}
+
+// GetInterfaceByMACHandler handles looking up a network interface and the computer it belongs
+// to by MAC address.
+func (h *NetworkInterfaceHandler) GetInterfaceByMACHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	lookup, err := h.Service.GetInterfaceByMAC(ctx, mux.Vars(r)["mac"])
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "look up network interface")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, lookup)
+}
+
+// withPreconditions makes the changes made with ctx conditional on the If-Match header naming
+// a version of the interface's computer, like the changes made to the computer itself
+func (h *NetworkInterfaceHandler) withPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool) {
+	return withComputerPreconditions(ctx, w, r, h.ErrorHandler, h.ResponseHelper, h.RequireIfMatch)
+}
+
+// parseInterfaceIDs parses the computer and interface IDs of a request, responding with an
+// error if either is invalid
+func (h *NetworkInterfaceHandler) parseInterfaceIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	computerID, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, vars["id"])
+	if !valid {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, vars["interface_id"])
+	if !valid {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return computerID, id, true
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// createTestInterfaceHandler returns a handler over an in-memory store holding one computer
+func createTestInterfaceHandler(t *testing.T) (*NetworkInterfaceHandler, model.Computer, repository.NetworkInterfaceRepository) {
+	t.Helper()
+
+	store := repository.NewMemoryStore()
+	computer := model.Computer{ID: uuid.New(), ComputerName: "LAPTOP-01", MACAddress: "00:1B:44:11:3A:B7", IPAddress: "192.168.1.100"}
+	if err := store.Computers().CreateComputer(context.Background(), computer); err != nil {
+		t.Fatalf("Failed to create computer: %v", err)
+	}
+
+	tx := &MockTransactor{Repos: repository.Repositories{Computers: store.Computers(), Interfaces: store.Interfaces(), Events: &MockEventRepository{},
+		Subnets: &MockSubnetRepository{}}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+	svc := service.NewNetworkInterfaceService(store.Computers(), store.Interfaces(), tx, logger)
+	return NewNetworkInterfaceHandler(svc, logger), computer, store.Interfaces()
+}
+
+func TestGetInterfacesHandler_ListsPrimary(t *testing.T) {
+	handler, computer, _ := createTestInterfaceHandler(t)
+
+	req, _ := http.NewRequest("GET", "/computers/"+computer.ID.String()+"/interfaces", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+	handler.GetInterfacesHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Interfaces []model.NetworkInterface `json:"interfaces"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Interfaces) != 1 || !response.Interfaces[0].Primary || response.Interfaces[0].MACAddress != computer.MACAddress {
+		t.Errorf("Expected the primary interface, got %+v", response.Interfaces)
+	}
+}
+
+func TestGetInterfacesHandler_UnknownComputer(t *testing.T) {
+	handler, _, _ := createTestInterfaceHandler(t)
+
+	id := uuid.New().String()
+	req, _ := http.NewRequest("GET", "/computers/"+id+"/interfaces", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": id})
+	rr := httptest.NewRecorder()
+	handler.GetInterfacesHandler(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestCreateInterfaceHandler_Success(t *testing.T) {
+	handler, computer, _ := createTestInterfaceHandler(t)
+
+	req := createJSONRequest("POST", "/computers/"+computer.ID.String()+"/interfaces", map[string]interface{}{
+		"mac_address": "aa-bb-cc-dd-ee-ff",
+		"type":        "wifi",
+	})
+	req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+	handler.CreateInterfaceHandler(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Data model.NetworkInterface `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Data.MACAddress != "AA:BB:CC:DD:EE:FF" || response.Data.Type != model.NetworkInterfaceTypeWiFi || response.Data.Primary {
+		t.Errorf("Unexpected interface %+v", response.Data)
+	}
+}
+
+func TestCreateInterfaceHandler_DuplicateMAC(t *testing.T) {
+	handler, computer, _ := createTestInterfaceHandler(t)
+
+	req := createJSONRequest("POST", "/computers/"+computer.ID.String()+"/interfaces", map[string]interface{}{
+		"mac_address": computer.MACAddress,
+	})
+	req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+	handler.CreateInterfaceHandler(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusConflict, rr.Code, rr.Body.String())
+	}
+}
+
+func TestCreateInterfaceHandler_InvalidType(t *testing.T) {
+	handler, computer, _ := createTestInterfaceHandler(t)
+
+	req := createJSONRequest("POST", "/computers/"+computer.ID.String()+"/interfaces", map[string]interface{}{
+		"mac_address": "AA:BB:CC:DD:EE:FF",
+		"type":        "token-ring",
+	})
+	req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+	handler.CreateInterfaceHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestCreateInterfaceHandler_Preconditions(t *testing.T) {
+	tests := []struct {
+		name               string
+		ifMatch            string
+		expectedStatus     int
+		expectedInterfaces int
+	}{
+		{"missing If-Match", "", http.StatusPreconditionRequired, 1},
+		{"stale If-Match", `"99"`, http.StatusPreconditionFailed, 1},
+		{"current If-Match", `"1"`, http.StatusCreated, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, computer, interfaces := createTestInterfaceHandler(t)
+			handler.RequireIfMatch = true
+
+			req := createJSONRequest("POST", "/computers/"+computer.ID.String()+"/interfaces", map[string]interface{}{
+				"mac_address": "AA:BB:CC:DD:EE:FF",
+			})
+			req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String()})
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rr := httptest.NewRecorder()
+			handler.CreateInterfaceHandler(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			created, err := interfaces.GetInterfacesByComputer(context.Background(), computer.ID)
+			if err != nil {
+				t.Fatalf("Failed to list interfaces: %v", err)
+			}
+			if len(created) != tt.expectedInterfaces {
+				t.Errorf("Expected %d interfaces, got %d", tt.expectedInterfaces, len(created))
+			}
+		})
+	}
+}
+
+func TestDeleteInterfaceHandler_PrimaryConflict(t *testing.T) {
+	handler, computer, interfaces := createTestInterfaceHandler(t)
+
+	primary, err := interfaces.GetInterfaceByMAC(context.Background(), computer.MACAddress)
+	if err != nil {
+		t.Fatalf("Failed to get primary interface: %v", err)
+	}
+
+	req, _ := http.NewRequest("DELETE", "/computers/"+computer.ID.String()+"/interfaces/"+primary.ID.String(), nil)
+	req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String(), "interface_id": primary.ID.String()})
+	rr := httptest.NewRecorder()
+	handler.DeleteInterfaceHandler(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestDeleteInterfaceHandler_InvalidInterfaceID(t *testing.T) {
+	handler, computer, _ := createTestInterfaceHandler(t)
+
+	req, _ := http.NewRequest("DELETE", "/computers/"+computer.ID.String()+"/interfaces/invalid", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": computer.ID.String(), "interface_id": "invalid"})
+	rr := httptest.NewRecorder()
+	handler.DeleteInterfaceHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestGetInterfaceByMACHandler_Success(t *testing.T) {
+	handler, computer, _ := createTestInterfaceHandler(t)
+
+	req, _ := http.NewRequest("GET", "/interfaces/by-mac/00-1b-44-11-3a-b7", nil)
+	req = mux.SetURLVars(req, map[string]string{"mac": "00-1b-44-11-3a-b7"})
+	rr := httptest.NewRecorder()
+	handler.GetInterfaceByMACHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var lookup service.NetworkInterfaceLookup
+	if err := json.Unmarshal(rr.Body.Bytes(), &lookup); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if lookup.Computer.ID != computer.ID || !lookup.Interface.Primary {
+		t.Errorf("Unexpected lookup %+v", lookup)
+	}
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// PolicyHandler handles the HTTP requests for computer quota policies.
+type PolicyHandler struct {
+	Service service.PolicyServiceInterface
+	Logger  *slog.Logger
+
+	// Helper components for cleaner code organization
+	ErrorHandler   *ErrorHandler
+	ResponseHelper *ResponseHelper
+}
+
+// NewPolicyHandler creates a new PolicyHandler with dependencies and helpers
+func NewPolicyHandler(svc service.PolicyServiceInterface, logger *slog.Logger) *PolicyHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &PolicyHandler{
+		Service:        svc,
+		Logger:         logger,
+		ErrorHandler:   NewErrorHandler(logger),
+		ResponseHelper: NewResponseHelper(),
+	}
+}
+
+// CreatePolicyHandler handles the creation of a new quota policy.
+func (h *PolicyHandler) CreatePolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	var policy model.QuotaPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	created, err := h.Service.CreatePolicy(ctx, policy)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "create quota policy")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusCreated, "Quota policy created successfully", created)
+}
+
+// GetAllPoliciesHandler handles the retrieval of all quota policies with pagination.
+func (h *PolicyHandler) GetAllPoliciesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, LongRunningTimeout)
+	defer cancel()
+
+	paginationParams := h.ResponseHelper.ParsePaginationParams(r)
+
+	result, err := h.Service.GetAllPolicies(ctx, repository.PaginationParams{
+		Offset: paginationParams.Offset,
+		Limit:  paginationParams.Limit,
+	})
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve quota policies")
+		return
+	}
+
+	paginationMeta := h.ResponseHelper.CalculatePaginationMeta(paginationParams, result.TotalCount)
+
+	responseData := h.ResponseHelper.CreatePaginatedListResponseData(result.Items, paginationMeta, map[string]interface{}{
+		"policies": result.Items,
+	})
+	delete(responseData, "items") // Remove generic "items" key since we have "policies"
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, responseData)
+}
+
+// GetPolicyHandler handles the retrieval of a single quota policy by ID.
+func (h *PolicyHandler) GetPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateID(w, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	policy, err := h.Service.GetPolicy(ctx, id)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve quota policy")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, policy)
+}
+
+// UpdatePolicyHandler handles the replacement of a quota policy.
+func (h *PolicyHandler) UpdatePolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateID(w, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	var policy model.QuotaPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	updated, err := h.Service.UpdatePolicy(ctx, id, policy)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "update quota policy")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Quota policy updated successfully", updated)
+}
+
+// DeletePolicyHandler handles the deletion of a quota policy.
+func (h *PolicyHandler) DeletePolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateID(w, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	if err := h.Service.DeletePolicy(ctx, id); err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "delete quota policy")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Quota policy deleted successfully", map[string]interface{}{
+		"id": id,
+	})
+}
+
+// GetEmployeePolicyHandler handles the retrieval of the quota policy that applies to an employee.
+func (h *PolicyHandler) GetEmployeePolicyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	policy, err := h.Service.GetEffectivePolicy(ctx, mux.Vars(r)["employee_abbreviation"])
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve employee quota policy")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, policy)
+}
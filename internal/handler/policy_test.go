@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// MockPolicyRepository is a mock implementation of PolicyRepository
+type MockPolicyRepository struct {
+	CreatePolicyFunc          func(ctx context.Context, policy model.QuotaPolicy) (int64, error)
+	GetPolicyByIDFunc         func(ctx context.Context, id int64) (*model.QuotaPolicy, error)
+	GetApplicablePoliciesFunc func(ctx context.Context, employeeAbbreviation, department string) ([]model.QuotaPolicy, error)
+	UpdatePolicyFunc          func(ctx context.Context, id int64, policy model.QuotaPolicy) error
+	DeletePolicyFunc          func(ctx context.Context, id int64) error
+}
+
+func (m *MockPolicyRepository) CreatePolicy(ctx context.Context, policy model.QuotaPolicy) (int64, error) {
+	if m.CreatePolicyFunc != nil {
+		return m.CreatePolicyFunc(ctx, policy)
+	}
+	return 1, nil
+}
+
+func (m *MockPolicyRepository) GetPolicyByID(ctx context.Context, id int64) (*model.QuotaPolicy, error) {
+	if m.GetPolicyByIDFunc != nil {
+		return m.GetPolicyByIDFunc(ctx, id)
+	}
+	return nil, repository.ErrPolicyNotFound
+}
+
+func (m *MockPolicyRepository) GetAllPoliciesPaginated(ctx context.Context, params repository.PaginationParams) (*repository.PolicyPaginatedResult, error) {
+	return &repository.PolicyPaginatedResult{Items: []model.QuotaPolicy{}}, nil
+}
+
+func (m *MockPolicyRepository) GetApplicablePolicies(ctx context.Context, employeeAbbreviation, department string) ([]model.QuotaPolicy, error) {
+	if m.GetApplicablePoliciesFunc != nil {
+		return m.GetApplicablePoliciesFunc(ctx, employeeAbbreviation, department)
+	}
+	return nil, nil
+}
+
+func (m *MockPolicyRepository) UpdatePolicy(ctx context.Context, id int64, policy model.QuotaPolicy) error {
+	if m.UpdatePolicyFunc != nil {
+		return m.UpdatePolicyFunc(ctx, id, policy)
+	}
+	return nil
+}
+
+func (m *MockPolicyRepository) DeletePolicy(ctx context.Context, id int64) error {
+	if m.DeletePolicyFunc != nil {
+		return m.DeletePolicyFunc(ctx, id)
+	}
+	return nil
+}
+
+func createTestPolicyHandler() (*PolicyHandler, *MockPolicyRepository, *MockEmployeeRepository) {
+	policies := &MockPolicyRepository{}
+	employees := &MockEmployeeRepository{}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+
+	handler := NewPolicyHandler(service.NewPolicyService(policies, employees, logger), logger)
+	return handler, policies, employees
+}
+
+// Test CreatePolicyHandler
+
+func TestCreatePolicyHandler_Success(t *testing.T) {
+	handler, policies, _ := createTestPolicyHandler()
+
+	var stored model.QuotaPolicy
+	policies.CreatePolicyFunc = func(ctx context.Context, p model.QuotaPolicy) (int64, error) {
+		stored = p
+		return 7, nil
+	}
+	policies.GetPolicyByIDFunc = func(ctx context.Context, id int64) (*model.QuotaPolicy, error) {
+		stored.ID = id
+		return &stored, nil
+	}
+
+	req := createJSONRequest("POST", "/policies", model.QuotaPolicy{Scope: model.QuotaPolicyScopeDepartment, Target: "Engineering", MaxComputers: 5})
+	rr := httptest.NewRecorder()
+
+	handler.CreatePolicyHandler(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Errorf("Expected status code %d, got %d", http.StatusCreated, rr.Code)
+	}
+	if stored.Mode != model.QuotaPolicyModeWarn {
+		t.Errorf("Expected mode to default to warn, got %s", stored.Mode)
+	}
+}
+
+func TestCreatePolicyHandler_ValidationError(t *testing.T) {
+	handler, _, _ := createTestPolicyHandler()
+
+	req := createJSONRequest("POST", "/policies", model.QuotaPolicy{Scope: "team", MaxComputers: 3})
+	rr := httptest.NewRecorder()
+
+	handler.CreatePolicyHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestCreatePolicyHandler_UnknownEmployee(t *testing.T) {
+	handler, _, employees := createTestPolicyHandler()
+
+	employees.GetEmployeeByAbbreviationFunc = func(ctx context.Context, abbreviation string) (*model.Employee, error) {
+		return nil, repository.ErrEmployeeNotFound
+	}
+
+	req := createJSONRequest("POST", "/policies", model.QuotaPolicy{Scope: model.QuotaPolicyScopeEmployee, Target: "XYZ", MaxComputers: 1})
+	rr := httptest.NewRecorder()
+
+	handler.CreatePolicyHandler(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestCreatePolicyHandler_Duplicate(t *testing.T) {
+	handler, policies, _ := createTestPolicyHandler()
+
+	policies.CreatePolicyFunc = func(ctx context.Context, p model.QuotaPolicy) (int64, error) {
+		return 0, repository.ErrDuplicatePolicy
+	}
+
+	req := createJSONRequest("POST", "/policies", model.QuotaPolicy{Scope: model.QuotaPolicyScopeGlobal, MaxComputers: 3})
+	rr := httptest.NewRecorder()
+
+	handler.CreatePolicyHandler(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+// Test GetPolicyHandler and DeletePolicyHandler
+
+func TestGetPolicyHandler_InvalidID(t *testing.T) {
+	handler, _, _ := createTestPolicyHandler()
+
+	req, _ := http.NewRequest("GET", "/policies/abc", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "abc"})
+	rr := httptest.NewRecorder()
+
+	handler.GetPolicyHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestDeletePolicyHandler_NotFound(t *testing.T) {
+	handler, policies, _ := createTestPolicyHandler()
+
+	policies.DeletePolicyFunc = func(ctx context.Context, id int64) error {
+		return repository.ErrPolicyNotFound
+	}
+
+	req, _ := http.NewRequest("DELETE", "/policies/42", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "42"})
+	rr := httptest.NewRecorder()
+
+	handler.DeletePolicyHandler(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+// Test GetEmployeePolicyHandler
+
+func TestGetEmployeePolicyHandler_MostSpecificWins(t *testing.T) {
+	handler, policies, _ := createTestPolicyHandler()
+
+	policies.GetApplicablePoliciesFunc = func(ctx context.Context, employeeAbbreviation, department string) ([]model.QuotaPolicy, error) {
+		return []model.QuotaPolicy{
+			{ID: 1, Scope: model.QuotaPolicyScopeGlobal, MaxComputers: 3, Mode: model.QuotaPolicyModeWarn},
+			{ID: 2, Scope: model.QuotaPolicyScopeEmployee, Target: employeeAbbreviation, MaxComputers: 5, Mode: model.QuotaPolicyModeEnforce},
+		}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/employees/ABC/policy", nil)
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "ABC"})
+	rr := httptest.NewRecorder()
+
+	handler.GetEmployeePolicyHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var policy model.QuotaPolicy
+	if err := json.Unmarshal(rr.Body.Bytes(), &policy); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if policy.ID != 2 || policy.Mode != model.QuotaPolicyModeEnforce {
+		t.Errorf("Expected the employee override to apply, got policy %d (%s)", policy.ID, policy.Mode)
+	}
+}
+
+// Test quota enforcement through the computer handlers
+
+func TestAssignComputerToEmployeeHandler_QuotaEnforced(t *testing.T) {
+	handler, mockRepo, _ := createTestHandler()
+	handler.Service = service.NewComputerService(mockRepo, &MockEmployeeRepository{}, &MockTransactor{Repos: repository.Repositories{
+		Computers: mockRepo,
+		Employees: &MockEmployeeRepository{},
+		Events:    &MockEventRepository{},
+		Outbox:    &MockOutboxRepository{},
+		Policies: &MockPolicyRepository{
+			GetApplicablePoliciesFunc: func(ctx context.Context, employeeAbbreviation, department string) ([]model.QuotaPolicy, error) {
+				return []model.QuotaPolicy{{Scope: model.QuotaPolicyScopeGlobal, MaxComputers: 1, Mode: model.QuotaPolicyModeEnforce}}, nil
+			},
+		},
+	}}, handler.Logger)
+
+	computer := createTestComputer()
+	computer.EmployeeAbbreviation = ""
+	mockRepo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		return &computer, nil
+	}
+	mockRepo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		return []model.Computer{createTestComputer(), computer}, nil
+	}
+
+	req, _ := http.NewRequest("PUT", "/employees/ABC/computers/"+computer.ID.String(), nil)
+	req = mux.SetURLVars(req, map[string]string{"employee_abbreviation": "ABC", "computer_id": computer.ID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.AssignComputerToEmployeeHandler(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", http.StatusConflict, rr.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != "CONFLICT" {
+		t.Errorf("Expected CONFLICT code, got %s", response.Code)
+	}
+}
@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"computer-management-api/internal/logging"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,31 +19,30 @@ func NewResponseHelper() *ResponseHelper {
 	return &ResponseHelper{}
 }
 
-// ContextKey type for context keys to avoid collisions
-type ContextKey string
-
-const (
-	RequestIDKey ContextKey = "request_id"
-)
-
 // PaginationParams holds pagination parameters
 type PaginationParams struct {
-	Page     int `json:"page"`
-	PageSize int `json:"page_size"`
-	Offset   int `json:"offset"`
-	Limit    int `json:"limit"`
+	Page     int    `json:"page"`
+	PageSize int    `json:"page_size"`
+	Offset   int    `json:"offset"`
+	Limit    int    `json:"limit"`
+	Cursor   string `json:"cursor,omitempty"` // Opaque cursor of keyset pagination, replaces page when set
+	Count    string `json:"count,omitempty"`  // Requested total count mode: exact, estimated or none
 }
 
-// PaginationMeta holds pagination metadata for responses
+// PaginationMeta holds pagination metadata for responses. Listings paginated by cursor report
+// no page numbers, and the total is omitted when counting was skipped.
 type PaginationMeta struct {
-	Page         int  `json:"page"`
-	PageSize     int  `json:"page_size"`
-	TotalItems   int  `json:"total_items"`
-	TotalPages   int  `json:"total_pages"`
-	HasNext      bool `json:"has_next"`
-	HasPrevious  bool `json:"has_previous"`
-	NextPage     *int `json:"next_page,omitempty"`
-	PreviousPage *int `json:"previous_page,omitempty"`
+	Page                int    `json:"page,omitempty"`
+	PageSize            int    `json:"page_size"`
+	TotalItems          *int   `json:"total_items,omitempty"`
+	TotalItemsEstimated bool   `json:"total_items_estimated,omitempty"`
+	TotalPages          int    `json:"total_pages,omitempty"`
+	HasNext             bool   `json:"has_next"`
+	HasPrevious         bool   `json:"has_previous"`
+	NextPage            *int   `json:"next_page,omitempty"`
+	PreviousPage        *int   `json:"previous_page,omitempty"`
+	Cursor              string `json:"cursor,omitempty"`
+	NextCursor          string `json:"next_cursor,omitempty"`
 }
 
 // Default pagination constants
@@ -79,6 +82,8 @@ func (rh *ResponseHelper) ParsePaginationParams(r *http.Request) PaginationParam
 		PageSize: pageSize,
 		Offset:   offset,
 		Limit:    limit,
+		Cursor:   query.Get("cursor"),
+		Count:    query.Get("count"),
 	}
 }
 
@@ -105,7 +110,7 @@ func (rh *ResponseHelper) CalculatePaginationMeta(params PaginationParams, total
 	return PaginationMeta{
 		Page:         params.Page,
 		PageSize:     params.PageSize,
-		TotalItems:   totalItems,
+		TotalItems:   &totalItems,
 		TotalPages:   totalPages,
 		HasNext:      hasNext,
 		HasPrevious:  hasPrevious,
@@ -114,24 +119,83 @@ func (rh *ResponseHelper) CalculatePaginationMeta(params PaginationParams, total
 	}
 }
 
-// CreateRequestContext creates a context with timeout and optional request ID
-func (rh *ResponseHelper) CreateRequestContext(r *http.Request, timeout time.Duration) (context.Context, context.CancelFunc) {
-	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+// CalculateCursorPaginationMeta calculates pagination metadata for listings that support cursors
+// and optional counts. Whether another page follows is known from the result even without a
+// count, and its cursor is handed out in either mode so offset clients can switch to cursors.
+func (rh *ResponseHelper) CalculateCursorPaginationMeta(params PaginationParams, result *repository.PaginatedResult) PaginationMeta {
+	if params.Cursor == "" && result.TotalCount != repository.UnknownTotalCount && !result.TotalCountEstimated {
+		meta := rh.CalculatePaginationMeta(params, result.TotalCount)
+		if result.NextCursor != nil {
+			meta.NextCursor = result.NextCursor.Encode()
+		}
+		return meta
+	}
 
-	// Add request ID if available from headers (common in production)
-	if requestID := r.Header.Get("X-Request-ID"); requestID != "" {
-		ctx = context.WithValue(ctx, RequestIDKey, requestID)
+	meta := PaginationMeta{
+		PageSize: params.PageSize,
+		HasNext:  result.NextCursor != nil,
+	}
+	if result.NextCursor != nil {
+		meta.NextCursor = result.NextCursor.Encode()
+	}
+	if result.TotalCount != repository.UnknownTotalCount {
+		totalItems := result.TotalCount
+		meta.TotalItems = &totalItems
+		meta.TotalItemsEstimated = result.TotalCountEstimated
+	}
+
+	// Cursors only lead forward, so a cursor page reports no previous page to navigate to
+	if params.Cursor != "" {
+		meta.Cursor = params.Cursor
+		return meta
+	}
+
+	meta.Page = params.Page
+	meta.HasPrevious = params.Page > 1
+	if meta.HasNext {
+		next := params.Page + 1
+		meta.NextPage = &next
+	}
+	if meta.HasPrevious {
+		prev := params.Page - 1
+		meta.PreviousPage = &prev
 	}
+	return meta
+}
 
-	return ctx, cancel
+// CreateRequestContext creates a context with timeout. Changes made during the request are
+// attributed in the audit trail to the authenticated principal only, or to the anonymous actor.
+func (rh *ResponseHelper) CreateRequestContext(r *http.Request, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(r.Context(), timeout)
 }
 
-// GetRequestIDFromContext extracts request ID from context
-func (rh *ResponseHelper) GetRequestIDFromContext(ctx context.Context) string {
-	if requestID, ok := ctx.Value(RequestIDKey).(string); ok {
-		return requestID
+// ComputerETag returns the strong entity tag of a computer, derived from its version
+func (rh *ResponseHelper) ComputerETag(computer *model.Computer) string {
+	return `"` + strconv.FormatInt(computer.Version, 10) + `"`
+}
+
+// ParseIfMatch returns the computer versions named by an If-Match header and whether the header
+// was a wildcard. Weak and malformed entity tags never match, so they are skipped.
+func (rh *ResponseHelper) ParseIfMatch(header string) ([]int64, bool) {
+	versions := []int64{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+			versions = append(versions, version)
+		}
 	}
-	return ""
+	return versions, false
+}
+
+// GetRequestIDFromContext returns the ID the request ID middleware assigned to the request
+func (rh *ResponseHelper) GetRequestIDFromContext(ctx context.Context) string {
+	return logging.RequestID(ctx)
 }
 
 // SetCommonHeaders sets common HTTP headers for all responses
@@ -182,12 +246,3 @@ func (rh *ResponseHelper) CreatePaginatedListResponseData(items interface{}, pag
 
 	return data
 }
-
-// CreateHealthCheckData creates health check response data
-func (rh *ResponseHelper) CreateHealthCheckData() map[string]interface{} {
-	return map[string]interface{}{
-		"timestamp": time.Now().UTC(),
-		"service":   "computer-management-api",
-		"status":    "healthy",
-	}
-}
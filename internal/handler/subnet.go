@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/service"
+	apperrors "computer-management-api/pkg/errors"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// SubnetHandler handles the HTTP requests for subnets and the allocation of their addresses.
+type SubnetHandler struct {
+	Service service.SubnetServiceInterface
+	Logger  *slog.Logger
+
+	// RequireIfMatch rejects allocations that are not conditional on the computer's ETag
+	RequireIfMatch bool
+
+	// Helper components for cleaner code organization
+	ErrorHandler   *ErrorHandler
+	ResponseHelper *ResponseHelper
+}
+
+// AllocateAddressRequest is the body of an address allocation request
+type AllocateAddressRequest struct {
+	ComputerID uuid.UUID `json:"computer_id"`
+}
+
+// NewSubnetHandler creates a new SubnetHandler with dependencies and helpers
+func NewSubnetHandler(svc service.SubnetServiceInterface, logger *slog.Logger) *SubnetHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &SubnetHandler{
+		Service:        svc,
+		Logger:         logger,
+		ErrorHandler:   NewErrorHandler(logger),
+		ResponseHelper: NewResponseHelper(),
+	}
+}
+
+// CreateSubnetHandler handles the creation of a new subnet.
+func (h *SubnetHandler) CreateSubnetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	var subnet model.Subnet
+	if err := json.NewDecoder(r.Body).Decode(&subnet); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	created, err := h.Service.CreateSubnet(ctx, subnet)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "create subnet")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusCreated, "Subnet created successfully", created)
+}
+
+// GetAllSubnetsHandler handles the retrieval of all subnets, ordered by network address.
+func (h *SubnetHandler) GetAllSubnetsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	subnets, err := h.Service.GetSubnets(ctx)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve subnets")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"subnets": subnets,
+	})
+}
+
+// GetSubnetHandler handles the retrieval of a single subnet by ID.
+func (h *SubnetHandler) GetSubnetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	subnet, err := h.Service.GetSubnet(ctx, id)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve subnet")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, subnet)
+}
+
+// UpdateSubnetHandler handles the replacement of a subnet.
+func (h *SubnetHandler) UpdateSubnetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	var subnet model.Subnet
+	if err := json.NewDecoder(r.Body).Decode(&subnet); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+
+	updated, err := h.Service.UpdateSubnet(ctx, id, subnet)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "update subnet")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Subnet updated successfully", updated)
+}
+
+// DeleteSubnetHandler handles the deletion of a subnet.
+func (h *SubnetHandler) DeleteSubnetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	if err := h.Service.DeleteSubnet(ctx, id); err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "delete subnet")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Subnet deleted successfully", map[string]interface{}{
+		"id": id,
+	})
+}
+
+// GetSubnetUtilizationHandler handles reporting how many addresses of a subnet are assigned.
+func (h *SubnetHandler) GetSubnetUtilizationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	utilization, err := h.Service.GetUtilization(ctx, id)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve subnet utilization")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, utilization)
+}
+
+// GetAllSubnetUtilizationHandler handles reporting how many addresses of every subnet are assigned.
+func (h *SubnetHandler) GetAllSubnetUtilizationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, LongRunningTimeout)
+	defer cancel()
+
+	utilizations, err := h.Service.GetAllUtilization(ctx)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "retrieve subnet utilization")
+		return
+	}
+
+	h.ErrorHandler.SendJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"subnets": utilizations,
+	})
+}
+
+// AllocateAddressHandler handles assigning the next free address of a subnet to a computer.
+func (h *SubnetHandler) AllocateAddressHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	var request AllocateAddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.ErrorHandler.HandleJSONDecodeError(w, r, err)
+		return
+	}
+	if request.ComputerID == uuid.Nil {
+		h.ErrorHandler.HandleServiceError(w, r, apperrors.ValidationError("computer_id is required"), "allocate address")
+		return
+	}
+
+	// The allocation changes the computer, so If-Match names a version of the computer
+	ctx, valid = withComputerPreconditions(ctx, w, r, h.ErrorHandler, h.ResponseHelper, h.RequireIfMatch)
+	if !valid {
+		return
+	}
+
+	computer, err := h.Service.AllocateAddress(ctx, id, request.ComputerID)
+	if err != nil {
+		h.ErrorHandler.HandleServiceError(w, r, err, "allocate address")
+		return
+	}
+
+	// Send the computer's new ETag so the client can make its next change conditional
+	w.Header().Set("ETag", h.ResponseHelper.ComputerETag(computer))
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusOK, "Address allocated successfully", computer)
+}
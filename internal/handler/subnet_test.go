@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// MockSubnetRepository is a mock implementation of SubnetRepository. Without functions set it
+// holds no subnets and no assigned addresses.
+type MockSubnetRepository struct {
+	CreateSubnetFunc         func(ctx context.Context, subnet model.Subnet) error
+	GetSubnetsFunc           func(ctx context.Context) ([]model.Subnet, error)
+	GetSubnetByIDFunc        func(ctx context.Context, id uuid.UUID) (*model.Subnet, error)
+	GetSubnetContainingFunc  func(ctx context.Context, ip string) (*model.Subnet, error)
+	UpdateSubnetFunc         func(ctx context.Context, subnet model.Subnet) error
+	DeleteSubnetFunc         func(ctx context.Context, id uuid.UUID) error
+	GetAssignedAddressesFunc func(ctx context.Context, network string) ([]model.IPAddressAssignment, error)
+}
+
+func (m *MockSubnetRepository) CreateSubnet(ctx context.Context, subnet model.Subnet) error {
+	if m.CreateSubnetFunc != nil {
+		return m.CreateSubnetFunc(ctx, subnet)
+	}
+	return nil
+}
+
+func (m *MockSubnetRepository) GetSubnets(ctx context.Context) ([]model.Subnet, error) {
+	if m.GetSubnetsFunc != nil {
+		return m.GetSubnetsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockSubnetRepository) GetSubnetByID(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+	if m.GetSubnetByIDFunc != nil {
+		return m.GetSubnetByIDFunc(ctx, id)
+	}
+	return nil, repository.ErrSubnetNotFound
+}
+
+func (m *MockSubnetRepository) GetSubnetByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+	return m.GetSubnetByID(ctx, id)
+}
+
+func (m *MockSubnetRepository) GetSubnetContaining(ctx context.Context, ip string) (*model.Subnet, error) {
+	if m.GetSubnetContainingFunc != nil {
+		return m.GetSubnetContainingFunc(ctx, ip)
+	}
+	return nil, repository.ErrSubnetNotFound
+}
+
+func (m *MockSubnetRepository) GetSubnetContainingForUpdate(ctx context.Context, ip string) (*model.Subnet, error) {
+	return m.GetSubnetContaining(ctx, ip)
+}
+
+func (m *MockSubnetRepository) CountSubnets(ctx context.Context) (int, error) {
+	subnets, err := m.GetSubnets(ctx)
+	return len(subnets), err
+}
+
+func (m *MockSubnetRepository) LockAddress(ctx context.Context, ip string) error {
+	return nil
+}
+
+func (m *MockSubnetRepository) UpdateSubnet(ctx context.Context, subnet model.Subnet) error {
+	if m.UpdateSubnetFunc != nil {
+		return m.UpdateSubnetFunc(ctx, subnet)
+	}
+	return nil
+}
+
+func (m *MockSubnetRepository) DeleteSubnet(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteSubnetFunc != nil {
+		return m.DeleteSubnetFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockSubnetRepository) GetAssignedAddresses(ctx context.Context, network string) ([]model.IPAddressAssignment, error) {
+	if m.GetAssignedAddressesFunc != nil {
+		return m.GetAssignedAddressesFunc(ctx, network)
+	}
+	return nil, nil
+}
+
+// createTestSubnetHandler returns a handler whose repository holds a single subnet
+func createTestSubnetHandler(subnet model.Subnet) (*SubnetHandler, *MockSubnetRepository, *MockComputerRepository) {
+	subnets := &MockSubnetRepository{
+		GetSubnetsFunc: func(ctx context.Context) ([]model.Subnet, error) {
+			return []model.Subnet{subnet}, nil
+		},
+		GetSubnetByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+			if id != subnet.ID {
+				return nil, repository.ErrSubnetNotFound
+			}
+			return &subnet, nil
+		},
+	}
+	computers := &MockComputerRepository{}
+
+	tx := &MockTransactor{Repos: repository.Repositories{Computers: computers, Events: &MockEventRepository{}, Subnets: subnets}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+	return NewSubnetHandler(service.NewSubnetService(subnets, tx, logger), logger), subnets, computers
+}
+
+func TestCreateSubnetHandler_Success(t *testing.T) {
+	handler, subnets, _ := createTestSubnetHandler(model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24"})
+
+	var stored model.Subnet
+	subnets.CreateSubnetFunc = func(ctx context.Context, subnet model.Subnet) error {
+		stored = subnet
+		return nil
+	}
+	subnets.GetSubnetByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+		return &stored, nil
+	}
+
+	req := createJSONRequest("POST", "/subnets", map[string]interface{}{
+		"cidr":    "192.168.10.0/24",
+		"gateway": "192.168.10.1",
+		"vlan":    10,
+	})
+	rr := httptest.NewRecorder()
+	handler.CreateSubnetHandler(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	if stored.CIDR != "192.168.10.0/24" || stored.VLAN == nil || *stored.VLAN != 10 {
+		t.Errorf("Unexpected subnet stored: %+v", stored)
+	}
+}
+
+func TestCreateSubnetHandler_Overlap(t *testing.T) {
+	handler, subnets, _ := createTestSubnetHandler(model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/16"})
+	subnets.CreateSubnetFunc = func(ctx context.Context, subnet model.Subnet) error {
+		return repository.ErrSubnetOverlap
+	}
+
+	req := createJSONRequest("POST", "/subnets", map[string]interface{}{"cidr": "10.0.1.0/24"})
+	rr := httptest.NewRecorder()
+	handler.CreateSubnetHandler(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+}
+
+func TestCreateSubnetHandler_InvalidCIDR(t *testing.T) {
+	handler, _, _ := createTestSubnetHandler(model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24"})
+
+	req := createJSONRequest("POST", "/subnets", map[string]interface{}{"cidr": "10.0.0.1/24"})
+	rr := httptest.NewRecorder()
+	handler.CreateSubnetHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestGetSubnetHandler_NotFound(t *testing.T) {
+	handler, _, _ := createTestSubnetHandler(model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24"})
+
+	id := uuid.New().String()
+	req, _ := http.NewRequest("GET", "/subnets/"+id, nil)
+	req = mux.SetURLVars(req, map[string]string{"id": id})
+	rr := httptest.NewRecorder()
+	handler.GetSubnetHandler(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func TestGetSubnetUtilizationHandler_Success(t *testing.T) {
+	subnet := model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"}
+	handler, subnets, _ := createTestSubnetHandler(subnet)
+	subnets.GetAssignedAddressesFunc = func(ctx context.Context, network string) ([]model.IPAddressAssignment, error) {
+		return []model.IPAddressAssignment{{Address: "10.0.0.10", ComputerID: uuid.New()}}, nil
+	}
+
+	req, _ := http.NewRequest("GET", "/subnets/"+subnet.ID.String()+"/utilization", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": subnet.ID.String()})
+	rr := httptest.NewRecorder()
+	handler.GetSubnetUtilizationHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Capacity int `json:"capacity"`
+		Used     int `json:"used"`
+		Free     int `json:"free"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Capacity != 253 || response.Used != 1 || response.Free != 252 {
+		t.Errorf("Unexpected utilization %+v", response)
+	}
+}
+
+func TestAllocateAddressHandler_Success(t *testing.T) {
+	subnet := model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"}
+	handler, _, computers := createTestSubnetHandler(subnet)
+
+	computer := createTestComputer()
+	computers.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		c := computer
+		return &c, nil
+	}
+	computers.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, c model.Computer) error {
+		c.Version = computer.Version + 1
+		computer = c
+		return nil
+	}
+
+	req := createJSONRequest("POST", "/subnets/"+subnet.ID.String()+"/allocate", map[string]interface{}{
+		"computer_id": computer.ID,
+	})
+	req = mux.SetURLVars(req, map[string]string{"id": subnet.ID.String()})
+	rr := httptest.NewRecorder()
+	handler.AllocateAddressHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if computer.IPAddress != "10.0.0.2" {
+		t.Errorf("Expected the first free address 10.0.0.2, got %s", computer.IPAddress)
+	}
+	if etag := rr.Header().Get("ETag"); etag != handler.ResponseHelper.ComputerETag(&computer) {
+		t.Errorf("Expected the new ETag %s, got %s", handler.ResponseHelper.ComputerETag(&computer), etag)
+	}
+}
+
+func TestAllocateAddressHandler_Preconditions(t *testing.T) {
+	tests := []struct {
+		name           string
+		ifMatch        string
+		expectedStatus int
+	}{
+		{"missing If-Match", "", http.StatusPreconditionRequired},
+		{"stale If-Match", `"2"`, http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subnet := model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"}
+			handler, _, computers := createTestSubnetHandler(subnet)
+			handler.RequireIfMatch = true
+
+			computer := createTestComputer()
+			computer.Version = 3
+			computers.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+				c := computer
+				return &c, nil
+			}
+			computers.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, c model.Computer) error {
+				t.Error("Expected the address not to be allocated")
+				return nil
+			}
+
+			req := createJSONRequest("POST", "/subnets/"+subnet.ID.String()+"/allocate", map[string]interface{}{
+				"computer_id": computer.ID,
+			})
+			req = mux.SetURLVars(req, map[string]string{"id": subnet.ID.String()})
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			rr := httptest.NewRecorder()
+			handler.AllocateAddressHandler(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestAllocateAddressHandler_MissingComputerID(t *testing.T) {
+	subnet := model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24"}
+	handler, _, _ := createTestSubnetHandler(subnet)
+
+	req := createJSONRequest("POST", "/subnets/"+subnet.ID.String()+"/allocate", map[string]interface{}{})
+	req = mux.SetURLVars(req, map[string]string{"id": subnet.ID.String()})
+	rr := httptest.NewRecorder()
+	handler.AllocateAddressHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
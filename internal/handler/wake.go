@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"computer-management-api/internal/service"
+	apperrors "computer-management-api/pkg/errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// WakeHandler handles the HTTP requests waking computers with Wake-on-LAN magic packets.
+type WakeHandler struct {
+	Service service.WakeServiceInterface
+	Logger  *slog.Logger
+
+	// Helper components for cleaner code organization
+	ErrorHandler   *ErrorHandler
+	ResponseHelper *ResponseHelper
+}
+
+// NewWakeHandler creates a new WakeHandler with dependencies and helpers
+func NewWakeHandler(svc service.WakeServiceInterface, logger *slog.Logger) *WakeHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &WakeHandler{
+		Service:        svc,
+		Logger:         logger,
+		ErrorHandler:   NewErrorHandler(logger),
+		ResponseHelper: NewResponseHelper(),
+	}
+}
+
+// WakeComputerHandler handles sending a magic packet to a computer. A computer woken too
+// recently is refused with 429 Too Many Requests and a Retry-After header.
+func (h *WakeHandler) WakeComputerHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := h.ResponseHelper.CreateRequestContext(r, DefaultTimeout)
+	defer cancel()
+
+	id, valid := h.ErrorHandler.ParseAndValidateUUID(w, r, mux.Vars(r)["id"])
+	if !valid {
+		return
+	}
+
+	wake, err := h.Service.Wake(ctx, id)
+	if err != nil {
+		if appErr, ok := apperrors.AsAppError(err); ok && appErr.Code == apperrors.ErrorCodeRateLimit {
+			if retryAfter, ok := appErr.Details["retry_after_seconds"]; ok {
+				w.Header().Set("Retry-After", fmt.Sprint(retryAfter))
+			}
+		}
+		h.ErrorHandler.HandleServiceError(w, r, err, "wake computer")
+		return
+	}
+
+	h.ErrorHandler.SendSuccessResponse(w, http.StatusAccepted, "Magic packet sent", wake)
+}
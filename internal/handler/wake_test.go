@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/service"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// createTestWakeHandler returns a handler over a single computer whose subnet broadcasts to a
+// UDP listener on the loopback interface, which stands in for the network
+func createTestWakeHandler(t *testing.T) (*WakeHandler, *MockEventRepository, model.Computer, *net.UDPConn) {
+	t.Helper()
+
+	listener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	computer := createTestComputer()
+	computers := &MockComputerRepository{
+		GetComputerByIDFunc: func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+			if id != computer.ID {
+				return nil, repository.ErrComputerNotFound
+			}
+			c := computer
+			return &c, nil
+		},
+	}
+	subnets := &MockSubnetRepository{
+		GetSubnetContainingFunc: func(ctx context.Context, ip string) (*model.Subnet, error) {
+			return &model.Subnet{ID: uuid.New(), CIDR: "192.168.1.0/24", BroadcastAddress: "127.0.0.1"}, nil
+		},
+	}
+	events := &MockEventRepository{}
+
+	tx := &MockTransactor{Repos: repository.Repositories{Computers: computers, Events: events, Subnets: subnets}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+	svc := service.NewWakeService(tx, logger)
+	svc.Port = listener.LocalAddr().(*net.UDPAddr).Port
+	return NewWakeHandler(svc, logger), events, computer, listener
+}
+
+// wakeComputer sends a wake request for a computer to a handler
+func wakeComputer(handler *WakeHandler, id string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/computers/"+id+"/wake", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": id})
+	rr := httptest.NewRecorder()
+	handler.WakeComputerHandler(rr, req)
+	return rr
+}
+
+func TestWakeComputerHandler_Success(t *testing.T) {
+	handler, events, computer, listener := createTestWakeHandler(t)
+
+	rr := wakeComputer(handler, computer.ID.String())
+
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusAccepted, rr.Code, rr.Body.String())
+	}
+	var response struct {
+		Data model.Wake `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Data.ComputerID != computer.ID || response.Data.BroadcastAddress != "127.0.0.1" {
+		t.Errorf("Unexpected wake %+v", response.Data)
+	}
+
+	if err := listener.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("Failed to set deadline: %v", err)
+	}
+	buf := make([]byte, 1024)
+	if n, err := listener.Read(buf); err != nil || n != 102 {
+		t.Errorf("Expected a magic packet, got %d bytes: %v", n, err)
+	}
+	if len(events.Events) != 1 || events.Events[0].EventType != model.ComputerEventWoken {
+		t.Errorf("Expected the wake-up to be recorded, got %+v", events.Events)
+	}
+}
+
+func TestWakeComputerHandler_RateLimited(t *testing.T) {
+	handler, _, computer, _ := createTestWakeHandler(t)
+
+	if rr := wakeComputer(handler, computer.ID.String()); rr.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusAccepted, rr.Code, rr.Body.String())
+	}
+
+	rr := wakeComputer(handler, computer.ID.String())
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusTooManyRequests, rr.Code, rr.Body.String())
+	}
+	if retryAfter := rr.Header().Get("Retry-After"); retryAfter != "60" {
+		t.Errorf("Expected Retry-After 60, got %q", retryAfter)
+	}
+}
+
+func TestWakeComputerHandler_NotFound(t *testing.T) {
+	handler, _, _, _ := createTestWakeHandler(t)
+
+	if rr := wakeComputer(handler, uuid.New().String()); rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+	if rr := wakeComputer(handler, "not-a-uuid"); rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d: %s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+}
@@ -9,12 +9,15 @@ import (
 	"computer-management-api/internal/notification"
 	"computer-management-api/internal/repository"
 	"computer-management-api/internal/router"
+	"computer-management-api/internal/service"
+	notificationadapter "computer-management-api/internal/service/notification"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	neturl "net/url"
 	"os"
 	"strings"
 	"testing"
@@ -50,9 +53,14 @@ type TestConfig struct {
 
 // IntegrationTestSuite holds the test dependencies
 type IntegrationTestSuite struct {
-	DB     *sql.DB
-	Router http.Handler
-	Config *config.Config
+	DB         *sql.DB
+	Router     http.Handler
+	Config     *config.Config
+	Dispatcher *service.OutboxDispatcher
+	Notifier   *mockNotifier
+	Handlers   router.Handlers
+	APIKeys    *service.APIKeyService
+	Health     *service.HealthService
 }
 
 // setupIntegrationTest initializes the test environment
@@ -76,7 +84,29 @@ func setupIntegrationTest(t *testing.T) *IntegrationTestSuite {
 	// Initialize dependencies
 	repo := repository.NewComputerRepository(db)
 	notifier := &mockNotifier{} // Use mock for tests
-	computerHandler := handler.NewComputerHandler(repo, notifier, nil)
+	employeeRepo := repository.NewEmployeeRepository(db)
+	eventRepo := repository.NewEventRepository(db)
+	transactor := repository.NewTransactor(db)
+	computerService := service.NewComputerService(repo, employeeRepo, transactor, nil)
+	interfaceService := service.NewNetworkInterfaceService(repo, repository.NewNetworkInterfaceRepository(db), transactor, nil)
+	subnetService := service.NewSubnetService(repository.NewSubnetRepository(db), transactor, nil)
+	apiKeyService := service.NewAPIKeyService(repository.NewAPIKeyRepository(db), nil)
+	outboxRepo := repository.NewOutboxRepository(db)
+	dispatcher := service.NewOutboxDispatcher(outboxRepo, notificationadapter.NewServiceAdapter(notifier), service.DefaultDispatcherConfig(), nil)
+	healthService := service.NewHealthService(db, notifier, outboxRepo, service.DefaultHealthConfig(), nil)
+	handlers := router.Handlers{
+		Computer:  handler.NewComputerHandler(computerService, nil),
+		Interface: handler.NewNetworkInterfaceHandler(interfaceService, nil),
+		Subnet:    handler.NewSubnetHandler(subnetService, nil),
+		Employee:  handler.NewEmployeeHandler(service.NewEmployeeService(employeeRepo, nil), nil),
+		History:   handler.NewHistoryHandler(service.NewHistoryService(eventRepo, repo, employeeRepo, nil), nil),
+		Policy:    handler.NewPolicyHandler(service.NewPolicyService(repository.NewPolicyRepository(db), employeeRepo, nil), nil),
+		APIKey:    handler.NewAPIKeyHandler(apiKeyService, nil),
+		Health:    handler.NewHealthHandler(healthService, nil),
+	}
+
+	// Seed the employees referenced by the tests
+	seedEmployees(t, employeeRepo)
 
 	// Create test config
 	cfg = &config.Config{
@@ -91,12 +121,17 @@ func setupIntegrationTest(t *testing.T) *IntegrationTestSuite {
 		},
 	}
 
-	testRouter := router.NewRouter(computerHandler, cfg)
+	testRouter := router.NewRouter(handlers, apiKeyService, nil, nil, cfg)
 
 	return &IntegrationTestSuite{
-		DB:     db,
-		Router: testRouter,
-		Config: cfg,
+		DB:         db,
+		Router:     testRouter,
+		Config:     cfg,
+		Dispatcher: dispatcher,
+		Notifier:   notifier,
+		Handlers:   handlers,
+		APIKeys:    apiKeyService,
+		Health:     healthService,
 	}
 }
 
@@ -153,6 +188,15 @@ func initTestDatabase(t *testing.T, cfg *config.Config) *sql.DB {
 		t.Skipf("Failed to ping test database: %v", err)
 	}
 
+	// Bring the schema up to date
+	migrator, err := database.NewMigrator(db, nil)
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Failed to apply migrations: %v", err)
+	}
+
 	return db
 }
 
@@ -161,16 +205,34 @@ func cleanDatabase(t *testing.T, db *sql.DB) {
 	t.Helper()
 
 	// Use TRUNCATE for complete cleanup
-	_, err := db.Exec("TRUNCATE TABLE computers RESTART IDENTITY CASCADE")
+	_, err := db.Exec("TRUNCATE TABLE api_keys, quota_policies, subnets, notification_outbox, computer_events, computers, employees RESTART IDENTITY CASCADE")
 	if err != nil {
 		// Fallback to DELETE if TRUNCATE fails
 		_, err = db.Exec("DELETE FROM computers")
+		if err == nil {
+			_, err = db.Exec("DELETE FROM employees")
+		}
 		if err != nil {
 			t.Logf("Warning: Failed to clean database: %v", err)
 		}
 	}
 }
 
+// testEmployees are the employee abbreviations referenced by the integration tests
+var testEmployees = []string{"ABC", "DEF", "XYZ", "JDO", "TST", "TDB"}
+
+// seedEmployees creates the active employees computers are assigned to in the tests
+func seedEmployees(t *testing.T, repo repository.EmployeeRepository) {
+	t.Helper()
+
+	for _, abbreviation := range testEmployees {
+		employee := model.Employee{Abbreviation: abbreviation, Name: "Test " + abbreviation, Active: true}
+		if err := repo.CreateEmployee(context.Background(), employee); err != nil {
+			t.Fatalf("Failed to seed employee %s: %v", abbreviation, err)
+		}
+	}
+}
+
 // Helper functions
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -393,6 +455,88 @@ func TestIntegration_ComputerCRUD(t *testing.T) {
 			t.Errorf("Expected status %d after deletion, got %d", http.StatusNotFound, getResp.Code)
 		}
 	})
+
+	t.Run("Computer History Survives Deletion", func(t *testing.T) {
+		url := fmt.Sprintf("/api/v1/computers/%s/history", createdID.String())
+		req := httptest.NewRequest("GET", url, nil)
+		resp := httptest.NewRecorder()
+
+		suite.Router.ServeHTTP(resp, req)
+
+		if resp.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, resp.Code, resp.Body.String())
+		}
+
+		var response map[string]interface{}
+		parseJSONResponse(t, resp, &response)
+
+		events, ok := response["events"].([]interface{})
+		if !ok || len(events) < 3 {
+			t.Fatalf("Expected at least 3 events, got %+v", response["events"])
+		}
+		latest := events[0].(map[string]interface{})
+		if latest["event_type"] != "deleted" {
+			t.Errorf("Expected latest event to be a deletion, got %v", latest["event_type"])
+		}
+		oldest := events[len(events)-1].(map[string]interface{})
+		if oldest["event_type"] != "created" || oldest["new_employee_abbreviation"] != "ABC" {
+			t.Errorf("Expected oldest event to be the creation for ABC, got %+v", oldest)
+		}
+	})
+
+	t.Run("History Search Survives Deletion", func(t *testing.T) {
+		url := fmt.Sprintf("/api/v1/computers/%s/history?limit=100", createdID.String())
+		resp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, httptest.NewRequest("GET", url, nil))
+
+		var history map[string]interface{}
+		parseJSONResponse(t, resp, &history)
+		events, _ := history["events"].([]interface{})
+		if len(events) == 0 {
+			t.Fatalf("Expected the computer history, got %+v", history)
+		}
+		created := events[len(events)-1].(map[string]interface{})
+
+		// The newest event of the MAC address at the time of creation tells who held it
+		url = fmt.Sprintf("/api/v1/history?mac=aa:bb:cc:dd:ee:ff&at=%s", neturl.QueryEscape(created["occurred_at"].(string)))
+		resp = httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, httptest.NewRequest("GET", url, nil))
+
+		if resp.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, resp.Code, resp.Body.String())
+		}
+
+		var response map[string]interface{}
+		parseJSONResponse(t, resp, &response)
+
+		found, ok := response["events"].([]interface{})
+		if !ok || len(found) == 0 {
+			t.Fatalf("Expected events for the deleted computer's MAC address, got %+v", response["events"])
+		}
+		held := found[0].(map[string]interface{})
+		if held["computer_id"] != createdID.String() || held["new_employee_abbreviation"] != "ABC" {
+			t.Errorf("Expected the MAC address to be held by ABC at creation, got %+v", held)
+		}
+	})
+
+	t.Run("Employee History", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/employees/ABC/history", nil)
+		resp := httptest.NewRecorder()
+
+		suite.Router.ServeHTTP(resp, req)
+
+		if resp.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d. Body: %s", http.StatusOK, resp.Code, resp.Body.String())
+		}
+
+		var response map[string]interface{}
+		parseJSONResponse(t, resp, &response)
+
+		events, ok := response["events"].([]interface{})
+		if !ok || len(events) == 0 {
+			t.Errorf("Expected events for employee ABC, got %+v", response["events"])
+		}
+	})
 }
 
 func TestIntegration_ValidationErrors(t *testing.T) {
@@ -607,11 +751,55 @@ func TestIntegration_HealthCheck(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusOK, resp.Code)
 	}
 
-	var response map[string]interface{}
-	parseJSONResponse(t, resp, &response)
+	var report service.HealthReport
+	parseJSONResponse(t, resp, &report)
+	if report.Components[service.HealthComponentDatabase].Status != service.HealthStatusUp {
+		t.Errorf("Expected the database to be checked, got %+v", report.Components)
+	}
+
+	// The former health check reports readiness, so it fails once shutdown begins
+	suite.Health.BeginShutdown()
 
-	if response["message"] != "Service is healthy" {
-		t.Errorf("Expected healthy service message, got: %v", response["message"])
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, httptest.NewRequest("GET", "/api/v1/health", nil))
+	if resp.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d during shutdown, got %d", http.StatusServiceUnavailable, resp.Code)
+	}
+}
+
+func TestIntegration_Readiness(t *testing.T) {
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	req := httptest.NewRequest("GET", "/health/ready", nil)
+	resp := httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, resp.Code, resp.Body.String())
+	}
+
+	var report service.HealthReport
+	parseJSONResponse(t, resp, &report)
+	for _, name := range []string{service.HealthComponentDatabase, service.HealthComponentNotification, service.HealthComponentOutbox} {
+		if report.Components[name].Status != service.HealthStatusUp {
+			t.Errorf("Expected %s to be up, got %+v", name, report.Components[name])
+		}
+	}
+
+	// Readiness fails once shutdown begins, while the process stays live
+	suite.Health.BeginShutdown()
+
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, httptest.NewRequest("GET", "/health/ready", nil))
+	if resp.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d during shutdown, got %d", http.StatusServiceUnavailable, resp.Code)
+	}
+
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, httptest.NewRequest("GET", "/health/live", nil))
+	if resp.Code != http.StatusOK {
+		t.Errorf("Expected status %d for liveness, got %d", http.StatusOK, resp.Code)
 	}
 }
 
@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"computer-management-api/internal/config"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/router"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_APIKeyAuthentication verifies that routes require keys with the right scopes
+// and that changes are attributed to the key in the audit trail
+func TestIntegration_APIKeyAuthentication(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	cfg := &config.Config{Security: suite.Config.Security}
+	cfg.Security.RequireAuth = true
+	authRouter := router.NewRouter(suite.Handlers, suite.APIKeys, nil, nil, cfg)
+
+	admin, err := suite.APIKeys.IssueAPIKey(context.Background(), model.APIKey{Name: "admin", Scopes: []model.Scope{model.ScopeAPIKeysManage}})
+	require.NoError(t, err)
+
+	send := func(req *http.Request, key string) *httptest.ResponseRecorder {
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		resp := httptest.NewRecorder()
+		authRouter.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// Issue a key through the API
+	resp := send(createJSONRequest("POST", "/api/v1/api-keys", map[string]interface{}{
+		"name":   "inventory-sync",
+		"scopes": []string{"computers:read", "computers:write"},
+	}), admin.Key)
+	require.Equal(t, http.StatusCreated, resp.Code)
+	var created struct {
+		Data struct {
+			ID  int64  `json:"id"`
+			Key string `json:"key"`
+		} `json:"data"`
+	}
+	parseJSONResponse(t, resp, &created)
+	key := created.Data.Key
+
+	computer := map[string]interface{}{"mac_address": "00:1B:44:00:40:01", "computer_name": "Auth-PC-1", "ip_address": "10.7.0.1"}
+
+	assert.Equal(t, http.StatusOK, send(httptest.NewRequest("GET", "/api/v1/health", nil), "").Code)
+	assert.Equal(t, http.StatusUnauthorized, send(httptest.NewRequest("GET", "/api/v1/computers", nil), "").Code)
+	assert.Equal(t, http.StatusUnauthorized, send(httptest.NewRequest("GET", "/api/v1/computers", nil), "cma_0000").Code)
+	assert.Equal(t, http.StatusForbidden, send(httptest.NewRequest("GET", "/api/v1/computers", nil), admin.Key).Code)
+	assert.Equal(t, http.StatusForbidden, send(httptest.NewRequest("GET", "/api/v1/employees", nil), key).Code)
+
+	resp = send(createJSONRequest("POST", "/api/v1/computers", computer), key)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var actor string
+	require.NoError(t, suite.DB.QueryRow("SELECT actor FROM computer_events WHERE mac_address = '00:1B:44:00:40:01'").Scan(&actor))
+	assert.Equal(t, "api-key:inventory-sync", actor)
+
+	// API keys are never managed without credentials, even while authentication is not required
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, createJSONRequest("POST", "/api/v1/api-keys", map[string]interface{}{
+		"name": "open", "scopes": []string{"api_keys:manage"},
+	}))
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, httptest.NewRequest("GET", "/api/v1/api-keys", nil))
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	// Revoked keys are rejected
+	revoke := httptest.NewRequest("DELETE", "/api/v1/api-keys/"+strconv.FormatInt(created.Data.ID, 10), nil)
+	require.Equal(t, http.StatusOK, send(revoke, admin.Key).Code)
+	assert.Equal(t, http.StatusUnauthorized, send(httptest.NewRequest("GET", "/api/v1/computers", nil), key).Code)
+}
@@ -0,0 +1,181 @@
+package integration
+
+import (
+	"computer-management-api/internal/model"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_OptimisticConcurrency verifies that a change based on a stale ETag is rejected
+// instead of silently overwriting a concurrent change
+func TestIntegration_OptimisticConcurrency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	computer := model.Computer{
+		MACAddress:   "AA:BB:CC:DD:EE:60",
+		ComputerName: "Test-Computer-ETag",
+		IPAddress:    "192.168.1.60",
+	}
+	req := createJSONRequest("POST", "/api/v1/computers", computer)
+	resp := httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	parseJSONResponse(t, resp, &created)
+	url := "/api/v1/computers/" + created.Data.ID
+
+	req = createJSONRequest("GET", url, nil)
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+	etag := resp.Header().Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	// Polling with the current ETag is answered without a body
+	req = createJSONRequest("GET", url, nil)
+	req.Header.Set("If-None-Match", etag)
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+
+	// The first admin's change succeeds and bumps the version
+	computer.ComputerName = "Test-Computer-ETag-First"
+	req = createJSONRequest("PUT", url, computer)
+	req.Header.Set("If-Match", etag)
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"2"`, resp.Header().Get("ETag"))
+
+	// The second admin still holds the old ETag and must not overwrite it
+	computer.ComputerName = "Test-Computer-ETag-Second"
+	req = createJSONRequest("PUT", url, computer)
+	req.Header.Set("If-Match", etag)
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+
+	req = createJSONRequest("DELETE", url, nil)
+	req.Header.Set("If-Match", etag)
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+
+	var name string
+	require.NoError(t, suite.DB.QueryRow(`SELECT computer_name FROM computers WHERE id = $1`, created.Data.ID).Scan(&name))
+	assert.Equal(t, "Test-Computer-ETag-First", name)
+}
+
+// TestIntegration_ConcurrentIPAddressReservation verifies that computers created at the same
+// time cannot take the same IP address while no subnet is defined to lock
+func TestIntegration_ConcurrentIPAddressReservation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	const attempts = 8
+	codes := make(chan int, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			computer := model.Computer{
+				MACAddress:   fmt.Sprintf("AA:BB:CC:DD:EF:%02X", i),
+				ComputerName: fmt.Sprintf("Test-Computer-IP-%d", i),
+				IPAddress:    "192.168.1.70",
+			}
+			resp := httptest.NewRecorder()
+			suite.Router.ServeHTTP(resp, createJSONRequest("POST", "/api/v1/computers", computer))
+			codes <- resp.Code
+		}(i)
+	}
+	wg.Wait()
+	close(codes)
+
+	created := 0
+	for code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		default:
+			assert.Equal(t, http.StatusConflict, code)
+		}
+	}
+	assert.Equal(t, 1, created, "exactly one computer may take the address")
+
+	var count int
+	require.NoError(t, suite.DB.QueryRow(`SELECT COUNT(*) FROM computers WHERE ip_address = '192.168.1.70'`).Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+// TestIntegration_ConcurrentEnforcedQuota verifies that computers assigned to an employee at the
+// same time cannot exceed an enforced quota
+func TestIntegration_ConcurrentEnforcedQuota(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	policy := model.QuotaPolicy{Scope: model.QuotaPolicyScopeEmployee, Target: "XYZ", MaxComputers: 2, Mode: model.QuotaPolicyModeEnforce}
+	resp := httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, createJSONRequest("POST", "/api/v1/policies", policy))
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	const attempts = 8
+	codes := make(chan int, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			computer := model.Computer{
+				MACAddress:           fmt.Sprintf("AA:BB:CC:DD:F0:%02X", i),
+				ComputerName:         fmt.Sprintf("Test-Computer-Quota-Race-%d", i),
+				IPAddress:            fmt.Sprintf("192.168.2.%d", i+1),
+				EmployeeAbbreviation: "XYZ",
+			}
+			resp := httptest.NewRecorder()
+			suite.Router.ServeHTTP(resp, createJSONRequest("POST", "/api/v1/computers", computer))
+			codes <- resp.Code
+		}(i)
+	}
+	wg.Wait()
+	close(codes)
+
+	created := 0
+	for code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		default:
+			assert.Equal(t, http.StatusConflict, code)
+		}
+	}
+	assert.Equal(t, policy.MaxComputers, created)
+
+	var count int
+	require.NoError(t, suite.DB.QueryRow(`SELECT COUNT(*) FROM computers WHERE employee_abbreviation = 'XYZ'`).Scan(&count))
+	assert.Equal(t, policy.MaxComputers, count)
+}
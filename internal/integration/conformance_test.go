@@ -0,0 +1,31 @@
+package integration
+
+import (
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/repository/repositorytest"
+	"testing"
+)
+
+// TestIntegration_PostgresComputerRepositoryConformance runs the conformance cases the
+// in-memory and SQLite repositories are tested with against PostgreSQL
+func TestIntegration_PostgresComputerRepositoryConformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping database integration test in short mode")
+	}
+
+	cfg := loadTestConfig(t)
+	db := initTestDatabase(t, cfg)
+	defer func() {
+		cleanDatabase(t, db)
+		db.Close()
+	}()
+
+	repositorytest.RunComputerRepositoryTests(t, func(t *testing.T) repositorytest.Backend {
+		cleanDatabase(t, db)
+		return repositorytest.Backend{
+			Computers:  repository.NewComputerRepository(db),
+			Employees:  repository.NewEmployeeRepository(db),
+			Interfaces: repository.NewNetworkInterfaceRepository(db),
+		}
+	})
+}
@@ -30,6 +30,9 @@ func TestIntegration_DatabaseOperations(t *testing.T) {
 		db.Close()
 	}()
 
+	cleanDatabase(t, db)
+	seedEmployees(t, repository.NewEmployeeRepository(db))
+
 	repo := repository.NewComputerRepository(db)
 	ctx := context.Background()
 
@@ -146,7 +149,7 @@ func TestIntegration_DatabaseOperations(t *testing.T) {
 	})
 
 	t.Run("Get All Computers Paginated", func(t *testing.T) {
-		result, err := repo.GetAllComputersPaginated(ctx, repository.PaginationParams{
+		result, err := repo.GetAllComputersPaginated(ctx, repository.ComputerFilter{}, repository.PaginationParams{
 			Offset: 0,
 			Limit:  10,
 		})
@@ -282,7 +285,7 @@ func TestIntegration_DatabaseTransactions(t *testing.T) {
 
 		query := `
 			INSERT INTO computers (id, mac_address, computer_name, ip_address, employee_abbreviation, description)
-			VALUES ($1, $2, $3, $4, $5, $6)`
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)`
 
 		_, err = tx.ExecContext(ctx, query,
 			computer.ID,
@@ -354,7 +357,7 @@ func TestIntegration_DatabasePerformance(t *testing.T) {
 
 		// Test retrieval performance
 		start = time.Now()
-		result, err := repo.GetAllComputersPaginated(ctx, repository.PaginationParams{
+		result, err := repo.GetAllComputersPaginated(ctx, repository.ComputerFilter{}, repository.PaginationParams{
 			Offset: 0,
 			Limit:  100,
 		})
@@ -0,0 +1,109 @@
+package integration
+
+import (
+	"computer-management-api/internal/router"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_ExportComputers verifies that exports honor the list filters and that an
+// exported CSV file can be imported again
+func TestIntegration_ExportComputers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	for _, body := range []map[string]interface{}{
+		{"mac_address": "00:1B:44:00:30:01", "computer_name": "Export-PC-1", "ip_address": "10.5.0.1", "description": "Desk 1, window"},
+		{"mac_address": "00:1B:44:00:30:02", "computer_name": "Export-PC-2", "ip_address": "10.6.0.1", "employee_abbreviation": "ABC"},
+	} {
+		resp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, createJSONRequest("POST", "/api/v1/computers", body))
+		require.Equal(t, http.StatusCreated, resp.Code)
+	}
+
+	export := func(query string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, httptest.NewRequest("GET", "/api/v1/computers/export"+query, nil))
+		return resp
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		resp := export("?subnet=10.5.0.0/16")
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Header().Get("Content-Disposition"), "attachment")
+
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "Export-PC-1", records[1][3])
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		resp := export("?format=ndjson&sort=computer_name")
+		require.Equal(t, http.StatusOK, resp.Code)
+		lines := strings.Split(strings.TrimSpace(resp.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], "Export-PC-1")
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		resp := export("")
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		req := httptest.NewRequest("POST", "/api/v1/computers:import?mode=upsert", strings.NewReader(resp.Body.String()))
+		req.Header.Set("Content-Type", "text/csv")
+		importResp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(importResp, req)
+		require.Equal(t, http.StatusOK, importResp.Code)
+
+		var result struct {
+			Data struct {
+				Unchanged int `json:"unchanged"`
+			} `json:"data"`
+		}
+		parseJSONResponse(t, importResp, &result)
+		assert.Equal(t, 2, result.Data.Unchanged)
+	})
+}
+
+// TestIntegration_ExportOutlivesRequestTimeout verifies that exports are not cut off by the
+// request timeout that applies to every other route
+func TestIntegration_ExportOutlivesRequestTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	resp := httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, createJSONRequest("POST", "/api/v1/computers", map[string]interface{}{
+		"mac_address": "00:1B:44:00:31:01", "computer_name": "Export-PC-3", "ip_address": "10.7.0.1",
+	}))
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	// Every request takes longer than this timeout
+	cfg := *suite.Config
+	cfg.Security.RequestTimeout = time.Nanosecond
+	testRouter := router.NewRouter(suite.Handlers, suite.APIKeys, nil, nil, &cfg)
+
+	resp = httptest.NewRecorder()
+	testRouter.ServeHTTP(resp, httptest.NewRequest("GET", "/api/v1/computers/export", nil))
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "Export-PC-3", records[1][3])
+}
@@ -0,0 +1,146 @@
+package integration
+
+import (
+	"computer-management-api/internal/model"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_ComputerFilters verifies that list filters, search and sorting are applied by the database
+func TestIntegration_ComputerFilters(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	computers := []model.Computer{
+		{MACAddress: "00:1B:44:00:00:01", ComputerName: "Filter-Dell-Laptop", IPAddress: "10.1.0.10", EmployeeAbbreviation: "ABC", Description: "Developer workstation"},
+		{MACAddress: "00:1B:44:00:00:02", ComputerName: "Filter-HP-Desktop", IPAddress: "10.2.0.10", Description: "Reception desk"},
+		{MACAddress: "AA:BB:CC:00:00:03", ComputerName: "Filter-Lenovo-Laptop", IPAddress: "10.1.0.20", EmployeeAbbreviation: "DEF"},
+	}
+	for _, computer := range computers {
+		req := createJSONRequest("POST", "/api/v1/computers", computer)
+		resp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusCreated, resp.Code)
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{"mac prefix", "mac_prefix=00:1b:44", []string{"Filter-Dell-Laptop", "Filter-HP-Desktop"}},
+		{"subnet", "subnet=10.1.0.0/16", []string{"Filter-Dell-Laptop", "Filter-Lenovo-Laptop"}},
+		{"unassigned", "assigned=false", []string{"Filter-HP-Desktop"}},
+		{"employee", "employee=DEF", []string{"Filter-Lenovo-Laptop"}},
+		{"search name", "q=laptop", []string{"Filter-Dell-Laptop", "Filter-Lenovo-Laptop"}},
+		{"search description", "q=reception", []string{"Filter-HP-Desktop"}},
+		{"sort descending", "sort=-computer_name", []string{"Filter-Lenovo-Laptop", "Filter-HP-Desktop", "Filter-Dell-Laptop"}},
+		{"sort by ip", "sort=ip_address,computer_name&subnet=10.0.0.0/8", []string{"Filter-Dell-Laptop", "Filter-Lenovo-Laptop", "Filter-HP-Desktop"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := createJSONRequest("GET", "/api/v1/computers?page_size=100&"+tt.query, nil)
+			resp := httptest.NewRecorder()
+			suite.Router.ServeHTTP(resp, req)
+			require.Equal(t, http.StatusOK, resp.Code)
+
+			var result struct {
+				Computers []model.Computer `json:"computers"`
+			}
+			parseJSONResponse(t, resp, &result)
+
+			names := make([]string, 0, len(result.Computers))
+			for _, computer := range result.Computers {
+				names = append(names, computer.ComputerName)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+// TestIntegration_CursorPagination walks a listing by cursor and verifies every row is returned once
+func TestIntegration_CursorPagination(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	for i := 1; i <= 5; i++ {
+		computer := model.Computer{
+			MACAddress:   fmt.Sprintf("00:1B:44:00:10:%02X", i),
+			ComputerName: fmt.Sprintf("Cursor-PC-%d", i),
+			IPAddress:    fmt.Sprintf("10.3.0.%d", i),
+		}
+		req := createJSONRequest("POST", "/api/v1/computers", computer)
+		resp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusCreated, resp.Code)
+	}
+
+	var names []string
+	path := "/api/v1/computers?page_size=2&sort=-ip_address&subnet=10.3.0.0/24&count=exact"
+	for page := 0; path != ""; page++ {
+		require.Less(t, page, 5, "cursor pagination did not terminate")
+
+		req := createJSONRequest("GET", path, nil)
+		resp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+		var result struct {
+			Computers  []model.Computer `json:"computers"`
+			Pagination struct {
+				TotalItems *int   `json:"total_items"`
+				NextCursor string `json:"next_cursor"`
+			} `json:"pagination"`
+		}
+		parseJSONResponse(t, resp, &result)
+		if page == 0 {
+			require.NotNil(t, result.Pagination.TotalItems)
+			assert.Equal(t, 5, *result.Pagination.TotalItems)
+		} else {
+			assert.Nil(t, result.Pagination.TotalItems)
+		}
+
+		for _, computer := range result.Computers {
+			names = append(names, computer.ComputerName)
+		}
+		path = ""
+		if result.Pagination.NextCursor != "" {
+			path = "/api/v1/computers?page_size=2&sort=-ip_address&subnet=10.3.0.0/24&cursor=" + result.Pagination.NextCursor
+		}
+	}
+
+	assert.Equal(t, []string{"Cursor-PC-5", "Cursor-PC-4", "Cursor-PC-3", "Cursor-PC-2", "Cursor-PC-1"}, names)
+
+	// A cursor only continues the sort order it was issued for
+	req := createJSONRequest("GET", "/api/v1/computers?page_size=2&subnet=10.3.0.0/24", nil)
+	resp := httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var first struct {
+		Pagination struct {
+			NextCursor string `json:"next_cursor"`
+		} `json:"pagination"`
+	}
+	parseJSONResponse(t, resp, &first)
+	require.NotEmpty(t, first.Pagination.NextCursor)
+
+	req = createJSONRequest("GET", "/api/v1/computers?sort=-created_at&cursor="+first.Pagination.NextCursor, nil)
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
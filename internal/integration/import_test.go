@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"computer-management-api/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_ImportComputers verifies that imports are validated, applied atomically and
+// can update existing computers by MAC address
+func TestIntegration_ImportComputers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	importCSV := func(query, body string) (*httptest.ResponseRecorder, service.ImportReport) {
+		req := httptest.NewRequest("POST", "/api/v1/computers:import"+query, strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/csv")
+		resp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, req)
+
+		var result struct {
+			Data   service.ImportReport `json:"data"`
+			Report service.ImportReport `json:"report"`
+		}
+		parseJSONResponse(t, resp, &result)
+		if resp.Code != http.StatusOK {
+			return resp, result.Report
+		}
+		return resp, result.Data
+	}
+	countComputers := func() int {
+		var count int
+		require.NoError(t, suite.DB.QueryRow("SELECT COUNT(*) FROM computers").Scan(&count))
+		return count
+	}
+
+	rows := "mac_address,computer_name,ip_address,description\n" +
+		"00:1B:44:00:20:01,Import-PC-1,10.4.0.1,First\n" +
+		"00:1B:44:00:20:02,Import-PC-2,10.4.0.2,Second\n"
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		resp, report := importCSV("?dry_run=true", rows)
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, 2, report.Created)
+		assert.False(t, report.Applied)
+		assert.Equal(t, 0, countComputers())
+	})
+
+	t.Run("invalid row rejects the whole file", func(t *testing.T) {
+		resp, report := importCSV("", rows+"00:1B:44:00:20:01,Import-PC-3,10.4.0.3,Duplicate\n")
+		require.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, 4, report.Rows[2].Line)
+		assert.Equal(t, 0, countComputers())
+	})
+
+	t.Run("import", func(t *testing.T) {
+		resp, report := importCSV("", rows)
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.True(t, report.Applied)
+		assert.Equal(t, 2, countComputers())
+	})
+
+	t.Run("existing MAC addresses are rejected without upsert", func(t *testing.T) {
+		resp, _ := importCSV("", rows)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	})
+
+	t.Run("upsert updates by MAC address", func(t *testing.T) {
+		resp, report := importCSV("?mode=upsert", "mac_address,computer_name,ip_address,description\n"+
+			"00:1b:44:00:20:01,Import-PC-1,10.4.0.1,First\n"+
+			"00:1B:44:00:20:02,Import-PC-2-Renamed,10.4.0.2,Second\n"+
+			"00:1B:44:00:20:03,Import-PC-3,10.4.0.3,Third\n")
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, 1, report.Created)
+		assert.Equal(t, 1, report.Updated)
+		assert.Equal(t, 1, report.Unchanged)
+
+		var name string
+		require.NoError(t, suite.DB.QueryRow("SELECT computer_name FROM computers WHERE mac_address = '00:1B:44:00:20:02'").Scan(&name))
+		assert.Equal(t, "Import-PC-2-Renamed", name)
+		assert.Equal(t, 3, countComputers())
+	})
+}
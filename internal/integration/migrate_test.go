@@ -0,0 +1,159 @@
+package integration
+
+import (
+	"computer-management-api/internal/database"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+// initSQL is the schema created by the former scripts/database/init.sql, which databases set
+// up before the migrations were introduced still have
+const initSQL = `
+CREATE TABLE IF NOT EXISTS computers (
+    id UUID PRIMARY KEY,
+    mac_address VARCHAR(17) NOT NULL UNIQUE,
+    computer_name VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(15) NOT NULL,
+    employee_abbreviation VARCHAR(3),
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_computers_employee_abbreviation ON computers (employee_abbreviation);
+CREATE INDEX IF NOT EXISTS idx_computers_mac_address ON computers (mac_address);
+
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER update_computers_updated_at BEFORE UPDATE ON computers
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();`
+
+// openMigrationSchema connects to a new, empty schema of the test database, which is dropped
+// when the test ends
+func openMigrationSchema(t *testing.T) *sql.DB {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("Skipping migration integration test in short mode")
+	}
+
+	cfg := loadTestConfig(t)
+	admin, err := sql.Open("postgres", cfg.GetDatabaseDSN())
+	if err != nil {
+		t.Skipf("Failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := admin.PingContext(ctx); err != nil {
+		t.Skipf("Failed to ping test database: %v", err)
+	}
+
+	schema := "migrate_" + uuid.NewString()[:8]
+	if _, err := admin.Exec(fmt.Sprintf(`CREATE SCHEMA %s`, schema)); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(fmt.Sprintf(`DROP SCHEMA %s CASCADE`, schema)); err != nil {
+			t.Logf("Warning: Failed to drop schema %s: %v", schema, err)
+		}
+	})
+
+	db, err := sql.Open("postgres", cfg.GetDatabaseDSN()+" search_path="+schema)
+	if err != nil {
+		t.Fatalf("Failed to open schema %s: %v", schema, err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// TestIntegration_MigrateFromInitSQL upgrades a database created by the former init.sql script
+func TestIntegration_MigrateFromInitSQL(t *testing.T) {
+	db := openMigrationSchema(t)
+	ctx := context.Background()
+
+	if _, err := db.Exec(initSQL); err != nil {
+		t.Fatalf("Failed to create the init.sql schema: %v", err)
+	}
+	id := uuid.New()
+	_, err := db.Exec(`
+		INSERT INTO computers (id, mac_address, computer_name, ip_address, employee_abbreviation)
+		VALUES ($1, 'AA:BB:CC:DD:EE:01', 'LEGACY-001', '192.168.1.10', 'OLD')`, id)
+	if err != nil {
+		t.Fatalf("Failed to insert a computer: %v", err)
+	}
+
+	migrator, err := database.NewMigrator(db, nil)
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Failed to read the migration status: %v", err)
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Failed to apply migrations: %v", err)
+	}
+	if applied != len(statuses) {
+		t.Errorf("Expected %d migrations to be applied, got %d", len(statuses), applied)
+	}
+
+	// The free-form abbreviation became an employee, so the foreign key holds
+	var name string
+	if err := db.QueryRow(`SELECT name FROM employees WHERE abbreviation = 'OLD'`).Scan(&name); err != nil {
+		t.Fatalf("Expected the assigned abbreviation to become an employee: %v", err)
+	}
+
+	// Updates bump the version that was added to the existing computer
+	var version int64
+	var ipAddress string
+	err = db.QueryRow(`
+		UPDATE computers SET description = 'Upgraded' WHERE id = $1
+		RETURNING version, host(ip_address)`, id).Scan(&version, &ipAddress)
+	if err != nil {
+		t.Fatalf("Failed to update the computer: %v", err)
+	}
+	if version != 2 {
+		t.Errorf("Expected version 2 after the first update, got %d", version)
+	}
+	if ipAddress != "192.168.1.10" {
+		t.Errorf("Expected IP address 192.168.1.10, got %s", ipAddress)
+	}
+
+	var interfaces int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM network_interfaces WHERE computer_id = $1 AND is_primary`, id).Scan(&interfaces); err != nil {
+		t.Fatalf("Failed to count network interfaces: %v", err)
+	}
+	if interfaces != 1 {
+		t.Errorf("Expected the computer to get its primary interface, got %d", interfaces)
+	}
+
+	// Every migration can be reverted and applied again
+	if _, err := db.Exec(`DELETE FROM network_interfaces; DELETE FROM computers`); err != nil {
+		t.Fatalf("Failed to delete the computer: %v", err)
+	}
+	reverted, err := migrator.Down(ctx, len(statuses))
+	if err != nil {
+		t.Fatalf("Failed to revert migrations: %v", err)
+	}
+	if reverted != len(statuses) {
+		t.Errorf("Expected %d migrations to be reverted, got %d", len(statuses), reverted)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Failed to apply migrations to an empty database: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package integration
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_NotificationOutbox verifies notifications are stored with the change and delivered by the dispatcher
+func TestIntegration_NotificationOutbox(t *testing.T) {
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	computer := model.Computer{
+		MACAddress:           "AA:BB:CC:DD:EE:44",
+		ComputerName:         "Test-Computer-Outbox",
+		IPAddress:            "192.168.1.44",
+		EmployeeAbbreviation: "ABC",
+	}
+
+	req := createJSONRequest("POST", "/api/v1/computers", computer)
+	resp := httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	// The notification is queued but nothing has been sent yet
+	var pending int
+	require.NoError(t, suite.DB.QueryRow(`SELECT COUNT(*) FROM notification_outbox WHERE status = 'pending'`).Scan(&pending))
+	assert.Equal(t, 1, pending)
+	assert.Empty(t, suite.Notifier.notifications)
+
+	claimed, err := suite.Dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+
+	require.Len(t, suite.Notifier.notifications, 1)
+	assert.Equal(t, "ABC", suite.Notifier.notifications[0].EmployeeAbbreviation)
+
+	var delivered int
+	require.NoError(t, suite.DB.QueryRow(`SELECT COUNT(*) FROM notification_outbox WHERE status = 'delivered' AND attempts = 1`).Scan(&delivered))
+	assert.Equal(t, 1, delivered)
+}
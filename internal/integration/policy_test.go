@@ -0,0 +1,59 @@
+package integration
+
+import (
+	"computer-management-api/internal/model"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration_QuotaPolicy verifies that an enforced employee quota rejects further computers
+func TestIntegration_QuotaPolicy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	suite := setupIntegrationTest(t)
+	defer teardownIntegrationTest(t, suite)
+
+	policy := model.QuotaPolicy{Scope: model.QuotaPolicyScopeEmployee, Target: "DEF", MaxComputers: 1, Mode: model.QuotaPolicyModeEnforce}
+	req := createJSONRequest("POST", "/api/v1/policies", policy)
+	resp := httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	for i, expected := range []int{http.StatusCreated, http.StatusConflict} {
+		computer := model.Computer{
+			MACAddress:           fmt.Sprintf("AA:BB:CC:DD:EE:5%d", i),
+			ComputerName:         fmt.Sprintf("Test-Computer-Quota-%d", i),
+			IPAddress:            fmt.Sprintf("192.168.1.5%d", i),
+			EmployeeAbbreviation: "DEF",
+		}
+
+		req := createJSONRequest("POST", "/api/v1/computers", computer)
+		resp := httptest.NewRecorder()
+		suite.Router.ServeHTTP(resp, req)
+		assert.Equal(t, expected, resp.Code)
+	}
+
+	// The rejected computer was rolled back together with its audit event
+	var computers, events int
+	require.NoError(t, suite.DB.QueryRow(`SELECT COUNT(*) FROM computers WHERE employee_abbreviation = 'DEF'`).Scan(&computers))
+	require.NoError(t, suite.DB.QueryRow(`SELECT COUNT(*) FROM computer_events WHERE new_employee_abbreviation = 'DEF'`).Scan(&events))
+	assert.Equal(t, 1, computers)
+	assert.Equal(t, 1, events)
+
+	req = createJSONRequest("GET", "/api/v1/employees/DEF/policy", nil)
+	resp = httptest.NewRecorder()
+	suite.Router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var effective model.QuotaPolicy
+	parseJSONResponse(t, resp, &effective)
+	assert.Equal(t, model.QuotaPolicyScopeEmployee, effective.Scope)
+	assert.Equal(t, model.QuotaPolicyModeEnforce, effective.Mode)
+}
@@ -0,0 +1,182 @@
+// Package logging creates the application's structured logger and carries the details of an
+// HTTP request in its context, so that every record logged while serving the request names it.
+package logging
+
+import (
+	"computer-management-api/internal/tracing"
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// RequestIDHeader is the header a request ID is accepted from and returned in
+const RequestIDHeader = "X-Request-ID"
+
+// New creates a logger that writes records at or above level to w as JSON lines. Records
+// logged with the context of a request carry its request ID, route, client IP, principal and
+// trace ID.
+func New(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(NewHandler(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})))
+}
+
+// Discard returns a logger that drops every record
+func Discard() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+}
+
+// ParseLevel parses a log level name: debug, info, warn or error
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(name))
+	return level, err
+}
+
+// Request holds the details of an HTTP request that are added to its log records. The
+// middleware fills them in as the request passes through; handlers may run in their own
+// goroutine, hence the lock. All methods are safe to call on a nil Request.
+type Request struct {
+	id string
+
+	mu       sync.Mutex
+	route    string
+	clientIP string
+	actor    string
+	employee string
+}
+
+// NewRequest creates the log details of a request with the given ID
+func NewRequest(id string) *Request {
+	return &Request{id: id}
+}
+
+// ID returns the request ID
+func (r *Request) ID() string {
+	if r == nil {
+		return ""
+	}
+	return r.id
+}
+
+// SetRoute records the route template the request matched
+func (r *Request) SetRoute(route string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.route = route
+	r.mu.Unlock()
+}
+
+// SetClientIP records the address of the client
+func (r *Request) SetClientIP(ip string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.clientIP = ip
+	r.mu.Unlock()
+}
+
+// SetPrincipal records who made the request, and the employee a user with the employee role is
+func (r *Request) SetPrincipal(actor, employee string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.actor = actor
+	r.employee = employee
+	r.mu.Unlock()
+}
+
+// Actor returns who made the request, or "" if it was not authenticated
+func (r *Request) Actor() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.actor
+}
+
+// attrs returns the request details that are known
+func (r *Request) attrs() []slog.Attr {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attrs := []slog.Attr{slog.String("request_id", r.id)}
+	if r.route != "" {
+		attrs = append(attrs, slog.String("route", r.route))
+	}
+	if r.clientIP != "" {
+		attrs = append(attrs, slog.String("client_ip", r.clientIP))
+	}
+	if r.actor != "" {
+		attrs = append(attrs, slog.String("actor", r.actor))
+	}
+	if r.employee != "" {
+		attrs = append(attrs, slog.String("employee", r.employee))
+	}
+	return attrs
+}
+
+type requestKey struct{}
+
+// WithRequest returns a context carrying the log details of a request
+func WithRequest(ctx context.Context, r *Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+// RequestFromContext returns the log details of the request ctx belongs to, or nil
+func RequestFromContext(ctx context.Context) *Request {
+	r, _ := ctx.Value(requestKey{}).(*Request)
+	return r
+}
+
+// RequestID returns the ID of the request ctx belongs to, or ""
+func RequestID(ctx context.Context) string {
+	return RequestFromContext(ctx).ID()
+}
+
+// contextHandler adds the request details and trace ID of a record's context to the record
+type contextHandler struct {
+	slog.Handler
+}
+
+// NewHandler wraps next so that records logged with the context of a request carry its
+// details. Attributes the record already has are not repeated.
+func NewHandler(next slog.Handler) slog.Handler {
+	return &contextHandler{Handler: next}
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	var attrs []slog.Attr
+	if r := RequestFromContext(ctx); r != nil {
+		attrs = r.attrs()
+	}
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		attrs = append(attrs, slog.String("trace_id", traceID))
+	}
+
+	if len(attrs) > 0 {
+		present := make(map[string]bool, record.NumAttrs())
+		record.Attrs(func(a slog.Attr) bool {
+			present[a.Key] = true
+			return true
+		})
+		for _, a := range attrs {
+			if !present[a.Key] {
+				record.AddAttrs(a)
+			}
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
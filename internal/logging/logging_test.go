@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestNew_RequestDetails(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	req := NewRequest("req-1")
+	req.SetRoute("/api/v1/employees/{employee_abbreviation}/computers")
+	req.SetClientIP("203.0.113.7")
+	req.SetPrincipal("user:jdoe", "JDO")
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(WithRequest(context.Background(), req), "test")
+	defer span.End()
+
+	logger.InfoContext(ctx, "Computer assigned", "employee", "ABC")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %s", buf.String())
+	}
+	expected := map[string]interface{}{
+		"msg":        "Computer assigned",
+		"request_id": "req-1",
+		"route":      "/api/v1/employees/{employee_abbreviation}/computers",
+		"client_ip":  "203.0.113.7",
+		"actor":      "user:jdoe",
+		"employee":   "ABC", // The record's own attribute wins
+		"trace_id":   span.SpanContext().TraceID().String(),
+	}
+	for key, value := range expected {
+		if record[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, record[key])
+		}
+	}
+	if bytes.Count(buf.Bytes(), []byte(`"employee"`)) != 1 {
+		t.Errorf("Expected the employee attribute once, got %s", buf.String())
+	}
+}
+
+func TestNew_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelWarn)
+
+	logger.Info("Not logged")
+	logger.Warn("Logged")
+
+	if bytes.Contains(buf.Bytes(), []byte("Not logged")) || !bytes.Contains(buf.Bytes(), []byte("Logged")) {
+		t.Errorf("Expected only records at or above the level, got %s", buf.String())
+	}
+	if bytes.Contains(buf.Bytes(), []byte("request_id")) {
+		t.Errorf("Expected no request details outside a request, got %s", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, expected := range map[string]slog.Level{"debug": slog.LevelDebug, "info": slog.LevelInfo, "warn": slog.LevelWarn, "error": slog.LevelError} {
+		level, err := ParseLevel(name)
+		if err != nil || level != expected {
+			t.Errorf("ParseLevel(%q) = %v, %v; expected %v", name, level, err, expected)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
+
+func TestRequest_Nil(t *testing.T) {
+	var req *Request
+	req.SetRoute("/health")
+	req.SetClientIP("203.0.113.7")
+	req.SetPrincipal("user:jdoe", "")
+
+	if RequestID(context.Background()) != "" || req.Actor() != "" {
+		t.Error("Expected no request details outside a request")
+	}
+}
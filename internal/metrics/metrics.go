@@ -0,0 +1,186 @@
+// Package metrics exposes the application's Prometheus metrics. A nil *Metrics is valid and
+// records nothing, so components can be instrumented unconditionally.
+package metrics
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the names of the application's own metrics
+const Namespace = "computer_management"
+
+// inventoryTimeout limits how long a scrape waits for the inventory counts
+const inventoryTimeout = 5 * time.Second
+
+// Metrics holds the application's collectors in a dedicated registry
+type Metrics struct {
+	registry *prometheus.Registry
+	logger   *slog.Logger
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	notifications       *prometheus.CounterVec
+	notificationRetries prometheus.Counter
+	rateLimitRejections prometheus.Counter
+}
+
+// New creates the metrics and registers them together with the Go runtime and process collectors
+func New(logger *slog.Logger) *Metrics {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		logger:   logger,
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		notifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "notifications_total",
+			Help:      "Notifications sent to the notification service by result.",
+		}, []string{"result"}),
+		notificationRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "notification_retries_total",
+			Help:      "Notification send attempts that were retries of a failed attempt.",
+		}),
+		rateLimitRejections: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "rate_limit_rejections_total",
+			Help:      "Requests rejected by the per-client rate limit.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.notifications,
+		m.notificationRetries,
+		m.rateLimitRejections,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{ErrorLog: slog.NewLogLogger(m.logger.Handler(), slog.LevelError)})
+}
+
+// ObserveRequest records a served HTTP request
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// NotificationSent records a notification delivered to the notification service
+func (m *Metrics) NotificationSent() {
+	if m == nil {
+		return
+	}
+	m.notifications.WithLabelValues("success").Inc()
+}
+
+// NotificationFailed records a notification that could not be delivered
+func (m *Metrics) NotificationFailed() {
+	if m == nil {
+		return
+	}
+	m.notifications.WithLabelValues("failure").Inc()
+}
+
+// NotificationRetried records a retry of a failed notification send attempt
+func (m *Metrics) NotificationRetried() {
+	if m == nil {
+		return
+	}
+	m.notificationRetries.Inc()
+}
+
+// RateLimited records a request rejected by the rate limit
+func (m *Metrics) RateLimited() {
+	if m == nil {
+		return
+	}
+	m.rateLimitRejections.Inc()
+}
+
+// RegisterDB exports the connection pool statistics of db
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// InventorySource counts the computer inventory
+type InventorySource interface {
+	GetInventoryStats(ctx context.Context) (*model.InventoryStats, error)
+}
+
+// RegisterInventory exports inventory gauges, which are counted by source on every scrape
+func (m *Metrics) RegisterInventory(source InventorySource) error {
+	return m.registry.Register(&inventoryCollector{source: source, logger: m.logger})
+}
+
+// inventoryCollector collects the inventory gauges on demand
+type inventoryCollector struct {
+	source InventorySource
+	logger *slog.Logger
+}
+
+var (
+	inventoryComputersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "inventory", "computers"),
+		"Registered computers.", nil, nil)
+	inventoryAssignedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "inventory", "assigned_computers"),
+		"Computers assigned to an employee.", nil, nil)
+	inventoryOverQuotaDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, "inventory", "employees_over_quota"),
+		"Employees holding more computers than their quota policy allows.", nil, nil)
+)
+
+func (c *inventoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- inventoryComputersDesc
+	ch <- inventoryAssignedDesc
+	ch <- inventoryOverQuotaDesc
+}
+
+// Collect counts the inventory. The gauges are left out of the scrape when counting fails.
+func (c *inventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), inventoryTimeout)
+	defer cancel()
+
+	stats, err := c.source.GetInventoryStats(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "Failed to collect inventory metrics", "error", err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(inventoryComputersDesc, prometheus.GaugeValue, float64(stats.Computers))
+	ch <- prometheus.MustNewConstMetric(inventoryAssignedDesc, prometheus.GaugeValue, float64(stats.AssignedComputers))
+	ch <- prometheus.MustNewConstMetric(inventoryOverQuotaDesc, prometheus.GaugeValue, float64(stats.EmployeesOverQuota))
+}
@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubInventory returns fixed inventory counts
+type stubInventory struct {
+	stats *model.InventoryStats
+	err   error
+}
+
+func (s stubInventory) GetInventoryStats(ctx context.Context) (*model.InventoryStats, error) {
+	return s.stats, s.err
+}
+
+// scrape returns the exposition of a handler's /metrics endpoint
+func scrape(t *testing.T, handler http.Handler) string {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	body, _ := io.ReadAll(rr.Body)
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	m := New(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	m.ObserveRequest("GET", "/api/v1/computers/{id}", http.StatusOK, 20*time.Millisecond)
+	m.ObserveRequest("GET", "/api/v1/computers/{id}", http.StatusNotFound, time.Millisecond)
+	m.NotificationSent()
+	m.NotificationFailed()
+	m.NotificationRetried()
+	m.RateLimited()
+	if err := m.RegisterInventory(stubInventory{stats: &model.InventoryStats{Computers: 12, AssignedComputers: 7, EmployeesOverQuota: 1}}); err != nil {
+		t.Fatalf("Failed to register inventory metrics: %v", err)
+	}
+
+	body := scrape(t, m.Handler())
+	for _, expected := range []string{
+		`computer_management_http_requests_total{method="GET",route="/api/v1/computers/{id}",status="200"} 1`,
+		`computer_management_http_requests_total{method="GET",route="/api/v1/computers/{id}",status="404"} 1`,
+		`computer_management_http_request_duration_seconds_count{method="GET",route="/api/v1/computers/{id}"} 2`,
+		`computer_management_notifications_total{result="success"} 1`,
+		`computer_management_notifications_total{result="failure"} 1`,
+		`computer_management_notification_retries_total 1`,
+		`computer_management_rate_limit_rejections_total 1`,
+		`computer_management_inventory_computers 12`,
+		`computer_management_inventory_assigned_computers 7`,
+		`computer_management_inventory_employees_over_quota 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected %s in the metrics", expected)
+		}
+	}
+}
+
+func TestMetrics_InventoryFailure(t *testing.T) {
+	var logs bytes.Buffer
+	m := New(slog.New(slog.NewTextHandler(&logs, nil)))
+	if err := m.RegisterInventory(stubInventory{err: errors.New("connection refused")}); err != nil {
+		t.Fatalf("Failed to register inventory metrics: %v", err)
+	}
+
+	if body := scrape(t, m.Handler()); strings.Contains(body, "inventory_computers") {
+		t.Error("Expected the inventory gauges to be left out")
+	}
+	if !strings.Contains(logs.String(), "connection refused") {
+		t.Errorf("Expected the failure to be logged, got %s", logs.String())
+	}
+}
+
+func TestMetrics_Nil(t *testing.T) {
+	var m *Metrics
+	m.ObserveRequest("GET", "/health", http.StatusOK, time.Millisecond)
+	m.NotificationSent()
+	m.NotificationFailed()
+	m.NotificationRetried()
+	m.RateLimited()
+}
+
+func TestNewHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		metrics         *Metrics
+		profiling       bool
+		metricsStatus   int
+		profilingStatus int
+	}{
+		{"metrics only", New(nil), false, http.StatusOK, http.StatusNotFound},
+		{"profiling only", nil, true, http.StatusNotFound, http.StatusOK},
+		{"both", New(nil), true, http.StatusOK, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(tt.metrics, tt.profiling)
+			for path, expected := range map[string]int{"/metrics": tt.metricsStatus, "/debug/pprof/": tt.profilingStatus} {
+				rr := httptest.NewRecorder()
+				handler.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+				if rr.Code != expected {
+					t.Errorf("%s: expected status code %d, got %d", path, expected, rr.Code)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/pprof"
+)
+
+// NewHandler returns the handler of the internal metrics listener. It serves /metrics when m is
+// not nil and the pprof endpoints under /debug/pprof/ when profiling is enabled. The listener
+// must not be reachable from outside, as neither is authenticated.
+func NewHandler(m *Metrics, profiling bool) http.Handler {
+	mux := http.NewServeMux()
+
+	if m != nil {
+		mux.Handle("/metrics", m.Handler())
+	}
+
+	if profiling {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	return mux
+}
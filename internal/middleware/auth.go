@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"computer-management-api/internal/logging"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/service"
+	"computer-management-api/internal/tracing"
+	apperrors "computer-management-api/pkg/errors"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// APIKeyAuthenticator verifies the API key presented with a request
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, secret string) (*model.APIKey, error)
+}
+
+// TokenVerifier verifies a bearer token issued by the identity provider
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (*model.Principal, error)
+}
+
+// AuthMiddleware authenticates requests with API keys or identity provider tokens and checks
+// what routes require
+type AuthMiddleware struct {
+	apiKeys  APIKeyAuthenticator
+	tokens   TokenVerifier
+	required bool
+	logger   *slog.Logger
+}
+
+// NewAuthMiddleware creates a new authentication middleware. Either authenticator may be nil
+// to disable that kind of credentials. When required is false, requests without credentials
+// are allowed everywhere; credentials that are sent are still verified.
+func NewAuthMiddleware(apiKeys APIKeyAuthenticator, tokens TokenVerifier, required bool, logger *slog.Logger) *AuthMiddleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &AuthMiddleware{
+		apiKeys:  apiKeys,
+		tokens:   tokens,
+		required: required,
+		logger:   logger,
+	}
+}
+
+// Authenticate verifies the credentials of a request and stores the principal in the request
+// context. API keys are sent in the X-API-Key header or as bearer tokens; other bearer tokens
+// are verified with the identity provider's keys. Requests without credentials are passed on
+// unchanged so that routes decide whether they need them.
+func (am *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, err := am.authenticate(r)
+		if err != nil {
+			am.logger.WarnContext(r.Context(), "SECURITY: Authentication failed", "error", err)
+			am.writeError(w, r, err)
+			return
+		}
+		if principal == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		logging.RequestFromContext(r.Context()).SetPrincipal(principal.Actor, principal.EmployeeAbbreviation)
+		next.ServeHTTP(w, r.WithContext(service.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// authenticate returns the principal of the request's credentials, or nil without credentials
+func (am *AuthMiddleware) authenticate(r *http.Request) (*model.Principal, error) {
+	secret, bearer := credentialsFromRequest(r)
+	if secret == "" {
+		return nil, nil
+	}
+
+	if bearer && am.tokens != nil && !strings.HasPrefix(secret, service.APIKeyPrefix) {
+		return am.tokens.VerifyToken(r.Context(), secret)
+	}
+
+	if am.apiKeys == nil {
+		return nil, apperrors.UnauthorizedError("API keys are not supported")
+	}
+	key, err := am.apiKeys.Authenticate(r.Context(), secret)
+	if err != nil {
+		return nil, err
+	}
+	return key.Principal(), nil
+}
+
+// RequireScope returns a wrapper that only lets requests through whose principal was granted
+// scope. Without credentials the request is rejected with 401, with credentials lacking the
+// scope with 403. Nothing is checked when authentication is not required and none were sent.
+func (am *AuthMiddleware) RequireScope(scope model.Scope) func(http.HandlerFunc) http.Handler {
+	return am.require(scope, "", am.required)
+}
+
+// RequireScopeOrSelf is like RequireScope, but also lets users with the employee role through
+// when the route variable employeeVar names their own employee abbreviation.
+func (am *AuthMiddleware) RequireScopeOrSelf(scope model.Scope, employeeVar string) func(http.HandlerFunc) http.Handler {
+	return am.require(scope, employeeVar, am.required)
+}
+
+// RequireCredentials is like RequireScope, but rejects requests without credentials even when
+// authentication is not required. It guards the routes that grant access, which must never be
+// open.
+func (am *AuthMiddleware) RequireCredentials(scope model.Scope) func(http.HandlerFunc) http.Handler {
+	return am.require(scope, "", true)
+}
+
+func (am *AuthMiddleware) require(scope model.Scope, employeeVar string, required bool) func(http.HandlerFunc) http.Handler {
+	return func(next http.HandlerFunc) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := service.PrincipalFromContext(r.Context())
+			if principal == nil {
+				if required {
+					am.writeError(w, r, apperrors.UnauthorizedError("Authentication required"))
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if principal.HasScope(scope) || (employeeVar != "" && isSelf(principal, mux.Vars(r)[employeeVar])) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			am.logger.WarnContext(r.Context(), "SECURITY: Missing scope", "scope", scope, "method", r.Method, "path", r.URL.Path)
+			am.writeError(w, r, apperrors.ForbiddenError("Missing the "+string(scope)+" scope").
+				WithDetail("required_scope", string(scope)))
+		})
+	}
+}
+
+// isSelf reports whether an employee user is the employee with the given abbreviation
+func isSelf(principal *model.Principal, abbreviation string) bool {
+	return principal.HasRole(model.RoleEmployee) &&
+		principal.EmployeeAbbreviation != "" &&
+		strings.EqualFold(principal.EmployeeAbbreviation, abbreviation)
+}
+
+// writeError sends an application error as a JSON response. Unauthorized responses carry a
+// WWW-Authenticate challenge and server errors never expose their cause.
+func (am *AuthMiddleware) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok || appErr.GetHTTPStatus() >= http.StatusInternalServerError {
+		appErr = apperrors.InternalError("Failed to authenticate request", err)
+	}
+	appErr = appErr.WithRequestID(logging.RequestID(r.Context())).WithTraceID(tracing.TraceID(r.Context()))
+
+	status := appErr.GetHTTPStatus()
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(appErr.ToJSON()); err != nil {
+		am.logger.ErrorContext(r.Context(), "Failed to write authentication error", "error", err)
+	}
+}
+
+// credentialsFromRequest returns the API key sent in the X-API-Key header or the bearer token
+// of the Authorization header, and whether it was a bearer token
+func credentialsFromRequest(r *http.Request) (string, bool) {
+	if key := strings.TrimSpace(r.Header.Get("X-API-Key")); key != "" {
+		return key, false
+	}
+
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if found && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token), true
+	}
+	return "", false
+}
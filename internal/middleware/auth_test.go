@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"bytes"
+	"computer-management-api/internal/logging"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/service"
+	apperrors "computer-management-api/pkg/errors"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// stubAuthenticator accepts a fixed set of API keys
+type stubAuthenticator map[string]*model.APIKey
+
+func (s stubAuthenticator) Authenticate(ctx context.Context, secret string) (*model.APIKey, error) {
+	if secret == "broken" {
+		return nil, errors.New("connection refused")
+	}
+	if key, ok := s[secret]; ok {
+		return key, nil
+	}
+	return nil, apperrors.UnauthorizedError("Invalid API key")
+}
+
+// stubVerifier accepts a fixed set of identity provider tokens
+type stubVerifier map[string]*model.Principal
+
+func (s stubVerifier) VerifyToken(ctx context.Context, token string) (*model.Principal, error) {
+	if principal, ok := s[token]; ok {
+		return principal, nil
+	}
+	return nil, apperrors.UnauthorizedError("Invalid token: invalid signature")
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	authenticator := stubAuthenticator{
+		"cma_reader": {Name: "reader", Scopes: []model.Scope{model.ScopeComputersRead}},
+	}
+
+	tests := []struct {
+		name           string
+		required       bool
+		header         string
+		value          string
+		expectedStatus int
+		expectedActor  string
+	}{
+		{"no key, not required", false, "", "", http.StatusOK, service.AnonymousActor},
+		{"no key, required", true, "", "", http.StatusUnauthorized, ""},
+		{"API key header", true, "X-API-Key", "cma_reader", http.StatusOK, "api-key:reader"},
+		{"bearer token", true, "Authorization", "Bearer cma_reader", http.StatusOK, "api-key:reader"},
+		{"invalid key, not required", false, "X-API-Key", "cma_unknown", http.StatusUnauthorized, ""},
+		{"unknown key, required", true, "X-API-Key", "cma_writer", http.StatusUnauthorized, ""},
+		{"authenticator failure", true, "X-API-Key", "broken", http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			am := NewAuthMiddleware(authenticator, nil, tt.required, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+			var actor string
+			handler := am.Authenticate(am.RequireScope(model.ScopeComputersRead)(func(w http.ResponseWriter, r *http.Request) {
+				actor = service.ActorFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", "/api/v1/computers", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if actor != tt.expectedActor {
+				t.Errorf("Expected actor %q, got %q", tt.expectedActor, actor)
+			}
+			if rr.Code == http.StatusUnauthorized && rr.Header().Get("WWW-Authenticate") == "" {
+				t.Error("Expected a WWW-Authenticate challenge")
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_Forbidden(t *testing.T) {
+	authenticator := stubAuthenticator{
+		"cma_reader": {Name: "reader", Scopes: []model.Scope{model.ScopeComputersRead}},
+	}
+	am := NewAuthMiddleware(authenticator, nil, false, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+	handler := am.Authenticate(am.RequireScope(model.ScopeComputersWrite)(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected the handler not to be called")
+	}))
+
+	req := httptest.NewRequest("DELETE", "/api/v1/computers/1", nil)
+	req.Header.Set("X-API-Key", "cma_reader")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	var response struct {
+		Code    string            `json:"code"`
+		Details map[string]string `json:"details"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if rr.Code != http.StatusForbidden || response.Code != string(apperrors.ErrorCodeForbidden) {
+		t.Errorf("Expected 403 FORBIDDEN, got %d %s", rr.Code, response.Code)
+	}
+	if response.Details["required_scope"] != string(model.ScopeComputersWrite) {
+		t.Errorf("Expected the required scope in the details, got %v", response.Details)
+	}
+}
+
+func TestAuthMiddleware_RequireCredentials(t *testing.T) {
+	authenticator := stubAuthenticator{
+		"cma_admin":  {Name: "admin", Scopes: []model.Scope{model.ScopeAPIKeysManage}},
+		"cma_reader": {Name: "reader", Scopes: []model.Scope{model.ScopeComputersRead}},
+	}
+
+	tests := []struct {
+		name           string
+		key            string
+		expectedStatus int
+	}{
+		{"no key", "", http.StatusUnauthorized},
+		{"key without the scope", "cma_reader", http.StatusForbidden},
+		{"key with the scope", "cma_admin", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Authentication is not required, but API keys are never managed without one
+			am := NewAuthMiddleware(authenticator, nil, false, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+			handler := am.Authenticate(am.RequireCredentials(model.ScopeAPIKeysManage)(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest("GET", "/api/v1/api-keys", nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_Tokens(t *testing.T) {
+	verifier := stubVerifier{
+		"viewer-token": {Actor: "user:viewer", Roles: []model.Role{model.RoleViewer}, Scopes: model.RoleScopes[model.RoleViewer]},
+		"jdoe-token":   {Actor: "user:jdoe", Roles: []model.Role{model.RoleEmployee}, EmployeeAbbreviation: "JDO"},
+		"orphan-token": {Actor: "user:orphan", Roles: []model.Role{model.RoleEmployee}},
+	}
+	am := NewAuthMiddleware(stubAuthenticator{}, verifier, true, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+	router := mux.NewRouter()
+	router.Use(am.Authenticate)
+	router.Handle("/employees/{employee_abbreviation}/computers",
+		am.RequireScopeOrSelf(model.ScopeComputersRead, "employee_abbreviation")(func(w http.ResponseWriter, r *http.Request) {}))
+	router.Handle("/computers", am.RequireScope(model.ScopeComputersRead)(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		name           string
+		token          string
+		path           string
+		expectedStatus int
+	}{
+		{"viewer reads any employee", "viewer-token", "/employees/ABC/computers", http.StatusOK},
+		{"employee reads own computers", "jdoe-token", "/employees/jdo/computers", http.StatusOK},
+		{"employee reads other computers", "jdoe-token", "/employees/ABC/computers", http.StatusForbidden},
+		{"employee lists all computers", "jdoe-token", "/computers", http.StatusForbidden},
+		{"employee without abbreviation", "orphan-token", "/employees/ABC/computers", http.StatusForbidden},
+		{"invalid token", "forged-token", "/computers", http.StatusUnauthorized},
+		{"API key as bearer token", "cma_unknown", "/computers", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestLogRequests_Identity(t *testing.T) {
+	var logs bytes.Buffer
+	am := NewAuthMiddleware(stubAuthenticator{"cma_reader": {Name: "reader"}}, nil, false, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	handler := RequestID(NewLoggingMiddleware(logging.New(&logs, slog.LevelInfo)).LogRequests(am.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))))
+
+	req := httptest.NewRequest("GET", "/api/v1/computers", nil)
+	req.Header.Set("X-API-Key", "cma_reader")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !bytes.Contains(logs.Bytes(), []byte(`"actor":"api-key:reader"`)) {
+		t.Errorf("Expected the key in the request log, got %s", logs.String())
+	}
+}
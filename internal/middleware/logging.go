@@ -1,58 +1,61 @@
 package middleware
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 )
 
 // LoggingMiddleware provides request logging with security context
 type LoggingMiddleware struct {
-	logger *log.Logger
+	logger *slog.Logger
 }
 
 // NewLoggingMiddleware creates a new logging middleware
-func NewLoggingMiddleware(logger *log.Logger) *LoggingMiddleware {
+func NewLoggingMiddleware(logger *slog.Logger) *LoggingMiddleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
 	return &LoggingMiddleware{
 		logger: logger,
 	}
 }
 
-// LogRequests logs incoming requests with security information
+// LogRequests logs every request once it is done, at warning level for client errors and
+// error level for server errors. The record carries the request details collected by the
+// other middleware, so RequestID must wrap it.
 func (lm *LoggingMiddleware) LogRequests(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
-		// Get client IP from context (set by TrustedProxy middleware)
-		clientIP, _ := r.Context().Value("client_ip").(string)
-		if clientIP == "" {
-			clientIP = r.RemoteAddr
-		}
-
 		// Create a response writer wrapper to capture status code
 		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
 		// Process request
 		next.ServeHTTP(wrapped, r)
 
-		// Log request details
-		duration := time.Since(start)
-		lm.logger.Printf("[%s] %s %s %d %v - IP: %s, User-Agent: %s",
-			r.Method,
-			r.RequestURI,
-			r.Proto,
-			wrapped.statusCode,
-			duration,
-			clientIP,
-			r.UserAgent(),
+		level := slog.LevelInfo
+		switch {
+		case wrapped.statusCode >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case wrapped.statusCode >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		lm.logger.LogAttrs(r.Context(), level, "Request completed",
+			slog.String("method", r.Method),
+			slog.String("uri", r.RequestURI),
+			slog.String("proto", r.Proto),
+			slog.Int("status", wrapped.statusCode),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("user_agent", r.UserAgent()),
 		)
 
 		// Log security events
 		if wrapped.statusCode == http.StatusTooManyRequests {
-			lm.logger.Printf("SECURITY: Rate limit exceeded for IP: %s", clientIP)
+			lm.logger.WarnContext(r.Context(), "SECURITY: Rate limit exceeded")
 		}
 		if wrapped.statusCode == http.StatusRequestTimeout {
-			lm.logger.Printf("SECURITY: Request timeout for IP: %s", clientIP)
+			lm.logger.WarnContext(r.Context(), "SECURITY: Request timeout")
 		}
 	})
 }
@@ -67,3 +70,9 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap exposes the underlying writer to http.ResponseController, so streaming handlers can
+// flush and extend their write deadline
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"computer-management-api/internal/metrics"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// MetricsMiddleware records request counts and latencies by route template
+type MetricsMiddleware struct {
+	metrics *metrics.Metrics
+}
+
+// NewMetricsMiddleware creates a new metrics middleware; nil metrics record nothing
+func NewMetricsMiddleware(m *metrics.Metrics) *MetricsMiddleware {
+	return &MetricsMiddleware{
+		metrics: m,
+	}
+}
+
+// Instrument records the request under its route template, such as /api/v1/computers/{id}, so
+// that IDs in paths do not create a time series each. It must be used on the router, where the
+// matched route is known.
+func (mm *MetricsMiddleware) Instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		next.ServeHTTP(wrapped, r)
+
+		route := "unmatched"
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+		mm.metrics.ObserveRequest(r.Method, route, wrapped.statusCode, time.Since(start))
+	})
+}
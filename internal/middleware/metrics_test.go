@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"computer-management-api/internal/metrics"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	m := metrics.New(nil)
+	router := mux.NewRouter()
+	router.Use(NewMetricsMiddleware(m).Instrument)
+	router.HandleFunc("/computers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["id"] == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	for _, path := range []string{"/computers/1", "/computers/2", "/computers/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rr.Body)
+	for _, expected := range []string{
+		`http_requests_total{method="GET",route="/computers/{id}",status="200"} 2`,
+		`http_requests_total{method="GET",route="/computers/{id}",status="404"} 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("Expected %s in the metrics, got %s", expected, body)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"computer-management-api/internal/logging"
+	"net"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// maxRequestIDLength limits the length of a request ID accepted from a client
+const maxRequestIDLength = 128
+
+// RequestID accepts the request ID of an incoming X-Request-ID header, or generates one, and
+// returns it in the response's X-Request-ID header. The ID is stored in the request context
+// with the other details that log records of the request carry. It must wrap the request log.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(logging.RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(logging.RequestIDHeader, id)
+
+		req := logging.NewRequest(id)
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			req.SetClientIP(host)
+		} else {
+			req.SetClientIP(r.RemoteAddr)
+		}
+
+		next.ServeHTTP(w, r.WithContext(logging.WithRequest(r.Context(), req)))
+	})
+}
+
+// validRequestID reports whether a client's request ID can be logged and echoed as is: short,
+// and made of printable ASCII characters only
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// LogRoute records the matched route template, such as /api/v1/computers/{id}, in the log
+// details of the request. It must be used on the router, where the route is known.
+func LogRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				logging.RequestFromContext(r.Context()).SetRoute(template)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"bytes"
+	"computer-management-api/internal/logging"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"generated", "", false},
+		{"accepted", "client-req-42", true},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), false},
+		{"control characters", "id\nforged log line", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = logging.RequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", "/api/v1/computers", nil)
+			if tt.incoming != "" {
+				req.Header.Set(logging.RequestIDHeader, tt.incoming)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			id := rr.Header().Get(logging.RequestIDHeader)
+			if id == "" || id != seen {
+				t.Fatalf("Expected the request ID %q in the response, got %q", seen, id)
+			}
+			if (id == tt.incoming) != tt.keep {
+				t.Errorf("Incoming ID %q: got %q", tt.incoming, id)
+			}
+		})
+	}
+}
+
+func TestRequestID_LogsAndErrors(t *testing.T) {
+	var logs bytes.Buffer
+	logger := logging.New(&logs, slog.LevelInfo)
+	am := NewAuthMiddleware(stubAuthenticator{}, nil, true, logger)
+
+	router := mux.NewRouter()
+	router.Use(LogRoute)
+	router.Handle("/computers/{id}", am.RequireScope("computers:read")(func(w http.ResponseWriter, r *http.Request) {}))
+	handler := RequestID(NewLoggingMiddleware(logger).LogRequests(router))
+
+	req := httptest.NewRequest("GET", "/computers/1", nil)
+	req.Header.Set(logging.RequestIDHeader, "req-7")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if body["request_id"] != "req-7" {
+		t.Errorf("Expected the request ID in the error response, got %v", body)
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(logs.Bytes(), &record); err != nil {
+		t.Fatalf("Expected one JSON request log record, got %s", logs.String())
+	}
+	for key, value := range map[string]interface{}{"request_id": "req-7", "route": "/computers/{id}", "client_ip": "192.0.2.1", "level": "WARN", "status": float64(401)} {
+		if record[key] != value {
+			t.Errorf("Expected %s=%v in the request log, got %v", key, value, record[key])
+		}
+	}
+}
@@ -2,11 +2,16 @@ package middleware
 
 import (
 	"computer-management-api/internal/config"
+	"computer-management-api/internal/logging"
+	"computer-management-api/internal/metrics"
 	"context"
 	"net/http"
 	"strings"
 	"sync"
 
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/time/rate"
 )
 
@@ -16,17 +21,31 @@ type SecurityMiddleware struct {
 	rateLimiter *rate.Limiter
 	mu          sync.Mutex
 	clients     map[string]*rate.Limiter
+	metrics     *metrics.Metrics
+	// untimed are the routes RequestTimeout leaves alone
+	untimed map[*mux.Route]bool
 }
 
-// NewSecurityMiddleware creates a new security middleware with the given config
-func NewSecurityMiddleware(cfg *config.SecurityConfig) *SecurityMiddleware {
+// NewSecurityMiddleware creates a new security middleware with the given config. Rate limit
+// rejections are counted in m, which may be nil.
+func NewSecurityMiddleware(cfg *config.SecurityConfig, m *metrics.Metrics) *SecurityMiddleware {
 	return &SecurityMiddleware{
 		config:      cfg,
 		rateLimiter: rate.NewLimiter(rate.Limit(cfg.RateLimitRPS), cfg.RateLimitBurst),
 		clients:     make(map[string]*rate.Limiter),
+		metrics:     m,
+		untimed:     make(map[*mux.Route]bool),
 	}
 }
 
+// WithoutRequestTimeout exempts a route from RequestTimeout and returns it. It is meant for
+// responses streamed over longer than the request timeout, whose handlers bound their own run
+// time. Routes must be exempted before the router serves requests.
+func (sm *SecurityMiddleware) WithoutRequestTimeout(route *mux.Route) *mux.Route {
+	sm.untimed[route] = true
+	return route
+}
+
 // RateLimit applies rate limiting per client IP
 func (sm *SecurityMiddleware) RateLimit(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -41,6 +60,7 @@ func (sm *SecurityMiddleware) RateLimit(next http.Handler) http.Handler {
 		sm.mu.Unlock()
 
 		if !limiter.Allow() {
+			sm.metrics.RateLimited()
 			http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
 			return
 		}
@@ -64,8 +84,9 @@ func (sm *SecurityMiddleware) CORS(next http.Handler) http.Handler {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 		}
 
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, X-Request-ID, X-API-Key, If-Match, If-None-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, X-Request-ID")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 		w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
 
@@ -79,9 +100,15 @@ func (sm *SecurityMiddleware) CORS(next http.Handler) http.Handler {
 	})
 }
 
-// RequestTimeout applies timeout to requests
+// RequestTimeout applies timeout to requests, except those of the routes exempted with
+// WithoutRequestTimeout. It must be used on the router, where the route is known.
 func (sm *SecurityMiddleware) RequestTimeout(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil && sm.untimed[route] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		ctx, cancel := context.WithTimeout(r.Context(), sm.config.RequestTimeout)
 		defer cancel()
 
@@ -102,6 +129,9 @@ func (sm *SecurityMiddleware) RequestTimeout(next http.Handler) http.Handler {
 		case <-ctx.Done():
 			// Request timed out
 			if ctx.Err() == context.DeadlineExceeded {
+				span := trace.SpanFromContext(ctx)
+				span.AddEvent("request timeout")
+				span.SetStatus(codes.Error, "request timeout")
 				http.Error(w, "Request timeout", http.StatusRequestTimeout)
 			}
 			return
@@ -112,10 +142,8 @@ func (sm *SecurityMiddleware) RequestTimeout(next http.Handler) http.Handler {
 // TrustedProxy handles trusted proxy headers for real IP detection
 func (sm *SecurityMiddleware) TrustedProxy(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Set real IP in context for logging and rate limiting
-		realIP := sm.getClientIP(r)
-		ctx := context.WithValue(r.Context(), "client_ip", realIP)
-		r = r.WithContext(ctx)
+		// Record the real IP for the request's log records
+		logging.RequestFromContext(r.Context()).SetClientIP(sm.getClientIP(r))
 
 		next.ServeHTTP(w, r)
 	})
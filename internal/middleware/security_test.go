@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"computer-management-api/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func TestRequestTimeout_ExemptRoute(t *testing.T) {
+	sm := NewSecurityMiddleware(&config.SecurityConfig{RateLimitRPS: 100, RateLimitBurst: 100, RequestTimeout: 20 * time.Millisecond}, nil)
+	router := mux.NewRouter()
+	router.Use(sm.RequestTimeout)
+
+	router.HandleFunc("/computers", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	sm.WithoutRequestTimeout(router.HandleFunc("/computers/export", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		for i := 0; i < 3; i++ {
+			time.Sleep(20 * time.Millisecond)
+			if r.Context().Err() != nil {
+				return
+			}
+			w.Write([]byte("row\n"))
+		}
+	}))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/computers", nil))
+	if rr.Code != http.StatusRequestTimeout {
+		t.Errorf("Expected status code %d, got %d", http.StatusRequestTimeout, rr.Code)
+	}
+
+	// The exempted route outlives the request timeout and completes its response
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/computers/export", nil))
+	if rr.Code != http.StatusOK || rr.Body.String() != "row\nrow\nrow\n" {
+		t.Errorf("Expected the complete response, got %d: %q", rr.Code, rr.Body.String())
+	}
+}
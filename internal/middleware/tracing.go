@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"computer-management-api/internal/tracing"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans started by the HTTP middleware
+const tracerName = "computer-management-api/internal/middleware"
+
+// TracingMiddleware starts a server span for every request
+type TracingMiddleware struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracingMiddleware creates a new tracing middleware. The global tracer provider and
+// propagator are used when provider or propagator is nil.
+func NewTracingMiddleware(provider trace.TracerProvider, propagator propagation.TextMapPropagator) *TracingMiddleware {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+	return &TracingMiddleware{
+		tracer:     provider.Tracer(tracerName),
+		propagator: propagator,
+	}
+}
+
+// Trace continues the trace of an incoming traceparent header, or starts a new one, and
+// returns the trace ID in the X-Trace-ID response header. It must wrap the whole router so
+// that the span covers every middleware, including the request log; TraceRoute names the
+// span once the route is known.
+func (tm *TracingMiddleware) Trace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := tm.propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tm.tracer.Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+				semconv.ClientAddress(r.RemoteAddr),
+			),
+		)
+		defer span.End()
+
+		if traceID := tracing.TraceID(ctx); traceID != "" {
+			w.Header().Set(tracing.TraceIDHeader, traceID)
+		}
+
+		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(wrapped, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(wrapped.statusCode))
+		if wrapped.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(wrapped.statusCode))
+		}
+	})
+}
+
+// TraceRoute names the request's span after the matched route template, such as
+// GET /api/v1/computers/{id}. It must be used on the router, where the route is known.
+func TraceRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				span := trace.SpanFromContext(r.Context())
+				span.SetName(r.Method + " " + template)
+				span.SetAttributes(semconv.HTTPRoute(template))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
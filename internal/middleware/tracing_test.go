@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"computer-management-api/internal/tracing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracingMW := NewTracingMiddleware(provider, propagation.TraceContext{})
+
+	router := mux.NewRouter()
+	router.Use(TraceRoute)
+	router.HandleFunc("/computers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["id"] == "broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	handler := tracingMW.Trace(router)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest("GET", "/computers/1", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if got := rr.Header().Get(tracing.TraceIDHeader); got != traceID {
+		t.Errorf("Expected the incoming trace ID %s in the response, got %q", traceID, got)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/computers/broken", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/unknown", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(spans))
+	}
+
+	if spans[0].Name() != "GET /computers/{id}" {
+		t.Errorf("Expected the span to be named after the route, got %q", spans[0].Name())
+	}
+	if spans[0].SpanContext().TraceID().String() != traceID || !spans[0].Parent().IsRemote() {
+		t.Error("Expected the span to continue the incoming trace")
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("Expected an error status for a server error, got %v", spans[1].Status())
+	}
+	if spans[2].Name() != "GET" {
+		t.Errorf("Expected an unmatched request to be named by method, got %q", spans[2].Name())
+	}
+}
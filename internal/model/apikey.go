@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// APIKey identifies a client of the API. Only a hash of the secret key is stored; Prefix is
+// the start of the key and lets people recognize it without revealing the rest.
+type APIKey struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Scopes    []Scope    `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// APIKeyActorPrefix precedes the name of an API key in the audit trail
+const APIKeyActorPrefix = "api-key:"
+
+// Principal returns the principal of requests authenticated with the key
+func (k *APIKey) Principal() *Principal {
+	return &Principal{
+		Actor:  APIKeyActorPrefix + k.Name,
+		Scopes: k.Scopes,
+	}
+}
+
+// IsActive reports whether the key can be used at the given time
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
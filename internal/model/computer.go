@@ -6,14 +6,19 @@ import (
 	"github.com/google/uuid"
 )
 
-// Computer represents a computer in the system.
+// Computer represents a computer in the system. Version starts at 1 and is incremented on
+// every change; it is exposed as the ETag for optimistic concurrency control. Vendor and
+// MACWarnings are derived from the MAC address when a computer is returned and are not stored.
 type Computer struct {
 	ID                   uuid.UUID `json:"id"`
 	MACAddress           string    `json:"mac_address"`
+	Vendor               string    `json:"vendor,omitempty"`
+	MACWarnings          []string  `json:"mac_warnings,omitempty"`
 	ComputerName         string    `json:"computer_name"`
 	IPAddress            string    `json:"ip_address"`
 	EmployeeAbbreviation string    `json:"employee_abbreviation,omitempty"`
 	Description          string    `json:"description,omitempty"`
+	Version              int64     `json:"version"`
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
 }
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Employee represents an employee that computers can be assigned to.
+type Employee struct {
+	Abbreviation  string     `json:"abbreviation"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email,omitempty"`
+	Department    string     `json:"department,omitempty"`
+	Active        bool       `json:"active"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
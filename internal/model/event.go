@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ComputerEventType identifies the kind of change recorded in the audit trail.
+type ComputerEventType string
+
+const (
+	ComputerEventCreated    ComputerEventType = "created"
+	ComputerEventUpdated    ComputerEventType = "updated"
+	ComputerEventAssigned   ComputerEventType = "assigned"
+	ComputerEventUnassigned ComputerEventType = "unassigned"
+	ComputerEventDeleted    ComputerEventType = "deleted"
+	ComputerEventWoken      ComputerEventType = "woken"
+)
+
+// ComputerEvent is an append-only audit record of a change to a computer.
+// OldValue is nil for creations and NewValue is nil for deletions. Wake-ups change nothing and
+// record the computer that was woken as NewValue. MACAddress is the computer's MAC address after
+// the change, or before a deletion, and OldMACAddress the one before the change, so a change of
+// the MAC address is found under both.
+type ComputerEvent struct {
+	ID                      int64             `json:"id"`
+	ComputerID              uuid.UUID         `json:"computer_id"`
+	EventType               ComputerEventType `json:"event_type"`
+	Actor                   string            `json:"actor"`
+	MACAddress              string            `json:"mac_address"`
+	OldMACAddress           string            `json:"old_mac_address,omitempty"`
+	OldEmployeeAbbreviation string            `json:"old_employee_abbreviation,omitempty"`
+	NewEmployeeAbbreviation string            `json:"new_employee_abbreviation,omitempty"`
+	OldValue                *Computer         `json:"old_value,omitempty"`
+	NewValue                *Computer         `json:"new_value,omitempty"`
+	OccurredAt              time.Time         `json:"occurred_at"`
+}
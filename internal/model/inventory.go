@@ -0,0 +1,9 @@
+package model
+
+// InventoryStats summarizes the computer inventory
+type InventoryStats struct {
+	Computers         int
+	AssignedComputers int
+	// EmployeesOverQuota counts employees holding more computers than their quota policy allows
+	EmployeesOverQuota int
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NetworkInterfaceType identifies the kind of network adapter an interface belongs to.
+type NetworkInterfaceType string
+
+const (
+	NetworkInterfaceTypeEthernet NetworkInterfaceType = "ethernet"
+	NetworkInterfaceTypeWiFi     NetworkInterfaceType = "wifi"
+	NetworkInterfaceTypeDock     NetworkInterfaceType = "dock"
+	NetworkInterfaceTypeOther    NetworkInterfaceType = "other"
+)
+
+// NetworkInterface is a network adapter of a computer. MAC addresses are unique across all
+// interfaces of all computers. Every computer has exactly one primary interface, whose MAC
+// address is the computer's own MACAddress and whose address of the same family as the
+// computer's IPAddress is that address. The other family's address makes the computer dual-stack.
+// Vendor and MACWarnings are derived from the MAC address like those of a computer.
+type NetworkInterface struct {
+	ID          uuid.UUID            `json:"id"`
+	ComputerID  uuid.UUID            `json:"computer_id"`
+	MACAddress  string               `json:"mac_address"`
+	Vendor      string               `json:"vendor,omitempty"`
+	MACWarnings []string             `json:"mac_warnings,omitempty"`
+	Type        NetworkInterfaceType `json:"type"`
+	IPv4Address string               `json:"ipv4_address,omitempty"`
+	IPv6Address string               `json:"ipv6_address,omitempty"`
+	Primary     bool                 `json:"primary"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
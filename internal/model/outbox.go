@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// OutboxStatus represents the delivery state of an outbox message
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusDelivered OutboxStatus = "delivered"
+	OutboxStatusDead      OutboxStatus = "dead"
+)
+
+// OutboxMessage is a notification written in the same transaction as the change that
+// caused it and delivered later by the dispatcher.
+type OutboxMessage struct {
+	ID               int64        `json:"id"`
+	NotificationType string       `json:"notification_type"`
+	Payload          []byte       `json:"payload"`
+	Status           OutboxStatus `json:"status"`
+	Attempts         int          `json:"attempts"`
+	LastError        string       `json:"last_error,omitempty"`
+	NextAttemptAt    time.Time    `json:"next_attempt_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+	DeliveredAt      *time.Time   `json:"delivered_at,omitempty"`
+}
+
+// OutboxBacklog summarizes the messages waiting for delivery
+type OutboxBacklog struct {
+	Pending         int        `json:"pending"`
+	OldestPendingAt *time.Time `json:"oldest_pending_at,omitempty"`
+}
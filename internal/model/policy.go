@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// QuotaPolicyScope identifies who a quota policy applies to.
+type QuotaPolicyScope string
+
+const (
+	QuotaPolicyScopeGlobal     QuotaPolicyScope = "global"
+	QuotaPolicyScopeDepartment QuotaPolicyScope = "department"
+	QuotaPolicyScopeEmployee   QuotaPolicyScope = "employee"
+)
+
+// QuotaPolicyMode controls what happens when an employee reaches their quota.
+type QuotaPolicyMode string
+
+const (
+	// QuotaPolicyModeWarn sends a threshold notification once the quota is reached
+	QuotaPolicyModeWarn QuotaPolicyMode = "warn"
+	// QuotaPolicyModeEnforce rejects changes that would exceed the quota
+	QuotaPolicyModeEnforce QuotaPolicyMode = "enforce"
+)
+
+// QuotaPolicy limits how many computers an employee may hold. Target is the department
+// name or employee abbreviation and is empty for the global policy. The most specific
+// policy wins: employee over department over global.
+type QuotaPolicy struct {
+	ID           int64            `json:"id"`
+	Scope        QuotaPolicyScope `json:"scope"`
+	Target       string           `json:"target,omitempty"`
+	MaxComputers int              `json:"max_computers"`
+	Mode         QuotaPolicyMode  `json:"mode"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
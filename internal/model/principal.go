@@ -0,0 +1,81 @@
+package model
+
+// Scope grants access to a group of operations.
+type Scope string
+
+const (
+	ScopeComputersRead    Scope = "computers:read"
+	ScopeComputersWrite   Scope = "computers:write"
+	ScopeAssignmentsWrite Scope = "assignments:write"
+	ScopeEmployeesRead    Scope = "employees:read"
+	ScopeEmployeesWrite   Scope = "employees:write"
+	ScopePoliciesRead     Scope = "policies:read"
+	ScopePoliciesWrite    Scope = "policies:write"
+	ScopeSubnetsRead      Scope = "subnets:read"
+	ScopeSubnetsWrite     Scope = "subnets:write"
+	ScopeAPIKeysManage    Scope = "api_keys:manage"
+)
+
+// AllScopes lists every scope that can be granted
+var AllScopes = []Scope{
+	ScopeComputersRead,
+	ScopeComputersWrite,
+	ScopeAssignmentsWrite,
+	ScopeEmployeesRead,
+	ScopeEmployeesWrite,
+	ScopePoliciesRead,
+	ScopePoliciesWrite,
+	ScopeSubnetsRead,
+	ScopeSubnetsWrite,
+	ScopeAPIKeysManage,
+}
+
+// Role is a set of scopes granted to users signed in through the identity provider.
+type Role string
+
+const (
+	// RoleViewer can read computers, employees and policies
+	RoleViewer Role = "viewer"
+	// RoleITAdmin can perform every operation
+	RoleITAdmin Role = "it-admin"
+	// RoleEmployee can only read the computers assigned to themselves
+	RoleEmployee Role = "employee"
+)
+
+// RoleScopes are the scopes granted by each role. Employees have no scopes; their access to
+// their own computers is granted per route.
+var RoleScopes = map[Role][]Scope{
+	RoleViewer:   {ScopeComputersRead, ScopeEmployeesRead, ScopePoliciesRead, ScopeSubnetsRead},
+	RoleITAdmin:  AllScopes,
+	RoleEmployee: {},
+}
+
+// Principal is the authenticated client of a request: an API key or a signed-in user.
+type Principal struct {
+	// Actor names the principal in the audit trail and request log
+	Actor  string
+	Scopes []Scope
+	Roles  []Role
+	// EmployeeAbbreviation is the employee a user with the employee role is
+	EmployeeAbbreviation string
+}
+
+// HasScope reports whether the principal was granted scope
+func (p *Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole reports whether the principal has role
+func (p *Principal) HasRole(role Role) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subnet is an IP network computers are addressed from. Subnets do not overlap, and once any
+// subnet is defined every computer and network interface address must belong to one.
+// Gateway is reserved and never assigned to a computer. BroadcastAddress is where Wake-on-LAN
+// magic packets for the computers of an IPv4 subnet are sent, by default its directed broadcast
+// address.
+type Subnet struct {
+	ID               uuid.UUID `json:"id"`
+	CIDR             string    `json:"cidr"`
+	Gateway          string    `json:"gateway,omitempty"`
+	BroadcastAddress string    `json:"broadcast_address,omitempty"`
+	VLAN             *int      `json:"vlan,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// SubnetUtilization reports how many of the usable addresses of a subnet are assigned. The
+// usable addresses exclude the network and broadcast address of IPv4 subnets and the gateway;
+// IPv6 subnets can have more of them than fit an int64.
+type SubnetUtilization struct {
+	Subnet             Subnet   `json:"subnet"`
+	Capacity           *big.Int `json:"capacity"`
+	Used               int      `json:"used"`
+	Free               *big.Int `json:"free"`
+	UtilizationPercent float64  `json:"utilization_percent"`
+}
+
+// IPAddressAssignment is an address held by a network interface of a computer.
+type IPAddressAssignment struct {
+	Address    string    `json:"address"`
+	ComputerID uuid.UUID `json:"computer_id"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Wake describes a Wake-on-LAN magic packet sent to a computer.
+type Wake struct {
+	ComputerID       uuid.UUID `json:"computer_id"`
+	MACAddress       string    `json:"mac_address"`
+	BroadcastAddress string    `json:"broadcast_address"`
+	Port             int       `json:"port"`
+	Actor            string    `json:"actor"`
+	SentAt           time.Time `json:"sent_at"`
+}
@@ -2,16 +2,27 @@ package notification
 
 import (
 	"bytes"
+	"computer-management-api/internal/metrics"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracerName identifies the spans started by the notification client
+const tracerName = "computer-management-api/internal/notification"
+
 // NotificationLevel represents the severity level of a notification
 type NotificationLevel string
 
@@ -36,6 +47,9 @@ type NotificationConfig struct {
 	RetryAttempts  int
 	RetryDelay     time.Duration
 	MaxPayloadSize int64
+
+	// Metrics counts sent and failed notifications and retries; nil records nothing
+	Metrics *metrics.Metrics
 }
 
 // DefaultConfig returns a default configuration for the notification client
@@ -53,7 +67,7 @@ func DefaultConfig(url string) NotificationConfig {
 type notificationClient struct {
 	config NotificationConfig
 	client *http.Client
-	logger *log.Logger
+	logger *slog.Logger
 }
 
 // NewNotifier creates a new Notifier with default configuration
@@ -70,12 +84,12 @@ func NewNotifierWithConfig(config NotificationConfig) Notifier {
 	return &notificationClient{
 		config: config,
 		client: client,
-		logger: log.Default(),
+		logger: slog.Default(),
 	}
 }
 
 // SetLogger sets a custom logger for the notification client
-func (c *notificationClient) SetLogger(logger *log.Logger) {
+func (c *notificationClient) SetLogger(logger *slog.Logger) {
 	if logger != nil {
 		c.logger = logger
 	}
@@ -129,8 +143,30 @@ func (c *notificationClient) SendNotification(notification Notification) error {
 	return c.SendNotificationWithContext(ctx, notification)
 }
 
-// SendNotificationWithContext sends a notification with context support
+// SendNotificationWithContext sends a notification with context support. The send is traced
+// with a span per attempt, and the trace context is passed on in a traceparent header.
 func (c *notificationClient) SendNotificationWithContext(ctx context.Context, notification Notification) error {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "Notifier.SendNotification",
+		trace.WithAttributes(
+			attribute.String("notification.level", string(notification.Level)),
+			attribute.String("notification.employee_abbreviation", notification.EmployeeAbbreviation),
+		),
+	)
+	defer span.End()
+
+	err := c.send(ctx, notification)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.config.Metrics.NotificationFailed()
+	} else {
+		c.config.Metrics.NotificationSent()
+	}
+	return err
+}
+
+// send validates a notification and delivers it, retrying failed attempts
+func (c *notificationClient) send(ctx context.Context, notification Notification) error {
 	// Validate notification
 	if err := notification.Validate(); err != nil {
 		return fmt.Errorf("invalid notification: %w", err)
@@ -146,6 +182,11 @@ func (c *notificationClient) SendNotificationWithContext(ctx context.Context, no
 		notification.Source = "computer-management-api"
 	}
 
+	logger := c.logger
+	if notification.EmployeeAbbreviation != "" {
+		logger = logger.With("employee", notification.EmployeeAbbreviation)
+	}
+
 	var lastErr error
 	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
 		if attempt > 0 {
@@ -155,12 +196,13 @@ func (c *notificationClient) SendNotificationWithContext(ctx context.Context, no
 				return ctx.Err()
 			case <-time.After(c.config.RetryDelay * time.Duration(attempt)):
 			}
-			c.logger.Printf("Retrying notification send (attempt %d/%d)", attempt+1, c.config.RetryAttempts+1)
+			logger.InfoContext(ctx, "Retrying notification send", "attempt", attempt+1, "max_attempts", c.config.RetryAttempts+1)
+			c.config.Metrics.NotificationRetried()
 		}
 
-		if err := c.sendNotificationAttempt(ctx, notification); err != nil {
+		if err := c.sendNotificationAttempt(ctx, notification, attempt); err != nil {
 			lastErr = err
-			c.logger.Printf("Notification send attempt %d failed: %v", attempt+1, err)
+			logger.WarnContext(ctx, "Notification send attempt failed", "attempt", attempt+1, "error", err)
 
 			// Don't retry on validation, client errors, or payload size errors
 			if strings.Contains(err.Error(), "400") ||
@@ -178,8 +220,24 @@ func (c *notificationClient) SendNotificationWithContext(ctx context.Context, no
 	return fmt.Errorf("failed to send notification after %d attempts: %w", c.config.RetryAttempts+1, lastErr)
 }
 
-// sendNotificationAttempt performs a single notification send attempt
-func (c *notificationClient) sendNotificationAttempt(ctx context.Context, notification Notification) error {
+// sendNotificationAttempt performs a single notification send attempt in its own span
+func (c *notificationClient) sendNotificationAttempt(ctx context.Context, notification Notification, attempt int) (err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "POST",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String("POST"),
+			semconv.URLFull(c.config.URL),
+			semconv.HTTPRequestResendCount(attempt),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	payload, err := json.Marshal(notification)
 	if err != nil {
 		return fmt.Errorf("failed to marshal notification: %w", err)
@@ -198,12 +256,14 @@ func (c *notificationClient) sendNotificationAttempt(ctx context.Context, notifi
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "computer-management-api/1.0")
 	req.Header.Set("Accept", "application/json")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 
 	// Read response body for better error reporting
 	body, _ := io.ReadAll(resp.Body)
@@ -213,7 +273,7 @@ func (c *notificationClient) sendNotificationAttempt(ctx context.Context, notifi
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
-		c.logger.Printf("Warning: unexpected status code %d from notification service", resp.StatusCode)
+		c.logger.WarnContext(ctx, "Unexpected status code from notification service", "status", resp.StatusCode)
 	}
 
 	return nil
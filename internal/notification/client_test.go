@@ -1,13 +1,20 @@
 package notification
 
 import (
+	"computer-management-api/internal/metrics"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestNotification_Validate(t *testing.T) {
@@ -233,6 +240,88 @@ func TestNotificationClient_Retry_Mechanism(t *testing.T) {
 	}
 }
 
+func TestNotificationClient_Metrics(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	m := metrics.New(nil)
+	config := DefaultConfig(server.URL)
+	config.RetryDelay = 10 * time.Millisecond
+	config.Metrics = m
+	client := NewNotifierWithConfig(config)
+
+	// The first notification succeeds on its retry, the second is invalid
+	_ = client.SendNotification(Notification{Level: LevelInfo, Message: "Test message"})
+	_ = client.SendNotification(Notification{Level: LevelInfo})
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rr.Body)
+	for _, expected := range []string{
+		`computer_management_notifications_total{result="success"} 1`,
+		`computer_management_notifications_total{result="failure"} 1`,
+		`computer_management_notification_retries_total 1`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("Expected %s in the metrics", expected)
+		}
+	}
+}
+
+func TestNotificationClient_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	defer otel.SetTextMapPropagator(otel.GetTextMapPropagator())
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var traceparents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		if len(traceparents) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig(server.URL)
+	config.RetryDelay = 10 * time.Millisecond
+	client := NewNotifierWithConfig(config)
+
+	if err := client.SendNotification(Notification{Level: LevelInfo, Message: "Test message"}); err != nil {
+		t.Fatalf("Expected success after a retry, got: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected a span per attempt and one for the notification, got %d", len(spans))
+	}
+	parent := spans[2]
+	if parent.Name() != "Notifier.SendNotification" {
+		t.Errorf("Expected the notification span to end last, got %q", parent.Name())
+	}
+
+	for i, attempt := range spans[:2] {
+		if attempt.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("Attempt %d: expected a child of the notification span", i+1)
+		}
+		expected := "00-" + attempt.SpanContext().TraceID().String() + "-" + attempt.SpanContext().SpanID().String() + "-01"
+		if traceparents[i] != expected {
+			t.Errorf("Attempt %d: expected traceparent %s, got %q", i+1, expected, traceparents[i])
+		}
+	}
+}
+
 func TestNotificationClient_IsHealthy(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Custom errors for API key operations
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+// APIKeyRepository is an interface for interacting with API keys.
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key model.APIKey, keyHash string) (int64, error)
+	GetAPIKeyByID(ctx context.Context, id int64) (*model.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+	GetAllAPIKeys(ctx context.Context) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+}
+
+// apiKeyRepository is the concrete implementation of the APIKeyRepository interface.
+type apiKeyRepository struct {
+	DB DBTX
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository.
+func NewAPIKeyRepository(db *sql.DB) APIKeyRepository {
+	return &apiKeyRepository{DB: db}
+}
+
+const apiKeyColumns = `id, name, prefix, scopes, expires_at, revoked_at, created_at`
+
+// CreateAPIKey stores a new API key under the hash of its secret and returns its ID.
+func (r *apiKeyRepository) CreateAPIKey(ctx context.Context, key model.APIKey, keyHash string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	var id int64
+	if err := r.DB.QueryRowContext(ctx, query, key.Name, key.Prefix, keyHash, pq.Array(scopes), key.ExpiresAt).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetAPIKeyByID retrieves a single API key by ID.
+func (r *apiKeyRepository) GetAPIKeyByID(ctx context.Context, id int64) (*model.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+	key, err := scanAPIKey(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return &key, nil
+}
+
+// GetAPIKeyByHash retrieves the API key whose secret has the given hash, whether or not it is still active.
+func (r *apiKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	key, err := scanAPIKey(r.DB.QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return &key, nil
+}
+
+// GetAllAPIKeys retrieves every API key, including revoked ones, newest first.
+func (r *apiKeyRepository) GetAllAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []model.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey marks an API key as revoked. Revoking a revoked key keeps the original time.
+func (r *apiKeyRepository) RevokeAPIKey(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP) WHERE id = $1`
+
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// scanAPIKey scans an API key row
+func scanAPIKey(scanner rowScanner) (model.APIKey, error) {
+	var k model.APIKey
+	var scopes pq.StringArray
+	var expiresAt, revokedAt sql.NullTime
+	if err := scanner.Scan(&k.ID, &k.Name, &k.Prefix, &scopes, &expiresAt, &revokedAt, &k.CreatedAt); err != nil {
+		return model.APIKey{}, err
+	}
+
+	k.Scopes = make([]model.Scope, len(scopes))
+	for i, scope := range scopes {
+		k.Scopes[i] = model.Scope(scope)
+	}
+	if expiresAt.Valid {
+		k.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		k.RevokedAt = &revokedAt.Time
+	}
+	return k, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAPIKey_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAPIKeyRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO api_keys (name, prefix, key_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`)).
+		WithArgs("inventory-sync", "cma_1a2b3c4d", "hash", pq.Array([]string{"computers:read", "computers:write"}), nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	id, err := repo.CreateAPIKey(context.Background(), model.APIKey{
+		Name:   "inventory-sync",
+		Prefix: "cma_1a2b3c4d",
+		Scopes: []model.Scope{model.ScopeComputersRead, model.ScopeComputersWrite},
+	}, "hash")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAPIKeyByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAPIKeyRepository(db)
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM api_keys WHERE key_hash = $1`)).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "scopes", "expires_at", "revoked_at", "created_at"}).
+			AddRow(7, "inventory-sync", "cma_1a2b3c4d", "{computers:read}", nil, now, now))
+
+	key, err := repo.GetAPIKeyByHash(context.Background(), "hash")
+
+	require.NoError(t, err)
+	assert.Equal(t, "inventory-sync", key.Name)
+	assert.Equal(t, []model.Scope{model.ScopeComputersRead}, key.Scopes)
+	assert.Nil(t, key.ExpiresAt)
+	require.NotNil(t, key.RevokedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM api_keys WHERE key_hash = $1`)).
+		WithArgs("unknown").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "scopes", "expires_at", "revoked_at", "created_at"}))
+
+	_, err = repo.GetAPIKeyByHash(context.Background(), "unknown")
+	assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeAPIKey_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAPIKeyRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE api_keys SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP) WHERE id = $1`)).
+		WithArgs(int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.RevokeAPIKey(context.Background(), 9)
+
+	assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
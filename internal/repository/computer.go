@@ -5,12 +5,15 @@ import (
 	"computer-management-api/pkg/validation"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 )
 
 // Custom errors for better error handling
@@ -18,27 +21,39 @@ var (
 	ErrComputerNotFound = errors.New("computer not found")
 	ErrDuplicateMAC     = errors.New("computer with this MAC address already exists")
 	ErrInvalidMACFormat = errors.New("invalid MAC address format")
+	ErrNotAssigned      = errors.New("computer not found or not assigned to employee")
 )
 
 // PaginationParams holds pagination parameters for repository queries
 type PaginationParams struct {
 	Offset int
 	Limit  int
+	// After continues a keyset-paginated listing after the cursor's row; Offset is ignored when set.
+	// Only computer listings support cursors.
+	After *Cursor
+	// Count selects how the total count is determined; CountExact when empty. Only computer
+	// listings support other modes.
+	Count CountMode
 }
 
 // PaginatedResult holds paginated query results
 type PaginatedResult struct {
-	Items      []model.Computer
-	TotalCount int
+	Items               []model.Computer
+	TotalCount          int // UnknownTotalCount when counting was skipped
+	TotalCountEstimated bool
+	NextCursor          *Cursor // Position after the last item, nil on the last page
 }
 
 // ComputerRepository is an interface for interacting with computer data.
 type ComputerRepository interface {
 	CreateComputer(ctx context.Context, computer model.Computer) error
 	GetAllComputers(ctx context.Context) ([]model.Computer, error)
-	GetAllComputersPaginated(ctx context.Context, params PaginationParams) (*PaginatedResult, error)
+	GetAllComputersPaginated(ctx context.Context, filter ComputerFilter, params PaginationParams) (*PaginatedResult, error)
+	StreamComputers(ctx context.Context, filter ComputerFilter, fn func(model.Computer) error) error
 	GetComputerByMAC(ctx context.Context, macAddress string) (*model.Computer, error)
+	GetComputersByMACs(ctx context.Context, macAddresses []string) ([]model.Computer, error)
 	GetComputerByID(ctx context.Context, id uuid.UUID) (*model.Computer, error)
+	GetComputerByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Computer, error)
 	UpdateComputer(ctx context.Context, id uuid.UUID, computer model.Computer) error
 	DeleteComputer(ctx context.Context, id uuid.UUID) error
 	GetComputersByEmployee(ctx context.Context, employeeAbbreviation string) ([]model.Computer, error)
@@ -51,12 +66,12 @@ type ComputerRepository interface {
 // computerRepository is the concrete implementation of the ComputerRepository interface.
 
 type computerRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
-// NewComputerRepository creates a new ComputerRepository.
+// NewComputerRepository creates a new ComputerRepository. Every call is traced.
 func NewComputerRepository(db *sql.DB) ComputerRepository {
-	return &computerRepository{DB: db}
+	return traceComputerRepository(&computerRepository{DB: db}, semconv.DBSystemNamePostgreSQL)
 }
 
 // CreateComputer adds a new computer to the database.
@@ -72,14 +87,16 @@ func (r *computerRepository) CreateComputer(ctx context.Context, computer model.
 	}
 	computer.MACAddress = normalizedMAC
 
-	// Validate IP address
-	if err := validation.ValidateIP(computer.IPAddress); err != nil {
+	// Validate and canonicalize IP address
+	normalizedIP, err := validation.ValidateIP(computer.IPAddress)
+	if err != nil {
 		return fmt.Errorf("invalid IP address: %w", err)
 	}
+	computer.IPAddress = normalizedIP
 
 	query := `
 		INSERT INTO computers (id, mac_address, computer_name, ip_address, employee_abbreviation, description)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)`
 
 	_, err = r.DB.ExecContext(ctx, query,
 		computer.ID,
@@ -93,10 +110,15 @@ func (r *computerRepository) CreateComputer(ctx context.Context, computer model.
 	if err != nil {
 		// Check for unique constraint violations (PostgreSQL error code 23505)
 		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			if strings.Contains(err.Error(), "computers_mac_address_key") || strings.Contains(err.Error(), "computers_pkey") {
+			// The MAC address may also belong to an interface of another computer
+			if strings.Contains(err.Error(), "computers_mac_address_key") || strings.Contains(err.Error(), "computers_pkey") ||
+				strings.Contains(err.Error(), "network_interfaces_mac_address_key") {
 				return fmt.Errorf("%w: %s", ErrDuplicateMAC, computer.MACAddress)
 			}
 		}
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("%w: %s", ErrEmployeeNotFound, computer.EmployeeAbbreviation)
+		}
 		return fmt.Errorf("failed to create computer: %w", err)
 	}
 
@@ -109,7 +131,7 @@ func (r *computerRepository) GetAllComputers(ctx context.Context) ([]model.Compu
 	defer cancel()
 
 	query := `
-		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at 
+		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at 
 		FROM computers 
 		ORDER BY computer_name`
 
@@ -121,8 +143,8 @@ func (r *computerRepository) GetAllComputers(ctx context.Context) ([]model.Compu
 
 	var computers []model.Computer
 	for rows.Next() {
-		var c model.Computer
-		if err := rows.Scan(&c.ID, &c.MACAddress, &c.ComputerName, &c.IPAddress, &c.EmployeeAbbreviation, &c.Description, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		c, err := scanComputer(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan computer: %w", err)
 		}
 		computers = append(computers, c)
@@ -135,18 +157,38 @@ func (r *computerRepository) GetAllComputers(ctx context.Context) ([]model.Compu
 	return computers, nil
 }
 
-// GetAllComputersPaginated retrieves all computers with pagination support.
-func (r *computerRepository) GetAllComputersPaginated(ctx context.Context, params PaginationParams) (*PaginatedResult, error) {
+// GetAllComputersPaginated retrieves the computers matching filter with pagination support. One
+// row more than requested is fetched to tell whether another page follows without counting.
+func (r *computerRepository) GetAllComputersPaginated(ctx context.Context, filter ComputerFilter, params PaginationParams) (*PaginatedResult, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	query := `
-		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at 
-		FROM computers 
-		ORDER BY computer_name
-		OFFSET $1 LIMIT $2`
+	orderBy, err := filter.orderByClause()
+	if err != nil {
+		return nil, err
+	}
+	conditions, args := filter.conditions(nil)
+	countWhere, countArgs := joinConditions(conditions), args
+
+	offset := params.Offset
+	if params.After != nil {
+		var keyset string
+		keyset, args, err = filter.keysetCondition(params.After, append([]interface{}{}, args...))
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, keyset)
+		offset = 0
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at
+		FROM computers
+		%s
+		%s
+		OFFSET $%d LIMIT $%d`, joinConditions(conditions), orderBy, len(args)+1, len(args)+2)
 
-	rows, err := r.DB.QueryContext(ctx, query, params.Offset, params.Limit)
+	rows, err := r.DB.QueryContext(ctx, query, append(args, offset, params.Limit+1)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query computers: %w", err)
 	}
@@ -154,8 +196,8 @@ func (r *computerRepository) GetAllComputersPaginated(ctx context.Context, param
 
 	var computers []model.Computer
 	for rows.Next() {
-		var c model.Computer
-		if err := rows.Scan(&c.ID, &c.MACAddress, &c.ComputerName, &c.IPAddress, &c.EmployeeAbbreviation, &c.Description, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		c, err := scanComputer(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan computer: %w", err)
 		}
 		computers = append(computers, c)
@@ -165,18 +207,91 @@ func (r *computerRepository) GetAllComputersPaginated(ctx context.Context, param
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
-	// Get total count of computers for pagination
-	var totalCount int
-	countQuery := `SELECT COUNT(*) FROM computers`
-	err = r.DB.QueryRowContext(ctx, countQuery).Scan(&totalCount)
+	result := &PaginatedResult{Items: computers}
+	if len(computers) > params.Limit {
+		result.Items = computers[:params.Limit]
+		result.NextCursor = filter.cursorFor(result.Items[len(result.Items)-1])
+	}
+
+	result.TotalCount, result.TotalCountEstimated, err = r.countComputers(ctx, params.Count, countWhere, countArgs)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StreamComputers calls fn for every computer matching filter, in the filter's sort order. Rows
+// are read from the database as fn consumes them, so the result set is never held in memory. The
+// first error returned by fn stops the iteration and is returned. No timeout is applied, the
+// caller's context bounds the query.
+func (r *computerRepository) StreamComputers(ctx context.Context, filter ComputerFilter, fn func(model.Computer) error) error {
+	orderBy, err := filter.orderByClause()
+	if err != nil {
+		return err
+	}
+	where, args := filter.whereClause(nil)
+
+	query := fmt.Sprintf(`
+		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at
+		FROM computers
+		%s
+		%s`, where, orderBy)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get total count of computers: %w", err)
+		return fmt.Errorf("failed to query computers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c, err := scanComputer(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan computer: %w", err)
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row iteration error: %w", err)
 	}
+	return nil
+}
 
-	return &PaginatedResult{
-		Items:      computers,
-		TotalCount: totalCount,
-	}, nil
+// countComputers determines the number of computers matching where in the given mode. It reports
+// whether the count is an estimate.
+func (r *computerRepository) countComputers(ctx context.Context, mode CountMode, where string, args []interface{}) (int, bool, error) {
+	switch mode {
+	case CountNone:
+		return UnknownTotalCount, false, nil
+	case CountEstimated:
+		// The planner's estimate comes from table statistics and never scans the matching rows
+		var plan []byte
+		err := r.DB.QueryRowContext(ctx, `EXPLAIN (FORMAT JSON) SELECT 1 FROM computers `+where, args...).Scan(&plan)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to estimate count of computers: %w", err)
+		}
+		var explained []struct {
+			Plan struct {
+				Rows float64 `json:"Plan Rows"`
+			} `json:"Plan"`
+		}
+		if err := json.Unmarshal(plan, &explained); err != nil {
+			return 0, false, fmt.Errorf("failed to parse count estimate of computers: %w", err)
+		}
+		if len(explained) == 0 {
+			return 0, false, errors.New("failed to parse count estimate of computers: empty plan")
+		}
+		return int(explained[0].Plan.Rows), true, nil
+	default:
+		var totalCount int
+		err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM computers `+where, args...).Scan(&totalCount)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to get total count of computers: %w", err)
+		}
+		return totalCount, false, nil
+	}
 }
 
 // ComputerExists checks if a computer with the given MAC address already exists
@@ -201,14 +316,14 @@ func (r *computerRepository) GetComputerByMAC(ctx context.Context, macAddress st
 	defer cancel()
 
 	query := `
-		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at 
+		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at 
 		FROM computers 
 		WHERE mac_address = $1`
 
 	row := r.DB.QueryRowContext(ctx, query, macAddress)
 
-	var c model.Computer
-	if err := row.Scan(&c.ID, &c.MACAddress, &c.ComputerName, &c.IPAddress, &c.EmployeeAbbreviation, &c.Description, &c.CreatedAt, &c.UpdatedAt); err != nil {
+	c, err := scanComputer(row)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrComputerNotFound
 		}
@@ -217,20 +332,53 @@ func (r *computerRepository) GetComputerByMAC(ctx context.Context, macAddress st
 	return &c, nil
 }
 
+// GetComputersByMACs retrieves the computers with any of the given MAC addresses in a single query.
+// MAC addresses without a computer are skipped.
+func (r *computerRepository) GetComputersByMACs(ctx context.Context, macAddresses []string) ([]model.Computer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at
+		FROM computers
+		WHERE mac_address = ANY($1)`
+
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(macAddresses))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query computers by MAC: %w", err)
+	}
+	defer rows.Close()
+
+	var computers []model.Computer
+	for rows.Next() {
+		c, err := scanComputer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan computer: %w", err)
+		}
+		computers = append(computers, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return computers, nil
+}
+
 // GetComputerByID retrieves a single computer by its ID.
 func (r *computerRepository) GetComputerByID(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	query := `
-		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at 
+		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at 
 		FROM computers 
 		WHERE id = $1`
 
 	row := r.DB.QueryRowContext(ctx, query, id)
 
-	var c model.Computer
-	if err := row.Scan(&c.ID, &c.MACAddress, &c.ComputerName, &c.IPAddress, &c.EmployeeAbbreviation, &c.Description, &c.CreatedAt, &c.UpdatedAt); err != nil {
+	c, err := scanComputer(row)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrComputerNotFound
 		}
@@ -239,6 +387,28 @@ func (r *computerRepository) GetComputerByID(ctx context.Context, id uuid.UUID)
 	return &c, nil
 }
 
+// GetComputerByIDForUpdate retrieves a single computer by its ID and locks its row until the
+// surrounding transaction ends, so its version cannot change between a check and a write.
+func (r *computerRepository) GetComputerByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at
+		FROM computers
+		WHERE id = $1
+		FOR UPDATE`
+
+	c, err := scanComputer(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrComputerNotFound
+		}
+		return nil, fmt.Errorf("failed to lock computer: %w", err)
+	}
+	return &c, nil
+}
+
 // UpdateComputer updates a computer in the database.
 func (r *computerRepository) UpdateComputer(ctx context.Context, id uuid.UUID, computer model.Computer) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -246,7 +416,7 @@ func (r *computerRepository) UpdateComputer(ctx context.Context, id uuid.UUID, c
 
 	query := `
 		UPDATE computers
-		SET mac_address = $1, computer_name = $2, ip_address = $3, employee_abbreviation = $4, description = $5
+		SET mac_address = $1, computer_name = $2, ip_address = $3, employee_abbreviation = NULLIF($4, ''), description = $5
 		WHERE id = $6`
 
 	result, err := r.DB.ExecContext(ctx, query,
@@ -259,6 +429,12 @@ func (r *computerRepository) UpdateComputer(ctx context.Context, id uuid.UUID, c
 	)
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", ErrDuplicateMAC, computer.MACAddress)
+		}
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("%w: %s", ErrEmployeeNotFound, computer.EmployeeAbbreviation)
+		}
 		return fmt.Errorf("failed to update computer: %w", err)
 	}
 
@@ -305,7 +481,7 @@ func (r *computerRepository) GetComputersByEmployee(ctx context.Context, employe
 
 	// Leverage the index on employee_abbreviation for fast lookup
 	query := `
-		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at 
+		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at 
 		FROM computers 
 		WHERE employee_abbreviation = $1 
 		ORDER BY computer_name`
@@ -318,8 +494,8 @@ func (r *computerRepository) GetComputersByEmployee(ctx context.Context, employe
 
 	var computers []model.Computer
 	for rows.Next() {
-		var c model.Computer
-		if err := rows.Scan(&c.ID, &c.MACAddress, &c.ComputerName, &c.IPAddress, &c.EmployeeAbbreviation, &c.Description, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		c, err := scanComputer(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan computer: %w", err)
 		}
 		computers = append(computers, c)
@@ -334,50 +510,10 @@ func (r *computerRepository) GetComputersByEmployee(ctx context.Context, employe
 
 // GetComputersByEmployeePaginated retrieves all computers for a specific employee with pagination support.
 func (r *computerRepository) GetComputersByEmployeePaginated(ctx context.Context, employeeAbbreviation string, params PaginationParams) (*PaginatedResult, error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
-	query := `
-		SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at 
-		FROM computers 
-		WHERE employee_abbreviation = $1 
-		ORDER BY computer_name
-		OFFSET $2 LIMIT $3`
-
-	rows, err := r.DB.QueryContext(ctx, query, employeeAbbreviation, params.Offset, params.Limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query computers by employee: %w", err)
-	}
-	defer rows.Close()
-
-	var computers []model.Computer
-	for rows.Next() {
-		var c model.Computer
-		if err := rows.Scan(&c.ID, &c.MACAddress, &c.ComputerName, &c.IPAddress, &c.EmployeeAbbreviation, &c.Description, &c.CreatedAt, &c.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan computer: %w", err)
-		}
-		computers = append(computers, c)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("row iteration error: %w", err)
-	}
-
-	// Get total count of computers for pagination
-	var totalCount int
-	countQuery := `SELECT COUNT(*) FROM computers WHERE employee_abbreviation = $1`
-	err = r.DB.QueryRowContext(ctx, countQuery, employeeAbbreviation).Scan(&totalCount)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get total count of computers: %w", err)
-	}
-
-	return &PaginatedResult{
-		Items:      computers,
-		TotalCount: totalCount,
-	}, nil
+	return r.GetAllComputersPaginated(ctx, ComputerFilter{EmployeeAbbreviation: employeeAbbreviation}, params)
 }
 
-// RemoveComputerFromEmployee removes a computer from an employee by setting employee_abbreviation to empty.
+// RemoveComputerFromEmployee removes a computer from an employee by clearing employee_abbreviation.
 // This method verifies that the computer is currently assigned to the specified employee before removing it.
 func (r *computerRepository) RemoveComputerFromEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -386,7 +522,7 @@ func (r *computerRepository) RemoveComputerFromEmployee(ctx context.Context, com
 	// First, verify that the computer exists and is assigned to the specified employee
 	query := `
 		UPDATE computers 
-		SET employee_abbreviation = '', updated_at = CURRENT_TIMESTAMP
+		SET employee_abbreviation = NULL, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1 AND employee_abbreviation = $2`
 
 	result, err := r.DB.ExecContext(ctx, query, computerID, employeeAbbreviation)
@@ -401,7 +537,7 @@ func (r *computerRepository) RemoveComputerFromEmployee(ctx context.Context, com
 
 	if rowsAffected == 0 {
 		// Computer either doesn't exist or is not assigned to this employee
-		return fmt.Errorf("computer not found or not assigned to employee %s", employeeAbbreviation)
+		return fmt.Errorf("%w %s", ErrNotAssigned, employeeAbbreviation)
 	}
 
 	return nil
@@ -421,6 +557,9 @@ func (r *computerRepository) AssignComputerToEmployee(ctx context.Context, compu
 
 	result, err := r.DB.ExecContext(ctx, query, employeeAbbreviation, computerID)
 	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("%w: %s", ErrEmployeeNotFound, employeeAbbreviation)
+		}
 		return fmt.Errorf("failed to assign computer to employee: %w", err)
 	}
 
@@ -430,7 +569,47 @@ func (r *computerRepository) AssignComputerToEmployee(ctx context.Context, compu
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("computer with ID %s not found", computerID)
+		return fmt.Errorf("computer with ID %s not found: %w", computerID, ErrComputerNotFound)
+	}
+
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanComputer scans a computer row, mapping a NULL employee_abbreviation to an empty string
+func scanComputer(scanner rowScanner) (model.Computer, error) {
+	var c model.Computer
+	var employeeAbbreviation sql.NullString
+	if err := scanner.Scan(&c.ID, &c.MACAddress, &c.ComputerName, &c.IPAddress, &employeeAbbreviation, &c.Description, &c.Version, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return model.Computer{}, err
+	}
+	c.EmployeeAbbreviation = employeeAbbreviation.String
+	return c, nil
+}
+
+// isForeignKeyViolation reports whether err is a PostgreSQL foreign key violation (error code 23503)
+func isForeignKeyViolation(err error) bool {
+	return strings.Contains(err.Error(), "violates foreign key constraint")
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation (error code 23505)
+func isUniqueViolation(err error) bool {
+	return strings.Contains(err.Error(), "duplicate key value violates unique constraint")
+}
+
+// requireRowsAffected returns notFound if a statement changed no rows
+func requireRowsAffected(result sql.Result, notFound error) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return notFound
 	}
 
 	return nil
@@ -11,6 +11,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -44,7 +45,7 @@ func TestCreateComputer_Success(t *testing.T) {
 		Description:          "Test computer",
 	}
 
-	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO computers (id, mac_address, computer_name, ip_address, employee_abbreviation, description) VALUES ($1, $2, $3, $4, $5, $6)`)).
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO computers (id, mac_address, computer_name, ip_address, employee_abbreviation, description) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)`)).
 		WithArgs(computer.ID, computer.MACAddress, computer.ComputerName, computer.IPAddress, computer.EmployeeAbbreviation, computer.Description).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -138,12 +139,12 @@ func TestGetAllComputers_Success(t *testing.T) {
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "created_at", "updated_at"})
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"})
 	for _, computer := range expectedComputers {
-		rows.AddRow(computer.ID, computer.MACAddress, computer.ComputerName, computer.IPAddress, computer.EmployeeAbbreviation, computer.Description, computer.CreatedAt, computer.UpdatedAt)
+		rows.AddRow(computer.ID, computer.MACAddress, computer.ComputerName, computer.IPAddress, computer.EmployeeAbbreviation, computer.Description, computer.Version, computer.CreatedAt, computer.UpdatedAt)
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at FROM computers ORDER BY computer_name`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers ORDER BY computer_name`)).
 		WillReturnRows(rows)
 
 	ctx := context.Background()
@@ -160,7 +161,7 @@ func TestGetAllComputers_QueryError(t *testing.T) {
 	db, mock, repo := setupTestDB(t)
 	defer db.Close()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at FROM computers ORDER BY computer_name`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers ORDER BY computer_name`)).
 		WillReturnError(errors.New("database error"))
 
 	ctx := context.Background()
@@ -188,10 +189,10 @@ func TestGetComputerByID_Success(t *testing.T) {
 		UpdatedAt:            now,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "created_at", "updated_at"}).
-		AddRow(expectedComputer.ID, expectedComputer.MACAddress, expectedComputer.ComputerName, expectedComputer.IPAddress, expectedComputer.EmployeeAbbreviation, expectedComputer.Description, expectedComputer.CreatedAt, expectedComputer.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"}).
+		AddRow(expectedComputer.ID, expectedComputer.MACAddress, expectedComputer.ComputerName, expectedComputer.IPAddress, expectedComputer.EmployeeAbbreviation, expectedComputer.Description, expectedComputer.Version, expectedComputer.CreatedAt, expectedComputer.UpdatedAt)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at FROM computers WHERE id = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE id = $1`)).
 		WithArgs(computerID).
 		WillReturnRows(rows)
 
@@ -211,7 +212,7 @@ func TestGetComputerByID_NotFound(t *testing.T) {
 
 	computerID := uuid.New()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at FROM computers WHERE id = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE id = $1`)).
 		WithArgs(computerID).
 		WillReturnError(sql.ErrNoRows)
 
@@ -223,6 +224,44 @@ func TestGetComputerByID_NotFound(t *testing.T) {
 	assert.Nil(t, computer)
 }
 
+func TestGetComputerByIDForUpdate_Success(t *testing.T) {
+	db, mock, repo := setupTestDB(t)
+	defer db.Close()
+
+	computerID := uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"}).
+		AddRow(computerID, "AA:BB:CC:DD:EE:FF", "Test-PC", "192.168.1.100", nil, "", int64(4), now, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE id = $1 FOR UPDATE`)).
+		WithArgs(computerID).
+		WillReturnRows(rows)
+
+	computer, err := repo.GetComputerByIDForUpdate(context.Background(), computerID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), computer.Version)
+	assert.Equal(t, "", computer.EmployeeAbbreviation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetComputerByIDForUpdate_NotFound(t *testing.T) {
+	db, mock, repo := setupTestDB(t)
+	defer db.Close()
+
+	computerID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE id = $1 FOR UPDATE`)).
+		WithArgs(computerID).
+		WillReturnError(sql.ErrNoRows)
+
+	computer, err := repo.GetComputerByIDForUpdate(context.Background(), computerID)
+
+	assert.True(t, errors.Is(err, ErrComputerNotFound))
+	assert.Nil(t, computer)
+}
+
 func TestGetComputerByMAC_Success(t *testing.T) {
 	db, mock, repo := setupTestDB(t)
 	defer db.Close()
@@ -240,10 +279,10 @@ func TestGetComputerByMAC_Success(t *testing.T) {
 		UpdatedAt:            now,
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "created_at", "updated_at"}).
-		AddRow(expectedComputer.ID, expectedComputer.MACAddress, expectedComputer.ComputerName, expectedComputer.IPAddress, expectedComputer.EmployeeAbbreviation, expectedComputer.Description, expectedComputer.CreatedAt, expectedComputer.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"}).
+		AddRow(expectedComputer.ID, expectedComputer.MACAddress, expectedComputer.ComputerName, expectedComputer.IPAddress, expectedComputer.EmployeeAbbreviation, expectedComputer.Description, expectedComputer.Version, expectedComputer.CreatedAt, expectedComputer.UpdatedAt)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at FROM computers WHERE mac_address = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE mac_address = $1`)).
 		WithArgs(macAddress).
 		WillReturnRows(rows)
 
@@ -262,7 +301,7 @@ func TestGetComputerByMAC_NotFound(t *testing.T) {
 
 	macAddress := "AA:BB:CC:DD:EE:FF"
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at FROM computers WHERE mac_address = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE mac_address = $1`)).
 		WithArgs(macAddress).
 		WillReturnError(sql.ErrNoRows)
 
@@ -274,6 +313,26 @@ func TestGetComputerByMAC_NotFound(t *testing.T) {
 	assert.Nil(t, computer)
 }
 
+func TestGetComputersByMACs(t *testing.T) {
+	db, mock, repo := setupTestDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"}).
+		AddRow(uuid.New(), "AA:BB:CC:DD:EE:FF", "TEST-001", "192.168.1.100", nil, "", 1, now, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE mac_address = ANY($1)`)).
+		WithArgs(pq.Array([]string{"AA:BB:CC:DD:EE:FF", "00:1B:44:11:3A:B7"})).
+		WillReturnRows(rows)
+
+	computers, err := repo.GetComputersByMACs(context.Background(), []string{"AA:BB:CC:DD:EE:FF", "00:1B:44:11:3A:B7"})
+
+	assert.NoError(t, err)
+	assert.Len(t, computers, 1)
+	assert.Equal(t, "AA:BB:CC:DD:EE:FF", computers[0].MACAddress)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUpdateComputer_Success(t *testing.T) {
 	db, mock, repo := setupTestDB(t)
 	defer db.Close()
@@ -287,7 +346,7 @@ func TestUpdateComputer_Success(t *testing.T) {
 		Description:          "Updated computer",
 	}
 
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE computers SET mac_address = $1, computer_name = $2, ip_address = $3, employee_abbreviation = $4, description = $5 WHERE id = $6`)).
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE computers SET mac_address = $1, computer_name = $2, ip_address = $3, employee_abbreviation = NULLIF($4, ''), description = $5 WHERE id = $6`)).
 		WithArgs(computer.MACAddress, computer.ComputerName, computer.IPAddress, computer.EmployeeAbbreviation, computer.Description, computerID).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -309,7 +368,7 @@ func TestUpdateComputer_NotFound(t *testing.T) {
 		IPAddress:    "192.168.1.200",
 	}
 
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE computers SET mac_address = $1, computer_name = $2, ip_address = $3, employee_abbreviation = $4, description = $5 WHERE id = $6`)).
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE computers SET mac_address = $1, computer_name = $2, ip_address = $3, employee_abbreviation = NULLIF($4, ''), description = $5 WHERE id = $6`)).
 		WithArgs(computer.MACAddress, computer.ComputerName, computer.IPAddress, computer.EmployeeAbbreviation, computer.Description, computerID).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 
@@ -383,12 +442,12 @@ func TestGetComputersByEmployee_Success(t *testing.T) {
 		},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "created_at", "updated_at"})
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"})
 	for _, computer := range expectedComputers {
-		rows.AddRow(computer.ID, computer.MACAddress, computer.ComputerName, computer.IPAddress, computer.EmployeeAbbreviation, computer.Description, computer.CreatedAt, computer.UpdatedAt)
+		rows.AddRow(computer.ID, computer.MACAddress, computer.ComputerName, computer.IPAddress, computer.EmployeeAbbreviation, computer.Description, computer.Version, computer.CreatedAt, computer.UpdatedAt)
 	}
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at FROM computers WHERE employee_abbreviation = $1 ORDER BY computer_name`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE employee_abbreviation = $1 ORDER BY computer_name`)).
 		WithArgs(employeeAbbr).
 		WillReturnRows(rows)
 
@@ -408,9 +467,9 @@ func TestGetComputersByEmployee_Empty(t *testing.T) {
 
 	employeeAbbr := "XXX"
 
-	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "created_at", "updated_at"})
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"})
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at FROM computers WHERE employee_abbreviation = $1 ORDER BY computer_name`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE employee_abbreviation = $1 ORDER BY computer_name`)).
 		WithArgs(employeeAbbr).
 		WillReturnRows(rows)
 
@@ -473,7 +532,7 @@ func TestContextTimeout(t *testing.T) {
 	// Wait a bit to ensure context times out
 	time.Sleep(1 * time.Millisecond)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, created_at, updated_at FROM computers ORDER BY computer_name`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers ORDER BY computer_name`)).
 		WillDelayFor(100 * time.Millisecond).
 		WillReturnError(context.DeadlineExceeded)
 
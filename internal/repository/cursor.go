@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor is malformed or was issued for a
+// listing with a different sort order
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CountMode controls how the total number of matching rows is determined for a page
+type CountMode string
+
+const (
+	// CountExact runs a COUNT(*) over all matching rows
+	CountExact CountMode = "exact"
+	// CountEstimated uses the query planner's row estimate, which is cheap but approximate
+	CountEstimated CountMode = "estimated"
+	// CountNone skips counting altogether
+	CountNone CountMode = "none"
+)
+
+// UnknownTotalCount is reported as the total count when counting was skipped
+const UnknownTotalCount = -1
+
+// ParseCountMode parses a count mode, returning false for unknown values
+func ParseCountMode(value string) (CountMode, bool) {
+	switch mode := CountMode(strings.ToLower(value)); mode {
+	case CountExact, CountEstimated, CountNone:
+		return mode, true
+	default:
+		return "", false
+	}
+}
+
+// Cursor marks the position after the last row of a page in a keyset-paginated listing. It
+// records the sort order it was issued for, the sort key values of that row and its ID.
+type Cursor struct {
+	Sort   string    `json:"s"`
+	Values []string  `json:"v"`
+	ID     uuid.UUID `json:"id"`
+}
+
+// Encode returns the opaque string representation of the cursor handed out to clients
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor previously returned by Encode
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if cursor.ID == uuid.Nil {
+		return nil, fmt.Errorf("%w: missing row id", ErrInvalidCursor)
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Custom errors for employee operations
+var (
+	ErrEmployeeNotFound  = errors.New("employee not found")
+	ErrDuplicateEmployee = errors.New("employee with this abbreviation already exists")
+	ErrEmployeeInUse     = errors.New("employee still has computers assigned")
+)
+
+// EmployeePaginatedResult holds paginated employee query results
+type EmployeePaginatedResult struct {
+	Items      []model.Employee
+	TotalCount int
+}
+
+// EmployeeRepository is an interface for interacting with employee data.
+type EmployeeRepository interface {
+	CreateEmployee(ctx context.Context, employee model.Employee) error
+	GetEmployeeByAbbreviation(ctx context.Context, abbreviation string) (*model.Employee, error)
+	// GetEmployeeByAbbreviationForUpdate retrieves an employee and locks it until the
+	// surrounding transaction ends, serializing the changes to the employee's computers.
+	GetEmployeeByAbbreviationForUpdate(ctx context.Context, abbreviation string) (*model.Employee, error)
+	GetAllEmployeesPaginated(ctx context.Context, params PaginationParams) (*EmployeePaginatedResult, error)
+	UpdateEmployee(ctx context.Context, abbreviation string, employee model.Employee) error
+	DeleteEmployee(ctx context.Context, abbreviation string) error
+}
+
+// employeeRepository is the concrete implementation of the EmployeeRepository interface.
+type employeeRepository struct {
+	DB DBTX
+}
+
+// NewEmployeeRepository creates a new EmployeeRepository.
+func NewEmployeeRepository(db *sql.DB) EmployeeRepository {
+	return &employeeRepository{DB: db}
+}
+
+// CreateEmployee adds a new employee to the database.
+func (r *employeeRepository) CreateEmployee(ctx context.Context, employee model.Employee) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO employees (abbreviation, name, email, department, active)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.DB.ExecContext(ctx, query,
+		employee.Abbreviation,
+		employee.Name,
+		employee.Email,
+		employee.Department,
+		employee.Active,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			return fmt.Errorf("%w: %s", ErrDuplicateEmployee, employee.Abbreviation)
+		}
+		return fmt.Errorf("failed to create employee: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmployeeByAbbreviation retrieves a single employee by abbreviation.
+func (r *employeeRepository) GetEmployeeByAbbreviation(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT abbreviation, name, email, department, active, deactivated_at, created_at, updated_at
+		FROM employees
+		WHERE abbreviation = $1`
+
+	e, err := scanEmployee(r.DB.QueryRowContext(ctx, query, abbreviation))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+	return &e, nil
+}
+
+// GetEmployeeByAbbreviationForUpdate retrieves a single employee by abbreviation and locks its
+// row until the surrounding transaction ends. FOR NO KEY UPDATE does not conflict with the key
+// share locks taken by computers referencing the employee, so transactions that assigned a
+// computer can still lock it.
+func (r *employeeRepository) GetEmployeeByAbbreviationForUpdate(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT abbreviation, name, email, department, active, deactivated_at, created_at, updated_at
+		FROM employees
+		WHERE abbreviation = $1
+		FOR NO KEY UPDATE`
+
+	e, err := scanEmployee(r.DB.QueryRowContext(ctx, query, abbreviation))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+	return &e, nil
+}
+
+// GetAllEmployeesPaginated retrieves all employees with pagination support.
+func (r *employeeRepository) GetAllEmployeesPaginated(ctx context.Context, params PaginationParams) (*EmployeePaginatedResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT abbreviation, name, email, department, active, deactivated_at, created_at, updated_at
+		FROM employees
+		ORDER BY abbreviation
+		OFFSET $1 LIMIT $2`
+
+	rows, err := r.DB.QueryContext(ctx, query, params.Offset, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query employees: %w", err)
+	}
+	defer rows.Close()
+
+	var employees []model.Employee
+	for rows.Next() {
+		e, err := scanEmployee(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee: %w", err)
+		}
+		employees = append(employees, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM employees`
+	if err := r.DB.QueryRowContext(ctx, countQuery).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to get total count of employees: %w", err)
+	}
+
+	return &EmployeePaginatedResult{
+		Items:      employees,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// UpdateEmployee updates an employee. Deactivating an employee records the time they left,
+// reactivating clears it.
+func (r *employeeRepository) UpdateEmployee(ctx context.Context, abbreviation string, employee model.Employee) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE employees
+		SET name = $1, email = $2, department = $3, active = $4,
+			deactivated_at = CASE WHEN $4 THEN NULL ELSE COALESCE(deactivated_at, CURRENT_TIMESTAMP) END
+		WHERE abbreviation = $5`
+
+	result, err := r.DB.ExecContext(ctx, query,
+		employee.Name,
+		employee.Email,
+		employee.Department,
+		employee.Active,
+		abbreviation,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update employee: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrEmployeeNotFound
+	}
+
+	return nil
+}
+
+// DeleteEmployee deletes an employee. Employees that still have computers assigned cannot be deleted.
+func (r *employeeRepository) DeleteEmployee(ctx context.Context, abbreviation string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `DELETE FROM employees WHERE abbreviation = $1`
+
+	result, err := r.DB.ExecContext(ctx, query, abbreviation)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("%w: %s", ErrEmployeeInUse, abbreviation)
+		}
+		return fmt.Errorf("failed to delete employee: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrEmployeeNotFound
+	}
+
+	return nil
+}
+
+// scanEmployee scans an employee row
+func scanEmployee(scanner rowScanner) (model.Employee, error) {
+	var e model.Employee
+	var deactivatedAt sql.NullTime
+	if err := scanner.Scan(&e.Abbreviation, &e.Name, &e.Email, &e.Department, &e.Active, &deactivatedAt, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		return model.Employee{}, err
+	}
+	if deactivatedAt.Valid {
+		e.DeactivatedAt = &deactivatedAt.Time
+	}
+	return e, nil
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrEventNotFound is returned when a computer has no audit event of the requested type
+var ErrEventNotFound = errors.New("computer event not found")
+
+// EventFilter narrows a search of the audit trail across all computers. Zero values do not filter.
+type EventFilter struct {
+	MACAddress string    // Normalized MAC address the events were recorded under
+	From       time.Time // Events that occurred at or after this time
+	To         time.Time // Events that occurred at or before this time
+}
+
+// EventPaginatedResult holds paginated audit event query results
+type EventPaginatedResult struct {
+	Items      []model.ComputerEvent
+	TotalCount int
+}
+
+// EventRepository is an interface for the append-only computer audit trail.
+type EventRepository interface {
+	RecordEvent(ctx context.Context, event model.ComputerEvent) error
+	GetEventsByComputer(ctx context.Context, computerID uuid.UUID, params PaginationParams) (*EventPaginatedResult, error)
+	GetEventsByEmployee(ctx context.Context, employeeAbbreviation string, params PaginationParams) (*EventPaginatedResult, error)
+	// SearchEvents retrieves the events matching a filter, newest first, including those of
+	// deleted computers.
+	SearchEvents(ctx context.Context, filter EventFilter, params PaginationParams) (*EventPaginatedResult, error)
+	// GetLatestEvent retrieves the most recent event of a type recorded for a computer.
+	GetLatestEvent(ctx context.Context, computerID uuid.UUID, eventType model.ComputerEventType) (*model.ComputerEvent, error)
+	// GetLatestEventByType retrieves the most recent event of a type recorded for any computer.
+	GetLatestEventByType(ctx context.Context, eventType model.ComputerEventType) (*model.ComputerEvent, error)
+}
+
+// eventRepository is the concrete implementation of the EventRepository interface.
+type eventRepository struct {
+	DB DBTX
+}
+
+// NewEventRepository creates a new EventRepository.
+func NewEventRepository(db *sql.DB) EventRepository {
+	return &eventRepository{DB: db}
+}
+
+// RecordEvent appends an event to the audit trail.
+func (r *eventRepository) RecordEvent(ctx context.Context, event model.ComputerEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	oldValue, err := marshalSnapshot(event.OldValue)
+	if err != nil {
+		return fmt.Errorf("failed to encode old value: %w", err)
+	}
+	newValue, err := marshalSnapshot(event.NewValue)
+	if err != nil {
+		return fmt.Errorf("failed to encode new value: %w", err)
+	}
+
+	query := `
+		INSERT INTO computer_events (computer_id, event_type, actor, mac_address, old_mac_address, old_employee_abbreviation, new_employee_abbreviation, old_value, new_value)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9)`
+
+	_, err = r.DB.ExecContext(ctx, query,
+		event.ComputerID,
+		string(event.EventType),
+		event.Actor,
+		event.MACAddress,
+		event.OldMACAddress,
+		event.OldEmployeeAbbreviation,
+		event.NewEmployeeAbbreviation,
+		oldValue,
+		newValue,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record computer event: %w", err)
+	}
+
+	return nil
+}
+
+// GetEventsByComputer retrieves the history of a computer, newest first. History outlives
+// the computer itself, so events are returned for deleted computers as well.
+func (r *eventRepository) GetEventsByComputer(ctx context.Context, computerID uuid.UUID, params PaginationParams) (*EventPaginatedResult, error) {
+	return r.queryEvents(ctx, `WHERE computer_id = $1`, []interface{}{computerID}, params)
+}
+
+// GetEventsByEmployee retrieves every event in which a computer was handed to or taken
+// from the employee, newest first. Other changes to the employee's computers, such as renames
+// and wake-ups, leave the assignment as it was and are not returned.
+func (r *eventRepository) GetEventsByEmployee(ctx context.Context, employeeAbbreviation string, params PaginationParams) (*EventPaginatedResult, error) {
+	return r.queryEvents(ctx, `
+		WHERE (old_employee_abbreviation = $1 OR new_employee_abbreviation = $1)
+		AND old_employee_abbreviation IS DISTINCT FROM new_employee_abbreviation`, []interface{}{employeeAbbreviation}, params)
+}
+
+// SearchEvents retrieves the events matching a filter, newest first, including those of deleted
+// computers. Events of a MAC address match it before or after the change, which finds the change
+// that moved a computer off the address; both columns are indexed.
+func (r *eventRepository) SearchEvents(ctx context.Context, filter EventFilter, params PaginationParams) (*EventPaginatedResult, error) {
+	where, args := filter.whereClause(nil)
+	return r.queryEvents(ctx, where, args, params)
+}
+
+// whereClause translates the filter into a parameterized WHERE clause. Placeholders are numbered
+// after the given args, which are returned with the filter's values appended.
+func (f EventFilter) whereClause(args []interface{}) (string, []interface{}) {
+	var conditions []string
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if f.MACAddress != "" {
+		add("(mac_address = $%[1]d OR old_mac_address = $%[1]d)", f.MACAddress)
+	}
+	if !f.From.IsZero() {
+		add("occurred_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("occurred_at <= $%d", f.To)
+	}
+	return joinConditions(conditions), args
+}
+
+// GetLatestEvent retrieves the most recent event of a type recorded for a computer.
+func (r *eventRepository) GetLatestEvent(ctx context.Context, computerID uuid.UUID, eventType model.ComputerEventType) (*model.ComputerEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + eventColumns + `
+		FROM computer_events
+		WHERE computer_id = $1 AND event_type = $2
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT 1`
+
+	e, err := scanEvent(r.DB.QueryRowContext(ctx, query, computerID, string(eventType)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to get computer event: %w", err)
+	}
+	return &e, nil
+}
+
+// GetLatestEventByType retrieves the most recent event of a type recorded for any computer.
+func (r *eventRepository) GetLatestEventByType(ctx context.Context, eventType model.ComputerEventType) (*model.ComputerEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + eventColumns + `
+		FROM computer_events
+		WHERE event_type = $1
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT 1`
+
+	e, err := scanEvent(r.DB.QueryRowContext(ctx, query, string(eventType)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to get computer event: %w", err)
+	}
+	return &e, nil
+}
+
+// queryEvents runs a paginated query over computer_events using a WHERE clause over args
+func (r *eventRepository) queryEvents(ctx context.Context, where string, args []interface{}, params PaginationParams) (*EventPaginatedResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := fmt.Sprintf(`
+		SELECT `+eventColumns+`
+		FROM computer_events
+		%s
+		ORDER BY occurred_at DESC, id DESC
+		OFFSET $%d LIMIT $%d`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(args, params.Offset, params.Limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query computer events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []model.ComputerEvent{}
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan computer event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM computer_events ` + where
+	if err := r.DB.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to get total count of computer events: %w", err)
+	}
+
+	return &EventPaginatedResult{
+		Items:      events,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// eventColumns lists the computer_events columns in the order scanEvent reads them
+const eventColumns = `id, computer_id, event_type, actor, mac_address, old_mac_address, old_employee_abbreviation, new_employee_abbreviation, old_value, new_value, occurred_at`
+
+// scanEvent scans an audit event row
+func scanEvent(scanner rowScanner) (model.ComputerEvent, error) {
+	var e model.ComputerEvent
+	var eventType string
+	var oldMAC, oldEmployee, newEmployee sql.NullString
+	var oldValue, newValue []byte
+	if err := scanner.Scan(&e.ID, &e.ComputerID, &eventType, &e.Actor, &e.MACAddress, &oldMAC, &oldEmployee, &newEmployee, &oldValue, &newValue, &e.OccurredAt); err != nil {
+		return model.ComputerEvent{}, err
+	}
+	e.EventType = model.ComputerEventType(eventType)
+	e.OldMACAddress = oldMAC.String
+	e.OldEmployeeAbbreviation = oldEmployee.String
+	e.NewEmployeeAbbreviation = newEmployee.String
+
+	var err error
+	if e.OldValue, err = unmarshalSnapshot(oldValue); err != nil {
+		return model.ComputerEvent{}, err
+	}
+	if e.NewValue, err = unmarshalSnapshot(newValue); err != nil {
+		return model.ComputerEvent{}, err
+	}
+	return e, nil
+}
+
+// marshalSnapshot encodes a computer snapshot for a JSONB column, mapping nil to NULL
+func marshalSnapshot(computer *model.Computer) (interface{}, error) {
+	if computer == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(computer)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// unmarshalSnapshot decodes a JSONB computer snapshot, mapping NULL to nil
+func unmarshalSnapshot(data []byte) (*model.Computer, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var computer model.Computer
+	if err := json.Unmarshal(data, &computer); err != nil {
+		return nil, fmt.Errorf("failed to decode computer snapshot: %w", err)
+	}
+	return &computer, nil
+}
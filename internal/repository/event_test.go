@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordEvent_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewEventRepository(db)
+	computerID := uuid.New()
+	event := model.ComputerEvent{
+		ComputerID:              computerID,
+		EventType:               model.ComputerEventUnassigned,
+		Actor:                   "jane.admin",
+		MACAddress:              "AA:BB:CC:DD:EE:FF",
+		OldMACAddress:           "AA:BB:CC:DD:EE:FF",
+		OldEmployeeAbbreviation: "ABC",
+		OldValue:                &model.Computer{ID: computerID, MACAddress: "AA:BB:CC:DD:EE:FF", EmployeeAbbreviation: "ABC"},
+		NewValue:                &model.Computer{ID: computerID, MACAddress: "AA:BB:CC:DD:EE:FF"},
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO computer_events (computer_id, event_type, actor, mac_address, old_mac_address, old_employee_abbreviation, new_employee_abbreviation, old_value, new_value) VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9)`)).
+		WithArgs(computerID, "unassigned", "jane.admin", "AA:BB:CC:DD:EE:FF", "AA:BB:CC:DD:EE:FF", "ABC", "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.RecordEvent(context.Background(), event)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetEventsByComputer_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewEventRepository(db)
+	computerID := uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "computer_id", "event_type", "actor", "mac_address", "old_mac_address", "old_employee_abbreviation", "new_employee_abbreviation", "old_value", "new_value", "occurred_at"}).
+		AddRow(2, computerID, "deleted", "jane.admin", "AA:BB:CC:DD:EE:FF", "AA:BB:CC:DD:EE:FF", "ABC", nil, []byte(`{"id":"`+computerID.String()+`","employee_abbreviation":"ABC"}`), nil, now).
+		AddRow(1, computerID, "created", "anonymous", "AA:BB:CC:DD:EE:FF", nil, nil, "ABC", nil, []byte(`{"id":"`+computerID.String()+`","employee_abbreviation":"ABC"}`), now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, computer_id, event_type, actor, mac_address, old_mac_address, old_employee_abbreviation, new_employee_abbreviation, old_value, new_value, occurred_at FROM computer_events WHERE computer_id = $1 ORDER BY occurred_at DESC, id DESC OFFSET $2 LIMIT $3`)).
+		WithArgs(computerID, 0, 10).
+		WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM computer_events WHERE computer_id = $1`)).
+		WithArgs(computerID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	result, err := repo.GetEventsByComputer(context.Background(), computerID, PaginationParams{Offset: 0, Limit: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.TotalCount)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, model.ComputerEventDeleted, result.Items[0].EventType)
+	assert.Equal(t, "ABC", result.Items[0].OldEmployeeAbbreviation)
+	assert.Nil(t, result.Items[0].NewValue)
+	require.NotNil(t, result.Items[1].NewValue)
+	assert.Equal(t, "ABC", result.Items[1].NewValue.EmployeeAbbreviation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetEventsByEmployee_OnlyAssignmentChanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewEventRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE (old_employee_abbreviation = $1 OR new_employee_abbreviation = $1) AND old_employee_abbreviation IS DISTINCT FROM new_employee_abbreviation ORDER BY`)).
+		WithArgs("ABC", 0, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "computer_id", "event_type", "actor", "mac_address", "old_mac_address", "old_employee_abbreviation", "new_employee_abbreviation", "old_value", "new_value", "occurred_at"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM computer_events WHERE (old_employee_abbreviation = $1 OR new_employee_abbreviation = $1) AND old_employee_abbreviation IS DISTINCT FROM new_employee_abbreviation`)).
+		WithArgs("ABC").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	result, err := repo.GetEventsByEmployee(context.Background(), "ABC", PaginationParams{Offset: 0, Limit: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.TotalCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewEventRepository(db)
+	computerID := uuid.New()
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM computer_events WHERE (mac_address = $1 OR old_mac_address = $1) AND occurred_at <= $2 ORDER BY occurred_at DESC, id DESC OFFSET $3 LIMIT $4`)).
+		WithArgs("AA:BB:CC:DD:EE:FF", at, 0, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "computer_id", "event_type", "actor", "mac_address", "old_mac_address", "old_employee_abbreviation", "new_employee_abbreviation", "old_value", "new_value", "occurred_at"}).
+			AddRow(4, computerID, "assigned", "jane.admin", "AA:BB:CC:DD:EE:FF", "AA:BB:CC:DD:EE:FF", nil, "ABC", nil, nil, at.Add(-time.Hour)))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM computer_events WHERE (mac_address = $1 OR old_mac_address = $1) AND occurred_at <= $2`)).
+		WithArgs("AA:BB:CC:DD:EE:FF", at).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	result, err := repo.SearchEvents(context.Background(), EventFilter{MACAddress: "AA:BB:CC:DD:EE:FF", To: at}, PaginationParams{Offset: 0, Limit: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.TotalCount)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "ABC", result.Items[0].NewEmployeeAbbreviation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLatestEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewEventRepository(db)
+	computerID := uuid.New()
+	now := time.Now()
+
+	query := regexp.QuoteMeta(`FROM computer_events WHERE computer_id = $1 AND event_type = $2 ORDER BY occurred_at DESC, id DESC LIMIT 1`)
+	mock.ExpectQuery(query).
+		WithArgs(computerID, "woken").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "computer_id", "event_type", "actor", "mac_address", "old_mac_address", "old_employee_abbreviation", "new_employee_abbreviation", "old_value", "new_value", "occurred_at"}).
+			AddRow(7, computerID, "woken", "helpdesk", "AA:BB:CC:DD:EE:FF", nil, nil, "ABC", nil, nil, now))
+	mock.ExpectQuery(query).
+		WithArgs(computerID, "deleted").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	event, err := repo.GetLatestEvent(context.Background(), computerID, model.ComputerEventWoken)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), event.ID)
+	assert.Equal(t, "helpdesk", event.Actor)
+	assert.True(t, now.Equal(event.OccurredAt))
+
+	_, err = repo.GetLatestEvent(context.Background(), computerID, model.ComputerEventDeleted)
+	assert.ErrorIs(t, err, ErrEventNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLatestEventByType(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewEventRepository(db)
+	computerID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM computer_events WHERE event_type = $1 ORDER BY occurred_at DESC, id DESC LIMIT 1`)).
+		WithArgs("deleted").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "computer_id", "event_type", "actor", "mac_address", "old_mac_address", "old_employee_abbreviation", "new_employee_abbreviation", "old_value", "new_value", "occurred_at"}).
+			AddRow(9, computerID, "deleted", "jane.admin", "AA:BB:CC:DD:EE:FF", "AA:BB:CC:DD:EE:FF", "ABC", nil, nil, nil, now))
+
+	event, err := repo.GetLatestEventByType(context.Background(), model.ComputerEventDeleted)
+
+	require.NoError(t, err)
+	assert.Equal(t, computerID, event.ComputerID)
+	assert.True(t, now.Equal(event.OccurredAt))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithinTransaction_RollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM computers WHERE id = $1`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO computer_events`)).
+		WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	computerID := uuid.New()
+	err = NewTransactor(db).WithinTransaction(context.Background(), func(repos Repositories) error {
+		if err := repos.Computers.DeleteComputer(context.Background(), computerID); err != nil {
+			return err
+		}
+		return repos.Events.RecordEvent(context.Background(), model.ComputerEvent{ComputerID: computerID, EventType: model.ComputerEventDeleted})
+	})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWithinTransaction_Commits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO computer_events`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = NewTransactor(db).WithinTransaction(context.Background(), func(repos Repositories) error {
+		return repos.Events.RecordEvent(context.Background(), model.ComputerEvent{ComputerID: uuid.New(), EventType: model.ComputerEventCreated})
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
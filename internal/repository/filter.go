@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrInvalidSortField is returned when a listing is sorted by a field that cannot be sorted on
+var ErrInvalidSortField = errors.New("invalid sort field")
+
+// SortField orders listing results by a single field
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// ComputerFilter narrows and orders a computer listing. Zero values do not filter.
+type ComputerFilter struct {
+	MACPrefix            string     // Normalized MAC address prefix, e.g. "00:1B:44"
+	MACPrefixes          []string   // Any of these normalized MAC address prefixes; an empty, non-nil list matches nothing
+	Vendor               string     // Organization the MAC address is registered to, resolved into MACPrefixes by the service
+	Subnet               *net.IPNet // IP addresses within this network
+	IPIn                 *net.IPNet // Computers with an address within this network, their own or one of their interfaces'
+	EmployeeAbbreviation string
+	Assigned             *bool // Only assigned (true) or unassigned (false) computers
+	CreatedAfter         time.Time
+	CreatedBefore        time.Time
+	UpdatedAfter         time.Time
+	UpdatedBefore        time.Time
+	Query                string // Full-text search across computer name and description
+	Sort                 []SortField
+}
+
+// sortColumn describes how computers are ordered by a sortable field
+type sortColumn struct {
+	expression string                        // SQL expression rows are ordered by
+	cast       string                        // Cast applied to cursor values compared with the expression
+	value      func(c model.Computer) string // Value of the field for a row, as stored in cursors
+}
+
+// computerSortColumns maps the fields computers can be sorted by to their SQL expressions. The
+// employee abbreviation is coalesced so unassigned computers can be compared in keyset pagination.
+var computerSortColumns = map[string]sortColumn{
+	"computer_name": {
+		expression: "computer_name",
+		value:      func(c model.Computer) string { return c.ComputerName },
+	},
+	"mac_address": {
+		expression: "mac_address",
+		value:      func(c model.Computer) string { return c.MACAddress },
+	},
+	"ip_address": {
+		expression: "ip_address",
+		cast:       "::inet",
+		value:      func(c model.Computer) string { return c.IPAddress },
+	},
+	"employee_abbreviation": {
+		expression: "COALESCE(employee_abbreviation, '')",
+		value:      func(c model.Computer) string { return c.EmployeeAbbreviation },
+	},
+	"created_at": {
+		expression: "created_at",
+		cast:       "::timestamptz",
+		value:      func(c model.Computer) string { return c.CreatedAt.Format(time.RFC3339Nano) },
+	},
+	"updated_at": {
+		expression: "updated_at",
+		cast:       "::timestamptz",
+		value:      func(c model.Computer) string { return c.UpdatedAt.Format(time.RFC3339Nano) },
+	},
+}
+
+// computerSearchVector is the text search document of a computer. It must match the expression
+// of the idx_computers_search index for searches to use it.
+const computerSearchVector = `to_tsvector('simple', computer_name || ' ' || COALESCE(description, ''))`
+
+// ParseComputerSort parses a comma-separated list of sort fields, each optionally prefixed with
+// "-" for descending order, e.g. "-created_at,computer_name"
+func ParseComputerSort(value string) ([]SortField, error) {
+	var fields []SortField
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := SortField{Field: strings.TrimPrefix(part, "-"), Descending: strings.HasPrefix(part, "-")}
+		if _, ok := computerSortColumns[field.Field]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSortField, field.Field)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// whereClause translates the filter into a parameterized WHERE clause. Placeholders are numbered
+// after the given args, which are returned with the filter's values appended.
+func (f ComputerFilter) whereClause(args []interface{}) (string, []interface{}) {
+	conditions, args := f.conditions(args)
+	return joinConditions(conditions), args
+}
+
+// conditions translates the filter into parameterized SQL conditions
+func (f ComputerFilter) conditions(args []interface{}) ([]string, []interface{}) {
+	var conditions []string
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if f.MACPrefix != "" {
+		add("mac_address LIKE $%d", escapeLike(f.MACPrefix)+"%")
+	}
+	if f.MACPrefixes != nil {
+		patterns := make([]string, len(f.MACPrefixes))
+		for i, prefix := range f.MACPrefixes {
+			patterns[i] = escapeLike(prefix) + "%"
+		}
+		add("mac_address LIKE ANY($%d)", pq.Array(patterns))
+	}
+	if f.Subnet != nil {
+		add("ip_address <<= $%d::inet", f.Subnet.String())
+	}
+	if f.IPIn != nil {
+		add("(ip_address <<= $%[1]d::inet OR EXISTS (SELECT 1 FROM network_interfaces ni WHERE ni.computer_id = computers.id"+
+			" AND (ni.ipv4_address <<= $%[1]d::inet OR ni.ipv6_address <<= $%[1]d::inet)))", f.IPIn.String())
+	}
+	if f.EmployeeAbbreviation != "" {
+		add("employee_abbreviation = $%d", f.EmployeeAbbreviation)
+	}
+	if f.Assigned != nil {
+		if *f.Assigned {
+			conditions = append(conditions, "employee_abbreviation IS NOT NULL")
+		} else {
+			conditions = append(conditions, "employee_abbreviation IS NULL")
+		}
+	}
+	if !f.CreatedAfter.IsZero() {
+		add("created_at >= $%d", f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		add("created_at < $%d", f.CreatedBefore)
+	}
+	if !f.UpdatedAfter.IsZero() {
+		add("updated_at >= $%d", f.UpdatedAfter)
+	}
+	if !f.UpdatedBefore.IsZero() {
+		add("updated_at < $%d", f.UpdatedBefore)
+	}
+	if f.Query != "" {
+		add(computerSearchVector+" @@ websearch_to_tsquery('simple', $%d)", f.Query)
+	}
+
+	return conditions, args
+}
+
+// sortFields returns the fields the listing is sorted by. Without sort fields computers are
+// sorted by name.
+func (f ComputerFilter) sortFields() []SortField {
+	if len(f.Sort) == 0 {
+		return []SortField{{Field: "computer_name"}}
+	}
+	return f.Sort
+}
+
+// sortKey returns the canonical form of the sort order, e.g. "-created_at,computer_name".
+// Cursors record it so they are only used with the order they were issued for.
+func (f ComputerFilter) sortKey() string {
+	sort := f.sortFields()
+	parts := make([]string, 0, len(sort))
+	for _, field := range sort {
+		if field.Descending {
+			parts = append(parts, "-"+field.Field)
+		} else {
+			parts = append(parts, field.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// orderByClause translates the sort fields into an ORDER BY clause. Only whitelisted columns are
+// used and the ID breaks ties so pages are stable.
+func (f ComputerFilter) orderByClause() (string, error) {
+	sort := f.sortFields()
+
+	terms := make([]string, 0, len(sort)+1)
+	for _, field := range sort {
+		column, ok := computerSortColumns[field.Field]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrInvalidSortField, field.Field)
+		}
+		term := column.expression
+		if field.Descending {
+			term += " DESC"
+		}
+		terms = append(terms, term)
+	}
+	terms = append(terms, "id")
+
+	return "ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// cursorFor returns the cursor positioned after the given row in the filter's sort order
+func (f ComputerFilter) cursorFor(c model.Computer) *Cursor {
+	sort := f.sortFields()
+	values := make([]string, 0, len(sort))
+	for _, field := range sort {
+		values = append(values, computerSortColumns[field.Field].value(c))
+	}
+	return &Cursor{Sort: f.sortKey(), Values: values, ID: c.ID}
+}
+
+// keysetCondition translates a cursor into a condition matching the rows after it in the filter's
+// sort order. For a sort by (a, b) and the ID it expands to
+// (a > $1) OR (a = $1 AND b > $2) OR (a = $1 AND b = $2 AND id > $3), with < for descending fields.
+func (f ComputerFilter) keysetCondition(cursor *Cursor, args []interface{}) (string, []interface{}, error) {
+	sort := f.sortFields()
+	if cursor.Sort != f.sortKey() || len(cursor.Values) != len(sort) {
+		return "", nil, fmt.Errorf("%w: issued for a different sort order", ErrInvalidCursor)
+	}
+
+	type key struct {
+		expression  string
+		operator    string
+		placeholder string
+	}
+	keys := make([]key, 0, len(sort)+1)
+	for i, field := range sort {
+		column, ok := computerSortColumns[field.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s", ErrInvalidSortField, field.Field)
+		}
+		operator := ">"
+		if field.Descending {
+			operator = "<"
+		}
+		args = append(args, cursor.Values[i])
+		keys = append(keys, key{column.expression, operator, fmt.Sprintf("$%d%s", len(args), column.cast)})
+	}
+	args = append(args, cursor.ID.String())
+	keys = append(keys, key{"id", ">", fmt.Sprintf("$%d::uuid", len(args))})
+
+	alternatives := make([]string, 0, len(keys))
+	for i, k := range keys {
+		terms := make([]string, 0, i+1)
+		for _, previous := range keys[:i] {
+			terms = append(terms, previous.expression+" = "+previous.placeholder)
+		}
+		terms = append(terms, k.expression+" "+k.operator+" "+k.placeholder)
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args, nil
+}
+
+// joinConditions combines conditions into a WHERE clause, or returns an empty string if there are none
+func joinConditions(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// escapeLike escapes the LIKE wildcards in a literal pattern prefix
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
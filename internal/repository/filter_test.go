@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputerFilter_WhereClause(t *testing.T) {
+	_, subnet, _ := net.ParseCIDR("10.1.0.0/16")
+	assigned := false
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   ComputerFilter
+		expected string
+		args     []interface{}
+	}{
+		{
+			name:     "no filter",
+			filter:   ComputerFilter{},
+			expected: "",
+		},
+		{
+			name:     "mac prefix",
+			filter:   ComputerFilter{MACPrefix: "00:1B"},
+			expected: "WHERE mac_address LIKE $1",
+			args:     []interface{}{"00:1B%"},
+		},
+		{
+			name:     "like wildcards are escaped",
+			filter:   ComputerFilter{MACPrefix: "A_%"},
+			expected: "WHERE mac_address LIKE $1",
+			args:     []interface{}{`A\_\%%`},
+		},
+		{
+			name:     "any of several mac prefixes",
+			filter:   ComputerFilter{MACPrefixes: []string{"00:1B:44", "70:B3:D5:1"}},
+			expected: "WHERE mac_address LIKE ANY($1)",
+			args:     []interface{}{pq.Array([]string{"00:1B:44%", "70:B3:D5:1%"})},
+		},
+		{
+			name:     "subnet and unassigned",
+			filter:   ComputerFilter{Subnet: subnet, Assigned: &assigned},
+			expected: "WHERE ip_address <<= $1::inet AND employee_abbreviation IS NULL",
+			args:     []interface{}{"10.1.0.0/16"},
+		},
+		{
+			name:   "addresses in network",
+			filter: ComputerFilter{IPIn: subnet},
+			expected: "WHERE (ip_address <<= $1::inet OR EXISTS (SELECT 1 FROM network_interfaces ni WHERE ni.computer_id = computers.id" +
+				" AND (ni.ipv4_address <<= $1::inet OR ni.ipv6_address <<= $1::inet)))",
+			args: []interface{}{"10.1.0.0/16"},
+		},
+		{
+			name:     "employee, date range and search",
+			filter:   ComputerFilter{EmployeeAbbreviation: "ABC", CreatedAfter: after, Query: "dell laptop"},
+			expected: "WHERE employee_abbreviation = $1 AND created_at >= $2 AND " + computerSearchVector + " @@ websearch_to_tsquery('simple', $3)",
+			args:     []interface{}{"ABC", after, "dell laptop"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := tt.filter.whereClause(nil)
+			assert.Equal(t, tt.expected, where)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestParseComputerSort(t *testing.T) {
+	sort, err := ParseComputerSort("-created_at, computer_name,")
+	require.NoError(t, err)
+	assert.Equal(t, []SortField{{Field: "created_at", Descending: true}, {Field: "computer_name"}}, sort)
+
+	orderBy, err := ComputerFilter{Sort: sort}.orderByClause()
+	require.NoError(t, err)
+	assert.Equal(t, "ORDER BY created_at DESC, computer_name, id", orderBy)
+
+	orderBy, err = ComputerFilter{}.orderByClause()
+	require.NoError(t, err)
+	assert.Equal(t, "ORDER BY computer_name, id", orderBy)
+
+	_, err = ParseComputerSort("computer_name; DROP TABLE computers")
+	assert.True(t, errors.Is(err, ErrInvalidSortField))
+
+	_, err = ComputerFilter{Sort: []SortField{{Field: "description"}}}.orderByClause()
+	assert.True(t, errors.Is(err, ErrInvalidSortField))
+}
+
+func TestGetAllComputersPaginated_Filtered(t *testing.T) {
+	db, mock, repo := setupTestDB(t)
+	defer db.Close()
+
+	assigned := true
+	filter := ComputerFilter{
+		MACPrefix: "00:1B",
+		Assigned:  &assigned,
+		Sort:      []SortField{{Field: "ip_address", Descending: true}},
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"})
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE mac_address LIKE $1 AND employee_abbreviation IS NOT NULL ORDER BY ip_address DESC, id OFFSET $2 LIMIT $3`)).
+		WithArgs("00:1B%", 20, 11).
+		WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM computers WHERE mac_address LIKE $1 AND employee_abbreviation IS NOT NULL`)).
+		WithArgs("00:1B%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	result, err := repo.GetAllComputersPaginated(context.Background(), filter, PaginationParams{Offset: 20, Limit: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.TotalCount)
+	assert.Nil(t, result.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	cursor := Cursor{Sort: "-created_at", Values: []string{"2024-01-01T00:00:00Z"}, ID: uuid.New()}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, *decoded)
+
+	for _, value := range []string{"not base64!", "bm90IGpzb24", cursor.Encode()[:10]} {
+		_, err := DecodeCursor(value)
+		assert.True(t, errors.Is(err, ErrInvalidCursor), value)
+	}
+}
+
+func TestComputerFilter_KeysetCondition(t *testing.T) {
+	id := uuid.New()
+	filter := ComputerFilter{Sort: []SortField{{Field: "ip_address", Descending: true}, {Field: "computer_name"}}}
+
+	condition, args, err := filter.keysetCondition(&Cursor{Sort: "-ip_address,computer_name", Values: []string{"10.0.0.1", "PC"}, ID: id}, []interface{}{"ABC"})
+	require.NoError(t, err)
+	assert.Equal(t, "((ip_address < $2::inet) OR (ip_address = $2::inet AND computer_name > $3) OR "+
+		"(ip_address = $2::inet AND computer_name = $3 AND id > $4::uuid))", condition)
+	assert.Equal(t, []interface{}{"ABC", "10.0.0.1", "PC", id.String()}, args)
+
+	_, _, err = filter.keysetCondition(&Cursor{Sort: "computer_name", Values: []string{"PC"}, ID: id}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidCursor))
+}
+
+func TestGetAllComputersPaginated_Cursor(t *testing.T) {
+	db, mock, repo := setupTestDB(t)
+	defer db.Close()
+
+	after := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"}).
+		AddRow(first, "00:1B:44:11:3A:B7", "PC-B", "192.168.1.2", nil, "", 1, now, now).
+		AddRow(second, "00:1B:44:11:3A:B8", "PC-C", "192.168.1.3", nil, "", 1, now, now)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at FROM computers WHERE employee_abbreviation = $1 AND ((computer_name > $2) OR (computer_name = $2 AND id > $3::uuid)) ORDER BY computer_name, id OFFSET $4 LIMIT $5`)).
+		WithArgs("ABC", "PC-A", after.String(), 0, 2).
+		WillReturnRows(rows)
+
+	result, err := repo.GetComputersByEmployeePaginated(context.Background(), "ABC", PaginationParams{
+		Offset: 50,
+		Limit:  1,
+		After:  &Cursor{Sort: "computer_name", Values: []string{"PC-A"}, ID: after},
+		Count:  CountNone,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, first, result.Items[0].ID)
+	assert.Equal(t, UnknownTotalCount, result.TotalCount)
+	assert.Equal(t, &Cursor{Sort: "computer_name", Values: []string{"PC-B"}, ID: first}, result.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAllComputersPaginated_EstimatedCount(t *testing.T) {
+	db, mock, repo := setupTestDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM computers WHERE employee_abbreviation IS NULL ORDER BY computer_name, id OFFSET $1 LIMIT $2`)).
+		WithArgs(0, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`EXPLAIN (FORMAT JSON) SELECT 1 FROM computers WHERE employee_abbreviation IS NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 1234}}]`))
+
+	assigned := false
+	result, err := repo.GetAllComputersPaginated(context.Background(), ComputerFilter{Assigned: &assigned}, PaginationParams{Limit: 10, Count: CountEstimated})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1234, result.TotalCount)
+	assert.True(t, result.TotalCountEstimated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamComputers(t *testing.T) {
+	db, mock, repo := setupTestDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "mac_address", "computer_name", "ip_address", "employee_abbreviation", "description", "version", "created_at", "updated_at"}).
+		AddRow(uuid.New(), "00:1B:44:11:3A:B7", "PC-A", "10.0.0.1", "ABC", "", 1, now, now).
+		AddRow(uuid.New(), "00:1B:44:11:3A:B8", "PC-B", "10.0.0.2", "ABC", "", 1, now, now).
+		AddRow(uuid.New(), "00:1B:44:11:3A:B9", "PC-C", "10.0.0.3", "ABC", "", 1, now, now)
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM computers WHERE employee_abbreviation = $1 ORDER BY created_at DESC, id`)).
+		WithArgs("ABC").
+		WillReturnRows(rows)
+
+	stop := errors.New("stop")
+	var names []string
+	err := repo.StreamComputers(context.Background(), ComputerFilter{EmployeeAbbreviation: "ABC", Sort: []SortField{{Field: "created_at", Descending: true}}},
+		func(c model.Computer) error {
+			names = append(names, c.ComputerName)
+			if len(names) == 2 {
+				return stop
+			}
+			return nil
+		})
+
+	assert.True(t, errors.Is(err, stop))
+	assert.Equal(t, []string{"PC-A", "PC-B"}, names)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
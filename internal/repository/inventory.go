@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// InventoryRepository is an interface for summarizing the computer inventory.
+type InventoryRepository interface {
+	GetInventoryStats(ctx context.Context, defaultMaxComputers int) (*model.InventoryStats, error)
+}
+
+// inventoryRepository is the concrete implementation of the InventoryRepository interface.
+type inventoryRepository struct {
+	DB DBTX
+}
+
+// NewInventoryRepository creates a new InventoryRepository.
+func NewInventoryRepository(db *sql.DB) InventoryRepository {
+	return &inventoryRepository{DB: db}
+}
+
+// GetInventoryStats counts the computers, the assigned computers and the employees holding more
+// computers than their most specific quota policy allows. defaultMaxComputers applies to
+// employees without any policy.
+func (r *inventoryRepository) GetInventoryStats(ctx context.Context, defaultMaxComputers int) (*model.InventoryStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		WITH assigned AS (
+			SELECT employee_abbreviation, COUNT(*) AS computers
+			FROM computers
+			WHERE employee_abbreviation IS NOT NULL
+			GROUP BY employee_abbreviation
+		)
+		SELECT
+			(SELECT COUNT(*) FROM computers),
+			(SELECT COALESCE(SUM(computers), 0)::BIGINT FROM assigned),
+			(SELECT COUNT(*)
+			FROM assigned a
+			JOIN employees e ON e.abbreviation = a.employee_abbreviation
+			WHERE a.computers > COALESCE((
+				SELECT max_computers
+				FROM quota_policies
+				WHERE scope = 'global'
+					OR (scope = 'department' AND target = e.department)
+					OR (scope = 'employee' AND target = e.abbreviation)
+				ORDER BY ` + policySpecificityOrder + ` DESC
+				LIMIT 1
+			), $1))`
+
+	var stats model.InventoryStats
+	err := r.DB.QueryRowContext(ctx, query, defaultMaxComputers).
+		Scan(&stats.Computers, &stats.AssignedComputers, &stats.EmployeesOverQuota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory stats: %w", err)
+	}
+	return &stats, nil
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetInventoryStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewInventoryRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY ` + policySpecificityOrder + ` DESC LIMIT 1 ), $1))`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"computers", "assigned", "over_quota"}).AddRow(12, 7, 1))
+
+	stats, err := repo.GetInventoryStats(context.Background(), 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, 12, stats.Computers)
+	assert.Equal(t, 7, stats.AssignedComputers)
+	assert.Equal(t, 1, stats.EmployeesOverQuota)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
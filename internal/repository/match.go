@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The functions in this file evaluate computer filters and sort orders the way the PostgreSQL
+// queries do, for the backends that cannot express them in SQL.
+
+// inetKey returns a key that orders IP addresses like PostgreSQL's inet type: IPv4 before
+// IPv6, then numerically. It is nil for an invalid address.
+func inetKey(address string) []byte {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return append([]byte{4}, ip4...)
+	}
+	return append([]byte{6}, ip.To16()...)
+}
+
+// ipInSubnet reports whether address lies within the CIDR network subnet
+func ipInSubnet(address, subnet string) bool {
+	ip := net.ParseIP(address)
+	_, network, err := net.ParseCIDR(subnet)
+	if ip == nil || err != nil {
+		return false
+	}
+	return network.Contains(ip)
+}
+
+// networksOverlap reports whether two CIDR networks share any address, like PostgreSQL's &&
+func networksOverlap(a, b string) bool {
+	_, x, errA := net.ParseCIDR(a)
+	_, y, errB := net.ParseCIDR(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return x.Contains(y.IP) || y.Contains(x.IP)
+}
+
+// compareSortValues orders two values of a sort field, as stored in cursors, the way the
+// PostgreSQL expression of the field does. Text is compared byte-wise, which matches the C
+// collation.
+func compareSortValues(field, a, b string) int {
+	switch field {
+	case "ip_address":
+		return bytes.Compare(inetKey(a), inetKey(b))
+	case "created_at", "updated_at":
+		ta, _ := time.Parse(time.RFC3339Nano, a)
+		tb, _ := time.Parse(time.RFC3339Nano, b)
+		return ta.Compare(tb)
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// searchTerm is a word or a quoted phrase of a search query
+type searchTerm struct {
+	words   []string
+	negated bool
+}
+
+// searchMatches reports whether a computer's name and description match a full-text query,
+// approximating websearch_to_tsquery with the simple configuration: words match
+// case-insensitively, quoted words must follow each other, "or" separates alternatives and a
+// leading "-" excludes a word. A query without words matches nothing.
+func searchMatches(name, description, query string) bool {
+	document := searchWords(name + " " + description)
+
+	matched := false
+	for _, alternative := range parseSearchQuery(query) {
+		all := true
+		for _, term := range alternative {
+			if containsPhrase(document, term.words) == term.negated {
+				all = false
+				break
+			}
+		}
+		matched = matched || all
+	}
+	return matched
+}
+
+// parseSearchQuery splits a query into alternatives separated by "or", each a list of terms
+// that must all match
+func parseSearchQuery(query string) [][]searchTerm {
+	var alternatives [][]searchTerm
+	var current []searchTerm
+
+	for query = strings.TrimSpace(query); query != ""; query = strings.TrimSpace(query) {
+		var term searchTerm
+		if strings.HasPrefix(query, "-") {
+			term.negated = true
+			query = query[1:]
+		}
+
+		var token string
+		if strings.HasPrefix(query, `"`) {
+			end := strings.Index(query[1:], `"`)
+			if end < 0 {
+				token, query = query[1:], ""
+			} else {
+				token, query = query[1:end+1], query[end+2:]
+			}
+		} else {
+			end := strings.IndexFunc(query, unicode.IsSpace)
+			if end < 0 {
+				end = len(query)
+			}
+			token, query = query[:end], query[end:]
+			if !term.negated && strings.EqualFold(token, "or") {
+				if len(current) > 0 {
+					alternatives = append(alternatives, current)
+					current = nil
+				}
+				continue
+			}
+		}
+
+		if term.words = searchWords(token); len(term.words) > 0 {
+			current = append(current, term)
+		}
+	}
+
+	if len(current) > 0 {
+		alternatives = append(alternatives, current)
+	}
+	return alternatives
+}
+
+// searchWords splits text into lower-case words of letters and digits
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsPhrase reports whether words contains phrase as consecutive words
+func containsPhrase(words, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		found := true
+		for j, word := range phrase {
+			if words[i+j] != word {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
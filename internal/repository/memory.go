@@ -0,0 +1,777 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/pkg/validation"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore keeps computers, their network interfaces and employees in process memory. Its
+// repositories enforce the same constraints and return the same errors as the PostgreSQL ones:
+// MAC addresses unique across all interfaces, primary interfaces mirroring their computer,
+// computers referring to existing employees and employees that cannot be deleted while they
+// hold computers. It has no transactions, so it suits tests and tools rather than the server.
+type MemoryStore struct {
+	mu         sync.RWMutex
+	computers  map[uuid.UUID]model.Computer
+	interfaces map[uuid.UUID]model.NetworkInterface
+	employees  map[string]model.Employee
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		computers:  make(map[uuid.UUID]model.Computer),
+		interfaces: make(map[uuid.UUID]model.NetworkInterface),
+		employees:  make(map[string]model.Employee),
+	}
+}
+
+// Computers returns the ComputerRepository of the store
+func (s *MemoryStore) Computers() ComputerRepository {
+	return &memoryComputerRepository{store: s}
+}
+
+// Interfaces returns the NetworkInterfaceRepository of the store
+func (s *MemoryStore) Interfaces() NetworkInterfaceRepository {
+	return &memoryNetworkInterfaceRepository{store: s}
+}
+
+// Employees returns the EmployeeRepository of the store
+func (s *MemoryStore) Employees() EmployeeRepository {
+	return &memoryEmployeeRepository{store: s}
+}
+
+// memoryNow returns the current time with the microsecond precision of PostgreSQL timestamps
+func memoryNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// memoryComputerRepository is the in-memory implementation of the ComputerRepository interface.
+type memoryComputerRepository struct {
+	store *MemoryStore
+}
+
+// CreateComputer adds a new computer after validating and normalizing its MAC and IP address.
+func (r *memoryComputerRepository) CreateComputer(ctx context.Context, computer model.Computer) error {
+	normalizedMAC, err := validation.ValidateMAC(computer.MACAddress)
+	if err != nil {
+		return ErrInvalidMACFormat
+	}
+	computer.MACAddress = normalizedMAC
+
+	normalizedIP, err := validation.ValidateIP(computer.IPAddress)
+	if err != nil {
+		return fmt.Errorf("invalid IP address: %w", err)
+	}
+	computer.IPAddress = normalizedIP
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.computers[computer.ID]; ok || r.store.macTaken(computer.MACAddress, uuid.Nil) {
+		return fmt.Errorf("%w: %s", ErrDuplicateMAC, computer.MACAddress)
+	}
+	if !r.store.employeeExists(computer.EmployeeAbbreviation) {
+		return fmt.Errorf("%w: %s", ErrEmployeeNotFound, computer.EmployeeAbbreviation)
+	}
+
+	now := memoryNow()
+	computer.Version = 1
+	computer.CreatedAt = now
+	computer.UpdatedAt = now
+	r.store.computers[computer.ID] = computer
+
+	// A new computer starts with its primary interface
+	primary := model.NetworkInterface{
+		ID:         uuid.New(),
+		ComputerID: computer.ID,
+		MACAddress: computer.MACAddress,
+		Type:       model.NetworkInterfaceTypeEthernet,
+		Primary:    true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	setInterfaceAddress(&primary, computer.IPAddress)
+	r.store.interfaces[primary.ID] = primary
+
+	return nil
+}
+
+// GetAllComputers retrieves all computers ordered by name.
+func (r *memoryComputerRepository) GetAllComputers(ctx context.Context) ([]model.Computer, error) {
+	return r.list(func(model.Computer) bool { return true }), nil
+}
+
+// GetAllComputersPaginated retrieves the computers matching filter with pagination support.
+func (r *memoryComputerRepository) GetAllComputersPaginated(ctx context.Context, filter ComputerFilter, params PaginationParams) (*PaginatedResult, error) {
+	computers, err := r.filtered(filter)
+	if err != nil {
+		return nil, err
+	}
+	totalCount := len(computers)
+
+	offset := params.Offset
+	if params.After != nil {
+		if computers, err = afterCursor(computers, filter, params.After); err != nil {
+			return nil, err
+		}
+		offset = 0
+	}
+	if offset > len(computers) {
+		offset = len(computers)
+	}
+	computers = computers[offset:]
+
+	result := &PaginatedResult{Items: computers}
+	if len(computers) > params.Limit {
+		result.Items = computers[:params.Limit]
+		result.NextCursor = filter.cursorFor(result.Items[len(result.Items)-1])
+	}
+	if len(result.Items) == 0 {
+		result.Items = nil
+	}
+
+	// Counting is free in memory, so estimates are exact
+	result.TotalCount = totalCount
+	if params.Count == CountNone {
+		result.TotalCount = UnknownTotalCount
+	}
+	return result, nil
+}
+
+// StreamComputers calls fn for every computer matching filter, in the filter's sort order. The
+// matching computers are copied before fn is called, so fn may use the repository.
+func (r *memoryComputerRepository) StreamComputers(ctx context.Context, filter ComputerFilter, fn func(model.Computer) error) error {
+	computers, err := r.filtered(filter)
+	if err != nil {
+		return err
+	}
+	for _, c := range computers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetComputerByMAC retrieves a computer by its MAC address.
+func (r *memoryComputerRepository) GetComputerByMAC(ctx context.Context, macAddress string) (*model.Computer, error) {
+	computers := r.list(func(c model.Computer) bool { return c.MACAddress == macAddress })
+	if len(computers) == 0 {
+		return nil, ErrComputerNotFound
+	}
+	return &computers[0], nil
+}
+
+// GetComputersByMACs retrieves the computers with any of the given MAC addresses. MAC addresses
+// without a computer are skipped.
+func (r *memoryComputerRepository) GetComputersByMACs(ctx context.Context, macAddresses []string) ([]model.Computer, error) {
+	wanted := make(map[string]bool, len(macAddresses))
+	for _, mac := range macAddresses {
+		wanted[mac] = true
+	}
+	return r.list(func(c model.Computer) bool { return wanted[c.MACAddress] }), nil
+}
+
+// GetComputerByID retrieves a single computer by its ID.
+func (r *memoryComputerRepository) GetComputerByID(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	c, ok := r.store.computers[id]
+	if !ok {
+		return nil, ErrComputerNotFound
+	}
+	return &c, nil
+}
+
+// GetComputerByIDForUpdate retrieves a single computer by its ID. The store has no transactions
+// to hold a lock for, so it is the same as GetComputerByID.
+func (r *memoryComputerRepository) GetComputerByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	return r.GetComputerByID(ctx, id)
+}
+
+// UpdateComputer replaces a computer and increments its version.
+func (r *memoryComputerRepository) UpdateComputer(ctx context.Context, id uuid.UUID, computer model.Computer) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.computers[id]
+	if !ok {
+		return ErrComputerNotFound
+	}
+	primary, hasPrimary := r.store.primaryInterface(id)
+	if r.store.macTaken(computer.MACAddress, primary.ID) {
+		return fmt.Errorf("%w: %s", ErrDuplicateMAC, computer.MACAddress)
+	}
+	if !r.store.employeeExists(computer.EmployeeAbbreviation) {
+		return fmt.Errorf("%w: %s", ErrEmployeeNotFound, computer.EmployeeAbbreviation)
+	}
+
+	existing.MACAddress = computer.MACAddress
+	existing.ComputerName = computer.ComputerName
+	existing.IPAddress = computer.IPAddress
+	existing.EmployeeAbbreviation = computer.EmployeeAbbreviation
+	existing.Description = computer.Description
+	r.store.touch(existing)
+
+	// Changing the MAC or IP address of a computer changes its primary interface
+	synced := primary
+	synced.MACAddress = existing.MACAddress
+	setInterfaceAddress(&synced, existing.IPAddress)
+	changed := synced.MACAddress != primary.MACAddress || synced.IPv4Address != primary.IPv4Address ||
+		synced.IPv6Address != primary.IPv6Address
+	if hasPrimary && changed {
+		synced.UpdatedAt = memoryNow()
+		r.store.interfaces[primary.ID] = synced
+	}
+
+	return nil
+}
+
+// DeleteComputer deletes a computer.
+func (r *memoryComputerRepository) DeleteComputer(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.computers[id]; !ok {
+		return ErrComputerNotFound
+	}
+	delete(r.store.computers, id)
+	for interfaceID, iface := range r.store.interfaces {
+		if iface.ComputerID == id {
+			delete(r.store.interfaces, interfaceID)
+		}
+	}
+
+	return nil
+}
+
+// GetComputersByEmployee retrieves all computers of an employee ordered by name.
+func (r *memoryComputerRepository) GetComputersByEmployee(ctx context.Context, employeeAbbreviation string) ([]model.Computer, error) {
+	return r.list(func(c model.Computer) bool { return c.EmployeeAbbreviation == employeeAbbreviation }), nil
+}
+
+// GetComputersByEmployeePaginated retrieves all computers of an employee with pagination support.
+func (r *memoryComputerRepository) GetComputersByEmployeePaginated(ctx context.Context, employeeAbbreviation string, params PaginationParams) (*PaginatedResult, error) {
+	return r.GetAllComputersPaginated(ctx, ComputerFilter{EmployeeAbbreviation: employeeAbbreviation}, params)
+}
+
+// ComputerExists checks if a computer with the given MAC address already exists.
+func (r *memoryComputerRepository) ComputerExists(ctx context.Context, macAddress string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, c := range r.store.computers {
+		if c.MACAddress == macAddress {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RemoveComputerFromEmployee unassigns a computer if it is assigned to the given employee.
+func (r *memoryComputerRepository) RemoveComputerFromEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	c, ok := r.store.computers[computerID]
+	if !ok || c.EmployeeAbbreviation == "" || c.EmployeeAbbreviation != employeeAbbreviation {
+		return fmt.Errorf("%w %s", ErrNotAssigned, employeeAbbreviation)
+	}
+	c.EmployeeAbbreviation = ""
+	r.store.touch(c)
+
+	return nil
+}
+
+// AssignComputerToEmployee assigns a computer to an employee.
+func (r *memoryComputerRepository) AssignComputerToEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	c, ok := r.store.computers[computerID]
+	if !ok {
+		return fmt.Errorf("computer with ID %s not found: %w", computerID, ErrComputerNotFound)
+	}
+	if !r.store.employeeExists(employeeAbbreviation) {
+		return fmt.Errorf("%w: %s", ErrEmployeeNotFound, employeeAbbreviation)
+	}
+	c.EmployeeAbbreviation = employeeAbbreviation
+	r.store.touch(c)
+
+	return nil
+}
+
+// list returns the computers accepted by keep, ordered by name and ID, or nil if there are none
+func (r *memoryComputerRepository) list(keep func(model.Computer) bool) []model.Computer {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var computers []model.Computer
+	for _, c := range r.store.computers {
+		if keep(c) {
+			computers = append(computers, c)
+		}
+	}
+	sort.Slice(computers, func(i, j int) bool {
+		if computers[i].ComputerName != computers[j].ComputerName {
+			return computers[i].ComputerName < computers[j].ComputerName
+		}
+		return compareUUIDs(computers[i].ID, computers[j].ID) < 0
+	})
+	return computers
+}
+
+// filtered returns the computers matching filter in the filter's sort order
+func (r *memoryComputerRepository) filtered(filter ComputerFilter) ([]model.Computer, error) {
+	if _, err := filter.orderByClause(); err != nil {
+		return nil, err
+	}
+
+	computers := r.list(func(c model.Computer) bool {
+		return filter.matches(c) && (filter.IPIn == nil || r.store.hasAddressIn(c, filter.IPIn.String()))
+	})
+	sort.SliceStable(computers, func(i, j int) bool {
+		return compareComputers(filter, computers[i], computers[j]) < 0
+	})
+	return computers, nil
+}
+
+// afterCursor returns the computers, sorted in the filter's order, that follow the cursor
+func afterCursor(computers []model.Computer, filter ComputerFilter, cursor *Cursor) ([]model.Computer, error) {
+	sortFields := filter.sortFields()
+	if cursor.Sort != filter.sortKey() || len(cursor.Values) != len(sortFields) {
+		return nil, fmt.Errorf("%w: issued for a different sort order", ErrInvalidCursor)
+	}
+
+	position := sort.Search(len(computers), func(i int) bool {
+		values := filter.cursorFor(computers[i]).Values
+		for k, field := range sortFields {
+			cmp := compareSortValues(field.Field, values[k], cursor.Values[k])
+			if field.Descending {
+				cmp = -cmp
+			}
+			if cmp != 0 {
+				return cmp > 0
+			}
+		}
+		return compareUUIDs(computers[i].ID, cursor.ID) > 0
+	})
+	return computers[position:], nil
+}
+
+// compareComputers orders two computers by the filter's sort fields, then by ID
+func compareComputers(filter ComputerFilter, a, b model.Computer) int {
+	for _, field := range filter.sortFields() {
+		value := computerSortColumns[field.Field].value
+		cmp := compareSortValues(field.Field, value(a), value(b))
+		if field.Descending {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return compareUUIDs(a.ID, b.ID)
+}
+
+// compareUUIDs orders UUIDs like PostgreSQL does, byte by byte
+func compareUUIDs(a, b uuid.UUID) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// matches reports whether a computer passes every condition of the filter
+func (f ComputerFilter) matches(c model.Computer) bool {
+	switch {
+	case f.MACPrefix != "" && !strings.HasPrefix(c.MACAddress, f.MACPrefix),
+		f.MACPrefixes != nil && !hasAnyPrefix(c.MACAddress, f.MACPrefixes),
+		f.Subnet != nil && !ipInSubnet(c.IPAddress, f.Subnet.String()),
+		f.EmployeeAbbreviation != "" && c.EmployeeAbbreviation != f.EmployeeAbbreviation,
+		f.Assigned != nil && *f.Assigned != (c.EmployeeAbbreviation != ""),
+		!f.CreatedAfter.IsZero() && c.CreatedAt.Before(f.CreatedAfter),
+		!f.CreatedBefore.IsZero() && !c.CreatedAt.Before(f.CreatedBefore),
+		!f.UpdatedAfter.IsZero() && c.UpdatedAt.Before(f.UpdatedAfter),
+		!f.UpdatedBefore.IsZero() && !c.UpdatedAt.Before(f.UpdatedBefore),
+		f.Query != "" && !searchMatches(c.ComputerName, c.Description, f.Query):
+		return false
+	}
+	return true
+}
+
+// hasAnyPrefix reports whether value starts with one of the prefixes
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// macTaken reports whether a network interface other than except has the MAC address. Every
+// computer has a primary interface with its MAC address, so this covers computers as well. The
+// caller holds the lock.
+func (s *MemoryStore) macTaken(macAddress string, except uuid.UUID) bool {
+	for id, iface := range s.interfaces {
+		if id != except && iface.MACAddress == macAddress {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAddressIn reports whether the IP address of a computer or one of its interfaces lies within
+// the CIDR network subnet. The caller holds the lock.
+func (s *MemoryStore) hasAddressIn(c model.Computer, subnet string) bool {
+	if ipInSubnet(c.IPAddress, subnet) {
+		return true
+	}
+	for _, iface := range s.interfaces {
+		if iface.ComputerID == c.ID && (ipInSubnet(iface.IPv4Address, subnet) || ipInSubnet(iface.IPv6Address, subnet)) {
+			return true
+		}
+	}
+	return false
+}
+
+// setInterfaceAddress stores an IP address of a computer in the interface address of its family,
+// leaving the interface's address of the other family as it is
+func setInterfaceAddress(iface *model.NetworkInterface, ip string) {
+	if validation.IsIPv4(ip) {
+		iface.IPv4Address = ip
+	} else {
+		iface.IPv6Address = ip
+	}
+}
+
+// primaryInterface returns the primary interface of a computer. The caller holds the lock.
+func (s *MemoryStore) primaryInterface(computerID uuid.UUID) (model.NetworkInterface, bool) {
+	for _, iface := range s.interfaces {
+		if iface.ComputerID == computerID && iface.Primary {
+			return iface, true
+		}
+	}
+	return model.NetworkInterface{}, false
+}
+
+// employeeExists reports whether a computer may refer to the employee; no employee is always
+// allowed. The caller holds the lock.
+func (s *MemoryStore) employeeExists(abbreviation string) bool {
+	if abbreviation == "" {
+		return true
+	}
+	_, ok := s.employees[abbreviation]
+	return ok
+}
+
+// touch stores a changed computer with its version incremented. The caller holds the lock.
+func (s *MemoryStore) touch(c model.Computer) {
+	c.Version++
+	c.UpdatedAt = memoryNow()
+	s.computers[c.ID] = c
+}
+
+// memoryEmployeeRepository is the in-memory implementation of the EmployeeRepository interface.
+type memoryEmployeeRepository struct {
+	store *MemoryStore
+}
+
+// CreateEmployee adds a new employee.
+func (r *memoryEmployeeRepository) CreateEmployee(ctx context.Context, employee model.Employee) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.employees[employee.Abbreviation]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateEmployee, employee.Abbreviation)
+	}
+
+	now := memoryNow()
+	employee.CreatedAt = now
+	employee.UpdatedAt = now
+	employee.DeactivatedAt = nil
+	r.store.employees[employee.Abbreviation] = employee
+
+	return nil
+}
+
+// GetEmployeeByAbbreviation retrieves a single employee by abbreviation.
+func (r *memoryEmployeeRepository) GetEmployeeByAbbreviation(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	e, ok := r.store.employees[abbreviation]
+	if !ok {
+		return nil, ErrEmployeeNotFound
+	}
+	return copyEmployee(e), nil
+}
+
+// GetEmployeeByAbbreviationForUpdate retrieves a single employee by abbreviation. The store has
+// no transactions to hold a lock for, so it is the same as GetEmployeeByAbbreviation.
+func (r *memoryEmployeeRepository) GetEmployeeByAbbreviationForUpdate(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	return r.GetEmployeeByAbbreviation(ctx, abbreviation)
+}
+
+// GetAllEmployeesPaginated retrieves all employees ordered by abbreviation with pagination support.
+func (r *memoryEmployeeRepository) GetAllEmployeesPaginated(ctx context.Context, params PaginationParams) (*EmployeePaginatedResult, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	abbreviations := make([]string, 0, len(r.store.employees))
+	for abbreviation := range r.store.employees {
+		abbreviations = append(abbreviations, abbreviation)
+	}
+	sort.Strings(abbreviations)
+
+	var employees []model.Employee
+	for i := params.Offset; i < len(abbreviations) && i < params.Offset+params.Limit; i++ {
+		employees = append(employees, *copyEmployee(r.store.employees[abbreviations[i]]))
+	}
+
+	return &EmployeePaginatedResult{
+		Items:      employees,
+		TotalCount: len(abbreviations),
+	}, nil
+}
+
+// UpdateEmployee updates an employee. Deactivating an employee records the time they left,
+// reactivating clears it.
+func (r *memoryEmployeeRepository) UpdateEmployee(ctx context.Context, abbreviation string, employee model.Employee) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.employees[abbreviation]
+	if !ok {
+		return ErrEmployeeNotFound
+	}
+
+	now := memoryNow()
+	existing.Name = employee.Name
+	existing.Email = employee.Email
+	existing.Department = employee.Department
+	existing.Active = employee.Active
+	if employee.Active {
+		existing.DeactivatedAt = nil
+	} else if existing.DeactivatedAt == nil {
+		existing.DeactivatedAt = &now
+	}
+	existing.UpdatedAt = now
+	r.store.employees[abbreviation] = existing
+
+	return nil
+}
+
+// DeleteEmployee deletes an employee. Employees that still have computers assigned cannot be deleted.
+func (r *memoryEmployeeRepository) DeleteEmployee(ctx context.Context, abbreviation string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.employees[abbreviation]; !ok {
+		return ErrEmployeeNotFound
+	}
+	for _, c := range r.store.computers {
+		if c.EmployeeAbbreviation == abbreviation {
+			return fmt.Errorf("%w: %s", ErrEmployeeInUse, abbreviation)
+		}
+	}
+	delete(r.store.employees, abbreviation)
+
+	return nil
+}
+
+// copyEmployee returns a copy of an employee that shares no memory with the store
+func copyEmployee(e model.Employee) *model.Employee {
+	if e.DeactivatedAt != nil {
+		deactivatedAt := *e.DeactivatedAt
+		e.DeactivatedAt = &deactivatedAt
+	}
+	return &e
+}
+
+// memoryNetworkInterfaceRepository is the in-memory implementation of the NetworkInterfaceRepository interface.
+type memoryNetworkInterfaceRepository struct {
+	store *MemoryStore
+}
+
+// CreateInterface adds a new secondary interface to a computer.
+func (r *memoryNetworkInterfaceRepository) CreateInterface(ctx context.Context, iface model.NetworkInterface) error {
+	normalizedMAC, err := validation.ValidateMAC(iface.MACAddress)
+	if err != nil {
+		return ErrInvalidMACFormat
+	}
+	iface.MACAddress = normalizedMAC
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.interfaces[iface.ID]; ok || r.store.macTaken(iface.MACAddress, uuid.Nil) {
+		return fmt.Errorf("%w: %s", ErrDuplicateMAC, iface.MACAddress)
+	}
+	if _, ok := r.store.computers[iface.ComputerID]; !ok {
+		return fmt.Errorf("computer with ID %s not found: %w", iface.ComputerID, ErrComputerNotFound)
+	}
+
+	now := memoryNow()
+	iface.Primary = false
+	iface.CreatedAt = now
+	iface.UpdatedAt = now
+	r.store.interfaces[iface.ID] = iface
+
+	return nil
+}
+
+// GetInterfacesByComputer retrieves the interfaces of a computer, the primary one first.
+func (r *memoryNetworkInterfaceRepository) GetInterfacesByComputer(ctx context.Context, computerID uuid.UUID) ([]model.NetworkInterface, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var interfaces []model.NetworkInterface
+	for _, iface := range r.store.interfaces {
+		if iface.ComputerID == computerID {
+			interfaces = append(interfaces, iface)
+		}
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		a, b := interfaces[i], interfaces[j]
+		switch {
+		case a.Primary != b.Primary:
+			return a.Primary
+		case !a.CreatedAt.Equal(b.CreatedAt):
+			return a.CreatedAt.Before(b.CreatedAt)
+		default:
+			return compareUUIDs(a.ID, b.ID) < 0
+		}
+	})
+	return interfaces, nil
+}
+
+// GetAllInterfaces retrieves the interfaces of all computers, grouped by computer with the
+// primary interface first.
+func (r *memoryNetworkInterfaceRepository) GetAllInterfaces(ctx context.Context) ([]model.NetworkInterface, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	interfaces := make([]model.NetworkInterface, 0, len(r.store.interfaces))
+	for _, iface := range r.store.interfaces {
+		interfaces = append(interfaces, iface)
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		a, b := interfaces[i], interfaces[j]
+		switch {
+		case a.ComputerID != b.ComputerID:
+			return compareUUIDs(a.ComputerID, b.ComputerID) < 0
+		case a.Primary != b.Primary:
+			return a.Primary
+		case !a.CreatedAt.Equal(b.CreatedAt):
+			return a.CreatedAt.Before(b.CreatedAt)
+		default:
+			return compareUUIDs(a.ID, b.ID) < 0
+		}
+	})
+	return interfaces, nil
+}
+
+// GetInterfaceByID retrieves an interface of a computer by its ID.
+func (r *memoryNetworkInterfaceRepository) GetInterfaceByID(ctx context.Context, computerID, id uuid.UUID) (*model.NetworkInterface, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	iface, ok := r.store.interfaces[id]
+	if !ok || iface.ComputerID != computerID {
+		return nil, ErrInterfaceNotFound
+	}
+	return &iface, nil
+}
+
+// GetInterfaceByMAC retrieves the interface with a MAC address, whichever computer it belongs to.
+func (r *memoryNetworkInterfaceRepository) GetInterfaceByMAC(ctx context.Context, macAddress string) (*model.NetworkInterface, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, iface := range r.store.interfaces {
+		if iface.MACAddress == macAddress {
+			return &iface, nil
+		}
+	}
+	return nil, ErrInterfaceNotFound
+}
+
+// UpdateInterface updates the addresses and type of an interface.
+func (r *memoryNetworkInterfaceRepository) UpdateInterface(ctx context.Context, iface model.NetworkInterface) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.interfaces[iface.ID]
+	if !ok || existing.ComputerID != iface.ComputerID {
+		return ErrInterfaceNotFound
+	}
+	if r.store.macTaken(iface.MACAddress, iface.ID) {
+		return fmt.Errorf("%w: %s", ErrDuplicateMAC, iface.MACAddress)
+	}
+
+	existing.MACAddress = iface.MACAddress
+	existing.Type = iface.Type
+	existing.IPv4Address = iface.IPv4Address
+	existing.IPv6Address = iface.IPv6Address
+	existing.UpdatedAt = memoryNow()
+	r.store.interfaces[iface.ID] = existing
+
+	return nil
+}
+
+// SetPrimaryInterface makes an interface the primary one of its computer.
+func (r *memoryNetworkInterfaceRepository) SetPrimaryInterface(ctx context.Context, computerID, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	iface, ok := r.store.interfaces[id]
+	if !ok || iface.ComputerID != computerID {
+		return ErrInterfaceNotFound
+	}
+
+	now := memoryNow()
+	if primary, ok := r.store.primaryInterface(computerID); ok && primary.ID != id {
+		primary.Primary = false
+		primary.UpdatedAt = now
+		r.store.interfaces[primary.ID] = primary
+	}
+	iface.Primary = true
+	iface.UpdatedAt = now
+	r.store.interfaces[id] = iface
+
+	return nil
+}
+
+// DeleteInterface deletes an interface of a computer.
+func (r *memoryNetworkInterfaceRepository) DeleteInterface(ctx context.Context, computerID, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	iface, ok := r.store.interfaces[id]
+	if !ok || iface.ComputerID != computerID {
+		return ErrInterfaceNotFound
+	}
+	delete(r.store.interfaces, id)
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package repository_test
+
+import (
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/repository/repositorytest"
+	"testing"
+)
+
+func TestMemoryComputerRepositoryConformance(t *testing.T) {
+	repositorytest.RunComputerRepositoryTests(t, func(t *testing.T) repositorytest.Backend {
+		store := repository.NewMemoryStore()
+		return repositorytest.Backend{Computers: store.Computers(), Employees: store.Employees(), Interfaces: store.Interfaces()}
+	})
+}
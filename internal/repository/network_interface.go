@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/pkg/validation"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Custom errors for network interface operations
+var (
+	ErrInterfaceNotFound = errors.New("network interface not found")
+)
+
+// NetworkInterfaceRepository is an interface for interacting with the network interfaces of
+// computers. Every computer has a primary interface, created with the computer and kept in
+// sync with its MAC and IP address by the database; a MAC address can only be used once
+// across all interfaces, which includes the MAC addresses of computers.
+type NetworkInterfaceRepository interface {
+	// CreateInterface adds a secondary interface; use SetPrimaryInterface to promote it.
+	CreateInterface(ctx context.Context, iface model.NetworkInterface) error
+	GetInterfacesByComputer(ctx context.Context, computerID uuid.UUID) ([]model.NetworkInterface, error)
+	// GetAllInterfaces retrieves the interfaces of all computers, grouped by computer with the
+	// primary interface first.
+	GetAllInterfaces(ctx context.Context) ([]model.NetworkInterface, error)
+	GetInterfaceByID(ctx context.Context, computerID, id uuid.UUID) (*model.NetworkInterface, error)
+	GetInterfaceByMAC(ctx context.Context, macAddress string) (*model.NetworkInterface, error)
+	// UpdateInterface changes the addresses and type of an interface, but not whether it is primary.
+	UpdateInterface(ctx context.Context, iface model.NetworkInterface) error
+	// SetPrimaryInterface makes an interface the primary one of its computer, demoting the
+	// former primary interface. The computer's own MAC and IP address are left to the caller.
+	SetPrimaryInterface(ctx context.Context, computerID, id uuid.UUID) error
+	DeleteInterface(ctx context.Context, computerID, id uuid.UUID) error
+}
+
+// networkInterfaceRepository is the concrete implementation of the NetworkInterfaceRepository interface.
+type networkInterfaceRepository struct {
+	DB DBTX
+}
+
+// NewNetworkInterfaceRepository creates a new NetworkInterfaceRepository.
+func NewNetworkInterfaceRepository(db *sql.DB) NetworkInterfaceRepository {
+	return &networkInterfaceRepository{DB: db}
+}
+
+// networkInterfaceColumns selects the IP addresses with host, which leaves out the prefix length
+// a text cast of an inet would add
+const networkInterfaceColumns = `id, computer_id, mac_address, interface_type, COALESCE(host(ipv4_address), ''), COALESCE(host(ipv6_address), ''), is_primary, created_at, updated_at`
+
+// CreateInterface adds a new secondary interface to a computer.
+func (r *networkInterfaceRepository) CreateInterface(ctx context.Context, iface model.NetworkInterface) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	normalizedMAC, err := validation.ValidateMAC(iface.MACAddress)
+	if err != nil {
+		return ErrInvalidMACFormat
+	}
+	iface.MACAddress = normalizedMAC
+
+	query := `
+		INSERT INTO network_interfaces (id, computer_id, mac_address, interface_type, ipv4_address, ipv6_address, is_primary)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::inet, NULLIF($6, '')::inet, FALSE)`
+
+	_, err = r.DB.ExecContext(ctx, query, iface.ID, iface.ComputerID, iface.MACAddress, string(iface.Type), iface.IPv4Address, iface.IPv6Address)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", ErrDuplicateMAC, iface.MACAddress)
+		}
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("computer with ID %s not found: %w", iface.ComputerID, ErrComputerNotFound)
+		}
+		return fmt.Errorf("failed to create network interface: %w", err)
+	}
+
+	return nil
+}
+
+// GetInterfacesByComputer retrieves the interfaces of a computer, the primary one first.
+func (r *networkInterfaceRepository) GetInterfacesByComputer(ctx context.Context, computerID uuid.UUID) ([]model.NetworkInterface, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + networkInterfaceColumns + `
+		FROM network_interfaces
+		WHERE computer_id = $1
+		ORDER BY is_primary DESC, created_at, id`
+
+	rows, err := r.DB.QueryContext(ctx, query, computerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query network interfaces: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNetworkInterfaces(rows)
+}
+
+// GetAllInterfaces retrieves the interfaces of all computers, grouped by computer with the
+// primary interface first.
+func (r *networkInterfaceRepository) GetAllInterfaces(ctx context.Context) ([]model.NetworkInterface, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + networkInterfaceColumns + `
+		FROM network_interfaces
+		ORDER BY computer_id, is_primary DESC, created_at, id`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query network interfaces: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNetworkInterfaces(rows)
+}
+
+// GetInterfaceByID retrieves an interface of a computer by its ID.
+func (r *networkInterfaceRepository) GetInterfaceByID(ctx context.Context, computerID, id uuid.UUID) (*model.NetworkInterface, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + networkInterfaceColumns + `
+		FROM network_interfaces
+		WHERE id = $1 AND computer_id = $2`
+
+	iface, err := scanNetworkInterface(r.DB.QueryRowContext(ctx, query, id, computerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInterfaceNotFound
+		}
+		return nil, fmt.Errorf("failed to get network interface: %w", err)
+	}
+	return &iface, nil
+}
+
+// GetInterfaceByMAC retrieves the interface with a MAC address, whichever computer it belongs to.
+func (r *networkInterfaceRepository) GetInterfaceByMAC(ctx context.Context, macAddress string) (*model.NetworkInterface, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + networkInterfaceColumns + `
+		FROM network_interfaces
+		WHERE mac_address = $1`
+
+	iface, err := scanNetworkInterface(r.DB.QueryRowContext(ctx, query, macAddress))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInterfaceNotFound
+		}
+		return nil, fmt.Errorf("failed to get network interface by MAC: %w", err)
+	}
+	return &iface, nil
+}
+
+// UpdateInterface updates the addresses and type of an interface.
+func (r *networkInterfaceRepository) UpdateInterface(ctx context.Context, iface model.NetworkInterface) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE network_interfaces
+		SET mac_address = $1, interface_type = $2, ipv4_address = NULLIF($3, '')::inet, ipv6_address = NULLIF($4, '')::inet
+		WHERE id = $5 AND computer_id = $6`
+
+	result, err := r.DB.ExecContext(ctx, query, iface.MACAddress, string(iface.Type), iface.IPv4Address, iface.IPv6Address, iface.ID, iface.ComputerID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", ErrDuplicateMAC, iface.MACAddress)
+		}
+		return fmt.Errorf("failed to update network interface: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrInterfaceNotFound)
+}
+
+// SetPrimaryInterface makes an interface the primary one of its computer. The former primary
+// interface is demoted first, as the primary index is checked row by row.
+func (r *networkInterfaceRepository) SetPrimaryInterface(ctx context.Context, computerID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	demote := `
+		UPDATE network_interfaces
+		SET is_primary = FALSE
+		WHERE computer_id = $1 AND is_primary AND id <> $2
+			AND EXISTS (SELECT 1 FROM network_interfaces WHERE id = $2 AND computer_id = $1)`
+	if _, err := r.DB.ExecContext(ctx, demote, computerID, id); err != nil {
+		return fmt.Errorf("failed to demote primary network interface: %w", err)
+	}
+
+	promote := `
+		UPDATE network_interfaces
+		SET is_primary = TRUE
+		WHERE id = $1 AND computer_id = $2`
+	result, err := r.DB.ExecContext(ctx, promote, id, computerID)
+	if err != nil {
+		return fmt.Errorf("failed to promote network interface: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrInterfaceNotFound)
+}
+
+// DeleteInterface deletes an interface of a computer.
+func (r *networkInterfaceRepository) DeleteInterface(ctx context.Context, computerID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM network_interfaces WHERE id = $1 AND computer_id = $2`, id, computerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete network interface: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrInterfaceNotFound)
+}
+
+// scanNetworkInterfaces scans all network interface rows
+func scanNetworkInterfaces(rows *sql.Rows) ([]model.NetworkInterface, error) {
+	var interfaces []model.NetworkInterface
+	for rows.Next() {
+		iface, err := scanNetworkInterface(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan network interface: %w", err)
+		}
+		interfaces = append(interfaces, iface)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return interfaces, nil
+}
+
+// scanNetworkInterface scans a row selected with networkInterfaceColumns or sqliteNetworkInterfaceColumns
+func scanNetworkInterface(scanner rowScanner) (model.NetworkInterface, error) {
+	var iface model.NetworkInterface
+	var interfaceType string
+	if err := scanner.Scan(&iface.ID, &iface.ComputerID, &iface.MACAddress, &interfaceType, &iface.IPv4Address, &iface.IPv6Address,
+		&iface.Primary, &iface.CreatedAt, &iface.UpdatedAt); err != nil {
+		return model.NetworkInterface{}, err
+	}
+	iface.Type = model.NetworkInterfaceType(interfaceType)
+	return iface, nil
+}
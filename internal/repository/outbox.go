@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// OutboxRepository is an interface for the notification outbox.
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, message model.OutboxMessage) error
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error)
+	MarkDelivered(ctx context.Context, id int64) error
+	MarkRetry(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	MarkDead(ctx context.Context, id int64, lastError string) error
+	Backlog(ctx context.Context) (*model.OutboxBacklog, error)
+}
+
+// outboxRepository is the concrete implementation of the OutboxRepository interface.
+type outboxRepository struct {
+	DB DBTX
+}
+
+// NewOutboxRepository creates a new OutboxRepository.
+func NewOutboxRepository(db *sql.DB) OutboxRepository {
+	return &outboxRepository{DB: db}
+}
+
+// Enqueue adds a pending message to the outbox. Call it through a Transactor so the
+// message is only stored if the change that caused it commits.
+func (r *outboxRepository) Enqueue(ctx context.Context, message model.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO notification_outbox (notification_type, payload)
+		VALUES ($1, $2)`
+
+	if _, err := r.DB.ExecContext(ctx, query, message.NotificationType, string(message.Payload)); err != nil {
+		return fmt.Errorf("failed to enqueue notification: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue returns up to limit pending messages that are due for delivery, oldest first.
+// Claimed messages are hidden from other dispatchers for the duration of the lease, so a
+// dispatcher that crashes mid-delivery only delays the message instead of losing it.
+func (r *outboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE notification_outbox
+		SET next_attempt_at = CURRENT_TIMESTAMP + ($2 * INTERVAL '1 millisecond')
+		WHERE id IN (
+			SELECT id FROM notification_outbox
+			WHERE status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, notification_type, payload, status, attempts, COALESCE(last_error, ''), next_attempt_at, created_at`
+
+	rows, err := r.DB.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []model.OutboxMessage
+	for rows.Next() {
+		var m model.OutboxMessage
+		var status string
+		if err := rows.Scan(&m.ID, &m.NotificationType, &m.Payload, &status, &m.Attempts, &m.LastError, &m.NextAttemptAt, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		m.Status = model.OutboxStatus(status)
+		messages = append(messages, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	// RETURNING does not preserve the order of the subquery
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+
+	return messages, nil
+}
+
+// MarkDelivered records a successful delivery.
+func (r *outboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	query := `
+		UPDATE notification_outbox
+		SET status = 'delivered', attempts = attempts + 1, last_error = NULL, delivered_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	return r.updateMessage(ctx, query, id)
+}
+
+// MarkRetry records a failed delivery that should be attempted again at nextAttemptAt.
+func (r *outboxRepository) MarkRetry(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE notification_outbox
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1`
+
+	return r.updateMessage(ctx, query, id, lastError, nextAttemptAt)
+}
+
+// MarkDead moves a message that can never be delivered to the dead-letter state.
+func (r *outboxRepository) MarkDead(ctx context.Context, id int64, lastError string) error {
+	query := `
+		UPDATE notification_outbox
+		SET status = 'dead', attempts = attempts + 1, last_error = $2
+		WHERE id = $1`
+
+	return r.updateMessage(ctx, query, id, lastError)
+}
+
+// Backlog counts the pending messages and finds the oldest one. It only reads pending rows,
+// which the partial index covers, so it stays cheap however many messages were delivered.
+func (r *outboxRepository) Backlog(ctx context.Context) (*model.OutboxBacklog, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT COUNT(*), MIN(created_at)
+		FROM notification_outbox
+		WHERE status = 'pending'`
+
+	var backlog model.OutboxBacklog
+	var oldest sql.NullTime
+	if err := r.DB.QueryRowContext(ctx, query).Scan(&backlog.Pending, &oldest); err != nil {
+		return nil, fmt.Errorf("failed to count pending notifications: %w", err)
+	}
+	if oldest.Valid {
+		backlog.OldestPendingAt = &oldest.Time
+	}
+
+	return &backlog, nil
+}
+
+// updateMessage runs a single-row status update on an outbox message
+func (r *outboxRepository) updateMessage(ctx context.Context, query string, id int64, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, query, append([]interface{}{id}, args...)...)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox message %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("outbox message %d not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxBacklog(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewOutboxRepository(db)
+	query := regexp.QuoteMeta(`SELECT COUNT(*), MIN(created_at) FROM notification_outbox WHERE status = 'pending'`)
+	oldest := time.Now().Add(-time.Minute)
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"count", "min"}).AddRow(3, oldest))
+	backlog, err := repo.Backlog(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, backlog.Pending)
+	require.NotNil(t, backlog.OldestPendingAt)
+	assert.True(t, backlog.OldestPendingAt.Equal(oldest))
+
+	// An empty outbox has no oldest message
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"count", "min"}).AddRow(0, nil))
+	backlog, err = repo.Backlog(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, backlog.Pending)
+	assert.Nil(t, backlog.OldestPendingAt)
+
+	mock.ExpectQuery(query).WillReturnError(errors.New("connection refused"))
+	_, err = repo.Backlog(context.Background())
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Custom errors for quota policy operations
+var (
+	ErrPolicyNotFound  = errors.New("quota policy not found")
+	ErrDuplicatePolicy = errors.New("quota policy for this scope and target already exists")
+)
+
+// PolicyPaginatedResult holds paginated quota policy query results
+type PolicyPaginatedResult struct {
+	Items      []model.QuotaPolicy
+	TotalCount int
+}
+
+// PolicyRepository is an interface for interacting with quota policies.
+type PolicyRepository interface {
+	CreatePolicy(ctx context.Context, policy model.QuotaPolicy) (int64, error)
+	GetPolicyByID(ctx context.Context, id int64) (*model.QuotaPolicy, error)
+	GetAllPoliciesPaginated(ctx context.Context, params PaginationParams) (*PolicyPaginatedResult, error)
+	GetApplicablePolicies(ctx context.Context, employeeAbbreviation, department string) ([]model.QuotaPolicy, error)
+	UpdatePolicy(ctx context.Context, id int64, policy model.QuotaPolicy) error
+	DeletePolicy(ctx context.Context, id int64) error
+}
+
+// policyRepository is the concrete implementation of the PolicyRepository interface.
+type policyRepository struct {
+	DB DBTX
+}
+
+// NewPolicyRepository creates a new PolicyRepository.
+func NewPolicyRepository(db *sql.DB) PolicyRepository {
+	return &policyRepository{DB: db}
+}
+
+// CreatePolicy adds a new quota policy and returns its ID.
+func (r *policyRepository) CreatePolicy(ctx context.Context, policy model.QuotaPolicy) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO quota_policies (scope, target, max_computers, mode)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	var id int64
+	err := r.DB.QueryRowContext(ctx, query, string(policy.Scope), policy.Target, policy.MaxComputers, string(policy.Mode)).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%w: %s %s", ErrDuplicatePolicy, policy.Scope, policy.Target)
+		}
+		return 0, fmt.Errorf("failed to create quota policy: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetPolicyByID retrieves a single quota policy by ID.
+func (r *policyRepository) GetPolicyByID(ctx context.Context, id int64) (*model.QuotaPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, scope, target, max_computers, mode, created_at, updated_at
+		FROM quota_policies
+		WHERE id = $1`
+
+	p, err := scanPolicy(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to get quota policy: %w", err)
+	}
+	return &p, nil
+}
+
+// GetAllPoliciesPaginated retrieves all quota policies with pagination support, most general first.
+func (r *policyRepository) GetAllPoliciesPaginated(ctx context.Context, params PaginationParams) (*PolicyPaginatedResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, scope, target, max_computers, mode, created_at, updated_at
+		FROM quota_policies
+		ORDER BY ` + policySpecificityOrder + `, target
+		OFFSET $1 LIMIT $2`
+
+	rows, err := r.DB.QueryContext(ctx, query, params.Offset, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quota policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies, err := scanPolicies(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM quota_policies`
+	if err := r.DB.QueryRowContext(ctx, countQuery).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to get total count of quota policies: %w", err)
+	}
+
+	return &PolicyPaginatedResult{
+		Items:      policies,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// GetApplicablePolicies retrieves the global policy and any policies for the given department
+// or employee, most general first.
+func (r *policyRepository) GetApplicablePolicies(ctx context.Context, employeeAbbreviation, department string) ([]model.QuotaPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, scope, target, max_computers, mode, created_at, updated_at
+		FROM quota_policies
+		WHERE scope = 'global'
+			OR (scope = 'department' AND target = $1)
+			OR (scope = 'employee' AND target = $2)
+		ORDER BY ` + policySpecificityOrder
+
+	rows, err := r.DB.QueryContext(ctx, query, department, employeeAbbreviation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applicable quota policies: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPolicies(rows)
+}
+
+// UpdatePolicy replaces a quota policy.
+func (r *policyRepository) UpdatePolicy(ctx context.Context, id int64, policy model.QuotaPolicy) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE quota_policies
+		SET scope = $1, target = $2, max_computers = $3, mode = $4
+		WHERE id = $5`
+
+	result, err := r.DB.ExecContext(ctx, query, string(policy.Scope), policy.Target, policy.MaxComputers, string(policy.Mode), id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s %s", ErrDuplicatePolicy, policy.Scope, policy.Target)
+		}
+		return fmt.Errorf("failed to update quota policy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrPolicyNotFound
+	}
+
+	return nil
+}
+
+// DeletePolicy deletes a quota policy.
+func (r *policyRepository) DeletePolicy(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM quota_policies WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete quota policy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrPolicyNotFound
+	}
+
+	return nil
+}
+
+// policySpecificityOrder sorts policies from global to employee scope
+const policySpecificityOrder = `CASE scope WHEN 'global' THEN 0 WHEN 'department' THEN 1 ELSE 2 END`
+
+// scanPolicies scans all quota policy rows
+func scanPolicies(rows *sql.Rows) ([]model.QuotaPolicy, error) {
+	var policies []model.QuotaPolicy
+	for rows.Next() {
+		p, err := scanPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quota policy: %w", err)
+		}
+		policies = append(policies, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return policies, nil
+}
+
+// scanPolicy scans a quota policy row
+func scanPolicy(scanner rowScanner) (model.QuotaPolicy, error) {
+	var p model.QuotaPolicy
+	var scope, mode string
+	if err := scanner.Scan(&p.ID, &scope, &p.Target, &p.MaxComputers, &mode, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return model.QuotaPolicy{}, err
+	}
+	p.Scope = model.QuotaPolicyScope(scope)
+	p.Mode = model.QuotaPolicyMode(mode)
+	return p, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePolicy_Duplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPolicyRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO quota_policies (scope, target, max_computers, mode) VALUES ($1, $2, $3, $4) RETURNING id`)).
+		WithArgs("department", "Engineering", 5, "enforce").
+		WillReturnError(errors.New(`pq: duplicate key value violates unique constraint "quota_policies_scope_target_key"`))
+
+	_, err = repo.CreatePolicy(context.Background(), model.QuotaPolicy{
+		Scope:        model.QuotaPolicyScopeDepartment,
+		Target:       "Engineering",
+		MaxComputers: 5,
+		Mode:         model.QuotaPolicyModeEnforce,
+	})
+
+	assert.ErrorIs(t, err, ErrDuplicatePolicy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetApplicablePolicies_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPolicyRepository(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "scope", "target", "max_computers", "mode", "created_at", "updated_at"}).
+		AddRow(1, "global", "", 3, "warn", now, now).
+		AddRow(4, "employee", "ABC", 5, "enforce", now, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM quota_policies WHERE scope = 'global' OR (scope = 'department' AND target = $1) OR (scope = 'employee' AND target = $2)`)).
+		WithArgs("Engineering", "ABC").
+		WillReturnRows(rows)
+
+	policies, err := repo.GetApplicablePolicies(context.Background(), "ABC", "Engineering")
+
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, model.QuotaPolicyScopeGlobal, policies[0].Scope)
+	assert.Equal(t, model.QuotaPolicyModeEnforce, policies[1].Mode)
+	assert.Equal(t, 5, policies[1].MaxComputers)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeletePolicy_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPolicyRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM quota_policies WHERE id = $1`)).
+		WithArgs(int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeletePolicy(context.Background(), 9)
+
+	assert.ErrorIs(t, err, ErrPolicyNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,609 @@
+// Package repositorytest is a conformance suite for the repository implementations. Every
+// backend runs the same cases, so the in-memory and SQLite repositories behave like the
+// PostgreSQL ones the server is deployed with.
+package repositorytest
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Backend is the repositories of one empty store
+type Backend struct {
+	Computers  repository.ComputerRepository
+	Employees  repository.EmployeeRepository
+	Interfaces repository.NetworkInterfaceRepository
+}
+
+// RunComputerRepositoryTests runs the ComputerRepository conformance cases. newBackend is called
+// once per case and must return repositories without any computers or employees.
+//
+// Names and abbreviations are upper case, so the byte-wise order of the in-memory and SQLite
+// backends agrees with any PostgreSQL collation.
+func RunComputerRepositoryTests(t *testing.T, newBackend func(t *testing.T) Backend) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, b Backend)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"CreateRejectsInvalidInput", testCreateRejectsInvalidInput},
+		{"CreateRejectsDuplicates", testCreateRejectsDuplicates},
+		{"CreateRejectsUnknownEmployee", testCreateRejectsUnknownEmployee},
+		{"NotFound", testNotFound},
+		{"Update", testUpdate},
+		{"AssignAndRemove", testAssignAndRemove},
+		{"Delete", testDelete},
+		{"EmployeeInUse", testEmployeeInUse},
+		{"GetAllComputersOrderedByName", testGetAllComputersOrderedByName},
+		{"OffsetPagination", testOffsetPagination},
+		{"CursorPagination", testCursorPagination},
+		{"Sort", testSort},
+		{"Filters", testFilters},
+		{"CountModes", testCountModes},
+		{"StreamComputers", testStreamComputers},
+		{"GetComputersByMACs", testGetComputersByMACs},
+		{"EmployeePagination", testEmployeePagination},
+		{"PrimaryInterface", testPrimaryInterface},
+		{"InterfaceLifecycle", testInterfaceLifecycle},
+		{"GetAllInterfaces", testGetAllInterfaces},
+		{"InterfaceMACUniqueness", testInterfaceMACUniqueness},
+		{"SetPrimaryInterface", testSetPrimaryInterface},
+		{"IPv6Addresses", testIPv6Addresses},
+		{"AddressesInNetwork", testAddressesInNetwork},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newBackend(t))
+		})
+	}
+}
+
+// fixture is the computer created by the cases unless they need something else
+func fixture(name, mac, ip string) model.Computer {
+	return model.Computer{
+		ID:           uuid.New(),
+		MACAddress:   mac,
+		ComputerName: name,
+		IPAddress:    ip,
+		Description:  "Test computer",
+	}
+}
+
+// createEmployee adds an active employee
+func createEmployee(t *testing.T, b Backend, abbreviation, department string) {
+	t.Helper()
+	require.NoError(t, b.Employees.CreateEmployee(context.Background(), model.Employee{
+		Abbreviation: abbreviation,
+		Name:         "Employee " + abbreviation,
+		Email:        abbreviation + "@example.com",
+		Department:   department,
+		Active:       true,
+	}))
+}
+
+// createComputers adds the computers and returns them as stored
+func createComputers(t *testing.T, b Backend, computers ...model.Computer) []model.Computer {
+	t.Helper()
+	ctx := context.Background()
+
+	stored := make([]model.Computer, len(computers))
+	for i, c := range computers {
+		require.NoError(t, b.Computers.CreateComputer(ctx, c))
+		got, err := b.Computers.GetComputerByID(ctx, c.ID)
+		require.NoError(t, err)
+		stored[i] = *got
+	}
+	return stored
+}
+
+// names returns the names of the computers in order
+func names(computers []model.Computer) []string {
+	result := make([]string, len(computers))
+	for i, c := range computers {
+		result[i] = c.ComputerName
+	}
+	return result
+}
+
+func testCreateAndGet(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createEmployee(t, b, "ABC", "IT")
+
+	computer := fixture("WORKSTATION", "aa-bb-cc-dd-ee-01", "192.168.1.10")
+	computer.EmployeeAbbreviation = "ABC"
+	require.NoError(t, b.Computers.CreateComputer(ctx, computer))
+
+	got, err := b.Computers.GetComputerByID(ctx, computer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, computer.ID, got.ID)
+	assert.Equal(t, "AA:BB:CC:DD:EE:01", got.MACAddress, "MAC address is normalized")
+	assert.Equal(t, "WORKSTATION", got.ComputerName)
+	assert.Equal(t, "192.168.1.10", got.IPAddress)
+	assert.Equal(t, "ABC", got.EmployeeAbbreviation)
+	assert.Equal(t, "Test computer", got.Description)
+	assert.Equal(t, int64(1), got.Version)
+	assert.False(t, got.CreatedAt.IsZero())
+	assert.True(t, got.CreatedAt.Equal(got.UpdatedAt))
+
+	byMAC, err := b.Computers.GetComputerByMAC(ctx, "AA:BB:CC:DD:EE:01")
+	require.NoError(t, err)
+	assert.Equal(t, computer.ID, byMAC.ID)
+
+	locked, err := b.Computers.GetComputerByIDForUpdate(ctx, computer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, computer.ID, locked.ID)
+
+	exists, err := b.Computers.ComputerExists(ctx, "AA:BB:CC:DD:EE:01")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = b.Computers.ComputerExists(ctx, "AA:BB:CC:DD:EE:02")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	unassigned := fixture("SPARE", "AA:BB:CC:DD:EE:03", "192.168.1.11")
+	unassigned.Description = ""
+	require.NoError(t, b.Computers.CreateComputer(ctx, unassigned))
+	got, err = b.Computers.GetComputerByID(ctx, unassigned.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.EmployeeAbbreviation)
+	assert.Empty(t, got.Description)
+}
+
+func testCreateRejectsInvalidInput(t *testing.T, b Backend) {
+	ctx := context.Background()
+
+	err := b.Computers.CreateComputer(ctx, fixture("BAD-MAC", "not-a-mac", "192.168.1.10"))
+	assert.ErrorIs(t, err, repository.ErrInvalidMACFormat)
+
+	err = b.Computers.CreateComputer(ctx, fixture("BAD-IP", "AA:BB:CC:DD:EE:01", "999.1.1.1"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid IP address")
+
+	all, err := b.Computers.GetAllComputers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func testCreateRejectsDuplicates(t *testing.T, b Backend) {
+	ctx := context.Background()
+	original := createComputers(t, b, fixture("ORIGINAL", "AA:BB:CC:DD:EE:01", "10.0.0.1"))[0]
+
+	err := b.Computers.CreateComputer(ctx, fixture("SAME-MAC", "aa:bb:cc:dd:ee:01", "10.0.0.2"))
+	assert.ErrorIs(t, err, repository.ErrDuplicateMAC)
+
+	sameID := fixture("SAME-ID", "AA:BB:CC:DD:EE:02", "10.0.0.3")
+	sameID.ID = original.ID
+	err = b.Computers.CreateComputer(ctx, sameID)
+	assert.ErrorIs(t, err, repository.ErrDuplicateMAC)
+
+	got, err := b.Computers.GetComputerByID(ctx, original.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ORIGINAL", got.ComputerName)
+}
+
+func testCreateRejectsUnknownEmployee(t *testing.T, b Backend) {
+	computer := fixture("ORPHAN", "AA:BB:CC:DD:EE:01", "10.0.0.1")
+	computer.EmployeeAbbreviation = "XYZ"
+
+	err := b.Computers.CreateComputer(context.Background(), computer)
+	assert.ErrorIs(t, err, repository.ErrEmployeeNotFound)
+}
+
+func testNotFound(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createEmployee(t, b, "ABC", "IT")
+	missing := uuid.New()
+
+	_, err := b.Computers.GetComputerByID(ctx, missing)
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+
+	_, err = b.Computers.GetComputerByIDForUpdate(ctx, missing)
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+
+	_, err = b.Computers.GetComputerByMAC(ctx, "AA:BB:CC:DD:EE:99")
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+
+	err = b.Computers.UpdateComputer(ctx, missing, fixture("MISSING", "AA:BB:CC:DD:EE:99", "10.0.0.1"))
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+
+	err = b.Computers.DeleteComputer(ctx, missing)
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+
+	err = b.Computers.AssignComputerToEmployee(ctx, missing, "ABC")
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+
+	err = b.Computers.RemoveComputerFromEmployee(ctx, missing, "ABC")
+	assert.ErrorIs(t, err, repository.ErrNotAssigned)
+
+	_, err = b.Employees.GetEmployeeByAbbreviation(ctx, "XYZ")
+	assert.ErrorIs(t, err, repository.ErrEmployeeNotFound)
+}
+
+func testUpdate(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createEmployee(t, b, "ABC", "IT")
+	stored := createComputers(t, b,
+		fixture("FIRST", "AA:BB:CC:DD:EE:01", "10.0.0.1"),
+		fixture("SECOND", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+	)
+	first := stored[0]
+
+	changed := first
+	changed.ComputerName = "RENAMED"
+	changed.IPAddress = "10.0.0.100"
+	changed.Description = "Moved"
+	changed.EmployeeAbbreviation = "ABC"
+	require.NoError(t, b.Computers.UpdateComputer(ctx, first.ID, changed))
+
+	got, err := b.Computers.GetComputerByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "RENAMED", got.ComputerName)
+	assert.Equal(t, "10.0.0.100", got.IPAddress)
+	assert.Equal(t, "Moved", got.Description)
+	assert.Equal(t, "ABC", got.EmployeeAbbreviation)
+	assert.Equal(t, first.Version+1, got.Version, "every update increments the version")
+	assert.True(t, first.CreatedAt.Equal(got.CreatedAt))
+	assert.False(t, got.UpdatedAt.Before(first.UpdatedAt))
+
+	taken := *got
+	taken.MACAddress = "AA:BB:CC:DD:EE:02"
+	err = b.Computers.UpdateComputer(ctx, first.ID, taken)
+	assert.ErrorIs(t, err, repository.ErrDuplicateMAC)
+
+	unknownEmployee := *got
+	unknownEmployee.EmployeeAbbreviation = "XYZ"
+	err = b.Computers.UpdateComputer(ctx, first.ID, unknownEmployee)
+	assert.ErrorIs(t, err, repository.ErrEmployeeNotFound)
+
+	unchanged, err := b.Computers.GetComputerByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, got.Version, unchanged.Version, "failed updates leave the computer unchanged")
+	assert.Equal(t, "AA:BB:CC:DD:EE:01", unchanged.MACAddress)
+}
+
+func testAssignAndRemove(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createEmployee(t, b, "ABC", "IT")
+	createEmployee(t, b, "DEF", "IT")
+	computer := createComputers(t, b, fixture("LAPTOP", "AA:BB:CC:DD:EE:01", "10.0.0.1"))[0]
+
+	require.NoError(t, b.Computers.AssignComputerToEmployee(ctx, computer.ID, "ABC"))
+	got, err := b.Computers.GetComputerByID(ctx, computer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ABC", got.EmployeeAbbreviation)
+	assert.Equal(t, computer.Version+1, got.Version)
+
+	assigned, err := b.Computers.GetComputersByEmployee(ctx, "ABC")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"LAPTOP"}, names(assigned))
+
+	err = b.Computers.AssignComputerToEmployee(ctx, computer.ID, "XYZ")
+	assert.ErrorIs(t, err, repository.ErrEmployeeNotFound)
+
+	err = b.Computers.RemoveComputerFromEmployee(ctx, computer.ID, "DEF")
+	assert.ErrorIs(t, err, repository.ErrNotAssigned)
+
+	require.NoError(t, b.Computers.RemoveComputerFromEmployee(ctx, computer.ID, "ABC"))
+	got, err = b.Computers.GetComputerByID(ctx, computer.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.EmployeeAbbreviation)
+	assert.Equal(t, computer.Version+2, got.Version)
+
+	err = b.Computers.RemoveComputerFromEmployee(ctx, computer.ID, "ABC")
+	assert.ErrorIs(t, err, repository.ErrNotAssigned)
+
+	assigned, err = b.Computers.GetComputersByEmployee(ctx, "ABC")
+	require.NoError(t, err)
+	assert.Empty(t, assigned)
+}
+
+func testDelete(t *testing.T, b Backend) {
+	ctx := context.Background()
+	computer := createComputers(t, b, fixture("DOOMED", "AA:BB:CC:DD:EE:01", "10.0.0.1"))[0]
+
+	require.NoError(t, b.Computers.DeleteComputer(ctx, computer.ID))
+
+	_, err := b.Computers.GetComputerByID(ctx, computer.ID)
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+
+	err = b.Computers.DeleteComputer(ctx, computer.ID)
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+
+	// The MAC address is free again
+	require.NoError(t, b.Computers.CreateComputer(ctx, fixture("REPLACEMENT", "AA:BB:CC:DD:EE:01", "10.0.0.1")))
+}
+
+func testEmployeeInUse(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createEmployee(t, b, "ABC", "IT")
+	computer := fixture("LAPTOP", "AA:BB:CC:DD:EE:01", "10.0.0.1")
+	computer.EmployeeAbbreviation = "ABC"
+	createComputers(t, b, computer)
+
+	err := b.Employees.DeleteEmployee(ctx, "ABC")
+	assert.ErrorIs(t, err, repository.ErrEmployeeInUse)
+
+	require.NoError(t, b.Computers.RemoveComputerFromEmployee(ctx, computer.ID, "ABC"))
+	require.NoError(t, b.Employees.DeleteEmployee(ctx, "ABC"))
+
+	err = b.Employees.DeleteEmployee(ctx, "ABC")
+	assert.ErrorIs(t, err, repository.ErrEmployeeNotFound)
+}
+
+func testGetAllComputersOrderedByName(t *testing.T, b Backend) {
+	createComputers(t, b,
+		fixture("CHARLIE", "AA:BB:CC:DD:EE:01", "10.0.0.1"),
+		fixture("ALPHA", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+		fixture("BRAVO", "AA:BB:CC:DD:EE:03", "10.0.0.3"),
+	)
+
+	all, err := b.Computers.GetAllComputers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ALPHA", "BRAVO", "CHARLIE"}, names(all))
+}
+
+func testOffsetPagination(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createComputers(t, b,
+		fixture("PC-5", "AA:BB:CC:DD:EE:05", "10.0.0.5"),
+		fixture("PC-1", "AA:BB:CC:DD:EE:01", "10.0.0.1"),
+		fixture("PC-4", "AA:BB:CC:DD:EE:04", "10.0.0.4"),
+		fixture("PC-2", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+		fixture("PC-3", "AA:BB:CC:DD:EE:03", "10.0.0.3"),
+	)
+
+	page, err := b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{}, repository.PaginationParams{Offset: 2, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PC-3", "PC-4"}, names(page.Items))
+	assert.Equal(t, 5, page.TotalCount)
+	assert.False(t, page.TotalCountEstimated)
+	assert.NotNil(t, page.NextCursor, "another page follows")
+
+	page, err = b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{}, repository.PaginationParams{Offset: 4, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PC-5"}, names(page.Items))
+	assert.Nil(t, page.NextCursor, "last page")
+
+	page, err = b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{}, repository.PaginationParams{Offset: 10, Limit: 2})
+	require.NoError(t, err)
+	assert.Empty(t, page.Items)
+	assert.Equal(t, 5, page.TotalCount)
+}
+
+func testCursorPagination(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createComputers(t, b,
+		fixture("A", "AA:BB:CC:DD:EE:01", "10.0.0.9"),
+		fixture("B", "AA:BB:CC:DD:EE:02", "10.0.0.10"),
+		fixture("C", "AA:BB:CC:DD:EE:03", "10.0.0.10"),
+		fixture("D", "AA:BB:CC:DD:EE:04", "9.255.255.255"),
+		fixture("E", "AA:BB:CC:DD:EE:05", "10.0.1.0"),
+	)
+
+	sort, err := repository.ParseComputerSort("-ip_address,computer_name")
+	require.NoError(t, err)
+	filter := repository.ComputerFilter{Sort: sort}
+
+	var seen []string
+	params := repository.PaginationParams{Limit: 2}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "pagination does not terminate")
+
+		page, err := b.Computers.GetAllComputersPaginated(ctx, filter, params)
+		require.NoError(t, err)
+		assert.Equal(t, 5, page.TotalCount, "the total counts every match, not just those after the cursor")
+		seen = append(seen, names(page.Items)...)
+
+		if page.NextCursor == nil {
+			break
+		}
+		params.After = page.NextCursor
+	}
+	assert.Equal(t, []string{"E", "B", "C", "A", "D"}, seen)
+
+	// A cursor issued for another sort order is rejected
+	_, err = b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{}, params)
+	assert.Error(t, err)
+}
+
+func testSort(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createEmployee(t, b, "ABC", "IT")
+	createEmployee(t, b, "DEF", "IT")
+
+	first := fixture("X-RAY", "AA:BB:CC:DD:EE:03", "10.0.0.20")
+	first.EmployeeAbbreviation = "DEF"
+	second := fixture("YANKEE", "AA:BB:CC:DD:EE:01", "10.0.0.3")
+	third := fixture("ZULU", "AA:BB:CC:DD:EE:02", "10.0.0.100")
+	third.EmployeeAbbreviation = "ABC"
+	createComputers(t, b, first, second, third)
+
+	tests := []struct {
+		sort     string
+		expected []string
+	}{
+		{"computer_name", []string{"X-RAY", "YANKEE", "ZULU"}},
+		{"-computer_name", []string{"ZULU", "YANKEE", "X-RAY"}},
+		{"mac_address", []string{"YANKEE", "ZULU", "X-RAY"}},
+		{"ip_address", []string{"YANKEE", "X-RAY", "ZULU"}},
+		{"employee_abbreviation", []string{"YANKEE", "ZULU", "X-RAY"}},
+		{"-employee_abbreviation", []string{"X-RAY", "ZULU", "YANKEE"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			sort, err := repository.ParseComputerSort(tt.sort)
+			require.NoError(t, err)
+
+			page, err := b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{Sort: sort}, repository.PaginationParams{Limit: 10})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, names(page.Items))
+		})
+	}
+
+	_, err := b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{Sort: []repository.SortField{{Field: "password"}}}, repository.PaginationParams{Limit: 10})
+	assert.ErrorIs(t, err, repository.ErrInvalidSortField)
+}
+
+func testFilters(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createEmployee(t, b, "ABC", "IT")
+
+	office := fixture("OFFICE-PC", "00:1B:44:11:3A:B7", "192.168.1.10")
+	office.EmployeeAbbreviation = "ABC"
+	office.Description = "Reception desk workstation"
+	lab := fixture("LAB-SERVER", "00:1B:44:22:00:01", "10.1.2.3")
+	lab.Description = "Build server in the lab"
+	spare := fixture("SPARE-LAPTOP", "AA:BB:CC:00:00:01", "192.168.2.20")
+	spare.Description = ""
+	createComputers(t, b, office, lab, spare)
+
+	_, subnet, err := net.ParseCIDR("192.168.0.0/16")
+	require.NoError(t, err)
+	assigned, unassigned := true, false
+
+	tests := []struct {
+		name     string
+		filter   repository.ComputerFilter
+		expected []string
+	}{
+		{"mac prefix", repository.ComputerFilter{MACPrefix: "00:1B:44"}, []string{"LAB-SERVER", "OFFICE-PC"}},
+		{"any mac prefix", repository.ComputerFilter{MACPrefixes: []string{"00:1B:44:2", "AA:BB:C"}}, []string{"LAB-SERVER", "SPARE-LAPTOP"}},
+		{"no mac prefixes", repository.ComputerFilter{MACPrefixes: []string{}}, nil},
+		{"subnet", repository.ComputerFilter{Subnet: subnet}, []string{"OFFICE-PC", "SPARE-LAPTOP"}},
+		{"employee", repository.ComputerFilter{EmployeeAbbreviation: "ABC"}, []string{"OFFICE-PC"}},
+		{"assigned", repository.ComputerFilter{Assigned: &assigned}, []string{"OFFICE-PC"}},
+		{"unassigned", repository.ComputerFilter{Assigned: &unassigned}, []string{"LAB-SERVER", "SPARE-LAPTOP"}},
+		{"search name", repository.ComputerFilter{Query: "laptop"}, []string{"SPARE-LAPTOP"}},
+		{"search description", repository.ComputerFilter{Query: "server"}, []string{"LAB-SERVER"}},
+		{"search all words", repository.ComputerFilter{Query: "build lab"}, []string{"LAB-SERVER"}},
+		{"search without match", repository.ComputerFilter{Query: "printer"}, nil},
+		{"combined", repository.ComputerFilter{MACPrefix: "00:1B:44", Subnet: subnet}, []string{"OFFICE-PC"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := b.Computers.GetAllComputersPaginated(ctx, tt.filter, repository.PaginationParams{Limit: 10})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, nilIfEmpty(names(page.Items)))
+			assert.Equal(t, len(tt.expected), page.TotalCount)
+		})
+	}
+}
+
+// nilIfEmpty maps an empty slice to nil, so expectations of no results read naturally
+func nilIfEmpty(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func testCountModes(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createComputers(t, b,
+		fixture("PC-1", "AA:BB:CC:DD:EE:01", "10.0.0.1"),
+		fixture("PC-2", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+	)
+
+	page, err := b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{}, repository.PaginationParams{Limit: 1, Count: repository.CountNone})
+	require.NoError(t, err)
+	assert.Equal(t, repository.UnknownTotalCount, page.TotalCount)
+	assert.Len(t, page.Items, 1)
+	assert.NotNil(t, page.NextCursor)
+
+	page, err = b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{}, repository.PaginationParams{Limit: 1, Count: repository.CountExact})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.TotalCount)
+
+	page, err = b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{}, repository.PaginationParams{Limit: 1, Count: repository.CountEstimated})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, page.TotalCount, 0, "estimates may be approximate but are never unknown")
+}
+
+func testStreamComputers(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createComputers(t, b,
+		fixture("CHARLIE", "AA:BB:CC:DD:EE:01", "10.0.0.1"),
+		fixture("ALPHA", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+		fixture("BRAVO", "BB:BB:CC:DD:EE:03", "10.0.0.3"),
+	)
+
+	var streamed []string
+	err := b.Computers.StreamComputers(ctx, repository.ComputerFilter{MACPrefix: "AA"}, func(c model.Computer) error {
+		streamed = append(streamed, c.ComputerName)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ALPHA", "CHARLIE"}, streamed)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = b.Computers.StreamComputers(ctx, repository.ComputerFilter{}, func(c model.Computer) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls, "streaming stops at the first error")
+}
+
+func testGetComputersByMACs(t *testing.T, b Backend) {
+	createComputers(t, b,
+		fixture("ALPHA", "AA:BB:CC:DD:EE:01", "10.0.0.1"),
+		fixture("BRAVO", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+		fixture("CHARLIE", "AA:BB:CC:DD:EE:03", "10.0.0.3"),
+	)
+
+	found, err := b.Computers.GetComputersByMACs(context.Background(), []string{"AA:BB:CC:DD:EE:03", "AA:BB:CC:DD:EE:99", "AA:BB:CC:DD:EE:01"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"ALPHA", "CHARLIE"}, names(found))
+
+	found, err = b.Computers.GetComputersByMACs(context.Background(), []string{})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func testEmployeePagination(t *testing.T, b Backend) {
+	ctx := context.Background()
+	createEmployee(t, b, "CCC", "IT")
+	createEmployee(t, b, "AAA", "Sales")
+	createEmployee(t, b, "BBB", "IT")
+
+	err := b.Employees.CreateEmployee(ctx, model.Employee{Abbreviation: "AAA", Name: "Duplicate", Active: true})
+	assert.ErrorIs(t, err, repository.ErrDuplicateEmployee)
+
+	page, err := b.Employees.GetAllEmployeesPaginated(ctx, repository.PaginationParams{Offset: 1, Limit: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.TotalCount)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "BBB", page.Items[0].Abbreviation)
+	assert.Equal(t, "CCC", page.Items[1].Abbreviation)
+
+	deactivated := page.Items[0]
+	deactivated.Active = false
+	require.NoError(t, b.Employees.UpdateEmployee(ctx, "BBB", deactivated))
+	got, err := b.Employees.GetEmployeeByAbbreviation(ctx, "BBB")
+	require.NoError(t, err)
+	assert.False(t, got.Active)
+	require.NotNil(t, got.DeactivatedAt)
+
+	deactivated.Active = true
+	require.NoError(t, b.Employees.UpdateEmployee(ctx, "BBB", deactivated))
+	got, err = b.Employees.GetEmployeeByAbbreviation(ctx, "BBB")
+	require.NoError(t, err)
+	assert.True(t, got.Active)
+	assert.Nil(t, got.DeactivatedAt)
+
+	err = b.Employees.UpdateEmployee(ctx, "XYZ", deactivated)
+	assert.ErrorIs(t, err, repository.ErrEmployeeNotFound)
+}
@@ -0,0 +1,326 @@
+package repositorytest
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// secondaryInterface is the interface added to a computer by the cases
+func secondaryInterface(computerID uuid.UUID, mac string, interfaceType model.NetworkInterfaceType) model.NetworkInterface {
+	return model.NetworkInterface{
+		ID:         uuid.New(),
+		ComputerID: computerID,
+		MACAddress: mac,
+		Type:       interfaceType,
+	}
+}
+
+// interfaceMACs returns the MAC addresses of the interfaces in order
+func interfaceMACs(interfaces []model.NetworkInterface) []string {
+	result := make([]string, len(interfaces))
+	for i, iface := range interfaces {
+		result[i] = iface.MACAddress
+	}
+	return result
+}
+
+func testPrimaryInterface(t *testing.T, b Backend) {
+	ctx := context.Background()
+	computer := createComputers(t, b, fixture("LAPTOP", "AA:BB:CC:DD:EE:01", "10.0.0.1"))[0]
+
+	interfaces, err := b.Interfaces.GetInterfacesByComputer(ctx, computer.ID)
+	require.NoError(t, err)
+	require.Len(t, interfaces, 1, "a new computer has its primary interface")
+	primary := interfaces[0]
+	assert.True(t, primary.Primary)
+	assert.Equal(t, computer.ID, primary.ComputerID)
+	assert.Equal(t, "AA:BB:CC:DD:EE:01", primary.MACAddress)
+	assert.Equal(t, "10.0.0.1", primary.IPv4Address)
+	assert.Equal(t, model.NetworkInterfaceTypeEthernet, primary.Type)
+
+	changed := computer
+	changed.MACAddress = "AA:BB:CC:DD:EE:02"
+	changed.IPAddress = "10.0.0.2"
+	require.NoError(t, b.Computers.UpdateComputer(ctx, computer.ID, changed))
+
+	synced, err := b.Interfaces.GetInterfaceByID(ctx, computer.ID, primary.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "AA:BB:CC:DD:EE:02", synced.MACAddress, "the primary interface follows the computer")
+	assert.Equal(t, "10.0.0.2", synced.IPv4Address)
+	_, err = b.Interfaces.GetInterfaceByMAC(ctx, "AA:BB:CC:DD:EE:01")
+	assert.ErrorIs(t, err, repository.ErrInterfaceNotFound)
+
+	require.NoError(t, b.Computers.DeleteComputer(ctx, computer.ID))
+	interfaces, err = b.Interfaces.GetInterfacesByComputer(ctx, computer.ID)
+	require.NoError(t, err)
+	assert.Empty(t, interfaces, "interfaces are deleted with their computer")
+}
+
+func testInterfaceLifecycle(t *testing.T, b Backend) {
+	ctx := context.Background()
+	stored := createComputers(t, b,
+		fixture("LAPTOP", "AA:BB:CC:DD:EE:01", "10.0.0.1"),
+		fixture("DESKTOP", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+	)
+	laptop, desktop := stored[0], stored[1]
+
+	wifi := secondaryInterface(laptop.ID, "aa-bb-cc-dd-ee-10", model.NetworkInterfaceTypeWiFi)
+	wifi.IPv4Address = "10.0.1.1"
+	wifi.IPv6Address = "2001:db8::1"
+	wifi.Primary = true
+	require.NoError(t, b.Interfaces.CreateInterface(ctx, wifi))
+	dock := secondaryInterface(laptop.ID, "AA:BB:CC:DD:EE:11", model.NetworkInterfaceTypeDock)
+	require.NoError(t, b.Interfaces.CreateInterface(ctx, dock))
+
+	got, err := b.Interfaces.GetInterfaceByID(ctx, laptop.ID, wifi.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "AA:BB:CC:DD:EE:10", got.MACAddress, "MAC address is normalized")
+	assert.Equal(t, model.NetworkInterfaceTypeWiFi, got.Type)
+	assert.Equal(t, "10.0.1.1", got.IPv4Address)
+	assert.Equal(t, "2001:db8::1", got.IPv6Address)
+	assert.False(t, got.Primary, "new interfaces are secondary")
+	assert.False(t, got.CreatedAt.IsZero())
+
+	byMAC, err := b.Interfaces.GetInterfaceByMAC(ctx, "AA:BB:CC:DD:EE:11")
+	require.NoError(t, err)
+	assert.Equal(t, dock.ID, byMAC.ID)
+	assert.Empty(t, byMAC.IPv4Address)
+
+	interfaces, err := b.Interfaces.GetInterfacesByComputer(ctx, laptop.ID)
+	require.NoError(t, err)
+	require.Len(t, interfaces, 3)
+	assert.Equal(t, "AA:BB:CC:DD:EE:01", interfaces[0].MACAddress, "the primary interface comes first")
+	assert.ElementsMatch(t, []string{"AA:BB:CC:DD:EE:10", "AA:BB:CC:DD:EE:11"}, interfaceMACs(interfaces[1:]))
+
+	// Interfaces are only found through the computer they belong to
+	_, err = b.Interfaces.GetInterfaceByID(ctx, desktop.ID, wifi.ID)
+	assert.ErrorIs(t, err, repository.ErrInterfaceNotFound)
+	err = b.Interfaces.DeleteInterface(ctx, desktop.ID, wifi.ID)
+	assert.ErrorIs(t, err, repository.ErrInterfaceNotFound)
+
+	changed := *got
+	changed.MACAddress = "AA:BB:CC:DD:EE:12"
+	changed.Type = model.NetworkInterfaceTypeOther
+	changed.IPv4Address = ""
+	changed.IPv6Address = "2001:db8::2"
+	require.NoError(t, b.Interfaces.UpdateInterface(ctx, changed))
+	got, err = b.Interfaces.GetInterfaceByID(ctx, laptop.ID, wifi.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "AA:BB:CC:DD:EE:12", got.MACAddress)
+	assert.Equal(t, model.NetworkInterfaceTypeOther, got.Type)
+	assert.Empty(t, got.IPv4Address)
+	assert.Equal(t, "2001:db8::2", got.IPv6Address)
+
+	missing := changed
+	missing.ID = uuid.New()
+	err = b.Interfaces.UpdateInterface(ctx, missing)
+	assert.ErrorIs(t, err, repository.ErrInterfaceNotFound)
+
+	require.NoError(t, b.Interfaces.DeleteInterface(ctx, laptop.ID, wifi.ID))
+	_, err = b.Interfaces.GetInterfaceByID(ctx, laptop.ID, wifi.ID)
+	assert.ErrorIs(t, err, repository.ErrInterfaceNotFound)
+	err = b.Interfaces.DeleteInterface(ctx, laptop.ID, wifi.ID)
+	assert.ErrorIs(t, err, repository.ErrInterfaceNotFound)
+
+	err = b.Interfaces.CreateInterface(ctx, secondaryInterface(uuid.New(), "AA:BB:CC:DD:EE:13", model.NetworkInterfaceTypeWiFi))
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+	err = b.Interfaces.CreateInterface(ctx, secondaryInterface(laptop.ID, "not-a-mac", model.NetworkInterfaceTypeWiFi))
+	assert.ErrorIs(t, err, repository.ErrInvalidMACFormat)
+}
+
+func testGetAllInterfaces(t *testing.T, b Backend) {
+	ctx := context.Background()
+
+	interfaces, err := b.Interfaces.GetAllInterfaces(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, interfaces)
+
+	stored := createComputers(t, b,
+		fixture("LAPTOP", "AA:BB:CC:DD:EE:01", "10.0.0.1"),
+		fixture("DESKTOP", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+	)
+	laptop, desktop := stored[0], stored[1]
+	require.NoError(t, b.Interfaces.CreateInterface(ctx, secondaryInterface(laptop.ID, "AA:BB:CC:DD:EE:10", model.NetworkInterfaceTypeWiFi)))
+	require.NoError(t, b.Interfaces.CreateInterface(ctx, secondaryInterface(desktop.ID, "AA:BB:CC:DD:EE:20", model.NetworkInterfaceTypeDock)))
+
+	interfaces, err = b.Interfaces.GetAllInterfaces(ctx)
+	require.NoError(t, err)
+	require.Len(t, interfaces, 4)
+
+	// Each computer's interfaces are adjacent, its primary interface first
+	for i := 0; i < len(interfaces); i += 2 {
+		assert.Equal(t, interfaces[i].ComputerID, interfaces[i+1].ComputerID)
+		assert.True(t, interfaces[i].Primary)
+		assert.False(t, interfaces[i+1].Primary)
+	}
+	assert.ElementsMatch(t, []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02", "AA:BB:CC:DD:EE:10", "AA:BB:CC:DD:EE:20"}, interfaceMACs(interfaces))
+}
+
+func testInterfaceMACUniqueness(t *testing.T, b Backend) {
+	ctx := context.Background()
+	stored := createComputers(t, b,
+		fixture("LAPTOP", "AA:BB:CC:DD:EE:01", "10.0.0.1"),
+		fixture("DESKTOP", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+	)
+	laptop, desktop := stored[0], stored[1]
+	dock := secondaryInterface(laptop.ID, "AA:BB:CC:DD:EE:10", model.NetworkInterfaceTypeDock)
+	require.NoError(t, b.Interfaces.CreateInterface(ctx, dock))
+
+	// Interfaces cannot take the MAC address of a computer or another interface
+	err := b.Interfaces.CreateInterface(ctx, secondaryInterface(laptop.ID, "AA:BB:CC:DD:EE:02", model.NetworkInterfaceTypeWiFi))
+	assert.ErrorIs(t, err, repository.ErrDuplicateMAC)
+	err = b.Interfaces.CreateInterface(ctx, secondaryInterface(desktop.ID, "AA:BB:CC:DD:EE:10", model.NetworkInterfaceTypeWiFi))
+	assert.ErrorIs(t, err, repository.ErrDuplicateMAC)
+
+	taken := dock
+	taken.MACAddress = "AA:BB:CC:DD:EE:02"
+	err = b.Interfaces.UpdateInterface(ctx, taken)
+	assert.ErrorIs(t, err, repository.ErrDuplicateMAC)
+
+	// Computers cannot take the MAC address of another computer's interface
+	err = b.Computers.CreateComputer(ctx, fixture("DUPLICATE", "AA:BB:CC:DD:EE:10", "10.0.0.3"))
+	assert.ErrorIs(t, err, repository.ErrDuplicateMAC)
+
+	changed := desktop
+	changed.MACAddress = "AA:BB:CC:DD:EE:10"
+	err = b.Computers.UpdateComputer(ctx, desktop.ID, changed)
+	assert.ErrorIs(t, err, repository.ErrDuplicateMAC)
+
+	unchanged, err := b.Computers.GetComputerByID(ctx, desktop.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "AA:BB:CC:DD:EE:02", unchanged.MACAddress)
+
+	// A deleted interface frees its MAC address
+	require.NoError(t, b.Interfaces.DeleteInterface(ctx, laptop.ID, dock.ID))
+	require.NoError(t, b.Interfaces.CreateInterface(ctx, secondaryInterface(desktop.ID, "AA:BB:CC:DD:EE:10", model.NetworkInterfaceTypeDock)))
+}
+
+func testSetPrimaryInterface(t *testing.T, b Backend) {
+	ctx := context.Background()
+	laptop := createComputers(t, b, fixture("LAPTOP", "AA:BB:CC:DD:EE:01", "10.0.0.1"))[0]
+	wifi := secondaryInterface(laptop.ID, "AA:BB:CC:DD:EE:10", model.NetworkInterfaceTypeWiFi)
+	require.NoError(t, b.Interfaces.CreateInterface(ctx, wifi))
+
+	err := b.Interfaces.SetPrimaryInterface(ctx, laptop.ID, uuid.New())
+	assert.ErrorIs(t, err, repository.ErrInterfaceNotFound)
+	interfaces, err := b.Interfaces.GetInterfacesByComputer(ctx, laptop.ID)
+	require.NoError(t, err)
+	require.Len(t, interfaces, 2)
+	assert.True(t, interfaces[0].Primary, "a failed promotion keeps the primary interface")
+	formerPrimary := interfaces[0]
+
+	require.NoError(t, b.Interfaces.SetPrimaryInterface(ctx, laptop.ID, wifi.ID))
+	interfaces, err = b.Interfaces.GetInterfacesByComputer(ctx, laptop.ID)
+	require.NoError(t, err)
+	require.Len(t, interfaces, 2)
+	assert.Equal(t, wifi.ID, interfaces[0].ID)
+	assert.True(t, interfaces[0].Primary)
+	assert.Equal(t, formerPrimary.ID, interfaces[1].ID)
+	assert.False(t, interfaces[1].Primary)
+
+	// Promoting the primary interface again changes nothing
+	require.NoError(t, b.Interfaces.SetPrimaryInterface(ctx, laptop.ID, wifi.ID))
+
+	// The computer now follows its new primary interface
+	changed := laptop
+	changed.MACAddress = "AA:BB:CC:DD:EE:10"
+	changed.IPAddress = "10.0.1.1"
+	require.NoError(t, b.Computers.UpdateComputer(ctx, laptop.ID, changed))
+	got, err := b.Interfaces.GetInterfaceByID(ctx, laptop.ID, wifi.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.1", got.IPv4Address)
+	former, err := b.Interfaces.GetInterfaceByID(ctx, laptop.ID, formerPrimary.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "AA:BB:CC:DD:EE:01", former.MACAddress, "demoted interfaces keep their addresses")
+}
+
+func testIPv6Addresses(t *testing.T, b Backend) {
+	ctx := context.Background()
+	stored := createComputers(t, b,
+		fixture("IPV6-PC", "AA:BB:CC:DD:EE:01", "2001:0DB8:0000:0000:0000:0000:0000:0010"),
+		fixture("IPV4-PC", "AA:BB:CC:DD:EE:02", "::ffff:10.0.0.2"),
+	)
+	ipv6, ipv4 := stored[0], stored[1]
+	assert.Equal(t, "2001:db8::10", ipv6.IPAddress, "IPv6 addresses are stored compressed")
+	assert.Equal(t, "10.0.0.2", ipv4.IPAddress, "IPv4-mapped addresses are stored as IPv4")
+
+	err := b.Computers.CreateComputer(ctx, fixture("ZONED-PC", "AA:BB:CC:DD:EE:03", "fe80::1%eth0"))
+	assert.Error(t, err, "zones are rejected")
+
+	interfaces, err := b.Interfaces.GetInterfacesByComputer(ctx, ipv6.ID)
+	require.NoError(t, err)
+	require.Len(t, interfaces, 1)
+	primary := interfaces[0]
+	assert.Empty(t, primary.IPv4Address)
+	assert.Equal(t, "2001:db8::10", primary.IPv6Address, "the primary interface gets the address in its family")
+
+	// A dual-stack computer keeps the address of the other family on its primary interface
+	primary.IPv4Address = "10.0.0.1"
+	require.NoError(t, b.Interfaces.UpdateInterface(ctx, primary))
+	changed := ipv6
+	changed.IPAddress = "2001:db8::11"
+	require.NoError(t, b.Computers.UpdateComputer(ctx, ipv6.ID, changed))
+	synced, err := b.Interfaces.GetInterfaceByID(ctx, ipv6.ID, primary.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", synced.IPv4Address)
+	assert.Equal(t, "2001:db8::11", synced.IPv6Address)
+
+	// IPv4 addresses sort before IPv6 addresses
+	page, err := b.Computers.GetAllComputersPaginated(ctx, repository.ComputerFilter{
+		Sort: []repository.SortField{{Field: "ip_address"}},
+	}, repository.PaginationParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"IPV4-PC", "IPV6-PC"}, names(page.Items))
+}
+
+func testAddressesInNetwork(t *testing.T, b Backend) {
+	ctx := context.Background()
+	stored := createComputers(t, b,
+		fixture("IPV6-PC", "AA:BB:CC:DD:EE:01", "2001:db8::10"),
+		fixture("IPV4-PC", "AA:BB:CC:DD:EE:02", "10.0.0.2"),
+		fixture("OTHER-PC", "AA:BB:CC:DD:EE:03", "172.16.0.3"),
+	)
+	ipv6, ipv4 := stored[0], stored[1]
+
+	wifi := secondaryInterface(ipv4.ID, "AA:BB:CC:DD:EE:10", model.NetworkInterfaceTypeWiFi)
+	wifi.IPv6Address = "2001:db8:1::1"
+	require.NoError(t, b.Interfaces.CreateInterface(ctx, wifi))
+	dock := secondaryInterface(ipv6.ID, "AA:BB:CC:DD:EE:11", model.NetworkInterfaceTypeDock)
+	dock.IPv4Address = "10.0.5.5"
+	require.NoError(t, b.Interfaces.CreateInterface(ctx, dock))
+
+	network := func(cidr string) *net.IPNet {
+		_, n, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		return n
+	}
+
+	tests := []struct {
+		name     string
+		filter   repository.ComputerFilter
+		expected []string
+	}{
+		{"IPv6 network", repository.ComputerFilter{IPIn: network("2001:db8::/32")}, []string{"IPV4-PC", "IPV6-PC"}},
+		{"IPv6 interface network", repository.ComputerFilter{IPIn: network("2001:db8:1::/48")}, []string{"IPV4-PC"}},
+		{"IPv4 network", repository.ComputerFilter{IPIn: network("10.0.0.0/8")}, []string{"IPV4-PC", "IPV6-PC"}},
+		{"single host", repository.ComputerFilter{IPIn: network("172.16.0.3/32")}, []string{"OTHER-PC"}},
+		{"no match", repository.ComputerFilter{IPIn: network("192.168.0.0/16")}, nil},
+		{"subnet only matches the computer's address", repository.ComputerFilter{Subnet: network("2001:db8::/32")}, []string{"IPV6-PC"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := b.Computers.GetAllComputersPaginated(ctx, tt.filter, repository.PaginationParams{Limit: 10})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, nilIfEmpty(names(page.Items)))
+			assert.Equal(t, len(tt.expected), page.TotalCount)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"modernc.org/sqlite"
+)
+
+// SQLiteDriver is the database/sql driver name to open SQLite databases used by the SQLite
+// repositories with. The driver is pure Go and provides the functions their queries need on
+// every connection: inet_key, ip_in_subnet, networks_overlap, computer_matches and
+// gen_random_uuid.
+const SQLiteDriver = "sqlite"
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("inet_key", 1, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		if key := inetKey(sqliteText(args[0])); key != nil {
+			return key, nil
+		}
+		return nil, nil
+	})
+	sqlite.MustRegisterDeterministicScalarFunction("ip_in_subnet", 2, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return ipInSubnet(sqliteText(args[0]), sqliteText(args[1])), nil
+	})
+	sqlite.MustRegisterDeterministicScalarFunction("networks_overlap", 2, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return networksOverlap(sqliteText(args[0]), sqliteText(args[1])), nil
+	})
+	sqlite.MustRegisterDeterministicScalarFunction("computer_matches", 3, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return searchMatches(sqliteText(args[0]), sqliteText(args[1]), sqliteText(args[2])), nil
+	})
+	sqlite.MustRegisterScalarFunction("gen_random_uuid", 0, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		return uuid.NewString(), nil
+	})
+}
+
+// sqliteText returns a function argument as text; NULL is empty
+func sqliteText(value driver.Value) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// sqliteTimeFormat stores timestamps as UTC text of a fixed width, so they sort in time order
+// and are parsed back by the driver from columns declared as TIMESTAMP
+const sqliteTimeFormat = "2006-01-02T15:04:05.000000Z"
+
+// sqliteTime formats a time for storage in SQLite
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeFormat)
+}
+
+// sqliteNow returns the current time with the microsecond precision stored in SQLite
+func sqliteNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// parseSQLiteTime parses a timestamp read through an expression, which the driver returns as text
+func parseSQLiteTime(value string) (time.Time, error) {
+	return time.Parse(sqliteTimeFormat, value)
+}
+
+// isSQLiteConstraint reports whether err is a violation of the given SQLite constraint, such
+// as sqlite3.SQLITE_CONSTRAINT_UNIQUE
+func isSQLiteConstraint(err error, code int) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == code
+}
+
+// NewSQLiteStore creates the repositories of a SQLite database opened with SQLiteDriver
+func NewSQLiteStore(db *sql.DB) *Store {
+	return &Store{
+		Computers:  NewSQLiteComputerRepository(db),
+		Employees:  &sqliteEmployeeRepository{DB: db},
+		Events:     &sqliteEventRepository{DB: db},
+		Outbox:     &sqliteOutboxRepository{DB: db},
+		Policies:   &sqlitePolicyRepository{DB: db},
+		APIKeys:    &sqliteAPIKeyRepository{DB: db},
+		Inventory:  &sqliteInventoryRepository{DB: db},
+		Interfaces: &sqliteNetworkInterfaceRepository{DB: db},
+		Subnets:    &sqliteSubnetRepository{DB: db},
+		Transactor: &sqlTransactor{DB: db, repositories: sqliteRepositories},
+	}
+}
+
+// NewSQLiteComputerRepository creates a ComputerRepository for a SQLite database opened with
+// SQLiteDriver. Every call is traced.
+func NewSQLiteComputerRepository(db *sql.DB) ComputerRepository {
+	return traceComputerRepository(&sqliteComputerRepository{DB: db}, semconv.DBSystemNameSQLite)
+}
+
+// sqliteRepositories returns the SQLite repositories bound to tx
+func sqliteRepositories(tx DBTX) Repositories {
+	return Repositories{
+		Computers:  traceComputerRepository(&sqliteComputerRepository{DB: tx}, semconv.DBSystemNameSQLite),
+		Employees:  &sqliteEmployeeRepository{DB: tx},
+		Events:     &sqliteEventRepository{DB: tx},
+		Outbox:     &sqliteOutboxRepository{DB: tx},
+		Policies:   &sqlitePolicyRepository{DB: tx},
+		Interfaces: &sqliteNetworkInterfaceRepository{DB: tx},
+		Subnets:    &sqliteSubnetRepository{DB: tx},
+	}
+}
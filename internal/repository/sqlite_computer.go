@@ -0,0 +1,516 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/pkg/validation"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// sqliteComputerRepository is the SQLite implementation of the ComputerRepository interface. The
+// queries mirror the PostgreSQL ones; inet ordering, subnet matching and full-text search use
+// the Go functions registered by SQLiteDriver, and versions and timestamps are maintained by
+// the queries rather than by triggers.
+type sqliteComputerRepository struct {
+	DB DBTX
+}
+
+const sqliteComputerColumns = `id, mac_address, computer_name, ip_address, employee_abbreviation, COALESCE(description, ''), version, created_at, updated_at`
+
+// sqliteSortColumn describes how computers are ordered by a sortable field in SQLite
+type sqliteSortColumn struct {
+	expression  string                                        // SQL expression rows are ordered by
+	placeholder string                                        // Format of a cursor value compared with the expression
+	value       func(cursorValue string) (interface{}, error) // Argument for a cursor value, nil to use it as is
+}
+
+// sqliteComputerSortColumns maps the sortable fields of computerSortColumns to SQLite expressions
+var sqliteComputerSortColumns = map[string]sqliteSortColumn{
+	"computer_name":         {expression: "computer_name", placeholder: "?%d"},
+	"mac_address":           {expression: "mac_address", placeholder: "?%d"},
+	"ip_address":            {expression: "inet_key(ip_address)", placeholder: "inet_key(?%d)"},
+	"employee_abbreviation": {expression: "COALESCE(employee_abbreviation, '')", placeholder: "?%d"},
+	"created_at":            {expression: "created_at", placeholder: "?%d", value: sqliteCursorTime},
+	"updated_at":            {expression: "updated_at", placeholder: "?%d", value: sqliteCursorTime},
+}
+
+// sqliteCursorTime converts a cursor's RFC 3339 timestamp to the stored form
+func sqliteCursorTime(value string) (interface{}, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return sqliteTime(t), nil
+}
+
+// CreateComputer adds a new computer to the database.
+func (r *sqliteComputerRepository) CreateComputer(ctx context.Context, computer model.Computer) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Validate and normalize MAC address
+	normalizedMAC, err := validation.ValidateMAC(computer.MACAddress)
+	if err != nil {
+		return ErrInvalidMACFormat
+	}
+	computer.MACAddress = normalizedMAC
+
+	// Validate and canonicalize IP address
+	normalizedIP, err := validation.ValidateIP(computer.IPAddress)
+	if err != nil {
+		return fmt.Errorf("invalid IP address: %w", err)
+	}
+	computer.IPAddress = normalizedIP
+
+	now := sqliteTime(sqliteNow())
+	query := `
+		INSERT INTO computers (id, mac_address, computer_name, ip_address, employee_abbreviation, description, version, created_at, updated_at)
+		VALUES (?1, ?2, ?3, ?4, NULLIF(?5, ''), ?6, 1, ?7, ?7)`
+
+	_, err = r.DB.ExecContext(ctx, query,
+		computer.ID,
+		computer.MACAddress,
+		computer.ComputerName,
+		computer.IPAddress,
+		computer.EmployeeAbbreviation,
+		computer.Description,
+		now,
+	)
+	if err != nil {
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) || isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+			return fmt.Errorf("%w: %s", ErrDuplicateMAC, computer.MACAddress)
+		}
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+			return fmt.Errorf("%w: %s", ErrEmployeeNotFound, computer.EmployeeAbbreviation)
+		}
+		return fmt.Errorf("failed to create computer: %w", err)
+	}
+
+	return nil
+}
+
+// GetAllComputers retrieves all computers from the database.
+func (r *sqliteComputerRepository) GetAllComputers(ctx context.Context) ([]model.Computer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + sqliteComputerColumns + ` FROM computers ORDER BY computer_name, id`
+
+	return r.queryComputers(ctx, query)
+}
+
+// GetAllComputersPaginated retrieves the computers matching filter with pagination support. One
+// row more than requested is fetched to tell whether another page follows without counting.
+// SQLite has no row estimates, so estimated counts are exact.
+func (r *sqliteComputerRepository) GetAllComputersPaginated(ctx context.Context, filter ComputerFilter, params PaginationParams) (*PaginatedResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	orderBy, err := sqliteOrderByClause(filter)
+	if err != nil {
+		return nil, err
+	}
+	conditions, args := sqliteConditions(filter, nil)
+	countWhere, countArgs := joinConditions(conditions), args
+
+	offset := params.Offset
+	if params.After != nil {
+		var keyset string
+		keyset, args, err = sqliteKeysetCondition(filter, params.After, append([]interface{}{}, args...))
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, keyset)
+		offset = 0
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM computers
+		%s
+		%s
+		LIMIT ?%d OFFSET ?%d`, sqliteComputerColumns, joinConditions(conditions), orderBy, len(args)+1, len(args)+2)
+
+	computers, err := r.queryComputers(ctx, query, append(args, params.Limit+1, offset)...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PaginatedResult{Items: computers}
+	if len(computers) > params.Limit {
+		result.Items = computers[:params.Limit]
+		result.NextCursor = filter.cursorFor(result.Items[len(result.Items)-1])
+	}
+
+	if params.Count == CountNone {
+		result.TotalCount = UnknownTotalCount
+		return result, nil
+	}
+	err = r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM computers `+countWhere, countArgs...).Scan(&result.TotalCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of computers: %w", err)
+	}
+	return result, nil
+}
+
+// StreamComputers calls fn for every computer matching filter, in the filter's sort order. The
+// first error returned by fn stops the iteration and is returned. No timeout is applied, the
+// caller's context bounds the query.
+func (r *sqliteComputerRepository) StreamComputers(ctx context.Context, filter ComputerFilter, fn func(model.Computer) error) error {
+	orderBy, err := sqliteOrderByClause(filter)
+	if err != nil {
+		return err
+	}
+	conditions, args := sqliteConditions(filter, nil)
+
+	query := fmt.Sprintf(`SELECT %s FROM computers %s %s`, sqliteComputerColumns, joinConditions(conditions), orderBy)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query computers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c, err := scanComputer(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan computer: %w", err)
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row iteration error: %w", err)
+	}
+	return nil
+}
+
+// ComputerExists checks if a computer with the given MAC address already exists
+func (r *sqliteComputerRepository) ComputerExists(ctx context.Context, macAddress string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var exists bool
+	err := r.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM computers WHERE mac_address = ?1)`, macAddress).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check computer existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetComputerByMAC retrieves a computer by its MAC address.
+func (r *sqliteComputerRepository) GetComputerByMAC(ctx context.Context, macAddress string) (*model.Computer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + sqliteComputerColumns + ` FROM computers WHERE mac_address = ?1`
+
+	c, err := scanComputer(r.DB.QueryRowContext(ctx, query, macAddress))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrComputerNotFound
+		}
+		return nil, fmt.Errorf("failed to get computer by MAC: %w", err)
+	}
+	return &c, nil
+}
+
+// GetComputersByMACs retrieves the computers with any of the given MAC addresses in a single query.
+// MAC addresses without a computer are skipped.
+func (r *sqliteComputerRepository) GetComputersByMACs(ctx context.Context, macAddresses []string) ([]model.Computer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if len(macAddresses) == 0 {
+		return nil, nil
+	}
+
+	// SQLite has no arrays, so the addresses are passed as a JSON array
+	addresses, err := json.Marshal(macAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode MAC addresses: %w", err)
+	}
+
+	query := `SELECT ` + sqliteComputerColumns + ` FROM computers WHERE mac_address IN (SELECT value FROM json_each(?1))`
+
+	return r.queryComputers(ctx, query, string(addresses))
+}
+
+// GetComputerByID retrieves a single computer by its ID.
+func (r *sqliteComputerRepository) GetComputerByID(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + sqliteComputerColumns + ` FROM computers WHERE id = ?1`
+
+	c, err := scanComputer(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrComputerNotFound
+		}
+		return nil, fmt.Errorf("failed to get computer by ID: %w", err)
+	}
+	return &c, nil
+}
+
+// GetComputerByIDForUpdate retrieves a single computer by its ID. SQLite has no row locks: a
+// write transaction locks the whole database, which also keeps the version from changing.
+func (r *sqliteComputerRepository) GetComputerByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	return r.GetComputerByID(ctx, id)
+}
+
+// UpdateComputer updates a computer in the database and increments its version.
+func (r *sqliteComputerRepository) UpdateComputer(ctx context.Context, id uuid.UUID, computer model.Computer) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE computers
+		SET mac_address = ?1, computer_name = ?2, ip_address = ?3, employee_abbreviation = NULLIF(?4, ''), description = ?5,
+			version = version + 1, updated_at = ?6
+		WHERE id = ?7`
+
+	result, err := r.DB.ExecContext(ctx, query,
+		computer.MACAddress,
+		computer.ComputerName,
+		computer.IPAddress,
+		computer.EmployeeAbbreviation,
+		computer.Description,
+		sqliteTime(sqliteNow()),
+		id,
+	)
+	if err != nil {
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+			return fmt.Errorf("%w: %s", ErrDuplicateMAC, computer.MACAddress)
+		}
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+			return fmt.Errorf("%w: %s", ErrEmployeeNotFound, computer.EmployeeAbbreviation)
+		}
+		return fmt.Errorf("failed to update computer: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrComputerNotFound)
+}
+
+// DeleteComputer deletes a computer from the database.
+func (r *sqliteComputerRepository) DeleteComputer(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM computers WHERE id = ?1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete computer: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrComputerNotFound)
+}
+
+// GetComputersByEmployee retrieves all computers for a specific employee.
+func (r *sqliteComputerRepository) GetComputersByEmployee(ctx context.Context, employeeAbbreviation string) ([]model.Computer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + sqliteComputerColumns + ` FROM computers WHERE employee_abbreviation = ?1 ORDER BY computer_name, id`
+
+	return r.queryComputers(ctx, query, employeeAbbreviation)
+}
+
+// GetComputersByEmployeePaginated retrieves all computers for a specific employee with pagination support.
+func (r *sqliteComputerRepository) GetComputersByEmployeePaginated(ctx context.Context, employeeAbbreviation string, params PaginationParams) (*PaginatedResult, error) {
+	return r.GetAllComputersPaginated(ctx, ComputerFilter{EmployeeAbbreviation: employeeAbbreviation}, params)
+}
+
+// RemoveComputerFromEmployee unassigns a computer if it is assigned to the given employee.
+func (r *sqliteComputerRepository) RemoveComputerFromEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE computers
+		SET employee_abbreviation = NULL, version = version + 1, updated_at = ?1
+		WHERE id = ?2 AND employee_abbreviation = ?3`
+
+	result, err := r.DB.ExecContext(ctx, query, sqliteTime(sqliteNow()), computerID, employeeAbbreviation)
+	if err != nil {
+		return fmt.Errorf("failed to remove computer from employee: %w", err)
+	}
+
+	return requireRowsAffected(result, fmt.Errorf("%w %s", ErrNotAssigned, employeeAbbreviation))
+}
+
+// AssignComputerToEmployee assigns a computer to a specific employee.
+func (r *sqliteComputerRepository) AssignComputerToEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE computers
+		SET employee_abbreviation = ?1, version = version + 1, updated_at = ?2
+		WHERE id = ?3`
+
+	result, err := r.DB.ExecContext(ctx, query, employeeAbbreviation, sqliteTime(sqliteNow()), computerID)
+	if err != nil {
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+			return fmt.Errorf("%w: %s", ErrEmployeeNotFound, employeeAbbreviation)
+		}
+		return fmt.Errorf("failed to assign computer to employee: %w", err)
+	}
+
+	return requireRowsAffected(result, fmt.Errorf("computer with ID %s not found: %w", computerID, ErrComputerNotFound))
+}
+
+// queryComputers runs a query returning computer rows
+func (r *sqliteComputerRepository) queryComputers(ctx context.Context, query string, args ...interface{}) ([]model.Computer, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query computers: %w", err)
+	}
+	defer rows.Close()
+
+	var computers []model.Computer
+	for rows.Next() {
+		c, err := scanComputer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan computer: %w", err)
+		}
+		computers = append(computers, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return computers, nil
+}
+
+// sqliteConditions translates the filter into parameterized SQLite conditions, numbering the
+// placeholders after the given args
+func sqliteConditions(f ComputerFilter, args []interface{}) ([]string, []interface{}) {
+	var conditions []string
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if f.MACPrefix != "" {
+		// LIKE ignores case in SQLite, unlike in PostgreSQL
+		add("instr(mac_address, ?%d) = 1", f.MACPrefix)
+	}
+	if f.MACPrefixes != nil {
+		prefixes, _ := json.Marshal(f.MACPrefixes) // Encoding strings cannot fail
+		add("EXISTS (SELECT 1 FROM json_each(?%d) WHERE instr(mac_address, json_each.value) = 1)", string(prefixes))
+	}
+	if f.Subnet != nil {
+		add("ip_in_subnet(ip_address, ?%d)", f.Subnet.String())
+	}
+	if f.IPIn != nil {
+		add("(ip_in_subnet(ip_address, ?%[1]d) OR EXISTS (SELECT 1 FROM network_interfaces ni WHERE ni.computer_id = computers.id"+
+			" AND (ip_in_subnet(COALESCE(ni.ipv4_address, ''), ?%[1]d) OR ip_in_subnet(COALESCE(ni.ipv6_address, ''), ?%[1]d))))", f.IPIn.String())
+	}
+	if f.EmployeeAbbreviation != "" {
+		add("employee_abbreviation = ?%d", f.EmployeeAbbreviation)
+	}
+	if f.Assigned != nil {
+		if *f.Assigned {
+			conditions = append(conditions, "employee_abbreviation IS NOT NULL")
+		} else {
+			conditions = append(conditions, "employee_abbreviation IS NULL")
+		}
+	}
+	if !f.CreatedAfter.IsZero() {
+		add("created_at >= ?%d", sqliteTime(f.CreatedAfter))
+	}
+	if !f.CreatedBefore.IsZero() {
+		add("created_at < ?%d", sqliteTime(f.CreatedBefore))
+	}
+	if !f.UpdatedAfter.IsZero() {
+		add("updated_at >= ?%d", sqliteTime(f.UpdatedAfter))
+	}
+	if !f.UpdatedBefore.IsZero() {
+		add("updated_at < ?%d", sqliteTime(f.UpdatedBefore))
+	}
+	if f.Query != "" {
+		add("computer_matches(computer_name, COALESCE(description, ''), ?%d)", f.Query)
+	}
+
+	return conditions, args
+}
+
+// sqliteOrderByClause translates the sort fields into an ORDER BY clause, with the ID breaking ties
+func sqliteOrderByClause(f ComputerFilter) (string, error) {
+	sort := f.sortFields()
+
+	terms := make([]string, 0, len(sort)+1)
+	for _, field := range sort {
+		column, ok := sqliteComputerSortColumns[field.Field]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrInvalidSortField, field.Field)
+		}
+		term := column.expression
+		if field.Descending {
+			term += " DESC"
+		}
+		terms = append(terms, term)
+	}
+	terms = append(terms, "id")
+
+	return "ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// sqliteKeysetCondition translates a cursor into a condition matching the rows after it in the
+// filter's sort order, like ComputerFilter.keysetCondition does for PostgreSQL
+func sqliteKeysetCondition(f ComputerFilter, cursor *Cursor, args []interface{}) (string, []interface{}, error) {
+	sort := f.sortFields()
+	if cursor.Sort != f.sortKey() || len(cursor.Values) != len(sort) {
+		return "", nil, fmt.Errorf("%w: issued for a different sort order", ErrInvalidCursor)
+	}
+
+	type key struct {
+		expression  string
+		operator    string
+		placeholder string
+	}
+	keys := make([]key, 0, len(sort)+1)
+	for i, field := range sort {
+		column, ok := sqliteComputerSortColumns[field.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s", ErrInvalidSortField, field.Field)
+		}
+		operator := ">"
+		if field.Descending {
+			operator = "<"
+		}
+		var value interface{} = cursor.Values[i]
+		if column.value != nil {
+			var err error
+			if value, err = column.value(cursor.Values[i]); err != nil {
+				return "", nil, err
+			}
+		}
+		args = append(args, value)
+		keys = append(keys, key{column.expression, operator, fmt.Sprintf(column.placeholder, len(args))})
+	}
+	args = append(args, cursor.ID.String())
+	keys = append(keys, key{"id", ">", fmt.Sprintf("?%d", len(args))})
+
+	alternatives := make([]string, 0, len(keys))
+	for i, k := range keys {
+		terms := make([]string, 0, i+1)
+		for _, previous := range keys[:i] {
+			terms = append(terms, previous.expression+" = "+previous.placeholder)
+		}
+		terms = append(terms, k.expression+" "+k.operator+" "+k.placeholder)
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args, nil
+}
@@ -0,0 +1,1103 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/pkg/validation"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// The SQLite implementations of the repositories other than ComputerRepository. They mirror
+// the PostgreSQL ones; only the SQL dialect differs.
+
+// sqliteEmployeeRepository is the SQLite implementation of the EmployeeRepository interface.
+type sqliteEmployeeRepository struct {
+	DB DBTX
+}
+
+// CreateEmployee adds a new employee to the database.
+func (r *sqliteEmployeeRepository) CreateEmployee(ctx context.Context, employee model.Employee) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO employees (abbreviation, name, email, department, active, created_at, updated_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?6)`
+
+	_, err := r.DB.ExecContext(ctx, query,
+		employee.Abbreviation,
+		employee.Name,
+		employee.Email,
+		employee.Department,
+		employee.Active,
+		sqliteTime(sqliteNow()),
+	)
+	if err != nil {
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+			return fmt.Errorf("%w: %s", ErrDuplicateEmployee, employee.Abbreviation)
+		}
+		return fmt.Errorf("failed to create employee: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmployeeByAbbreviation retrieves a single employee by abbreviation.
+func (r *sqliteEmployeeRepository) GetEmployeeByAbbreviation(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT abbreviation, name, email, department, active, deactivated_at, created_at, updated_at
+		FROM employees
+		WHERE abbreviation = ?1`
+
+	e, err := scanEmployee(r.DB.QueryRowContext(ctx, query, abbreviation))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEmployeeNotFound
+		}
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+	return &e, nil
+}
+
+// GetEmployeeByAbbreviationForUpdate retrieves a single employee by abbreviation. SQLite has no
+// row locks: a write transaction locks the whole database.
+func (r *sqliteEmployeeRepository) GetEmployeeByAbbreviationForUpdate(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	return r.GetEmployeeByAbbreviation(ctx, abbreviation)
+}
+
+// GetAllEmployeesPaginated retrieves all employees with pagination support.
+func (r *sqliteEmployeeRepository) GetAllEmployeesPaginated(ctx context.Context, params PaginationParams) (*EmployeePaginatedResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT abbreviation, name, email, department, active, deactivated_at, created_at, updated_at
+		FROM employees
+		ORDER BY abbreviation
+		LIMIT ?2 OFFSET ?1`
+
+	rows, err := r.DB.QueryContext(ctx, query, params.Offset, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query employees: %w", err)
+	}
+	defer rows.Close()
+
+	var employees []model.Employee
+	for rows.Next() {
+		e, err := scanEmployee(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee: %w", err)
+		}
+		employees = append(employees, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	var totalCount int
+	if err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM employees`).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to get total count of employees: %w", err)
+	}
+
+	return &EmployeePaginatedResult{
+		Items:      employees,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// UpdateEmployee updates an employee. Deactivating an employee records the time they left,
+// reactivating clears it.
+func (r *sqliteEmployeeRepository) UpdateEmployee(ctx context.Context, abbreviation string, employee model.Employee) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE employees
+		SET name = ?1, email = ?2, department = ?3, active = ?4,
+			deactivated_at = CASE WHEN ?4 THEN NULL ELSE COALESCE(deactivated_at, ?5) END,
+			updated_at = ?5
+		WHERE abbreviation = ?6`
+
+	result, err := r.DB.ExecContext(ctx, query,
+		employee.Name,
+		employee.Email,
+		employee.Department,
+		employee.Active,
+		sqliteTime(sqliteNow()),
+		abbreviation,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update employee: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrEmployeeNotFound)
+}
+
+// DeleteEmployee deletes an employee. Employees that still have computers assigned cannot be deleted.
+func (r *sqliteEmployeeRepository) DeleteEmployee(ctx context.Context, abbreviation string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM employees WHERE abbreviation = ?1`, abbreviation)
+	if err != nil {
+		// SQLite reports ON DELETE RESTRICT through the trigger that implements it
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) || isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_TRIGGER) {
+			return fmt.Errorf("%w: %s", ErrEmployeeInUse, abbreviation)
+		}
+		return fmt.Errorf("failed to delete employee: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrEmployeeNotFound)
+}
+
+// sqliteEventRepository is the SQLite implementation of the EventRepository interface.
+type sqliteEventRepository struct {
+	DB DBTX
+}
+
+// RecordEvent appends an event to the audit trail.
+func (r *sqliteEventRepository) RecordEvent(ctx context.Context, event model.ComputerEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	oldValue, err := marshalSnapshot(event.OldValue)
+	if err != nil {
+		return fmt.Errorf("failed to encode old value: %w", err)
+	}
+	newValue, err := marshalSnapshot(event.NewValue)
+	if err != nil {
+		return fmt.Errorf("failed to encode new value: %w", err)
+	}
+
+	query := `
+		INSERT INTO computer_events (computer_id, event_type, actor, mac_address, old_mac_address, old_employee_abbreviation, new_employee_abbreviation, old_value, new_value, occurred_at)
+		VALUES (?1, ?2, ?3, ?4, NULLIF(?5, ''), NULLIF(?6, ''), NULLIF(?7, ''), ?8, ?9, ?10)`
+
+	_, err = r.DB.ExecContext(ctx, query,
+		event.ComputerID,
+		string(event.EventType),
+		event.Actor,
+		event.MACAddress,
+		event.OldMACAddress,
+		event.OldEmployeeAbbreviation,
+		event.NewEmployeeAbbreviation,
+		oldValue,
+		newValue,
+		sqliteTime(sqliteNow()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record computer event: %w", err)
+	}
+
+	return nil
+}
+
+// GetEventsByComputer retrieves the history of a computer, newest first, including the events
+// of deleted computers.
+func (r *sqliteEventRepository) GetEventsByComputer(ctx context.Context, computerID uuid.UUID, params PaginationParams) (*EventPaginatedResult, error) {
+	return r.queryEvents(ctx, `WHERE computer_id = ?1`, []interface{}{computerID}, params)
+}
+
+// GetEventsByEmployee retrieves every event in which a computer was handed to or taken from
+// the employee, newest first. Changes that leave the assignment as it was are not returned.
+func (r *sqliteEventRepository) GetEventsByEmployee(ctx context.Context, employeeAbbreviation string, params PaginationParams) (*EventPaginatedResult, error) {
+	return r.queryEvents(ctx, `
+		WHERE (old_employee_abbreviation = ?1 OR new_employee_abbreviation = ?1)
+		AND old_employee_abbreviation IS NOT new_employee_abbreviation`, []interface{}{employeeAbbreviation}, params)
+}
+
+// SearchEvents retrieves the events matching a filter, newest first, including those of deleted
+// computers. Events of a MAC address match it before or after the change.
+func (r *sqliteEventRepository) SearchEvents(ctx context.Context, filter EventFilter, params PaginationParams) (*EventPaginatedResult, error) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.MACAddress != "" {
+		add("(mac_address = ?%[1]d OR old_mac_address = ?%[1]d)", filter.MACAddress)
+	}
+	if !filter.From.IsZero() {
+		add("occurred_at >= ?%d", sqliteTime(filter.From))
+	}
+	if !filter.To.IsZero() {
+		add("occurred_at <= ?%d", sqliteTime(filter.To))
+	}
+	return r.queryEvents(ctx, joinConditions(conditions), args, params)
+}
+
+// GetLatestEvent retrieves the most recent event of a type recorded for a computer.
+func (r *sqliteEventRepository) GetLatestEvent(ctx context.Context, computerID uuid.UUID, eventType model.ComputerEventType) (*model.ComputerEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + eventColumns + `
+		FROM computer_events
+		WHERE computer_id = ?1 AND event_type = ?2
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT 1`
+
+	e, err := scanEvent(r.DB.QueryRowContext(ctx, query, computerID, string(eventType)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to get computer event: %w", err)
+	}
+	return &e, nil
+}
+
+// GetLatestEventByType retrieves the most recent event of a type recorded for any computer.
+func (r *sqliteEventRepository) GetLatestEventByType(ctx context.Context, eventType model.ComputerEventType) (*model.ComputerEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + eventColumns + `
+		FROM computer_events
+		WHERE event_type = ?1
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT 1`
+
+	e, err := scanEvent(r.DB.QueryRowContext(ctx, query, string(eventType)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("failed to get computer event: %w", err)
+	}
+	return &e, nil
+}
+
+// queryEvents runs a paginated query over computer_events using a WHERE clause over args
+func (r *sqliteEventRepository) queryEvents(ctx context.Context, where string, args []interface{}, params PaginationParams) (*EventPaginatedResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := fmt.Sprintf(`
+		SELECT `+eventColumns+`
+		FROM computer_events
+		%s
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT ?%d OFFSET ?%d`, where, len(args)+2, len(args)+1)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(args, params.Offset, params.Limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query computer events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []model.ComputerEvent{}
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan computer event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	var totalCount int
+	if err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM computer_events `+where, args...).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to get total count of computer events: %w", err)
+	}
+
+	return &EventPaginatedResult{
+		Items:      events,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// sqliteOutboxRepository is the SQLite implementation of the OutboxRepository interface.
+type sqliteOutboxRepository struct {
+	DB DBTX
+}
+
+// Enqueue adds a pending message to the outbox. Call it through a Transactor so the message is
+// only stored if the change that caused it commits.
+func (r *sqliteOutboxRepository) Enqueue(ctx context.Context, message model.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO notification_outbox (notification_type, payload, next_attempt_at, created_at)
+		VALUES (?1, ?2, ?3, ?3)`
+
+	if _, err := r.DB.ExecContext(ctx, query, message.NotificationType, string(message.Payload), sqliteTime(sqliteNow())); err != nil {
+		return fmt.Errorf("failed to enqueue notification: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue returns up to limit pending messages that are due for delivery, oldest first, and
+// hides them from other dispatchers for the duration of the lease. Writers are serialized by
+// SQLite, so no row locking is needed.
+func (r *sqliteOutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := sqliteNow()
+	query := `
+		UPDATE notification_outbox
+		SET next_attempt_at = ?3
+		WHERE id IN (
+			SELECT id FROM notification_outbox
+			WHERE status = 'pending' AND next_attempt_at <= ?2
+			ORDER BY id
+			LIMIT ?1
+		)
+		RETURNING id, notification_type, payload, status, attempts, COALESCE(last_error, ''), next_attempt_at, created_at`
+
+	rows, err := r.DB.QueryContext(ctx, query, limit, sqliteTime(now), sqliteTime(now.Add(lease)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []model.OutboxMessage
+	for rows.Next() {
+		var m model.OutboxMessage
+		var status, payload string
+		if err := rows.Scan(&m.ID, &m.NotificationType, &payload, &status, &m.Attempts, &m.LastError, &m.NextAttemptAt, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		m.Payload = []byte(payload)
+		m.Status = model.OutboxStatus(status)
+		messages = append(messages, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	// RETURNING does not preserve the order of the subquery
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+
+	return messages, nil
+}
+
+// MarkDelivered records a successful delivery.
+func (r *sqliteOutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	query := `
+		UPDATE notification_outbox
+		SET status = 'delivered', attempts = attempts + 1, last_error = NULL, delivered_at = ?2
+		WHERE id = ?1`
+
+	return r.updateMessage(ctx, query, id, sqliteTime(sqliteNow()))
+}
+
+// MarkRetry records a failed delivery that should be attempted again at nextAttemptAt.
+func (r *sqliteOutboxRepository) MarkRetry(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE notification_outbox
+		SET attempts = attempts + 1, last_error = ?2, next_attempt_at = ?3
+		WHERE id = ?1`
+
+	return r.updateMessage(ctx, query, id, lastError, sqliteTime(nextAttemptAt))
+}
+
+// MarkDead moves a message that can never be delivered to the dead-letter state.
+func (r *sqliteOutboxRepository) MarkDead(ctx context.Context, id int64, lastError string) error {
+	query := `
+		UPDATE notification_outbox
+		SET status = 'dead', attempts = attempts + 1, last_error = ?2
+		WHERE id = ?1`
+
+	return r.updateMessage(ctx, query, id, lastError)
+}
+
+// Backlog counts the pending messages and finds the oldest one.
+func (r *sqliteOutboxRepository) Backlog(ctx context.Context) (*model.OutboxBacklog, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT COUNT(*), MIN(created_at)
+		FROM notification_outbox
+		WHERE status = 'pending'`
+
+	var backlog model.OutboxBacklog
+	var oldest sql.NullString
+	if err := r.DB.QueryRowContext(ctx, query).Scan(&backlog.Pending, &oldest); err != nil {
+		return nil, fmt.Errorf("failed to count pending notifications: %w", err)
+	}
+	if oldest.Valid {
+		oldestAt, err := parseSQLiteTime(oldest.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse oldest pending notification time: %w", err)
+		}
+		backlog.OldestPendingAt = &oldestAt
+	}
+
+	return &backlog, nil
+}
+
+// updateMessage runs a single-row status update on an outbox message
+func (r *sqliteOutboxRepository) updateMessage(ctx context.Context, query string, id int64, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, query, append([]interface{}{id}, args...)...)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox message %d: %w", id, err)
+	}
+
+	return requireRowsAffected(result, fmt.Errorf("outbox message %d not found", id))
+}
+
+// sqlitePolicyRepository is the SQLite implementation of the PolicyRepository interface.
+type sqlitePolicyRepository struct {
+	DB DBTX
+}
+
+// CreatePolicy adds a new quota policy and returns its ID.
+func (r *sqlitePolicyRepository) CreatePolicy(ctx context.Context, policy model.QuotaPolicy) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO quota_policies (scope, target, max_computers, mode, created_at, updated_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?5)`
+
+	result, err := r.DB.ExecContext(ctx, query, string(policy.Scope), policy.Target, policy.MaxComputers, string(policy.Mode), sqliteTime(sqliteNow()))
+	if err != nil {
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+			return 0, fmt.Errorf("%w: %s %s", ErrDuplicatePolicy, policy.Scope, policy.Target)
+		}
+		return 0, fmt.Errorf("failed to create quota policy: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get quota policy id: %w", err)
+	}
+	return id, nil
+}
+
+// GetPolicyByID retrieves a single quota policy by ID.
+func (r *sqlitePolicyRepository) GetPolicyByID(ctx context.Context, id int64) (*model.QuotaPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, scope, target, max_computers, mode, created_at, updated_at
+		FROM quota_policies
+		WHERE id = ?1`
+
+	p, err := scanPolicy(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to get quota policy: %w", err)
+	}
+	return &p, nil
+}
+
+// GetAllPoliciesPaginated retrieves all quota policies with pagination support, most general first.
+func (r *sqlitePolicyRepository) GetAllPoliciesPaginated(ctx context.Context, params PaginationParams) (*PolicyPaginatedResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, scope, target, max_computers, mode, created_at, updated_at
+		FROM quota_policies
+		ORDER BY ` + policySpecificityOrder + `, target
+		LIMIT ?2 OFFSET ?1`
+
+	rows, err := r.DB.QueryContext(ctx, query, params.Offset, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quota policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies, err := scanPolicies(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalCount int
+	if err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM quota_policies`).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to get total count of quota policies: %w", err)
+	}
+
+	return &PolicyPaginatedResult{
+		Items:      policies,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// GetApplicablePolicies retrieves the global policy and any policies for the given department
+// or employee, most general first.
+func (r *sqlitePolicyRepository) GetApplicablePolicies(ctx context.Context, employeeAbbreviation, department string) ([]model.QuotaPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, scope, target, max_computers, mode, created_at, updated_at
+		FROM quota_policies
+		WHERE scope = 'global'
+			OR (scope = 'department' AND target = ?1)
+			OR (scope = 'employee' AND target = ?2)
+		ORDER BY ` + policySpecificityOrder
+
+	rows, err := r.DB.QueryContext(ctx, query, department, employeeAbbreviation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applicable quota policies: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPolicies(rows)
+}
+
+// UpdatePolicy replaces a quota policy.
+func (r *sqlitePolicyRepository) UpdatePolicy(ctx context.Context, id int64, policy model.QuotaPolicy) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE quota_policies
+		SET scope = ?1, target = ?2, max_computers = ?3, mode = ?4, updated_at = ?5
+		WHERE id = ?6`
+
+	result, err := r.DB.ExecContext(ctx, query, string(policy.Scope), policy.Target, policy.MaxComputers, string(policy.Mode), sqliteTime(sqliteNow()), id)
+	if err != nil {
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+			return fmt.Errorf("%w: %s %s", ErrDuplicatePolicy, policy.Scope, policy.Target)
+		}
+		return fmt.Errorf("failed to update quota policy: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrPolicyNotFound)
+}
+
+// DeletePolicy deletes a quota policy.
+func (r *sqlitePolicyRepository) DeletePolicy(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM quota_policies WHERE id = ?1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete quota policy: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrPolicyNotFound)
+}
+
+// sqliteAPIKeyRepository is the SQLite implementation of the APIKeyRepository interface. Scopes
+// are stored as a JSON array.
+type sqliteAPIKeyRepository struct {
+	DB DBTX
+}
+
+// CreateAPIKey stores a new API key under the hash of its secret and returns its ID.
+func (r *sqliteAPIKeyRepository) CreateAPIKey(ctx context.Context, key model.APIKey, keyHash string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+	encodedScopes, err := json.Marshal(scopes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode api key scopes: %w", err)
+	}
+
+	var expiresAt interface{}
+	if key.ExpiresAt != nil {
+		expiresAt = sqliteTime(*key.ExpiresAt)
+	}
+
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES (?1, ?2, ?3, ?4, ?5, ?6)`
+
+	result, err := r.DB.ExecContext(ctx, query, key.Name, key.Prefix, keyHash, string(encodedScopes), expiresAt, sqliteTime(sqliteNow()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get api key id: %w", err)
+	}
+	return id, nil
+}
+
+// GetAPIKeyByID retrieves a single API key by ID.
+func (r *sqliteAPIKeyRepository) GetAPIKeyByID(ctx context.Context, id int64) (*model.APIKey, error) {
+	return r.getAPIKey(ctx, `id = ?1`, id)
+}
+
+// GetAPIKeyByHash retrieves the API key whose secret has the given hash, whether or not it is still active.
+func (r *sqliteAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	return r.getAPIKey(ctx, `key_hash = ?1`, keyHash)
+}
+
+// GetAllAPIKeys retrieves every API key, including revoked ones, newest first.
+func (r *sqliteAPIKeyRepository) GetAllAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+apiKeyColumns+` FROM api_keys ORDER BY created_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []model.APIKey{}
+	for rows.Next() {
+		key, err := scanSQLiteAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey marks an API key as revoked. Revoking a revoked key keeps the original time.
+func (r *sqliteAPIKeyRepository) RevokeAPIKey(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?1) WHERE id = ?2`
+
+	result, err := r.DB.ExecContext(ctx, query, sqliteTime(sqliteNow()), id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrAPIKeyNotFound)
+}
+
+// getAPIKey retrieves the API key matching a single-argument condition
+func (r *sqliteAPIKeyRepository) getAPIKey(ctx context.Context, where string, arg interface{}) (*model.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	key, err := scanSQLiteAPIKey(r.DB.QueryRowContext(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE `+where, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return &key, nil
+}
+
+// scanSQLiteAPIKey scans an API key row with JSON-encoded scopes
+func scanSQLiteAPIKey(scanner rowScanner) (model.APIKey, error) {
+	var k model.APIKey
+	var scopes string
+	var expiresAt, revokedAt sql.NullTime
+	if err := scanner.Scan(&k.ID, &k.Name, &k.Prefix, &scopes, &expiresAt, &revokedAt, &k.CreatedAt); err != nil {
+		return model.APIKey{}, err
+	}
+
+	if err := json.Unmarshal([]byte(scopes), &k.Scopes); err != nil {
+		return model.APIKey{}, fmt.Errorf("failed to decode api key scopes: %w", err)
+	}
+	if k.Scopes == nil {
+		k.Scopes = []model.Scope{}
+	}
+	if expiresAt.Valid {
+		k.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		k.RevokedAt = &revokedAt.Time
+	}
+	return k, nil
+}
+
+// sqliteInventoryRepository is the SQLite implementation of the InventoryRepository interface.
+type sqliteInventoryRepository struct {
+	DB DBTX
+}
+
+// GetInventoryStats counts the computers, the assigned computers and the employees holding more
+// computers than their most specific quota policy allows. defaultMaxComputers applies to
+// employees without any policy.
+func (r *sqliteInventoryRepository) GetInventoryStats(ctx context.Context, defaultMaxComputers int) (*model.InventoryStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		WITH assigned AS (
+			SELECT employee_abbreviation, COUNT(*) AS computers
+			FROM computers
+			WHERE employee_abbreviation IS NOT NULL
+			GROUP BY employee_abbreviation
+		)
+		SELECT
+			(SELECT COUNT(*) FROM computers),
+			(SELECT COALESCE(SUM(computers), 0) FROM assigned),
+			(SELECT COUNT(*)
+			FROM assigned a
+			JOIN employees e ON e.abbreviation = a.employee_abbreviation
+			WHERE a.computers > COALESCE((
+				SELECT max_computers
+				FROM quota_policies
+				WHERE scope = 'global'
+					OR (scope = 'department' AND target = e.department)
+					OR (scope = 'employee' AND target = e.abbreviation)
+				ORDER BY ` + policySpecificityOrder + ` DESC
+				LIMIT 1
+			), ?1))`
+
+	var stats model.InventoryStats
+	err := r.DB.QueryRowContext(ctx, query, defaultMaxComputers).
+		Scan(&stats.Computers, &stats.AssignedComputers, &stats.EmployeesOverQuota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory stats: %w", err)
+	}
+	return &stats, nil
+}
+
+// sqliteNetworkInterfaceRepository is the SQLite implementation of the NetworkInterfaceRepository interface.
+type sqliteNetworkInterfaceRepository struct {
+	DB DBTX
+}
+
+// sqliteNetworkInterfaceColumns selects the columns scanned by scanNetworkInterface; IP addresses
+// are stored as canonical text
+const sqliteNetworkInterfaceColumns = `id, computer_id, mac_address, interface_type, COALESCE(ipv4_address, ''), COALESCE(ipv6_address, ''), is_primary, created_at, updated_at`
+
+// CreateInterface adds a new secondary interface to a computer.
+func (r *sqliteNetworkInterfaceRepository) CreateInterface(ctx context.Context, iface model.NetworkInterface) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	normalizedMAC, err := validation.ValidateMAC(iface.MACAddress)
+	if err != nil {
+		return ErrInvalidMACFormat
+	}
+	iface.MACAddress = normalizedMAC
+
+	query := `
+		INSERT INTO network_interfaces (id, computer_id, mac_address, interface_type, ipv4_address, ipv6_address, is_primary, created_at, updated_at)
+		VALUES (?1, ?2, ?3, ?4, NULLIF(?5, ''), NULLIF(?6, ''), FALSE, ?7, ?7)`
+
+	_, err = r.DB.ExecContext(ctx, query, iface.ID, iface.ComputerID, iface.MACAddress, string(iface.Type), iface.IPv4Address, iface.IPv6Address,
+		sqliteTime(sqliteNow()))
+	if err != nil {
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) || isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY) {
+			return fmt.Errorf("%w: %s", ErrDuplicateMAC, iface.MACAddress)
+		}
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+			return fmt.Errorf("computer with ID %s not found: %w", iface.ComputerID, ErrComputerNotFound)
+		}
+		return fmt.Errorf("failed to create network interface: %w", err)
+	}
+
+	return nil
+}
+
+// GetInterfacesByComputer retrieves the interfaces of a computer, the primary one first.
+func (r *sqliteNetworkInterfaceRepository) GetInterfacesByComputer(ctx context.Context, computerID uuid.UUID) ([]model.NetworkInterface, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + sqliteNetworkInterfaceColumns + `
+		FROM network_interfaces
+		WHERE computer_id = ?1
+		ORDER BY is_primary DESC, created_at, id`
+
+	rows, err := r.DB.QueryContext(ctx, query, computerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query network interfaces: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNetworkInterfaces(rows)
+}
+
+// GetAllInterfaces retrieves the interfaces of all computers, grouped by computer with the
+// primary interface first.
+func (r *sqliteNetworkInterfaceRepository) GetAllInterfaces(ctx context.Context) ([]model.NetworkInterface, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + sqliteNetworkInterfaceColumns + `
+		FROM network_interfaces
+		ORDER BY computer_id, is_primary DESC, created_at, id`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query network interfaces: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNetworkInterfaces(rows)
+}
+
+// GetInterfaceByID retrieves an interface of a computer by its ID.
+func (r *sqliteNetworkInterfaceRepository) GetInterfaceByID(ctx context.Context, computerID, id uuid.UUID) (*model.NetworkInterface, error) {
+	return r.getInterface(ctx, `id = ?1 AND computer_id = ?2`, id, computerID)
+}
+
+// GetInterfaceByMAC retrieves the interface with a MAC address, whichever computer it belongs to.
+func (r *sqliteNetworkInterfaceRepository) GetInterfaceByMAC(ctx context.Context, macAddress string) (*model.NetworkInterface, error) {
+	return r.getInterface(ctx, `mac_address = ?1`, macAddress)
+}
+
+// UpdateInterface updates the addresses and type of an interface.
+func (r *sqliteNetworkInterfaceRepository) UpdateInterface(ctx context.Context, iface model.NetworkInterface) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE network_interfaces
+		SET mac_address = ?1, interface_type = ?2, ipv4_address = NULLIF(?3, ''), ipv6_address = NULLIF(?4, ''), updated_at = ?5
+		WHERE id = ?6 AND computer_id = ?7`
+
+	result, err := r.DB.ExecContext(ctx, query, iface.MACAddress, string(iface.Type), iface.IPv4Address, iface.IPv6Address,
+		sqliteTime(sqliteNow()), iface.ID, iface.ComputerID)
+	if err != nil {
+		if isSQLiteConstraint(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+			return fmt.Errorf("%w: %s", ErrDuplicateMAC, iface.MACAddress)
+		}
+		return fmt.Errorf("failed to update network interface: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrInterfaceNotFound)
+}
+
+// SetPrimaryInterface makes an interface the primary one of its computer. The former primary
+// interface is demoted first, as the primary index is checked row by row.
+func (r *sqliteNetworkInterfaceRepository) SetPrimaryInterface(ctx context.Context, computerID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := sqliteTime(sqliteNow())
+	demote := `
+		UPDATE network_interfaces
+		SET is_primary = FALSE, updated_at = ?3
+		WHERE computer_id = ?1 AND is_primary AND id <> ?2
+			AND EXISTS (SELECT 1 FROM network_interfaces WHERE id = ?2 AND computer_id = ?1)`
+	if _, err := r.DB.ExecContext(ctx, demote, computerID, id, now); err != nil {
+		return fmt.Errorf("failed to demote primary network interface: %w", err)
+	}
+
+	promote := `
+		UPDATE network_interfaces
+		SET is_primary = TRUE, updated_at = ?3
+		WHERE id = ?1 AND computer_id = ?2`
+	result, err := r.DB.ExecContext(ctx, promote, id, computerID, now)
+	if err != nil {
+		return fmt.Errorf("failed to promote network interface: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrInterfaceNotFound)
+}
+
+// DeleteInterface deletes an interface of a computer.
+func (r *sqliteNetworkInterfaceRepository) DeleteInterface(ctx context.Context, computerID, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM network_interfaces WHERE id = ?1 AND computer_id = ?2`, id, computerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete network interface: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrInterfaceNotFound)
+}
+
+// getInterface retrieves the network interface matching a condition
+func (r *sqliteNetworkInterfaceRepository) getInterface(ctx context.Context, where string, args ...interface{}) (*model.NetworkInterface, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	iface, err := scanNetworkInterface(r.DB.QueryRowContext(ctx, `SELECT `+sqliteNetworkInterfaceColumns+` FROM network_interfaces WHERE `+where, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInterfaceNotFound
+		}
+		return nil, fmt.Errorf("failed to get network interface: %w", err)
+	}
+	return &iface, nil
+}
+
+// sqliteSubnetRepository is the SQLite implementation of the SubnetRepository interface.
+// Overlapping subnets are rejected by the statements that write them, and a write transaction
+// locks the whole database, so the ForUpdate methods need no row locks.
+type sqliteSubnetRepository struct {
+	DB DBTX
+}
+
+// sqliteSubnetColumns are the subnet columns, the gateway and broadcast address defaulting to
+// an empty string
+const sqliteSubnetColumns = `id, cidr, COALESCE(gateway, ''), COALESCE(broadcast_address, ''), vlan, description, created_at, updated_at`
+
+// CreateSubnet adds a new subnet unless it overlaps an existing one.
+func (r *sqliteSubnetRepository) CreateSubnet(ctx context.Context, subnet model.Subnet) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO subnets (id, cidr, gateway, broadcast_address, vlan, description, created_at, updated_at)
+		SELECT ?1, ?2, NULLIF(?3, ''), NULLIF(?4, ''), ?5, ?6, ?7, ?7
+		WHERE NOT EXISTS (SELECT 1 FROM subnets WHERE networks_overlap(cidr, ?2))`
+
+	result, err := r.DB.ExecContext(ctx, query, subnet.ID, subnet.CIDR, subnet.Gateway, subnet.BroadcastAddress, subnet.VLAN, subnet.Description,
+		sqliteTime(sqliteNow()))
+	if err != nil {
+		return fmt.Errorf("failed to create subnet: %w", err)
+	}
+
+	return requireRowsAffected(result, fmt.Errorf("%w: %s", ErrSubnetOverlap, subnet.CIDR))
+}
+
+// GetSubnets retrieves all subnets ordered by network address.
+func (r *sqliteSubnetRepository) GetSubnets(ctx context.Context) ([]model.Subnet, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + sqliteSubnetColumns + ` FROM subnets ORDER BY inet_key(substr(cidr, 1, instr(cidr, '/') - 1))`
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subnets: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSubnets(rows)
+}
+
+// GetSubnetByID retrieves a single subnet by its ID.
+func (r *sqliteSubnetRepository) GetSubnetByID(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+	return r.getSubnet(ctx, `id = ?1`, id)
+}
+
+// GetSubnetByIDForUpdate retrieves a single subnet by its ID.
+func (r *sqliteSubnetRepository) GetSubnetByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+	return r.GetSubnetByID(ctx, id)
+}
+
+// GetSubnetContaining retrieves the subnet an IP address belongs to.
+func (r *sqliteSubnetRepository) GetSubnetContaining(ctx context.Context, ip string) (*model.Subnet, error) {
+	return r.getSubnet(ctx, `ip_in_subnet(?1, cidr)`, ip)
+}
+
+// GetSubnetContainingForUpdate retrieves the subnet an IP address belongs to.
+func (r *sqliteSubnetRepository) GetSubnetContainingForUpdate(ctx context.Context, ip string) (*model.Subnet, error) {
+	return r.GetSubnetContaining(ctx, ip)
+}
+
+// LockAddress does nothing, as the write transaction already locks the whole database.
+func (r *sqliteSubnetRepository) LockAddress(ctx context.Context, ip string) error {
+	return nil
+}
+
+// CountSubnets returns the number of subnets.
+func (r *sqliteSubnetRepository) CountSubnets(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int
+	if err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM subnets`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count subnets: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateSubnet replaces the network, gateway, broadcast address, VLAN and description of a
+// subnet unless the new network overlaps another subnet.
+func (r *sqliteSubnetRepository) UpdateSubnet(ctx context.Context, subnet model.Subnet) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := r.GetSubnetByID(ctx, subnet.ID); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE subnets
+		SET cidr = ?1, gateway = NULLIF(?2, ''), broadcast_address = NULLIF(?3, ''), vlan = ?4, description = ?5, updated_at = ?6
+		WHERE id = ?7 AND NOT EXISTS (SELECT 1 FROM subnets WHERE id <> ?7 AND networks_overlap(cidr, ?1))`
+
+	result, err := r.DB.ExecContext(ctx, query, subnet.CIDR, subnet.Gateway, subnet.BroadcastAddress, subnet.VLAN, subnet.Description,
+		sqliteTime(sqliteNow()), subnet.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update subnet: %w", err)
+	}
+
+	return requireRowsAffected(result, fmt.Errorf("%w: %s", ErrSubnetOverlap, subnet.CIDR))
+}
+
+// DeleteSubnet deletes a subnet. The addresses assigned from it are left unchanged.
+func (r *sqliteSubnetRepository) DeleteSubnet(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM subnets WHERE id = ?1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete subnet: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrSubnetNotFound)
+}
+
+// GetAssignedAddresses retrieves the addresses of all network interfaces within a CIDR network.
+func (r *sqliteSubnetRepository) GetAssignedAddresses(ctx context.Context, network string) ([]model.IPAddressAssignment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT address, computer_id
+		FROM (
+			SELECT ipv4_address AS address, computer_id FROM network_interfaces WHERE ip_in_subnet(COALESCE(ipv4_address, ''), ?1)
+			UNION
+			SELECT ipv6_address, computer_id FROM network_interfaces WHERE ip_in_subnet(COALESCE(ipv6_address, ''), ?1)
+		)
+		ORDER BY inet_key(address)`
+
+	rows, err := r.DB.QueryContext(ctx, query, network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assigned addresses: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAddressAssignments(rows)
+}
+
+// getSubnet retrieves the subnet matching a condition
+func (r *sqliteSubnetRepository) getSubnet(ctx context.Context, where string, args ...interface{}) (*model.Subnet, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	s, err := scanSubnet(r.DB.QueryRowContext(ctx, `SELECT `+sqliteSubnetColumns+` FROM subnets WHERE `+where, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSubnetNotFound
+		}
+		return nil, fmt.Errorf("failed to get subnet: %w", err)
+	}
+	return &s, nil
+}
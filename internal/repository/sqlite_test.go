@@ -0,0 +1,310 @@
+package repository_test
+
+import (
+	"computer-management-api/internal/database"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/internal/repository/repositorytest"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSQLiteStore(t *testing.T) (*sql.DB, *repository.Store) {
+	t.Helper()
+
+	db, err := database.InitSQLite(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db, repository.NewSQLiteStore(db)
+}
+
+func TestSQLiteComputerRepositoryConformance(t *testing.T) {
+	repositorytest.RunComputerRepositoryTests(t, func(t *testing.T) repositorytest.Backend {
+		db, err := database.InitSQLite(":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		store := repository.NewSQLiteStore(db)
+		return repositorytest.Backend{Computers: store.Computers, Employees: store.Employees, Interfaces: store.Interfaces}
+	})
+}
+
+func TestSQLiteTransactor_RollsBackOnError(t *testing.T) {
+	_, store := setupSQLiteStore(t)
+	ctx := context.Background()
+	failure := errors.New("quota exceeded")
+
+	computer := model.Computer{ID: uuid.New(), MACAddress: "AA:BB:CC:DD:EE:01", ComputerName: "PC-1", IPAddress: "10.0.0.1"}
+	err := store.Transactor.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		require.NoError(t, repos.Computers.CreateComputer(ctx, computer))
+		require.NoError(t, repos.Outbox.Enqueue(ctx, model.OutboxMessage{NotificationType: "computer_created", Payload: []byte(`{}`)}))
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	_, err = store.Computers.GetComputerByID(ctx, computer.ID)
+	assert.ErrorIs(t, err, repository.ErrComputerNotFound)
+	backlog, err := store.Outbox.Backlog(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, backlog.Pending)
+
+	err = store.Transactor.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		return repos.Computers.CreateComputer(ctx, computer)
+	})
+	require.NoError(t, err)
+	_, err = store.Computers.GetComputerByID(ctx, computer.ID)
+	assert.NoError(t, err)
+}
+
+func TestSQLiteOutbox_Lifecycle(t *testing.T) {
+	_, store := setupSQLiteStore(t)
+	ctx := context.Background()
+
+	for _, notificationType := range []string{"first", "second", "third"} {
+		require.NoError(t, store.Outbox.Enqueue(ctx, model.OutboxMessage{NotificationType: notificationType, Payload: []byte(`{"n":1}`)}))
+	}
+
+	backlog, err := store.Outbox.Backlog(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, backlog.Pending)
+	require.NotNil(t, backlog.OldestPendingAt)
+
+	claimed, err := store.Outbox.ClaimDue(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	assert.Equal(t, "first", claimed[0].NotificationType)
+	assert.Equal(t, "second", claimed[1].NotificationType)
+	assert.JSONEq(t, `{"n":1}`, string(claimed[0].Payload))
+	assert.Equal(t, model.OutboxStatusPending, claimed[0].Status)
+
+	// Claimed messages are leased to this dispatcher
+	claimedAgain, err := store.Outbox.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimedAgain, 1)
+	assert.Equal(t, "third", claimedAgain[0].NotificationType)
+
+	require.NoError(t, store.Outbox.MarkDelivered(ctx, claimed[0].ID))
+	require.NoError(t, store.Outbox.MarkDead(ctx, claimed[1].ID, "rejected"))
+	require.NoError(t, store.Outbox.MarkRetry(ctx, claimedAgain[0].ID, "timeout", time.Now().Add(-time.Second)))
+
+	retried, err := store.Outbox.ClaimDue(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, 1, retried[0].Attempts)
+	assert.Equal(t, "timeout", retried[0].LastError)
+
+	backlog, err = store.Outbox.Backlog(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, backlog.Pending)
+
+	err = store.Outbox.MarkDelivered(ctx, 999)
+	assert.EqualError(t, err, "outbox message 999 not found")
+}
+
+func TestSQLiteEvents_AppendOnlyHistory(t *testing.T) {
+	db, store := setupSQLiteStore(t)
+	ctx := context.Background()
+	computerID := uuid.New()
+	snapshot := &model.Computer{ID: computerID, MACAddress: "AA:BB:CC:DD:EE:01", ComputerName: "PC-1", IPAddress: "10.0.0.1", Version: 1}
+
+	require.NoError(t, store.Events.RecordEvent(ctx, model.ComputerEvent{
+		ComputerID: computerID, EventType: model.ComputerEventCreated, Actor: "alice", MACAddress: snapshot.MACAddress, NewValue: snapshot,
+	}))
+	require.NoError(t, store.Events.RecordEvent(ctx, model.ComputerEvent{
+		ComputerID: computerID, EventType: model.ComputerEventAssigned, Actor: "alice", MACAddress: snapshot.MACAddress, NewEmployeeAbbreviation: "ABC",
+	}))
+
+	history, err := store.Events.GetEventsByComputer(ctx, computerID, repository.PaginationParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, history.TotalCount)
+	require.Len(t, history.Items, 2)
+	assert.Equal(t, model.ComputerEventAssigned, history.Items[0].EventType, "newest first")
+	assert.Empty(t, history.Items[0].OldEmployeeAbbreviation)
+	assert.Nil(t, history.Items[0].OldValue)
+	require.NotNil(t, history.Items[1].NewValue)
+	assert.Equal(t, "PC-1", history.Items[1].NewValue.ComputerName)
+
+	byEmployee, err := store.Events.GetEventsByEmployee(ctx, "ABC", repository.PaginationParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, byEmployee.TotalCount)
+
+	require.NoError(t, store.Events.RecordEvent(ctx, model.ComputerEvent{
+		ComputerID: computerID, EventType: model.ComputerEventWoken, Actor: "helpdesk", MACAddress: snapshot.MACAddress, NewValue: snapshot,
+	}), "wake-ups are recorded in the audit trail")
+	woken, err := store.Events.GetLatestEvent(ctx, computerID, model.ComputerEventWoken)
+	require.NoError(t, err)
+	assert.Equal(t, "helpdesk", woken.Actor)
+	assert.WithinDuration(t, time.Now(), woken.OccurredAt, time.Minute)
+	_, err = store.Events.GetLatestEvent(ctx, computerID, model.ComputerEventDeleted)
+	assert.ErrorIs(t, err, repository.ErrEventNotFound)
+	_, err = store.Events.GetLatestEventByType(ctx, model.ComputerEventDeleted)
+	assert.ErrorIs(t, err, repository.ErrEventNotFound)
+	latest, err := store.Events.GetLatestEventByType(ctx, model.ComputerEventAssigned)
+	require.NoError(t, err)
+	assert.Equal(t, "ABC", latest.NewEmployeeAbbreviation)
+
+	byMAC, err := store.Events.SearchEvents(ctx, repository.EventFilter{MACAddress: snapshot.MACAddress, To: time.Now()}, repository.PaginationParams{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, byMAC.TotalCount)
+	require.Len(t, byMAC.Items, 1)
+	assert.Equal(t, model.ComputerEventWoken, byMAC.Items[0].EventType)
+	moved := *snapshot
+	moved.MACAddress = "AA:BB:CC:DD:EE:09"
+	require.NoError(t, store.Events.RecordEvent(ctx, model.ComputerEvent{
+		ComputerID: computerID, EventType: model.ComputerEventUpdated, Actor: "alice", MACAddress: moved.MACAddress, OldMACAddress: snapshot.MACAddress,
+		OldEmployeeAbbreviation: "ABC", NewEmployeeAbbreviation: "ABC", OldValue: snapshot, NewValue: &moved,
+	}))
+	byOldMAC, err := store.Events.SearchEvents(ctx, repository.EventFilter{MACAddress: snapshot.MACAddress}, repository.PaginationParams{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, byOldMAC.Items, 1)
+	assert.Equal(t, moved.MACAddress, byOldMAC.Items[0].MACAddress, "the change off the MAC address is found under it")
+	assert.Equal(t, snapshot.MACAddress, byOldMAC.Items[0].OldMACAddress)
+	byEmployee, err = store.Events.GetEventsByEmployee(ctx, "ABC", repository.PaginationParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, byEmployee.TotalCount, "changes that keep the assignment are not in the employee's history")
+
+	before, err := store.Events.SearchEvents(ctx, repository.EventFilter{To: time.Now().Add(-time.Hour)}, repository.PaginationParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, before.TotalCount)
+	other, err := store.Events.SearchEvents(ctx, repository.EventFilter{MACAddress: "AA:BB:CC:DD:EE:02", From: time.Now().Add(-time.Hour)}, repository.PaginationParams{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, other.TotalCount)
+
+	_, err = db.Exec(`DELETE FROM computer_events`)
+	assert.ErrorContains(t, err, "append-only")
+}
+
+func TestSQLitePolicies_ApplicableAndInventory(t *testing.T) {
+	_, store := setupSQLiteStore(t)
+	ctx := context.Background()
+
+	// The schema seeds the global default
+	policies, err := store.Policies.GetAllPoliciesPaginated(ctx, repository.PaginationParams{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, policies.TotalCount)
+	assert.Equal(t, model.QuotaPolicyScopeGlobal, policies.Items[0].Scope)
+
+	id, err := store.Policies.CreatePolicy(ctx, model.QuotaPolicy{Scope: model.QuotaPolicyScopeEmployee, Target: "ABC", MaxComputers: 1, Mode: model.QuotaPolicyModeEnforce})
+	require.NoError(t, err)
+	_, err = store.Policies.CreatePolicy(ctx, model.QuotaPolicy{Scope: model.QuotaPolicyScopeDepartment, Target: "IT", MaxComputers: 5, Mode: model.QuotaPolicyModeWarn})
+	require.NoError(t, err)
+	_, err = store.Policies.CreatePolicy(ctx, model.QuotaPolicy{Scope: model.QuotaPolicyScopeEmployee, Target: "ABC", MaxComputers: 2, Mode: model.QuotaPolicyModeWarn})
+	assert.ErrorIs(t, err, repository.ErrDuplicatePolicy)
+
+	applicable, err := store.Policies.GetApplicablePolicies(ctx, "ABC", "IT")
+	require.NoError(t, err)
+	require.Len(t, applicable, 3)
+	assert.Equal(t, model.QuotaPolicyScopeGlobal, applicable[0].Scope)
+	assert.Equal(t, model.QuotaPolicyScopeEmployee, applicable[2].Scope)
+
+	require.NoError(t, store.Employees.CreateEmployee(ctx, model.Employee{Abbreviation: "ABC", Name: "Alice", Department: "IT", Active: true}))
+	for i, mac := range []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"} {
+		require.NoError(t, store.Computers.CreateComputer(ctx, model.Computer{
+			ID: uuid.New(), MACAddress: mac, ComputerName: "PC", IPAddress: "10.0.0.1", EmployeeAbbreviation: "ABC",
+		}), i)
+	}
+	require.NoError(t, store.Computers.CreateComputer(ctx, model.Computer{ID: uuid.New(), MACAddress: "AA:BB:CC:DD:EE:03", ComputerName: "PC", IPAddress: "10.0.0.1"}))
+
+	stats, err := store.Inventory.GetInventoryStats(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, model.InventoryStats{Computers: 3, AssignedComputers: 2, EmployeesOverQuota: 1}, *stats)
+
+	require.NoError(t, store.Policies.DeletePolicy(ctx, id))
+	stats, err = store.Inventory.GetInventoryStats(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.EmployeesOverQuota)
+
+	err = store.Policies.DeletePolicy(ctx, id)
+	assert.ErrorIs(t, err, repository.ErrPolicyNotFound)
+}
+
+func TestSQLiteAPIKeys_CreateAndRevoke(t *testing.T) {
+	_, store := setupSQLiteStore(t)
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+
+	id, err := store.APIKeys.CreateAPIKey(ctx, model.APIKey{
+		Name: "ci", Prefix: "cm_abc", Scopes: []model.Scope{model.ScopeComputersRead, model.ScopeComputersWrite}, ExpiresAt: &expiresAt,
+	}, "hash")
+	require.NoError(t, err)
+
+	key, err := store.APIKeys.GetAPIKeyByHash(ctx, "hash")
+	require.NoError(t, err)
+	assert.Equal(t, id, key.ID)
+	assert.Equal(t, []model.Scope{model.ScopeComputersRead, model.ScopeComputersWrite}, key.Scopes)
+	require.NotNil(t, key.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*key.ExpiresAt))
+	assert.Nil(t, key.RevokedAt)
+
+	require.NoError(t, store.APIKeys.RevokeAPIKey(ctx, id))
+	key, err = store.APIKeys.GetAPIKeyByID(ctx, id)
+	require.NoError(t, err)
+	assert.NotNil(t, key.RevokedAt)
+
+	keys, err := store.APIKeys.GetAllAPIKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	_, err = store.APIKeys.GetAPIKeyByHash(ctx, "unknown")
+	assert.ErrorIs(t, err, repository.ErrAPIKeyNotFound)
+	err = store.APIKeys.RevokeAPIKey(ctx, 999)
+	assert.ErrorIs(t, err, repository.ErrAPIKeyNotFound)
+}
+
+func TestSQLiteSubnets_OverlapAndAssignedAddresses(t *testing.T) {
+	_, store := setupSQLiteStore(t)
+	ctx := context.Background()
+	vlan := 20
+
+	office := model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24", Gateway: "10.0.0.1", BroadcastAddress: "10.0.0.255", VLAN: &vlan, Description: "Office"}
+	require.NoError(t, store.Subnets.CreateSubnet(ctx, office))
+	require.NoError(t, store.Subnets.CreateSubnet(ctx, model.Subnet{ID: uuid.New(), CIDR: "2001:db8::/64"}))
+	require.NoError(t, store.Subnets.CreateSubnet(ctx, model.Subnet{ID: uuid.New(), CIDR: "9.0.0.0/8"}))
+
+	err := store.Subnets.CreateSubnet(ctx, model.Subnet{ID: uuid.New(), CIDR: "10.0.0.128/25"})
+	assert.ErrorIs(t, err, repository.ErrSubnetOverlap)
+	office.CIDR = "10.0.0.0/4"
+	assert.ErrorIs(t, store.Subnets.UpdateSubnet(ctx, office), repository.ErrSubnetOverlap)
+
+	subnets, err := store.Subnets.GetSubnets(ctx)
+	require.NoError(t, err)
+	require.Len(t, subnets, 3)
+	assert.Equal(t, []string{"9.0.0.0/8", "10.0.0.0/24", "2001:db8::/64"}, []string{subnets[0].CIDR, subnets[1].CIDR, subnets[2].CIDR})
+	assert.Equal(t, "10.0.0.1", subnets[1].Gateway)
+	assert.Equal(t, "10.0.0.255", subnets[1].BroadcastAddress)
+	assert.Empty(t, subnets[0].BroadcastAddress)
+	require.NotNil(t, subnets[1].VLAN)
+	assert.Equal(t, 20, *subnets[1].VLAN)
+
+	containing, err := store.Subnets.GetSubnetContainingForUpdate(ctx, "10.0.0.77")
+	require.NoError(t, err)
+	assert.Equal(t, office.ID, containing.ID)
+	_, err = store.Subnets.GetSubnetContainingForUpdate(ctx, "192.168.1.1")
+	assert.ErrorIs(t, err, repository.ErrSubnetNotFound)
+
+	computer := model.Computer{ID: uuid.New(), MACAddress: "AA:BB:CC:DD:EE:01", ComputerName: "PC-1", IPAddress: "10.0.0.20"}
+	require.NoError(t, store.Computers.CreateComputer(ctx, computer))
+	require.NoError(t, store.Interfaces.CreateInterface(ctx, model.NetworkInterface{ID: uuid.New(), ComputerID: computer.ID,
+		MACAddress: "AA:BB:CC:DD:EE:02", Type: model.NetworkInterfaceTypeWiFi, IPv4Address: "10.0.0.3", IPv6Address: "2001:db8::3"}))
+
+	assigned, err := store.Subnets.GetAssignedAddresses(ctx, "10.0.0.0/24")
+	require.NoError(t, err)
+	require.Len(t, assigned, 2)
+	assert.Equal(t, "10.0.0.3", assigned[0].Address)
+	assert.Equal(t, "10.0.0.20", assigned[1].Address)
+	assert.Equal(t, computer.ID, assigned[1].ComputerID)
+
+	count, err := store.Subnets.CountSubnets(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.NoError(t, store.Subnets.DeleteSubnet(ctx, office.ID))
+	assert.ErrorIs(t, store.Subnets.DeleteSubnet(ctx, office.ID), repository.ErrSubnetNotFound)
+}
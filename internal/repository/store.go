@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"database/sql"
+)
+
+// Store bundles the repositories of one database
+type Store struct {
+	Computers  ComputerRepository
+	Employees  EmployeeRepository
+	Events     EventRepository
+	Outbox     OutboxRepository
+	Policies   PolicyRepository
+	APIKeys    APIKeyRepository
+	Inventory  InventoryRepository
+	Interfaces NetworkInterfaceRepository
+	Subnets    SubnetRepository
+	Transactor Transactor
+}
+
+// NewPostgresStore creates the repositories of a PostgreSQL database
+func NewPostgresStore(db *sql.DB) *Store {
+	return &Store{
+		Computers:  NewComputerRepository(db),
+		Employees:  NewEmployeeRepository(db),
+		Events:     NewEventRepository(db),
+		Outbox:     NewOutboxRepository(db),
+		Policies:   NewPolicyRepository(db),
+		APIKeys:    NewAPIKeyRepository(db),
+		Inventory:  NewInventoryRepository(db),
+		Interfaces: NewNetworkInterfaceRepository(db),
+		Subnets:    NewSubnetRepository(db),
+		Transactor: NewTransactor(db),
+	}
+}
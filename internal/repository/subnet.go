@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Custom errors for subnet operations
+var (
+	ErrSubnetNotFound = errors.New("subnet not found")
+	ErrSubnetOverlap  = errors.New("subnet overlaps an existing subnet")
+)
+
+// SubnetRepository is an interface for interacting with the subnets computers are addressed
+// from. Subnets cannot overlap. The ForUpdate methods lock the subnet until the surrounding
+// transaction ends, which serializes every change to the addresses assigned from it.
+type SubnetRepository interface {
+	CreateSubnet(ctx context.Context, subnet model.Subnet) error
+	// GetSubnets retrieves all subnets ordered by network address.
+	GetSubnets(ctx context.Context) ([]model.Subnet, error)
+	GetSubnetByID(ctx context.Context, id uuid.UUID) (*model.Subnet, error)
+	GetSubnetByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Subnet, error)
+	// GetSubnetContaining retrieves the subnet an IP address belongs to.
+	GetSubnetContaining(ctx context.Context, ip string) (*model.Subnet, error)
+	GetSubnetContainingForUpdate(ctx context.Context, ip string) (*model.Subnet, error)
+	CountSubnets(ctx context.Context) (int, error)
+	// LockAddress locks an IP address until the surrounding transaction ends, which serializes
+	// the changes to addresses outside every subnet.
+	LockAddress(ctx context.Context, ip string) error
+	UpdateSubnet(ctx context.Context, subnet model.Subnet) error
+	DeleteSubnet(ctx context.Context, id uuid.UUID) error
+	// GetAssignedAddresses retrieves the addresses of all network interfaces within a CIDR
+	// network, which include the IP addresses of computers, ordered by address.
+	GetAssignedAddresses(ctx context.Context, network string) ([]model.IPAddressAssignment, error)
+}
+
+// subnetRepository is the concrete implementation of the SubnetRepository interface.
+type subnetRepository struct {
+	DB DBTX
+}
+
+// NewSubnetRepository creates a new SubnetRepository.
+func NewSubnetRepository(db *sql.DB) SubnetRepository {
+	return &subnetRepository{DB: db}
+}
+
+// subnetColumns selects the gateway and broadcast address with host, which leaves out the
+// prefix length a text cast of an inet would add
+const subnetColumns = `id, cidr::text, COALESCE(host(gateway), ''), COALESCE(host(broadcast_address), ''), vlan, description, created_at, updated_at`
+
+// CreateSubnet adds a new subnet.
+func (r *subnetRepository) CreateSubnet(ctx context.Context, subnet model.Subnet) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO subnets (id, cidr, gateway, broadcast_address, vlan, description)
+		VALUES ($1, $2::cidr, NULLIF($3, '')::inet, NULLIF($4, '')::inet, $5, $6)`
+
+	_, err := r.DB.ExecContext(ctx, query, subnet.ID, subnet.CIDR, subnet.Gateway, subnet.BroadcastAddress, subnet.VLAN, subnet.Description)
+	if err != nil {
+		if isExclusionViolation(err) {
+			return fmt.Errorf("%w: %s", ErrSubnetOverlap, subnet.CIDR)
+		}
+		return fmt.Errorf("failed to create subnet: %w", err)
+	}
+
+	return nil
+}
+
+// GetSubnets retrieves all subnets ordered by network address.
+func (r *subnetRepository) GetSubnets(ctx context.Context) ([]model.Subnet, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+subnetColumns+` FROM subnets ORDER BY cidr`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query subnets: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSubnets(rows)
+}
+
+// GetSubnetByID retrieves a single subnet by its ID.
+func (r *subnetRepository) GetSubnetByID(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+	return r.getSubnet(ctx, `WHERE id = $1`, id)
+}
+
+// GetSubnetByIDForUpdate retrieves a single subnet by its ID and locks its row until the
+// surrounding transaction ends.
+func (r *subnetRepository) GetSubnetByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+	return r.getSubnet(ctx, `WHERE id = $1 FOR UPDATE`, id)
+}
+
+// GetSubnetContaining retrieves the subnet an IP address belongs to.
+func (r *subnetRepository) GetSubnetContaining(ctx context.Context, ip string) (*model.Subnet, error) {
+	return r.getSubnet(ctx, `WHERE cidr >>= $1::inet`, ip)
+}
+
+// GetSubnetContainingForUpdate retrieves the subnet an IP address belongs to and locks its row
+// until the surrounding transaction ends.
+func (r *subnetRepository) GetSubnetContainingForUpdate(ctx context.Context, ip string) (*model.Subnet, error) {
+	return r.getSubnet(ctx, `WHERE cidr >>= $1::inet FOR UPDATE`, ip)
+}
+
+// getSubnet retrieves the subnet matching a condition on its single argument
+func (r *subnetRepository) getSubnet(ctx context.Context, condition string, arg interface{}) (*model.Subnet, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	s, err := scanSubnet(r.DB.QueryRowContext(ctx, `SELECT `+subnetColumns+` FROM subnets `+condition, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSubnetNotFound
+		}
+		return nil, fmt.Errorf("failed to get subnet: %w", err)
+	}
+	return &s, nil
+}
+
+// addressLockClass is the first key of the advisory locks taken on IP addresses, keeping them
+// apart from other advisory locks. The value is arbitrary.
+const addressLockClass int32 = 1_702_063_201
+
+// LockAddress takes a transaction-level advisory lock on an IP address. Addresses are hashed
+// to 32 bits, so two of them may share a lock, which only serializes their changes.
+func (r *subnetRepository) LockAddress(ctx context.Context, ip string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := r.DB.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, addressLockClass, ip); err != nil {
+		return fmt.Errorf("failed to lock IP address: %w", err)
+	}
+	return nil
+}
+
+// CountSubnets returns the number of subnets.
+func (r *subnetRepository) CountSubnets(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int
+	if err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM subnets`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count subnets: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateSubnet replaces the network, gateway, broadcast address, VLAN and description of a subnet.
+func (r *subnetRepository) UpdateSubnet(ctx context.Context, subnet model.Subnet) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE subnets
+		SET cidr = $1::cidr, gateway = NULLIF($2, '')::inet, broadcast_address = NULLIF($3, '')::inet, vlan = $4, description = $5
+		WHERE id = $6`
+
+	result, err := r.DB.ExecContext(ctx, query, subnet.CIDR, subnet.Gateway, subnet.BroadcastAddress, subnet.VLAN, subnet.Description, subnet.ID)
+	if err != nil {
+		if isExclusionViolation(err) {
+			return fmt.Errorf("%w: %s", ErrSubnetOverlap, subnet.CIDR)
+		}
+		return fmt.Errorf("failed to update subnet: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrSubnetNotFound)
+}
+
+// DeleteSubnet deletes a subnet. The addresses assigned from it are left unchanged.
+func (r *subnetRepository) DeleteSubnet(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM subnets WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete subnet: %w", err)
+	}
+
+	return requireRowsAffected(result, ErrSubnetNotFound)
+}
+
+// GetAssignedAddresses retrieves the addresses of all network interfaces within a CIDR network.
+func (r *subnetRepository) GetAssignedAddresses(ctx context.Context, network string) ([]model.IPAddressAssignment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT host(address), computer_id
+		FROM (
+			SELECT ipv4_address AS address, computer_id FROM network_interfaces WHERE ipv4_address <<= $1::inet
+			UNION
+			SELECT ipv6_address, computer_id FROM network_interfaces WHERE ipv6_address <<= $1::inet
+		) assigned
+		ORDER BY address`
+
+	rows, err := r.DB.QueryContext(ctx, query, network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assigned addresses: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAddressAssignments(rows)
+}
+
+// isExclusionViolation reports whether err is a PostgreSQL exclusion constraint violation (error code 23P01)
+func isExclusionViolation(err error) bool {
+	return strings.Contains(err.Error(), "violates exclusion constraint")
+}
+
+// scanSubnets scans all subnet rows
+func scanSubnets(rows *sql.Rows) ([]model.Subnet, error) {
+	var subnets []model.Subnet
+	for rows.Next() {
+		s, err := scanSubnet(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subnet: %w", err)
+		}
+		subnets = append(subnets, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return subnets, nil
+}
+
+// scanSubnet scans a subnet row
+func scanSubnet(scanner rowScanner) (model.Subnet, error) {
+	var s model.Subnet
+	var vlan sql.NullInt64
+	if err := scanner.Scan(&s.ID, &s.CIDR, &s.Gateway, &s.BroadcastAddress, &vlan, &s.Description, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return model.Subnet{}, err
+	}
+	if vlan.Valid {
+		v := int(vlan.Int64)
+		s.VLAN = &v
+	}
+	return s, nil
+}
+
+// scanAddressAssignments scans all address assignment rows
+func scanAddressAssignments(rows *sql.Rows) ([]model.IPAddressAssignment, error) {
+	var assignments []model.IPAddressAssignment
+	for rows.Next() {
+		var a model.IPAddressAssignment
+		if err := rows.Scan(&a.Address, &a.ComputerID); err != nil {
+			return nil, fmt.Errorf("failed to scan assigned address: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return assignments, nil
+}
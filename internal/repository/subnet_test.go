@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSubnet_Overlap(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSubnetRepository(db)
+	subnet := model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24"}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO subnets (id, cidr, gateway, broadcast_address, vlan, description) VALUES ($1, $2::cidr, NULLIF($3, '')::inet, NULLIF($4, '')::inet, $5, $6)`)).
+		WithArgs(subnet.ID, "10.0.0.0/24", "", "", nil, "").
+		WillReturnError(errors.New(`pq: conflicting key value violates exclusion constraint "subnets_no_overlap"`))
+
+	err = repo.CreateSubnet(context.Background(), subnet)
+
+	assert.ErrorIs(t, err, ErrSubnetOverlap)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSubnetContaining_DoesNotLock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSubnetRepository(db)
+	now := time.Now()
+
+	mock.ExpectQuery(`FROM subnets WHERE cidr >>= \$1::inet$`).
+		WithArgs("10.0.0.5").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cidr", "gateway", "broadcast_address", "vlan", "description", "created_at", "updated_at"}).
+			AddRow(uuid.New(), "10.0.0.0/24", "", "", nil, "", now, now))
+	mock.ExpectQuery(`FROM subnets WHERE cidr >>= \$1::inet$`).
+		WithArgs("192.168.1.1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	subnet, err := repo.GetSubnetContaining(context.Background(), "10.0.0.5")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/24", subnet.CIDR)
+
+	_, err = repo.GetSubnetContaining(context.Background(), "192.168.1.1")
+	assert.ErrorIs(t, err, ErrSubnetNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSubnetContainingForUpdate_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSubnetRepository(db)
+	id := uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "cidr", "gateway", "broadcast_address", "vlan", "description", "created_at", "updated_at"}).
+		AddRow(id, "10.0.0.0/24", "10.0.0.1", "10.0.0.255", 20, "Office", now, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM subnets WHERE cidr >>= $1::inet FOR UPDATE`)).
+		WithArgs("10.0.0.5").
+		WillReturnRows(rows)
+
+	subnet, err := repo.GetSubnetContainingForUpdate(context.Background(), "10.0.0.5")
+
+	require.NoError(t, err)
+	assert.Equal(t, id, subnet.ID)
+	assert.Equal(t, "10.0.0.1", subnet.Gateway)
+	assert.Equal(t, "10.0.0.255", subnet.BroadcastAddress)
+	require.NotNil(t, subnet.VLAN)
+	assert.Equal(t, 20, *subnet.VLAN)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockAddress(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSubnetRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1, hashtext($2))`)).
+		WithArgs(addressLockClass, "2001:db8::5").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, repo.LockAddress(context.Background(), "2001:db8::5"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSubnetByID_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSubnetRepository(db)
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM subnets WHERE id = $1`)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = repo.GetSubnetByID(context.Background(), id)
+
+	assert.ErrorIs(t, err, ErrSubnetNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
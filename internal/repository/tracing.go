@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans started by the repositories
+const tracerName = "computer-management-api/internal/repository"
+
+// repositoryCall is a traced and logged call of a repository method
+type repositoryCall struct {
+	ctx       context.Context
+	span      trace.Span
+	method    string
+	operation string
+	start     time.Time
+}
+
+// startCall starts a client span for a repository method running a SQL operation on a table of
+// the given database system
+func startCall(ctx context.Context, system attribute.KeyValue, method, operation, table string) (context.Context, *repositoryCall) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			system,
+			semconv.DBOperationName(operation),
+			semconv.DBCollectionName(table),
+			semconv.CodeFunctionName(method),
+		),
+	)
+	return ctx, &repositoryCall{ctx: ctx, span: span, method: method, operation: operation, start: time.Now()}
+}
+
+// end records a failed call, ends its span and logs the call at debug level. Lookups that
+// find nothing are an expected outcome, not an error.
+func (c *repositoryCall) end(err error) {
+	attrs := []slog.Attr{
+		slog.String("method", c.method),
+		slog.String("operation", c.operation),
+		slog.Duration("duration", time.Since(c.start)),
+	}
+	if err != nil && !errors.Is(err, ErrComputerNotFound) {
+		c.span.RecordError(err)
+		c.span.SetStatus(codes.Error, err.Error())
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	c.span.End()
+	slog.LogAttrs(c.ctx, slog.LevelDebug, "Query executed", attrs...)
+}
+
+// tracedComputerRepository wraps a ComputerRepository with a span and a debug log record for
+// every method
+type tracedComputerRepository struct {
+	next   ComputerRepository
+	system attribute.KeyValue
+}
+
+// traceComputerRepository adds tracing to a ComputerRepository backed by a database system,
+// such as semconv.DBSystemNamePostgreSQL
+func traceComputerRepository(next ComputerRepository, system attribute.KeyValue) ComputerRepository {
+	return &tracedComputerRepository{next: next, system: system}
+}
+
+func (r *tracedComputerRepository) CreateComputer(ctx context.Context, computer model.Computer) error {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.CreateComputer", "INSERT", "computers")
+	err := r.next.CreateComputer(ctx, computer)
+	call.end(err)
+	return err
+}
+
+func (r *tracedComputerRepository) GetAllComputers(ctx context.Context) ([]model.Computer, error) {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.GetAllComputers", "SELECT", "computers")
+	computers, err := r.next.GetAllComputers(ctx)
+	call.end(err)
+	return computers, err
+}
+
+func (r *tracedComputerRepository) GetAllComputersPaginated(ctx context.Context, filter ComputerFilter, params PaginationParams) (*PaginatedResult, error) {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.GetAllComputersPaginated", "SELECT", "computers")
+	result, err := r.next.GetAllComputersPaginated(ctx, filter, params)
+	call.end(err)
+	return result, err
+}
+
+func (r *tracedComputerRepository) StreamComputers(ctx context.Context, filter ComputerFilter, fn func(model.Computer) error) error {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.StreamComputers", "SELECT", "computers")
+	err := r.next.StreamComputers(ctx, filter, fn)
+	call.end(err)
+	return err
+}
+
+func (r *tracedComputerRepository) GetComputerByMAC(ctx context.Context, macAddress string) (*model.Computer, error) {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.GetComputerByMAC", "SELECT", "computers")
+	computer, err := r.next.GetComputerByMAC(ctx, macAddress)
+	call.end(err)
+	return computer, err
+}
+
+func (r *tracedComputerRepository) GetComputersByMACs(ctx context.Context, macAddresses []string) ([]model.Computer, error) {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.GetComputersByMACs", "SELECT", "computers")
+	computers, err := r.next.GetComputersByMACs(ctx, macAddresses)
+	call.end(err)
+	return computers, err
+}
+
+func (r *tracedComputerRepository) GetComputerByID(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.GetComputerByID", "SELECT", "computers")
+	computer, err := r.next.GetComputerByID(ctx, id)
+	call.end(err)
+	return computer, err
+}
+
+func (r *tracedComputerRepository) GetComputerByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.GetComputerByIDForUpdate", "SELECT", "computers")
+	computer, err := r.next.GetComputerByIDForUpdate(ctx, id)
+	call.end(err)
+	return computer, err
+}
+
+func (r *tracedComputerRepository) UpdateComputer(ctx context.Context, id uuid.UUID, computer model.Computer) error {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.UpdateComputer", "UPDATE", "computers")
+	err := r.next.UpdateComputer(ctx, id, computer)
+	call.end(err)
+	return err
+}
+
+func (r *tracedComputerRepository) DeleteComputer(ctx context.Context, id uuid.UUID) error {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.DeleteComputer", "DELETE", "computers")
+	err := r.next.DeleteComputer(ctx, id)
+	call.end(err)
+	return err
+}
+
+func (r *tracedComputerRepository) GetComputersByEmployee(ctx context.Context, employeeAbbreviation string) ([]model.Computer, error) {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.GetComputersByEmployee", "SELECT", "computers")
+	computers, err := r.next.GetComputersByEmployee(ctx, employeeAbbreviation)
+	call.end(err)
+	return computers, err
+}
+
+func (r *tracedComputerRepository) GetComputersByEmployeePaginated(ctx context.Context, employeeAbbreviation string, params PaginationParams) (*PaginatedResult, error) {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.GetComputersByEmployeePaginated", "SELECT", "computers")
+	result, err := r.next.GetComputersByEmployeePaginated(ctx, employeeAbbreviation, params)
+	call.end(err)
+	return result, err
+}
+
+func (r *tracedComputerRepository) ComputerExists(ctx context.Context, macAddress string) (bool, error) {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.ComputerExists", "SELECT", "computers")
+	exists, err := r.next.ComputerExists(ctx, macAddress)
+	call.end(err)
+	return exists, err
+}
+
+func (r *tracedComputerRepository) RemoveComputerFromEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.RemoveComputerFromEmployee", "UPDATE", "computers")
+	err := r.next.RemoveComputerFromEmployee(ctx, computerID, employeeAbbreviation)
+	call.end(err)
+	return err
+}
+
+func (r *tracedComputerRepository) AssignComputerToEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+	ctx, call := startCall(ctx, r.system, "ComputerRepository.AssignComputerToEmployee", "UPDATE", "computers")
+	err := r.next.AssignComputerToEmployee(ctx, computerID, employeeAbbreviation)
+	call.end(err)
+	return err
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestComputerRepository_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	db, mock, repo := setupTestDB(t)
+	defer db.Close()
+
+	computerID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM computers WHERE id = $1`)).
+		WithArgs(computerID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM computers WHERE id = $1`)).
+		WithArgs(computerID).
+		WillReturnError(errors.New("connection reset"))
+
+	_, err := repo.GetComputerByID(context.Background(), computerID)
+	assert.ErrorIs(t, err, ErrComputerNotFound)
+	assert.Error(t, repo.DeleteComputer(context.Background(), computerID))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "ComputerRepository.GetComputerByID", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.operation.name", "SELECT"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.collection.name", "computers"))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code, "a missing computer is not a span error")
+
+	assert.Equal(t, "ComputerRepository.DeleteComputer", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.String("db.operation.name", "DELETE"))
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
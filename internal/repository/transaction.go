@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// DBTX is the subset of *sql.DB and *sql.Tx used by the repositories, so the same
+// repository code can run standalone or as part of a transaction.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Repositories groups the repositories bound to a single transaction.
+type Repositories struct {
+	Computers  ComputerRepository
+	Employees  EmployeeRepository
+	Events     EventRepository
+	Outbox     OutboxRepository
+	Policies   PolicyRepository
+	Interfaces NetworkInterfaceRepository
+	Subnets    SubnetRepository
+}
+
+// Transactor runs a unit of work inside a database transaction.
+type Transactor interface {
+	// WithinTransaction commits when fn returns nil and rolls back otherwise.
+	WithinTransaction(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+// sqlTransactor is the concrete implementation of the Transactor interface.
+type sqlTransactor struct {
+	DB *sql.DB
+	// repositories binds the repositories of the database to a transaction
+	repositories func(tx DBTX) Repositories
+}
+
+// NewTransactor creates a new Transactor for a PostgreSQL database.
+func NewTransactor(db *sql.DB) Transactor {
+	return &sqlTransactor{DB: db, repositories: postgresRepositories}
+}
+
+// postgresRepositories returns the PostgreSQL repositories bound to tx
+func postgresRepositories(tx DBTX) Repositories {
+	return Repositories{
+		Computers:  traceComputerRepository(&computerRepository{DB: tx}, semconv.DBSystemNamePostgreSQL),
+		Employees:  &employeeRepository{DB: tx},
+		Events:     &eventRepository{DB: tx},
+		Outbox:     &outboxRepository{DB: tx},
+		Policies:   &policyRepository{DB: tx},
+		Interfaces: &networkInterfaceRepository{DB: tx},
+		Subnets:    &subnetRepository{DB: tx},
+	}
+}
+
+// WithinTransaction runs fn with repositories that share a single transaction.
+func (t *sqlTransactor) WithinTransaction(ctx context.Context, fn func(repos Repositories) error) error {
+	tx, err := t.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(t.repositories(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -3,19 +3,57 @@ package router
 import (
 	"computer-management-api/internal/config"
 	"computer-management-api/internal/handler"
+	"computer-management-api/internal/metrics"
 	"computer-management-api/internal/middleware"
+	"computer-management-api/internal/model"
 
 	"github.com/gorilla/mux"
 )
 
-// NewRouter creates a new router and sets up the routes with security middleware.
-func NewRouter(h handler.ComputerHandlerInterface, cfg *config.Config) *mux.Router {
+// Handlers groups the HTTP handlers served by the router.
+type Handlers struct {
+	Computer  handler.ComputerHandlerInterface
+	Interface handler.NetworkInterfaceHandlerInterface
+	Subnet    handler.SubnetHandlerInterface
+	Employee  handler.EmployeeHandlerInterface
+	History   handler.HistoryHandlerInterface
+	Policy    handler.PolicyHandlerInterface
+	APIKey    handler.APIKeyHandlerInterface
+	Health    handler.HealthHandlerInterface
+
+	// NetworkConfig serves the DHCP and DNS exports; they are not routed while it is nil
+	NetworkConfig handler.NetworkConfigHandlerInterface
+
+	// Wake sends Wake-on-LAN magic packets; it is not routed while it is nil
+	Wake handler.WakeHandlerInterface
+}
+
+// NewRouter creates a new router and sets up the routes with security middleware. Requests are
+// authenticated with API keys or identity provider tokens, either of which may be nil, and
+// every route except the health checks declares the scope it requires. API keys are only
+// managed with credentials, even while authentication is not required. Requests are recorded
+// in m unless it is nil.
+func NewRouter(handlers Handlers, apiKeys middleware.APIKeyAuthenticator, tokens middleware.TokenVerifier, m *metrics.Metrics, cfg *config.Config) *mux.Router {
+	h := handlers.Computer
+	ih := handlers.Interface
+	sh := handlers.Subnet
+	eh := handlers.Employee
+	hh := handlers.History
+	ph := handlers.Policy
+	kh := handlers.APIKey
+	lh := handlers.Health
+
 	r := mux.NewRouter()
 
 	// Initialize security middleware
-	securityMW := middleware.NewSecurityMiddleware(&cfg.Security)
+	securityMW := middleware.NewSecurityMiddleware(&cfg.Security, m)
+	authMW := middleware.NewAuthMiddleware(apiKeys, tokens, cfg.Security.RequireAuth, nil)
+	metricsMW := middleware.NewMetricsMiddleware(m)
 
-	// Apply global middleware in order
+	// Apply global middleware in order; metrics come first to see every response
+	r.Use(metricsMW.Instrument)
+	r.Use(middleware.TraceRoute)
+	r.Use(middleware.LogRoute)
 	r.Use(securityMW.SecurityHeaders)
 	r.Use(securityMW.CORS)
 	r.Use(securityMW.TrustedProxy)
@@ -23,21 +61,93 @@ func NewRouter(h handler.ComputerHandlerInterface, cfg *config.Config) *mux.Rout
 	r.Use(securityMW.RequestTimeout)
 
 	api := r.PathPrefix("/api/v1").Subrouter()
+	api.Use(authMW.Authenticate)
+
+	computersRead := authMW.RequireScope(model.ScopeComputersRead)
+	computersReadOrSelf := authMW.RequireScopeOrSelf(model.ScopeComputersRead, "employee_abbreviation")
+	computersWrite := authMW.RequireScope(model.ScopeComputersWrite)
+	assignmentsWrite := authMW.RequireScope(model.ScopeAssignmentsWrite)
+	employeesRead := authMW.RequireScope(model.ScopeEmployeesRead)
+	employeesWrite := authMW.RequireScope(model.ScopeEmployeesWrite)
+	policiesRead := authMW.RequireScope(model.ScopePoliciesRead)
+	policiesWrite := authMW.RequireScope(model.ScopePoliciesWrite)
+	subnetsRead := authMW.RequireScope(model.ScopeSubnetsRead)
+	subnetsWrite := authMW.RequireScope(model.ScopeSubnetsWrite)
+	apiKeysManage := authMW.RequireCredentials(model.ScopeAPIKeysManage) // Even without REQUIRE_AUTH
 
 	// Computer CRUD operations
-	api.HandleFunc("/computers", h.CreateComputerHandler).Methods("POST")
-	api.HandleFunc("/computers", h.GetAllComputersHandler).Methods("GET")
-	api.HandleFunc("/computers/{id}", h.GetComputerHandler).Methods("GET")
-	api.HandleFunc("/computers/{id}", h.UpdateComputerHandler).Methods("PUT")
-	api.HandleFunc("/computers/{id}", h.DeleteComputerHandler).Methods("DELETE")
+	api.Handle("/computers", computersWrite(h.CreateComputerHandler)).Methods("POST")
+	api.Handle("/computers", computersRead(h.GetAllComputersHandler)).Methods("GET")
+	api.Handle("/computers:import", computersWrite(h.ImportComputersHandler)).Methods("POST")
+	// Exports stream for up to handler.ExportTimeout, longer than the request timeout
+	securityMW.WithoutRequestTimeout(
+		api.Handle("/computers/export", computersRead(h.ExportComputersHandler)).Methods("GET")) // Before /computers/{id}
+	api.Handle("/computers/{id}", computersRead(h.GetComputerHandler)).Methods("GET")
+	api.Handle("/computers/{id}", computersWrite(h.UpdateComputerHandler)).Methods("PUT")
+	api.Handle("/computers/{id}", computersWrite(h.PatchComputerHandler)).Methods("PATCH")
+	api.Handle("/computers/{id}", computersWrite(h.DeleteComputerHandler)).Methods("DELETE")
+	api.Handle("/computers/{id}/history", computersRead(hh.GetComputerHistoryHandler)).Methods("GET")
+	api.Handle("/history", computersRead(hh.SearchHistoryHandler)).Methods("GET")
+
+	// Network interface operations
+	api.Handle("/computers/{id}/interfaces", computersRead(ih.GetInterfacesHandler)).Methods("GET")
+	api.Handle("/computers/{id}/interfaces", computersWrite(ih.CreateInterfaceHandler)).Methods("POST")
+	api.Handle("/computers/{id}/interfaces/{interface_id}", computersRead(ih.GetInterfaceHandler)).Methods("GET")
+	api.Handle("/computers/{id}/interfaces/{interface_id}", computersWrite(ih.UpdateInterfaceHandler)).Methods("PUT")
+	api.Handle("/computers/{id}/interfaces/{interface_id}", computersWrite(ih.DeleteInterfaceHandler)).Methods("DELETE")
+	api.Handle("/interfaces/by-mac/{mac}", computersRead(ih.GetInterfaceByMACHandler)).Methods("GET")
+
+	// Employee CRUD operations
+	api.Handle("/employees", employeesWrite(eh.CreateEmployeeHandler)).Methods("POST")
+	api.Handle("/employees", employeesRead(eh.GetAllEmployeesHandler)).Methods("GET")
+	api.Handle("/employees/{employee_abbreviation}", employeesRead(eh.GetEmployeeHandler)).Methods("GET")
+	api.Handle("/employees/{employee_abbreviation}", employeesWrite(eh.UpdateEmployeeHandler)).Methods("PUT")
+	api.Handle("/employees/{employee_abbreviation}", employeesWrite(eh.DeleteEmployeeHandler)).Methods("DELETE")
+	api.Handle("/employees/{employee_abbreviation}/history", employeesRead(hh.GetEmployeeHistoryHandler)).Methods("GET")
+	api.Handle("/employees/{employee_abbreviation}/policy", policiesRead(ph.GetEmployeePolicyHandler)).Methods("GET")
 
 	// Employee-specific operations
-	api.HandleFunc("/employees/{employee_abbreviation}/computers", h.GetEmployeeComputersHandler).Methods("GET")
-	api.HandleFunc("/employees/{employee_abbreviation}/computers/{computer_id}", h.RemoveComputerFromEmployeeHandler).Methods("DELETE")
-	api.HandleFunc("/employees/{employee_abbreviation}/computers/{computer_id}", h.AssignComputerToEmployeeHandler).Methods("PUT")
+	api.Handle("/employees/{employee_abbreviation}/computers", computersReadOrSelf(h.GetEmployeeComputersHandler)).Methods("GET") // Employees see their own
+	api.Handle("/employees/{employee_abbreviation}/computers/{computer_id}", assignmentsWrite(h.RemoveComputerFromEmployeeHandler)).Methods("DELETE")
+	api.Handle("/employees/{employee_abbreviation}/computers/{computer_id}", assignmentsWrite(h.AssignComputerToEmployeeHandler)).Methods("PUT")
+
+	// Quota policy operations
+	api.Handle("/policies", policiesWrite(ph.CreatePolicyHandler)).Methods("POST")
+	api.Handle("/policies", policiesRead(ph.GetAllPoliciesHandler)).Methods("GET")
+	api.Handle("/policies/{id}", policiesRead(ph.GetPolicyHandler)).Methods("GET")
+	api.Handle("/policies/{id}", policiesWrite(ph.UpdatePolicyHandler)).Methods("PUT")
+	api.Handle("/policies/{id}", policiesWrite(ph.DeletePolicyHandler)).Methods("DELETE")
+
+	// Subnet and address allocation operations
+	api.Handle("/subnets", subnetsWrite(sh.CreateSubnetHandler)).Methods("POST")
+	api.Handle("/subnets", subnetsRead(sh.GetAllSubnetsHandler)).Methods("GET")
+	api.Handle("/subnets/utilization", subnetsRead(sh.GetAllSubnetUtilizationHandler)).Methods("GET") // Before /subnets/{id}
+	api.Handle("/subnets/{id}", subnetsRead(sh.GetSubnetHandler)).Methods("GET")
+	api.Handle("/subnets/{id}", subnetsWrite(sh.UpdateSubnetHandler)).Methods("PUT")
+	api.Handle("/subnets/{id}", subnetsWrite(sh.DeleteSubnetHandler)).Methods("DELETE")
+	api.Handle("/subnets/{id}/utilization", subnetsRead(sh.GetSubnetUtilizationHandler)).Methods("GET")
+	api.Handle("/subnets/{id}/allocate", subnetsWrite(sh.AllocateAddressHandler)).Methods("POST")
+
+	// Wake-on-LAN, which sends magic packets into the network
+	if wh := handlers.Wake; wh != nil {
+		api.Handle("/computers/{id}/wake", computersWrite(wh.WakeComputerHandler)).Methods("POST")
+	}
+
+	// DHCP and DNS configuration generated from the inventory
+	if nh := handlers.NetworkConfig; nh != nil {
+		api.Handle("/exports/dhcp", computersRead(nh.ExportDHCPHandler)).Methods("GET")
+		api.Handle("/exports/dns", computersRead(nh.ExportDNSHandler)).Methods("GET")
+	}
+
+	// API key management
+	api.Handle("/api-keys", apiKeysManage(kh.CreateAPIKeyHandler)).Methods("POST")
+	api.Handle("/api-keys", apiKeysManage(kh.GetAllAPIKeysHandler)).Methods("GET")
+	api.Handle("/api-keys/{id}", apiKeysManage(kh.RevokeAPIKeyHandler)).Methods("DELETE")
 
-	// Health check
-	api.HandleFunc("/health", h.HealthHandler).Methods("GET")
+	// Health checks, open to load balancers and probes
+	api.HandleFunc("/health", lh.ReadyHandler).Methods("GET") // Former health check, now the readiness probe
+	r.HandleFunc("/health/live", lh.LiveHandler).Methods("GET")
+	r.HandleFunc("/health/ready", lh.ReadyHandler).Methods("GET")
 
 	return r
 }
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"context"
+	"strings"
+)
+
+// AnonymousActor is recorded in the audit trail when a change carries no actor
+const AnonymousActor = "anonymous"
+
+// maxActorLength matches the width of the computer_events.actor column
+const maxActorLength = 255
+
+type actorContextKey struct{}
+
+// WithActor returns a context that attributes changes made with it to actor
+func WithActor(ctx context.Context, actor string) context.Context {
+	actor = truncateActor(strings.TrimSpace(actor))
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor responsible for the current change. Without an explicit
+// actor, changes are attributed to the authenticated principal.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorContextKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	if principal := PrincipalFromContext(ctx); principal != nil && principal.Actor != "" {
+		return truncateActor(principal.Actor)
+	}
+	return AnonymousActor
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal returns a context for a request made by an authenticated principal
+func WithPrincipal(ctx context.Context, principal *model.Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal the current request was authenticated as, if any
+func PrincipalFromContext(ctx context.Context) *model.Principal {
+	principal, _ := ctx.Value(principalContextKey{}).(*model.Principal)
+	return principal
+}
+
+// truncateActor shortens an actor to the width of the audit trail column
+func truncateActor(actor string) string {
+	if len(actor) > maxActorLength {
+		actor = actor[:maxActorLength]
+	}
+	return actor
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/validation"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, so keys are easy to recognize in configuration and logs
+const APIKeyPrefix = "cma_"
+
+// apiKeySecretBytes is the amount of randomness in an API key
+const apiKeySecretBytes = 20
+
+// apiKeyDisplayLength is the length of the key prefix stored to identify a key
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// IssuedAPIKey is a newly issued API key. Key is the secret and is only ever returned here.
+type IssuedAPIKey struct {
+	model.APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyService issues, revokes and verifies API keys
+type APIKeyService struct {
+	repo   repository.APIKeyRepository
+	logger *slog.Logger
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(repo repository.APIKeyRepository, logger *slog.Logger) *APIKeyService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &APIKeyService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// IssueAPIKey creates an API key with the given name, scopes and optional expiry
+func (s *APIKeyService) IssueAPIKey(ctx context.Context, key model.APIKey) (*IssuedAPIKey, error) {
+	validationErrors := validation.ValidateAPIKeyInput(&key)
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		validationErrors = append(validationErrors, "expiry must be in the future")
+	}
+	if len(validationErrors) > 0 {
+		return nil, validationErrorFromList(validationErrors)
+	}
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		return nil, errors.InternalError("failed to generate api key", err)
+	}
+	key.Prefix = secret[:apiKeyDisplayLength]
+
+	id, err := s.repo.CreateAPIKey(ctx, key, HashAPIKey(secret))
+	if err != nil {
+		return nil, mapAPIKeyRepositoryError(err, "failed to create api key")
+	}
+
+	created, err := s.repo.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		return nil, mapAPIKeyRepositoryError(err, "failed to retrieve created api key")
+	}
+
+	s.logger.InfoContext(ctx, "API key issued", "api_key_id", created.ID, "name", created.Name, "prefix", created.Prefix,
+		"scopes", created.Scopes, "actor", ActorFromContext(ctx))
+
+	return &IssuedAPIKey{APIKey: *created, Key: secret}, nil
+}
+
+// GetAllAPIKeys retrieves every API key without its secret
+func (s *APIKeyService) GetAllAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	keys, err := s.repo.GetAllAPIKeys(ctx)
+	if err != nil {
+		return nil, mapAPIKeyRepositoryError(err, "failed to retrieve api keys")
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key. Requests made with it are rejected from then on.
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id int64) error {
+	if err := s.repo.RevokeAPIKey(ctx, id); err != nil {
+		return mapAPIKeyRepositoryError(err, "failed to revoke api key")
+	}
+
+	s.logger.InfoContext(ctx, "API key revoked", "api_key_id", id, "actor", ActorFromContext(ctx))
+
+	return nil
+}
+
+// Authenticate returns the active API key matching secret. Unknown, revoked and expired keys
+// are rejected with an unauthorized error.
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (*model.APIKey, error) {
+	if !strings.HasPrefix(secret, APIKeyPrefix) {
+		return nil, errors.UnauthorizedError("Invalid API key")
+	}
+
+	key, err := s.repo.GetAPIKeyByHash(ctx, HashAPIKey(secret))
+	if err != nil {
+		if stderrors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, errors.UnauthorizedError("Invalid API key")
+		}
+		return nil, mapAPIKeyRepositoryError(err, "failed to authenticate api key")
+	}
+
+	if !key.IsActive(time.Now()) {
+		if key.RevokedAt != nil {
+			return nil, errors.UnauthorizedError("API key has been revoked")
+		}
+		return nil, errors.UnauthorizedError("API key has expired")
+	}
+
+	return key, nil
+}
+
+// HashAPIKey returns the hex SHA-256 digest under which an API key is stored. Keys are long
+// random strings, so a fast hash is sufficient and allows looking them up directly.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateAPIKey returns a new random API key
+func generateAPIKey() (string, error) {
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + hex.EncodeToString(secret), nil
+}
+
+// mapAPIKeyRepositoryError translates API key repository errors into application errors
+func mapAPIKeyRepositoryError(err error, message string) error {
+	switch {
+	case stderrors.Is(err, repository.ErrAPIKeyNotFound):
+		return errors.NotFoundError("API key")
+	default:
+		return mapRepositoryError(err, message)
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	apperrors "computer-management-api/pkg/errors"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mockAPIKeyRepository serves API keys by hash
+type mockAPIKeyRepository struct {
+	repository.APIKeyRepository
+	byHash map[string]model.APIKey
+	err    error
+}
+
+func (m *mockAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	key, ok := m.byHash[keyHash]
+	if !ok {
+		return nil, repository.ErrAPIKeyNotFound
+	}
+	return &key, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	repo := &mockAPIKeyRepository{byHash: map[string]model.APIKey{
+		HashAPIKey("cma_active"):  {ID: 1, Name: "active", ExpiresAt: &future},
+		HashAPIKey("cma_revoked"): {ID: 2, Name: "revoked", RevokedAt: &past},
+		HashAPIKey("cma_expired"): {ID: 3, Name: "expired", ExpiresAt: &past},
+	}}
+	svc := NewAPIKeyService(repo, nil)
+
+	key, err := svc.Authenticate(context.Background(), "cma_active")
+	if err != nil || key.Name != "active" {
+		t.Fatalf("Expected the active key, got %v, %v", key, err)
+	}
+
+	for _, secret := range []string{"cma_revoked", "cma_expired", "cma_unknown", "active"} {
+		_, err := svc.Authenticate(context.Background(), secret)
+		if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeUnauthorized {
+			t.Errorf("Expected %s to be unauthorized, got %v", secret, err)
+		}
+	}
+
+	repo.err = errors.New("connection refused")
+	_, err = svc.Authenticate(context.Background(), "cma_active")
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeDatabase {
+		t.Errorf("Expected a database error, got %v", err)
+	}
+}
+
+func TestActorFromContext_Principal(t *testing.T) {
+	key := &model.APIKey{Name: "inventory-sync"}
+	ctx := WithPrincipal(context.Background(), key.Principal())
+	if actor := ActorFromContext(ctx); actor != "api-key:inventory-sync" {
+		t.Errorf("Expected the key to be the actor, got %s", actor)
+	}
+
+	if actor := ActorFromContext(WithActor(ctx, "jdoe")); actor != "jdoe" {
+		t.Errorf("Expected an explicit actor to take precedence, got %s", actor)
+	}
+}
@@ -4,19 +4,35 @@ import (
 	"computer-management-api/internal/model"
 	"computer-management-api/internal/repository"
 	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/oui"
+	"computer-management-api/pkg/patch"
+	"computer-management-api/pkg/validation"
 	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
-	"log"
-	"strings"
+	"log/slog"
 
 	"github.com/google/uuid"
 )
 
 // ComputerService handles business logic for computer operations
 type ComputerService struct {
-	repo     repository.ComputerRepository
-	notifier NotificationService
-	logger   *log.Logger
+	repo      repository.ComputerRepository
+	employees repository.EmployeeRepository
+	tx        repository.Transactor
+	logger    *slog.Logger
+
+	// RequireHostnames rejects computer names that cannot be used as DNS hostnames, as needed
+	// when DHCP and DNS configuration is generated from the inventory
+	RequireHostnames bool
+
+	// Vendors identifies the organizations MAC addresses are registered to
+	Vendors VendorRegistry
+
+	// RejectSuspiciousMACs rejects multicast and locally administered MAC addresses, which are
+	// otherwise accepted and flagged with warnings
+	RejectSuspiciousMACs bool
 }
 
 // NotificationService interface for sending notifications
@@ -24,14 +40,15 @@ type NotificationService interface {
 	SendComputerNotification(ctx context.Context, notification ComputerNotification) error
 }
 
-// ComputerNotification represents a notification about computer operations
+// ComputerNotification represents a notification about computer operations.
+// It is stored as the payload of an outbox message until the dispatcher delivers it.
 type ComputerNotification struct {
-	Type                 NotificationType
-	EmployeeAbbreviation string
-	ComputerCount        int
-	ComputerName         string
-	Message              string
-	Metadata             map[string]string
+	Type                 NotificationType  `json:"type"`
+	EmployeeAbbreviation string            `json:"employee_abbreviation,omitempty"`
+	ComputerCount        int               `json:"computer_count,omitempty"`
+	ComputerName         string            `json:"computer_name,omitempty"`
+	Message              string            `json:"message"`
+	Metadata             map[string]string `json:"metadata,omitempty"`
 }
 
 // NotificationType represents the type of notification
@@ -44,29 +61,24 @@ const (
 	NotificationTypeComputerDeleted   NotificationType = "computer_deleted"
 )
 
-// Business rules constants
-const (
-	MaxComputersPerEmployee = 3
-	MinEmployeeAbbrevLength = 2
-	MaxEmployeeAbbrevLength = 10
-)
-
 // NewComputerService creates a new computer service
-func NewComputerService(repo repository.ComputerRepository, notifier NotificationService, logger *log.Logger) *ComputerService {
+func NewComputerService(repo repository.ComputerRepository, employees repository.EmployeeRepository, tx repository.Transactor, logger *slog.Logger) *ComputerService {
 	if logger == nil {
-		logger = log.Default()
+		logger = slog.Default()
 	}
 	return &ComputerService{
-		repo:     repo,
-		notifier: notifier,
-		logger:   logger,
+		repo:      repo,
+		employees: employees,
+		tx:        tx,
+		logger:    logger,
+		Vendors:   oui.Embedded(),
 	}
 }
 
 // CreateComputer creates a new computer with business logic validation
 func (s *ComputerService) CreateComputer(ctx context.Context, computer model.Computer) (*model.Computer, error) {
-	// Validate business rules
-	if err := s.validateComputerForCreation(ctx, computer); err != nil {
+	// Validate business rules (also normalizes the MAC address)
+	if err := s.validateComputerForCreation(ctx, &computer); err != nil {
 		return nil, err
 	}
 
@@ -75,101 +87,191 @@ func (s *ComputerService) CreateComputer(ctx context.Context, computer model.Com
 		computer.ID = uuid.New()
 	}
 
-	// Normalize MAC address
-	computer.MACAddress = strings.ToUpper(strings.ReplaceAll(computer.MACAddress, "-", ":"))
+	// Create the computer, its audit event and its notifications atomically, rolling back
+	// if its IP address is taken or the employee's quota policy is enforced and would be exceeded
+	err := s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if err := reserveIPAddress(ctx, repos.Subnets, computer.IPAddress, computer.ID); err != nil {
+			return err
+		}
+		if err := repos.Computers.CreateComputer(ctx, computer); err != nil {
+			return err
+		}
+		if err := repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventCreated, nil, &computer)); err != nil {
+			return err
+		}
 
-	// Create the computer
-	if err := s.repo.CreateComputer(ctx, computer); err != nil {
-		return nil, errors.DatabaseError("failed to create computer", err)
+		if computer.EmployeeAbbreviation == "" {
+			return nil
+		}
+		if err := enqueueNotification(ctx, repos, newCreationNotification(computer)); err != nil {
+			return err
+		}
+		return applyQuotaPolicy(ctx, repos, computer.EmployeeAbbreviation)
+	})
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to create computer")
 	}
 
-	// Check if we need to send a notification
-	go s.checkAndNotifyThreshold(computer.EmployeeAbbreviation)
+	s.logger.InfoContext(ctx, "Computer created", "computer_id", computer.ID, "mac_address", computer.MACAddress,
+		"employee", computer.EmployeeAbbreviation)
 
-	// Send creation notification
-	if computer.EmployeeAbbreviation != "" {
-		go s.sendCreationNotification(computer)
-	}
-
-	s.logger.Printf("Computer created successfully: ID=%s, MAC=%s, Employee=%s",
-		computer.ID, computer.MACAddress, computer.EmployeeAbbreviation)
+	describeComputer(s.Vendors, &computer)
+	s.logMACWarnings(ctx, computer.ID, computer.MACWarnings)
 
 	return &computer, nil
 }
 
-// GetAllComputers retrieves computers with pagination
-func (s *ComputerService) GetAllComputers(ctx context.Context, params repository.PaginationParams) (*repository.PaginatedResult, error) {
-	result, err := s.repo.GetAllComputersPaginated(ctx, params)
+// GetAllComputers retrieves the computers matching filter with pagination
+func (s *ComputerService) GetAllComputers(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error) {
+	resolveVendorFilter(s.Vendors, &filter)
+	result, err := s.repo.GetAllComputersPaginated(ctx, filter, params)
 	if err != nil {
-		return nil, errors.DatabaseError("failed to retrieve computers", err)
+		return nil, mapRepositoryError(err, "failed to retrieve computers")
 	}
+	describeComputers(s.Vendors, result.Items)
 
-	s.logger.Printf("Retrieved %d computers (offset %d, limit %d)",
-		len(result.Items), params.Offset, params.Limit)
+	s.logger.DebugContext(ctx, "Retrieved computers", "count", len(result.Items), "offset", params.Offset, "limit", params.Limit)
 
 	return result, nil
 }
 
+// ExportComputers calls fn for every computer matching filter without loading them all into
+// memory. An error returned by fn stops the export and is returned unchanged.
+func (s *ComputerService) ExportComputers(ctx context.Context, filter repository.ComputerFilter, fn func(model.Computer) error) error {
+	resolveVendorFilter(s.Vendors, &filter)
+	count := 0
+	var fnErr error
+	err := s.repo.StreamComputers(ctx, filter, func(c model.Computer) error {
+		describeComputer(s.Vendors, &c)
+		if fnErr = fn(c); fnErr != nil {
+			return fnErr
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		if fnErr != nil {
+			return fnErr
+		}
+		return mapRepositoryError(err, "failed to export computers")
+	}
+
+	s.logger.InfoContext(ctx, "Exported computers", "count", count)
+
+	return nil
+}
+
 // GetComputerByID retrieves a computer by its ID
 func (s *ComputerService) GetComputerByID(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
 	computer, err := s.repo.GetComputerByID(ctx, id)
 	if err != nil {
-		if err == repository.ErrComputerNotFound {
-			return nil, errors.NotFoundError("computer")
-		}
-		return nil, errors.DatabaseError("failed to retrieve computer", err)
+		return nil, mapRepositoryError(err, "failed to retrieve computer")
 	}
+	describeComputer(s.Vendors, computer)
 
 	return computer, nil
 }
 
 // UpdateComputer updates an existing computer
 func (s *ComputerService) UpdateComputer(ctx context.Context, id uuid.UUID, updates model.Computer) (*model.Computer, error) {
-	// Check if computer exists
-	existing, err := s.repo.GetComputerByID(ctx, id)
-	if err != nil {
-		if err == repository.ErrComputerNotFound {
-			return nil, errors.NotFoundError("computer")
-		}
-		return nil, errors.DatabaseError("failed to retrieve computer for update", err)
+	// Validate business rules for update (also normalizes the MAC address)
+	if err := s.validateComputerForUpdate(ctx, id, &updates); err != nil {
+		return nil, err
 	}
 
-	// Validate business rules for update
-	if err := s.validateComputerForUpdate(ctx, id, updates); err != nil {
-		return nil, err
+	// Update the computer and record the change in the audit trail atomically, provided it
+	// still has the version the request was conditional on and a new IP address is free. The
+	// change is decided on the locked row, not one read before the transaction.
+	var existing, updated *model.Computer
+	err := s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		var err error
+		if existing, err = lockComputer(ctx, repos, id); err != nil {
+			return err
+		}
+
+		// Only a change of assignment requires the target employee to be active
+		if updates.EmployeeAbbreviation != "" && updates.EmployeeAbbreviation != existing.EmployeeAbbreviation {
+			if err := s.validateAssignableEmployee(ctx, updates.EmployeeAbbreviation); err != nil {
+				return err
+			}
+		}
+		if updates.IPAddress != existing.IPAddress {
+			if err := reserveIPAddress(ctx, repos.Subnets, updates.IPAddress, id); err != nil {
+				return err
+			}
+		}
+
+		// Preserve ID and timestamps
+		updates.ID = id
+		updates.CreatedAt = existing.CreatedAt
+		if err := repos.Computers.UpdateComputer(ctx, id, updates); err != nil {
+			return err
+		}
+
+		if updated, err = repos.Computers.GetComputerByID(ctx, id); err != nil {
+			return err
+		}
+
+		if err := repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventUpdated, existing, updated)); err != nil {
+			return err
+		}
+
+		// Only a reassignment is worth notifying about
+		if existing.EmployeeAbbreviation == updated.EmployeeAbbreviation {
+			return nil
+		}
+		if err := enqueueNotification(ctx, repos, newUpdateNotification(*existing, *updated)); err != nil {
+			return err
+		}
+		return applyQuotaPolicy(ctx, repos, updated.EmployeeAbbreviation)
+	})
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to update computer")
 	}
 
-	// Preserve ID and timestamps
-	updates.ID = id
-	updates.CreatedAt = existing.CreatedAt
+	s.logger.InfoContext(ctx, "Computer updated", "computer_id", id)
 
-	// Normalize MAC address if provided
-	if updates.MACAddress != "" {
-		updates.MACAddress = strings.ToUpper(strings.ReplaceAll(updates.MACAddress, "-", ":"))
+	describeComputer(s.Vendors, updated)
+	if updated.MACAddress != existing.MACAddress {
+		s.logMACWarnings(ctx, id, updated.MACWarnings)
 	}
 
-	// Update the computer
-	if err := s.repo.UpdateComputer(ctx, id, updates); err != nil {
-		return nil, errors.DatabaseError("failed to update computer", err)
+	return updated, nil
+}
+
+// PatchComputer applies a JSON Merge Patch or, for patch.JSONPatchMediaType, a JSON Patch to a
+// computer. Fields the patch does not mention keep their values and a merge patch null clears a
+// field. Validation runs on the patched result, so a partial update cannot produce an invalid record.
+func (s *ComputerService) PatchComputer(ctx context.Context, id uuid.UUID, mediaType string, document []byte) (*model.Computer, error) {
+	existing, err := s.repo.GetComputerByID(ctx, id)
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to retrieve computer for update")
 	}
 
-	// Get updated computer
-	updated, err := s.repo.GetComputerByID(ctx, id)
+	original, err := json.Marshal(existing)
 	if err != nil {
-		return nil, errors.DatabaseError("failed to retrieve updated computer", err)
+		return nil, errors.InternalError("failed to encode computer", err)
 	}
 
-	// Send update notification if employee changed
-	if existing.EmployeeAbbreviation != updates.EmployeeAbbreviation {
-		go s.sendUpdateNotification(*existing, *updated)
-		if updates.EmployeeAbbreviation != "" {
-			go s.checkAndNotifyThreshold(updates.EmployeeAbbreviation)
-		}
+	var patched []byte
+	if mediaType == patch.JSONPatchMediaType {
+		patched, err = patch.JSONPatch(original, document)
+	} else {
+		patched, err = patch.MergePatch(original, document)
+	}
+	if err != nil {
+		return nil, mapPatchError(err)
 	}
 
-	s.logger.Printf("Computer updated successfully: ID=%s", id)
+	var updates model.Computer
+	if err := json.Unmarshal(patched, &updates); err != nil {
+		return nil, errors.ValidationError("patched document is not a valid computer")
+	}
+	if updates.ID != id {
+		return nil, errors.ValidationError("computer id cannot be changed")
+	}
 
-	return updated, nil
+	return s.UpdateComputer(ctx, id, updates)
 }
 
 // DeleteComputer deletes a computer
@@ -177,23 +279,31 @@ func (s *ComputerService) DeleteComputer(ctx context.Context, id uuid.UUID) erro
 	// Check if computer exists
 	computer, err := s.repo.GetComputerByID(ctx, id)
 	if err != nil {
-		if err == repository.ErrComputerNotFound {
-			return errors.NotFoundError("computer")
-		}
-		return errors.DatabaseError("failed to retrieve computer for deletion", err)
+		return mapRepositoryError(err, "failed to retrieve computer for deletion")
 	}
 
-	// Delete the computer
-	if err := s.repo.DeleteComputer(ctx, id); err != nil {
-		return errors.DatabaseError("failed to delete computer", err)
-	}
+	// Delete the computer and record the deletion in the audit trail atomically
+	err = s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if err := checkComputerVersion(ctx, repos, id); err != nil {
+			return err
+		}
+		if err := repos.Computers.DeleteComputer(ctx, id); err != nil {
+			return err
+		}
+		if err := repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventDeleted, computer, nil)); err != nil {
+			return err
+		}
 
-	// Send deletion notification
-	if computer.EmployeeAbbreviation != "" {
-		go s.sendDeletionNotification(*computer)
+		if computer.EmployeeAbbreviation == "" {
+			return nil
+		}
+		return enqueueNotification(ctx, repos, newDeletionNotification(*computer))
+	})
+	if err != nil {
+		return mapRepositoryError(err, "failed to delete computer")
 	}
 
-	s.logger.Printf("Computer deleted successfully: ID=%s", id)
+	s.logger.InfoContext(ctx, "Computer deleted", "computer_id", id)
 
 	return nil
 }
@@ -206,44 +316,107 @@ func (s *ComputerService) GetComputersByEmployee(ctx context.Context, employeeAb
 
 	result, err := s.repo.GetComputersByEmployeePaginated(ctx, employeeAbbrev, params)
 	if err != nil {
-		return nil, errors.DatabaseError("failed to retrieve employee computers", err)
+		return nil, mapRepositoryError(err, "failed to retrieve employee computers")
 	}
+	describeComputers(s.Vendors, result.Items)
 
-	s.logger.Printf("Retrieved %d computers for employee %s (offset %d, limit %d)",
-		len(result.Items), employeeAbbrev, params.Offset, params.Limit)
+	s.logger.DebugContext(ctx, "Retrieved employee computers", "employee", employeeAbbrev, "count", len(result.Items),
+		"offset", params.Offset, "limit", params.Limit)
 
 	return result, nil
 }
 
-// Business logic validation methods
-
-func (s *ComputerService) validateComputerForCreation(ctx context.Context, computer model.Computer) error {
-	// Validate required fields
-	if computer.MACAddress == "" {
-		return errors.ValidationError("MAC address is required")
-	}
-	if computer.ComputerName == "" {
-		return errors.ValidationError("computer name is required")
+// AssignComputerToEmployee assigns an existing computer to an employee
+func (s *ComputerService) AssignComputerToEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbrev string) (*model.Computer, error) {
+	if err := s.validateEmployeeAbbreviation(employeeAbbrev); err != nil {
+		return nil, err
 	}
-	if computer.IPAddress == "" {
-		return errors.ValidationError("IP address is required")
+
+	if err := s.validateAssignableEmployee(ctx, employeeAbbrev); err != nil {
+		return nil, err
 	}
 
-	// Validate MAC address format
-	if !isValidMACAddress(computer.MACAddress) {
-		return errors.ValidationError("invalid MAC address format")
+	// The audit trail and notification describe the locked row, and the assigned computer is read
+	// back with its new version
+	var assigned *model.Computer
+	err := s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		existing, err := lockComputer(ctx, repos, computerID)
+		if err != nil {
+			return err
+		}
+		if err := repos.Computers.AssignComputerToEmployee(ctx, computerID, employeeAbbrev); err != nil {
+			return err
+		}
+		if assigned, err = repos.Computers.GetComputerByID(ctx, computerID); err != nil {
+			return err
+		}
+		if err := repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventAssigned, existing, assigned)); err != nil {
+			return err
+		}
+
+		if existing.EmployeeAbbreviation == employeeAbbrev {
+			return nil
+		}
+		if err := enqueueNotification(ctx, repos, newUpdateNotification(*existing, *assigned)); err != nil {
+			return err
+		}
+		return applyQuotaPolicy(ctx, repos, employeeAbbrev)
+	})
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to assign computer to employee")
 	}
 
-	// Validate IP address format
-	if !isValidIPAddress(computer.IPAddress) {
-		return errors.ValidationError("invalid IP address format")
+	s.logger.InfoContext(ctx, "Computer assigned", "computer_id", computerID, "employee", employeeAbbrev)
+
+	describeComputer(s.Vendors, assigned)
+
+	return assigned, nil
+}
+
+// RemoveComputerFromEmployee unassigns a computer from the given employee and returns the
+// unassigned computer
+func (s *ComputerService) RemoveComputerFromEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbrev string) (*model.Computer, error) {
+	if err := s.validateEmployeeAbbreviation(employeeAbbrev); err != nil {
+		return nil, err
 	}
 
-	// Validate employee abbreviation
-	if computer.EmployeeAbbreviation != "" {
-		if err := s.validateEmployeeAbbreviation(computer.EmployeeAbbreviation); err != nil {
+	var unassigned *model.Computer
+	err := s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if err := checkComputerVersion(ctx, repos, computerID); err != nil {
+			return err
+		}
+		if err := repos.Computers.RemoveComputerFromEmployee(ctx, computerID, employeeAbbrev); err != nil {
+			return err
+		}
+
+		var err error
+		if unassigned, err = repos.Computers.GetComputerByID(ctx, computerID); err != nil {
 			return err
 		}
+		previous := *unassigned
+		previous.EmployeeAbbreviation = employeeAbbrev
+
+		return repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventUnassigned, &previous, unassigned))
+	})
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to remove computer from employee")
+	}
+
+	s.logger.InfoContext(ctx, "Computer removed from employee", "computer_id", computerID, "employee", employeeAbbrev)
+
+	describeComputer(s.Vendors, unassigned)
+
+	return unassigned, nil
+}
+
+// Business logic validation methods
+
+func (s *ComputerService) validateComputerForCreation(ctx context.Context, computer *model.Computer) error {
+	// Validate field formats and normalize the MAC address
+	validationErrors := append(validation.ValidateComputerInput(computer), s.hostnameErrors(computer)...)
+	validationErrors = append(validationErrors, macAddressErrors(s.RejectSuspiciousMACs, computer.MACAddress)...)
+	if len(validationErrors) > 0 {
+		return validationErrorFromList(validationErrors)
 	}
 
 	// Check if MAC address already exists
@@ -252,100 +425,164 @@ func (s *ComputerService) validateComputerForCreation(ctx context.Context, compu
 		return errors.DatabaseError("failed to check computer existence", err)
 	}
 	if exists {
-		return errors.AlreadyExistsError("computer with this MAC address")
+		return errors.AlreadyExistsError("Computer with this MAC address")
 	}
 
-	// Check employee computer count if employee is specified
 	if computer.EmployeeAbbreviation != "" {
-		computers, err := s.repo.GetComputersByEmployee(ctx, computer.EmployeeAbbreviation)
-		if err != nil {
-			return errors.DatabaseError("failed to check employee computer count", err)
-		}
-		if len(computers) >= MaxComputersPerEmployee {
-			return errors.ValidationError(fmt.Sprintf("employee already has the maximum number of computers (%d)", MaxComputersPerEmployee))
+		if err := s.validateAssignableEmployee(ctx, computer.EmployeeAbbreviation); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func (s *ComputerService) validateComputerForUpdate(ctx context.Context, id uuid.UUID, computer model.Computer) error {
-	// Validate fields that are being updated
-	if computer.MACAddress != "" {
-		if !isValidMACAddress(computer.MACAddress) {
-			return errors.ValidationError("invalid MAC address format")
-		}
+func (s *ComputerService) validateComputerForUpdate(ctx context.Context, id uuid.UUID, computer *model.Computer) error {
+	// Validate field formats and normalize the MAC address
+	validationErrors := append(validation.ValidateComputerInputForUpdate(computer), s.hostnameErrors(computer)...)
+	validationErrors = append(validationErrors, macAddressErrors(s.RejectSuspiciousMACs, computer.MACAddress)...)
+	if len(validationErrors) > 0 {
+		return validationErrorFromList(validationErrors)
+	}
 
-		// Check if MAC address is already used by another computer
-		existing, err := s.repo.GetComputerByMAC(ctx, computer.MACAddress)
-		if err != nil && err != repository.ErrComputerNotFound {
-			return errors.DatabaseError("failed to check MAC address uniqueness", err)
-		}
-		if existing != nil && existing.ID != id {
-			return errors.AlreadyExistsError("computer with this MAC address")
-		}
+	// Check if MAC address is already used by another computer
+	existing, err := s.repo.GetComputerByMAC(ctx, computer.MACAddress)
+	if err != nil && !stderrors.Is(err, repository.ErrComputerNotFound) {
+		return errors.DatabaseError("failed to check MAC address uniqueness", err)
+	}
+	if existing != nil && existing.ID != id {
+		return errors.AlreadyExistsError("Computer with this MAC address")
 	}
 
-	if computer.IPAddress != "" && !isValidIPAddress(computer.IPAddress) {
-		return errors.ValidationError("invalid IP address format")
+	return nil
+}
+
+// hostnameErrors checks that the computer name is a DNS hostname when RequireHostnames is set. An
+// empty name is already reported as missing.
+func (s *ComputerService) hostnameErrors(computer *model.Computer) []string {
+	if !s.RequireHostnames || computer.ComputerName == "" {
+		return nil
 	}
+	if err := validation.ValidateHostname(computer.ComputerName); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
 
-	if computer.EmployeeAbbreviation != "" {
-		if err := s.validateEmployeeAbbreviation(computer.EmployeeAbbreviation); err != nil {
-			return err
-		}
+// logMACWarnings logs the warnings about a MAC address accepted for a computer
+func (s *ComputerService) logMACWarnings(ctx context.Context, id uuid.UUID, warnings []string) {
+	if len(warnings) > 0 {
+		s.logger.WarnContext(ctx, "Computer registered with a suspicious MAC address", "computer_id", id, "warnings", warnings)
 	}
+}
 
+func (s *ComputerService) validateEmployeeAbbreviation(abbrev string) error {
+	if abbrev == "" {
+		return errors.ValidationError("employee abbreviation is required")
+	}
+	if err := validation.ValidateEmployeeAbbreviation(abbrev); err != nil {
+		return errors.ValidationError(err.Error())
+	}
 	return nil
 }
 
-func (s *ComputerService) validateEmployeeAbbreviation(abbrev string) error {
-	if len(abbrev) < MinEmployeeAbbrevLength {
-		return errors.ValidationError(fmt.Sprintf("employee abbreviation must be at least %d characters", MinEmployeeAbbrevLength))
+// validateAssignableEmployee ensures computers are only assigned to known, active employees
+func (s *ComputerService) validateAssignableEmployee(ctx context.Context, abbrev string) error {
+	employee, err := s.employees.GetEmployeeByAbbreviation(ctx, abbrev)
+	if err != nil {
+		return mapRepositoryError(err, "failed to retrieve employee")
 	}
-	if len(abbrev) > MaxEmployeeAbbrevLength {
-		return errors.ValidationError(fmt.Sprintf("employee abbreviation must be at most %d characters", MaxEmployeeAbbrevLength))
+	if !employee.Active {
+		return errors.NewAppError(errors.ErrorCodeConflict, fmt.Sprintf("Employee %s is deactivated and cannot be assigned computers", abbrev))
 	}
 	return nil
 }
 
-// Notification methods
+// newComputerEvent builds an audit event for a change from oldValue to newValue made by the
+// actor carried in ctx. Either value may be nil for creations and deletions.
+func newComputerEvent(ctx context.Context, eventType model.ComputerEventType, oldValue, newValue *model.Computer) model.ComputerEvent {
+	event := model.ComputerEvent{
+		EventType: eventType,
+		Actor:     ActorFromContext(ctx),
+		OldValue:  oldValue,
+		NewValue:  newValue,
+	}
+	if oldValue != nil {
+		event.ComputerID = oldValue.ID
+		event.MACAddress = oldValue.MACAddress
+		event.OldMACAddress = oldValue.MACAddress
+		event.OldEmployeeAbbreviation = oldValue.EmployeeAbbreviation
+	}
+	if newValue != nil {
+		event.ComputerID = newValue.ID
+		event.MACAddress = newValue.MACAddress
+		event.NewEmployeeAbbreviation = newValue.EmployeeAbbreviation
+	}
+	return event
+}
 
-func (s *ComputerService) checkAndNotifyThreshold(employeeAbbrev string) {
-	if employeeAbbrev == "" {
-		return
+// validationErrorFromList converts a list of validation messages into a detailed validation error
+func validationErrorFromList(validationErrors []string) error {
+	fields := make(map[string]string, len(validationErrors))
+	for i, msg := range validationErrors {
+		fields[fmt.Sprintf("error_%d", i)] = msg
 	}
+	return errors.ValidationErrorWithDetails("Validation failed", fields)
+}
 
-	ctx := context.Background()
-	computers, err := s.repo.GetComputersByEmployee(ctx, employeeAbbrev)
-	if err != nil {
-		s.logger.Printf("Failed to check employee computers for notification: %v", err)
-		return
-	}
-
-	if len(computers) >= MaxComputersPerEmployee {
-		notification := ComputerNotification{
-			Type:                 NotificationTypeThresholdExceeded,
-			EmployeeAbbreviation: employeeAbbrev,
-			ComputerCount:        len(computers),
-			Message:              fmt.Sprintf("Employee %s has %d computers assigned (threshold: %d)", employeeAbbrev, len(computers), MaxComputersPerEmployee),
-			Metadata: map[string]string{
-				"threshold": fmt.Sprintf("%d", MaxComputersPerEmployee),
-				"count":     fmt.Sprintf("%d", len(computers)),
-			},
-		}
+// mapRepositoryError translates repository sentinel errors into application errors
+func mapRepositoryError(err error, message string) error {
+	// Business rule violations raised inside a transaction are already application errors
+	if appErr, ok := errors.AsAppError(err); ok {
+		return appErr
+	}
+
+	switch {
+	case stderrors.Is(err, repository.ErrComputerNotFound):
+		return errors.NotFoundError("Computer")
+	case stderrors.Is(err, repository.ErrEmployeeNotFound):
+		return errors.NotFoundError("Employee")
+	case stderrors.Is(err, repository.ErrNotAssigned):
+		return errors.NewAppError(errors.ErrorCodeNotFound, "Computer not found or not assigned to this employee")
+	case stderrors.Is(err, repository.ErrDuplicateMAC):
+		return errors.AlreadyExistsError("Computer with this MAC address")
+	case stderrors.Is(err, repository.ErrInvalidMACFormat):
+		return errors.ValidationError("invalid MAC address format")
+	case stderrors.Is(err, repository.ErrInvalidSortField), stderrors.Is(err, repository.ErrInvalidCursor):
+		return errors.ValidationError(err.Error())
+	case stderrors.Is(err, context.DeadlineExceeded):
+		return errors.NewAppErrorWithCause(errors.ErrorCodeTimeout, message, err)
+	default:
+		return errors.DatabaseError(message, err)
+	}
+}
 
-		if err := s.notifier.SendComputerNotification(ctx, notification); err != nil {
-			s.logger.Printf("Failed to send threshold notification for employee %s: %v", employeeAbbrev, err)
-		} else {
-			s.logger.Printf("Threshold notification sent for employee %s (%d computers)", employeeAbbrev, len(computers))
-		}
+// mapPatchError translates patch errors into application errors
+func mapPatchError(err error) error {
+	if stderrors.Is(err, patch.ErrTestFailed) {
+		return errors.NewAppError(errors.ErrorCodeConflict, "Patch test operation failed")
 	}
+	return errors.BadRequestError(err.Error())
 }
 
-func (s *ComputerService) sendCreationNotification(computer model.Computer) {
-	ctx := context.Background()
-	notification := ComputerNotification{
+// Notification methods
+
+// enqueueNotification writes a notification to the outbox of the current transaction,
+// so it is delivered if and only if the change that caused it commits
+func enqueueNotification(ctx context.Context, repos repository.Repositories, notification ComputerNotification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	return repos.Outbox.Enqueue(ctx, model.OutboxMessage{
+		NotificationType: string(notification.Type),
+		Payload:          payload,
+	})
+}
+
+func newCreationNotification(computer model.Computer) ComputerNotification {
+	return ComputerNotification{
 		Type:                 NotificationTypeComputerCreated,
 		EmployeeAbbreviation: computer.EmployeeAbbreviation,
 		ComputerName:         computer.ComputerName,
@@ -356,15 +593,10 @@ func (s *ComputerService) sendCreationNotification(computer model.Computer) {
 			"mac_address":   computer.MACAddress,
 		},
 	}
-
-	if err := s.notifier.SendComputerNotification(ctx, notification); err != nil {
-		s.logger.Printf("Failed to send creation notification: %v", err)
-	}
 }
 
-func (s *ComputerService) sendUpdateNotification(old, new model.Computer) {
-	ctx := context.Background()
-	notification := ComputerNotification{
+func newUpdateNotification(old, new model.Computer) ComputerNotification {
+	return ComputerNotification{
 		Type:                 NotificationTypeComputerUpdated,
 		EmployeeAbbreviation: new.EmployeeAbbreviation,
 		ComputerName:         new.ComputerName,
@@ -376,15 +608,10 @@ func (s *ComputerService) sendUpdateNotification(old, new model.Computer) {
 			"new_employee":  new.EmployeeAbbreviation,
 		},
 	}
-
-	if err := s.notifier.SendComputerNotification(ctx, notification); err != nil {
-		s.logger.Printf("Failed to send update notification: %v", err)
-	}
 }
 
-func (s *ComputerService) sendDeletionNotification(computer model.Computer) {
-	ctx := context.Background()
-	notification := ComputerNotification{
+func newDeletionNotification(computer model.Computer) ComputerNotification {
+	return ComputerNotification{
 		Type:                 NotificationTypeComputerDeleted,
 		EmployeeAbbreviation: computer.EmployeeAbbreviation,
 		ComputerName:         computer.ComputerName,
@@ -395,36 +622,4 @@ func (s *ComputerService) sendDeletionNotification(computer model.Computer) {
 			"mac_address":   computer.MACAddress,
 		},
 	}
-
-	if err := s.notifier.SendComputerNotification(ctx, notification); err != nil {
-		s.logger.Printf("Failed to send deletion notification: %v", err)
-	}
-}
-
-// Validation helper functions (these should ideally be moved to a validation package)
-
-func isValidMACAddress(mac string) bool {
-	// Implement MAC address validation logic
-	// For now, just check basic format
-	mac = strings.ReplaceAll(mac, "-", ":")
-	parts := strings.Split(mac, ":")
-	if len(parts) != 6 {
-		return false
-	}
-	for _, part := range parts {
-		if len(part) != 2 {
-			return false
-		}
-	}
-	return true
-}
-
-func isValidIPAddress(ip string) bool {
-	// Implement IP address validation logic
-	// For now, just check basic format
-	parts := strings.Split(ip, ".")
-	if len(parts) != 4 {
-		return false
-	}
-	return true
 }
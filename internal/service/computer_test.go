@@ -0,0 +1,851 @@
+package service
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	apperrors "computer-management-api/pkg/errors"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mock implementations for testing
+
+// mockComputerRepository is a mock implementation of ComputerRepository
+type mockComputerRepository struct {
+	repository.ComputerRepository
+
+	CreateComputerFunc             func(ctx context.Context, computer model.Computer) error
+	GetComputerByIDFunc            func(ctx context.Context, id uuid.UUID) (*model.Computer, error)
+	GetComputerByMACFunc           func(ctx context.Context, macAddress string) (*model.Computer, error)
+	GetComputerByIDForUpdateFunc   func(ctx context.Context, id uuid.UUID) (*model.Computer, error)
+	DeleteComputerFunc             func(ctx context.Context, id uuid.UUID) error
+	GetComputersByEmployeeFunc     func(ctx context.Context, employeeAbbreviation string) ([]model.Computer, error)
+	ComputerExistsFunc             func(ctx context.Context, macAddress string) (bool, error)
+	AssignComputerToEmployeeFunc   func(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error
+	RemoveComputerFromEmployeeFunc func(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error
+	UpdateComputerFunc             func(ctx context.Context, id uuid.UUID, computer model.Computer) error
+	GetComputersByMACsFunc         func(ctx context.Context, macAddresses []string) ([]model.Computer, error)
+}
+
+func (m *mockComputerRepository) CreateComputer(ctx context.Context, computer model.Computer) error {
+	if m.CreateComputerFunc != nil {
+		return m.CreateComputerFunc(ctx, computer)
+	}
+	return nil
+}
+
+func (m *mockComputerRepository) GetComputerByID(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	if m.GetComputerByIDFunc != nil {
+		return m.GetComputerByIDFunc(ctx, id)
+	}
+	return nil, repository.ErrComputerNotFound
+}
+
+func (m *mockComputerRepository) GetComputerByMAC(ctx context.Context, macAddress string) (*model.Computer, error) {
+	if m.GetComputerByMACFunc != nil {
+		return m.GetComputerByMACFunc(ctx, macAddress)
+	}
+	return nil, repository.ErrComputerNotFound
+}
+
+func (m *mockComputerRepository) GetComputerByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+	if m.GetComputerByIDForUpdateFunc != nil {
+		return m.GetComputerByIDForUpdateFunc(ctx, id)
+	}
+	return m.GetComputerByID(ctx, id)
+}
+
+func (m *mockComputerRepository) UpdateComputer(ctx context.Context, id uuid.UUID, computer model.Computer) error {
+	if m.UpdateComputerFunc != nil {
+		return m.UpdateComputerFunc(ctx, id, computer)
+	}
+	return nil
+}
+
+func (m *mockComputerRepository) GetComputersByMACs(ctx context.Context, macAddresses []string) ([]model.Computer, error) {
+	if m.GetComputersByMACsFunc != nil {
+		return m.GetComputersByMACsFunc(ctx, macAddresses)
+	}
+	return nil, nil
+}
+
+func (m *mockComputerRepository) DeleteComputer(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteComputerFunc != nil {
+		return m.DeleteComputerFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *mockComputerRepository) GetComputersByEmployee(ctx context.Context, employeeAbbreviation string) ([]model.Computer, error) {
+	if m.GetComputersByEmployeeFunc != nil {
+		return m.GetComputersByEmployeeFunc(ctx, employeeAbbreviation)
+	}
+	return []model.Computer{}, nil
+}
+
+func (m *mockComputerRepository) ComputerExists(ctx context.Context, macAddress string) (bool, error) {
+	if m.ComputerExistsFunc != nil {
+		return m.ComputerExistsFunc(ctx, macAddress)
+	}
+	return false, nil
+}
+
+func (m *mockComputerRepository) AssignComputerToEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+	if m.AssignComputerToEmployeeFunc != nil {
+		return m.AssignComputerToEmployeeFunc(ctx, computerID, employeeAbbreviation)
+	}
+	return nil
+}
+
+func (m *mockComputerRepository) RemoveComputerFromEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbreviation string) error {
+	if m.RemoveComputerFromEmployeeFunc != nil {
+		return m.RemoveComputerFromEmployeeFunc(ctx, computerID, employeeAbbreviation)
+	}
+	return nil
+}
+
+// mockEmployeeRepository is a mock implementation of EmployeeRepository
+type mockEmployeeRepository struct {
+	repository.EmployeeRepository
+
+	GetEmployeeByAbbreviationFunc func(ctx context.Context, abbreviation string) (*model.Employee, error)
+	locked                        []string
+}
+
+func (m *mockEmployeeRepository) GetEmployeeByAbbreviation(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	if m.GetEmployeeByAbbreviationFunc != nil {
+		return m.GetEmployeeByAbbreviationFunc(ctx, abbreviation)
+	}
+	return &model.Employee{Abbreviation: abbreviation, Active: true}, nil
+}
+
+func (m *mockEmployeeRepository) GetEmployeeByAbbreviationForUpdate(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	m.locked = append(m.locked, abbreviation)
+	return m.GetEmployeeByAbbreviation(ctx, abbreviation)
+}
+
+// mockEventRepository records audit events in memory
+type mockEventRepository struct {
+	repository.EventRepository
+
+	events []model.ComputerEvent
+}
+
+func (m *mockEventRepository) RecordEvent(ctx context.Context, event model.ComputerEvent) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockEventRepository) GetLatestEvent(ctx context.Context, computerID uuid.UUID, eventType model.ComputerEventType) (*model.ComputerEvent, error) {
+	for i := len(m.events) - 1; i >= 0; i-- {
+		if m.events[i].ComputerID == computerID && m.events[i].EventType == eventType {
+			return &m.events[i], nil
+		}
+	}
+	return nil, repository.ErrEventNotFound
+}
+
+func (m *mockEventRepository) GetLatestEventByType(ctx context.Context, eventType model.ComputerEventType) (*model.ComputerEvent, error) {
+	for i := len(m.events) - 1; i >= 0; i-- {
+		if m.events[i].EventType == eventType {
+			return &m.events[i], nil
+		}
+	}
+	return nil, repository.ErrEventNotFound
+}
+
+// mockPolicyRepository serves a fixed set of quota policies, most general first
+type mockPolicyRepository struct {
+	repository.PolicyRepository
+
+	policies []model.QuotaPolicy
+}
+
+func (m *mockPolicyRepository) GetApplicablePolicies(ctx context.Context, employeeAbbreviation, department string) ([]model.QuotaPolicy, error) {
+	var applicable []model.QuotaPolicy
+	for _, policy := range m.policies {
+		if policy.Scope == model.QuotaPolicyScopeGlobal ||
+			(policy.Scope == model.QuotaPolicyScopeDepartment && policy.Target == department) ||
+			(policy.Scope == model.QuotaPolicyScopeEmployee && policy.Target == employeeAbbreviation) {
+			applicable = append(applicable, policy)
+		}
+	}
+	return applicable, nil
+}
+
+// mockTransactor runs the unit of work directly against the mock repositories
+type mockTransactor struct {
+	repos repository.Repositories
+}
+
+func (m *mockTransactor) WithinTransaction(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	return fn(m.repos)
+}
+
+// mockOutboxRepository records enqueued messages in memory
+type mockOutboxRepository struct {
+	repository.OutboxRepository
+
+	messages []model.OutboxMessage
+}
+
+func (m *mockOutboxRepository) Enqueue(ctx context.Context, message model.OutboxMessage) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+// notifications decodes the payloads of the enqueued messages
+func (m *mockOutboxRepository) notifications(t *testing.T) []ComputerNotification {
+	t.Helper()
+	notifications := make([]ComputerNotification, 0, len(m.messages))
+	for _, message := range m.messages {
+		var notification ComputerNotification
+		if err := json.Unmarshal(message.Payload, &notification); err != nil {
+			t.Fatalf("Invalid outbox payload: %v", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications
+}
+
+// Helper functions for tests
+
+func createTestComputer() model.Computer {
+	return model.Computer{
+		ID:                   uuid.New(),
+		MACAddress:           "00:1b:44:11:3a:b7",
+		ComputerName:         "TEST-001",
+		IPAddress:            "192.168.1.100",
+		EmployeeAbbreviation: "ABC",
+	}
+}
+
+func createTestService() (*ComputerService, *mockComputerRepository, *mockOutboxRepository) {
+	svc, repo, _, outbox := createTestServiceWithEvents()
+	return svc, repo, outbox
+}
+
+// createTestRepositories returns transactional repositories with the given quota policies
+func createTestRepositories(repo *mockComputerRepository, outbox *mockOutboxRepository, policies ...model.QuotaPolicy) repository.Repositories {
+	return repository.Repositories{
+		Computers: repo,
+		Employees: &mockEmployeeRepository{},
+		Outbox:    outbox,
+		Policies:  &mockPolicyRepository{policies: policies},
+		Subnets:   &mockSubnetRepository{},
+	}
+}
+
+func createTestServiceWithEvents() (*ComputerService, *mockComputerRepository, *mockEventRepository, *mockOutboxRepository) {
+	repo := &mockComputerRepository{}
+	events := &mockEventRepository{}
+	employees := &mockEmployeeRepository{}
+	outbox := &mockOutboxRepository{}
+	tx := &mockTransactor{repos: repository.Repositories{Computers: repo, Employees: employees, Events: events, Outbox: outbox, Policies: &mockPolicyRepository{},
+		Subnets: &mockSubnetRepository{}}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+	return NewComputerService(repo, employees, tx, logger), repo, events, outbox
+}
+
+// Test CreateComputer
+
+func TestCreateComputer_NormalizesMAC(t *testing.T) {
+	svc, repo, _ := createTestService()
+
+	var stored model.Computer
+	repo.CreateComputerFunc = func(ctx context.Context, c model.Computer) error {
+		stored = c
+		return nil
+	}
+
+	created, err := svc.CreateComputer(context.Background(), createTestComputer())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stored.MACAddress != "00:1B:44:11:3A:B7" {
+		t.Errorf("Expected normalized MAC to be stored, got %s", stored.MACAddress)
+	}
+	if created.MACAddress != stored.MACAddress {
+		t.Errorf("Expected returned MAC %s, got %s", stored.MACAddress, created.MACAddress)
+	}
+}
+
+func TestCreateComputer_ValidationError(t *testing.T) {
+	svc, _, _ := createTestService()
+
+	computer := createTestComputer()
+	computer.EmployeeAbbreviation = "TOOLONG"
+
+	_, err := svc.CreateComputer(context.Background(), computer)
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		t.Fatalf("Expected AppError, got %v", err)
+	}
+	if appErr.Code != apperrors.ErrorCodeValidation {
+		t.Errorf("Expected validation error code, got %s", appErr.Code)
+	}
+	if len(appErr.Details) == 0 {
+		t.Error("Expected validation details to be present")
+	}
+}
+
+func TestCreateComputer_DuplicateMAC(t *testing.T) {
+	svc, repo, _ := createTestService()
+
+	repo.ComputerExistsFunc = func(ctx context.Context, mac string) (bool, error) {
+		return true, nil
+	}
+
+	_, err := svc.CreateComputer(context.Background(), createTestComputer())
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		t.Fatalf("Expected AppError, got %v", err)
+	}
+	if appErr.Code != apperrors.ErrorCodeAlreadyExists {
+		t.Errorf("Expected already exists error code, got %s", appErr.Code)
+	}
+}
+
+func TestCreateComputer_IPAddressTaken(t *testing.T) {
+	svc, repo, _ := createTestService()
+	svc.tx.(*mockTransactor).repos.Subnets = &mockSubnetRepository{
+		assigned: []model.IPAddressAssignment{{Address: createTestComputer().IPAddress, ComputerID: uuid.New()}},
+	}
+	repo.CreateComputerFunc = func(ctx context.Context, c model.Computer) error {
+		t.Error("Expected the computer not to be created")
+		return nil
+	}
+
+	_, err := svc.CreateComputer(context.Background(), createTestComputer())
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeConflict {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+}
+
+func TestCreateComputer_RequireHostnames(t *testing.T) {
+	svc, _, _ := createTestService()
+
+	computer := createTestComputer()
+	computer.ComputerName = "Reception PC"
+
+	// Any name is accepted until hostnames are required
+	if _, err := svc.CreateComputer(context.Background(), computer); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	svc.RequireHostnames = true
+	computer.ID = uuid.New()
+	_, err := svc.CreateComputer(context.Background(), computer)
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeValidation {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+
+	computer.ComputerName = "reception-pc"
+	if _, err := svc.CreateComputer(context.Background(), computer); err != nil {
+		t.Errorf("Unexpected error for a hostname: %v", err)
+	}
+}
+
+func TestCreateComputer_SuspiciousMAC(t *testing.T) {
+	svc, _, _ := createTestService()
+
+	computer := createTestComputer()
+	computer.MACAddress = "01:00:5e:00:00:fb"
+	computer.Vendor = "Spoofed Vendor"
+
+	// Multicast and locally administered addresses are flagged until they are rejected
+	created, err := svc.CreateComputer(context.Background(), computer)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created.Vendor != "" || len(created.MACWarnings) != 1 || !strings.Contains(created.MACWarnings[0], "multicast") {
+		t.Errorf("Expected a multicast warning and no vendor, got %q %v", created.Vendor, created.MACWarnings)
+	}
+
+	svc.RejectSuspiciousMACs = true
+	computer.ID = uuid.New()
+	_, err = svc.CreateComputer(context.Background(), computer)
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeValidation {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+
+	computer.MACAddress = "00:50:56:00:00:01"
+	created, err = svc.CreateComputer(context.Background(), computer)
+	if err != nil {
+		t.Fatalf("Unexpected error for a universal address: %v", err)
+	}
+	if created.Vendor != "VMware, Inc." || created.MACWarnings != nil {
+		t.Errorf("Expected the registered vendor without warnings, got %q %v", created.Vendor, created.MACWarnings)
+	}
+}
+
+func TestCreateComputer_UnknownEmployee(t *testing.T) {
+	svc, _, _ := createTestService()
+	svc.employees = &mockEmployeeRepository{
+		GetEmployeeByAbbreviationFunc: func(ctx context.Context, abbreviation string) (*model.Employee, error) {
+			return nil, repository.ErrEmployeeNotFound
+		},
+	}
+
+	_, err := svc.CreateComputer(context.Background(), createTestComputer())
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		t.Fatalf("Expected AppError, got %v", err)
+	}
+	if appErr.Code != apperrors.ErrorCodeNotFound {
+		t.Errorf("Expected not found error code, got %s", appErr.Code)
+	}
+}
+
+func TestCreateComputer_RecordsEvent(t *testing.T) {
+	svc, _, events, _ := createTestServiceWithEvents()
+
+	ctx := WithActor(context.Background(), "jane.admin")
+	created, err := svc.CreateComputer(ctx, createTestComputer())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.EventType != model.ComputerEventCreated || event.Actor != "jane.admin" {
+		t.Errorf("Expected created event by jane.admin, got %s by %s", event.EventType, event.Actor)
+	}
+	if event.ComputerID != created.ID || event.MACAddress != created.MACAddress {
+		t.Errorf("Expected event for computer %s, got %s", created.ID, event.ComputerID)
+	}
+	if event.OldValue != nil || event.NewValue == nil {
+		t.Error("Expected creation event to carry only a new value")
+	}
+}
+
+func TestDeleteComputer_RecordsEvent(t *testing.T) {
+	svc, repo, events, _ := createTestServiceWithEvents()
+
+	computer := createTestComputer()
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		return &computer, nil
+	}
+
+	if err := svc.DeleteComputer(context.Background(), computer.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.EventType != model.ComputerEventDeleted || event.Actor != AnonymousActor {
+		t.Errorf("Expected deleted event by %s, got %s by %s", AnonymousActor, event.EventType, event.Actor)
+	}
+	if event.OldEmployeeAbbreviation != "ABC" || event.NewValue != nil {
+		t.Error("Expected deletion event to keep the last holder and carry no new value")
+	}
+}
+
+func TestUpdateComputer_RecordsMACChange(t *testing.T) {
+	svc, repo, events, _ := createTestServiceWithEvents()
+
+	stored := createTestComputer()
+	stored.MACAddress = "00:1B:44:11:3A:B7"
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		computer := stored
+		return &computer, nil
+	}
+	repo.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, computer model.Computer) error {
+		stored = computer
+		return nil
+	}
+
+	updates := stored
+	updates.MACAddress = "00:1B:44:11:3A:B8"
+	if _, err := svc.UpdateComputer(context.Background(), stored.ID, updates); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.MACAddress != "00:1B:44:11:3A:B8" || event.OldMACAddress != "00:1B:44:11:3A:B7" {
+		t.Errorf("Expected the change to be recorded under both MAC addresses, got %s -> %s", event.OldMACAddress, event.MACAddress)
+	}
+}
+
+func TestAssignComputerToEmployee_DecidesOnLockedRow(t *testing.T) {
+	svc, repo, events, outbox := createTestServiceWithEvents()
+
+	// A concurrent change reassigned the computer to DEF after it was last read without a lock
+	computer := createTestComputer()
+	repo.GetComputerByIDForUpdateFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		locked := computer
+		locked.EmployeeAbbreviation = "DEF"
+		locked.Version = 2
+		return &locked, nil
+	}
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		assigned := computer
+		assigned.EmployeeAbbreviation = "XYZ"
+		assigned.Version = 3
+		return &assigned, nil
+	}
+
+	assigned, err := svc.AssignComputerToEmployee(context.Background(), computer.ID, "XYZ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if assigned.Version != 3 {
+		t.Errorf("Expected the assigned computer with its new version 3, got %d", assigned.Version)
+	}
+	if len(events.events) != 1 || events.events[0].OldEmployeeAbbreviation != "DEF" {
+		t.Fatalf("Expected the reassignment from the locked holder DEF, got %+v", events.events)
+	}
+	if len(outbox.messages) == 0 {
+		t.Error("Expected the reassignment to be notified")
+	}
+}
+
+func TestDeleteComputer_VersionMismatch(t *testing.T) {
+	svc, repo, events, _ := createTestServiceWithEvents()
+
+	computer := createTestComputer()
+	computer.Version = 5
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		return &computer, nil
+	}
+	repo.DeleteComputerFunc = func(ctx context.Context, id uuid.UUID) error {
+		t.Error("Expected the computer not to be deleted")
+		return nil
+	}
+
+	err := svc.DeleteComputer(WithExpectedVersions(context.Background(), 4), computer.ID)
+
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok || appErr.Code != apperrors.ErrorCodePreconditionFailed {
+		t.Fatalf("Expected precondition failed error, got %v", err)
+	}
+	if appErr.Details["current_version"] != int64(5) {
+		t.Errorf("Expected current version 5 in details, got %v", appErr.Details["current_version"])
+	}
+	if len(events.events) != 0 {
+		t.Errorf("Expected no events, got %d", len(events.events))
+	}
+}
+
+func TestDeleteComputer_VersionMatch(t *testing.T) {
+	svc, repo, _ := createTestService()
+
+	computer := createTestComputer()
+	computer.Version = 5
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		return &computer, nil
+	}
+
+	if err := svc.DeleteComputer(WithExpectedVersions(context.Background(), 4, 5), computer.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+// Test AssignComputerToEmployee and RemoveComputerFromEmployee
+
+func TestAssignComputerToEmployee_NotFound(t *testing.T) {
+	svc, _, _ := createTestService()
+
+	_, err := svc.AssignComputerToEmployee(context.Background(), uuid.New(), "ABC")
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		t.Fatalf("Expected AppError, got %v", err)
+	}
+	if appErr.Code != apperrors.ErrorCodeNotFound {
+		t.Errorf("Expected not found error code, got %s", appErr.Code)
+	}
+}
+
+func TestAssignComputerToEmployee_InvalidAbbreviation(t *testing.T) {
+	svc, _, _ := createTestService()
+
+	_, err := svc.AssignComputerToEmployee(context.Background(), uuid.New(), "AB")
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		t.Fatalf("Expected AppError, got %v", err)
+	}
+	if !strings.Contains(appErr.Message, "exactly 3 characters") {
+		t.Errorf("Expected abbreviation length error, got %s", appErr.Message)
+	}
+}
+
+func TestRemoveComputerFromEmployee_NotAssigned(t *testing.T) {
+	svc, repo, _ := createTestService()
+
+	repo.RemoveComputerFromEmployeeFunc = func(ctx context.Context, id uuid.UUID, emp string) error {
+		return repository.ErrNotAssigned
+	}
+
+	_, err := svc.RemoveComputerFromEmployee(context.Background(), uuid.New(), "ABC")
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		t.Fatalf("Expected AppError, got %v", err)
+	}
+	if appErr.GetHTTPStatus() != 404 {
+		t.Errorf("Expected 404 status, got %d", appErr.GetHTTPStatus())
+	}
+}
+
+func TestRemoveComputerFromEmployee_RecordsEvent(t *testing.T) {
+	svc, repo, events, _ := createTestServiceWithEvents()
+
+	computer := createTestComputer()
+	computer.EmployeeAbbreviation = ""
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		return &computer, nil
+	}
+
+	if _, err := svc.RemoveComputerFromEmployee(context.Background(), computer.ID, "ABC"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.EventType != model.ComputerEventUnassigned {
+		t.Errorf("Expected unassigned event, got %s", event.EventType)
+	}
+	if event.OldEmployeeAbbreviation != "ABC" || event.NewEmployeeAbbreviation != "" {
+		t.Errorf("Expected unassignment from ABC, got %s -> %s", event.OldEmployeeAbbreviation, event.NewEmployeeAbbreviation)
+	}
+}
+
+// Test notification outbox
+
+func TestCreateComputer_EnqueuesNotifications(t *testing.T) {
+	svc, repo, outbox := createTestService()
+
+	repo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		return []model.Computer{createTestComputer(), createTestComputer(), createTestComputer()}, nil
+	}
+
+	if _, err := svc.CreateComputer(context.Background(), createTestComputer()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	queued := outbox.notifications(t)
+	if len(queued) != 2 {
+		t.Fatalf("Expected 2 queued notifications, got %d", len(queued))
+	}
+	if queued[0].Type != NotificationTypeComputerCreated || queued[1].Type != NotificationTypeThresholdExceeded {
+		t.Errorf("Expected created and threshold notifications, got %s and %s", queued[0].Type, queued[1].Type)
+	}
+	if outbox.messages[1].NotificationType != string(NotificationTypeThresholdExceeded) {
+		t.Errorf("Expected message type to match payload, got %s", outbox.messages[1].NotificationType)
+	}
+}
+
+func TestCreateComputer_FailedWriteEnqueuesNothing(t *testing.T) {
+	svc, repo, outbox := createTestService()
+
+	repo.CreateComputerFunc = func(ctx context.Context, c model.Computer) error {
+		return repository.ErrDuplicateMAC
+	}
+
+	if _, err := svc.CreateComputer(context.Background(), createTestComputer()); err == nil {
+		t.Fatal("Expected error")
+	}
+	if len(outbox.messages) != 0 {
+		t.Errorf("Expected no queued notifications, got %d", len(outbox.messages))
+	}
+}
+
+func TestApplyQuotaPolicy_ThresholdExceeded(t *testing.T) {
+	_, repo, outbox := createTestService()
+
+	repo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		if emp != "ABC" {
+			t.Errorf("Expected employee ABC, got %s", emp)
+		}
+		return []model.Computer{createTestComputer(), createTestComputer(), createTestComputer(), createTestComputer()}, nil
+	}
+
+	repos := createTestRepositories(repo, outbox)
+	if err := applyQuotaPolicy(context.Background(), repos, "ABC"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	queued := outbox.notifications(t)
+	if len(queued) == 0 {
+		t.Fatal("Expected notification to be queued")
+	}
+	if queued[0].Type != NotificationTypeThresholdExceeded {
+		t.Errorf("Expected threshold notification, got %s", queued[0].Type)
+	}
+	if queued[0].EmployeeAbbreviation != "ABC" {
+		t.Errorf("Expected employee ABC, got %s", queued[0].EmployeeAbbreviation)
+	}
+	if !strings.Contains(queued[0].Message, "4 computers") {
+		t.Errorf("Expected message to contain '4 computers', got %s", queued[0].Message)
+	}
+}
+
+func TestApplyQuotaPolicy_BelowThreshold(t *testing.T) {
+	_, repo, outbox := createTestService()
+
+	repo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		return []model.Computer{createTestComputer()}, nil
+	}
+
+	repos := createTestRepositories(repo, outbox)
+	if err := applyQuotaPolicy(context.Background(), repos, "ABC"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(outbox.messages) > 0 {
+		t.Error("Expected no notification to be queued for computers below threshold")
+	}
+}
+
+func TestApplyQuotaPolicy_EmptyEmployee(t *testing.T) {
+	_, repo, outbox := createTestService()
+
+	repoCalled := false
+	repo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		repoCalled = true
+		return []model.Computer{}, nil
+	}
+
+	repos := createTestRepositories(repo, outbox)
+	if err := applyQuotaPolicy(context.Background(), repos, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if repoCalled {
+		t.Error("Repository method should not be called for empty employee")
+	}
+	if len(outbox.messages) > 0 {
+		t.Error("No notification should be queued for empty employee")
+	}
+}
+
+func TestApplyQuotaPolicy_EnforcedQuotaExceeded(t *testing.T) {
+	_, repo, outbox := createTestService()
+
+	repo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		return []model.Computer{createTestComputer(), createTestComputer(), createTestComputer()}, nil
+	}
+
+	repos := createTestRepositories(repo, outbox,
+		model.QuotaPolicy{Scope: model.QuotaPolicyScopeGlobal, MaxComputers: 5, Mode: model.QuotaPolicyModeWarn},
+		model.QuotaPolicy{Scope: model.QuotaPolicyScopeEmployee, Target: "ABC", MaxComputers: 2, Mode: model.QuotaPolicyModeEnforce},
+	)
+	err := applyQuotaPolicy(context.Background(), repos, "ABC")
+
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		t.Fatalf("Expected AppError, got %v", err)
+	}
+	if appErr.Code != apperrors.ErrorCodeConflict {
+		t.Errorf("Expected conflict error code, got %s", appErr.Code)
+	}
+	if len(outbox.messages) > 0 {
+		t.Error("Expected no notification to be queued for an enforced quota")
+	}
+}
+
+func TestApplyQuotaPolicy_EnforcedQuotaReached(t *testing.T) {
+	_, repo, outbox := createTestService()
+
+	repo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		return []model.Computer{createTestComputer(), createTestComputer()}, nil
+	}
+
+	repos := createTestRepositories(repo, outbox,
+		model.QuotaPolicy{Scope: model.QuotaPolicyScopeGlobal, MaxComputers: 2, Mode: model.QuotaPolicyModeEnforce},
+	)
+	if err := applyQuotaPolicy(context.Background(), repos, "ABC"); err != nil {
+		t.Errorf("Expected the quota itself to be allowed, got %v", err)
+	}
+}
+
+func TestApplyQuotaPolicy_LocksEmployeeBeforeCounting(t *testing.T) {
+	_, repo, outbox := createTestService()
+	repos := createTestRepositories(repo, outbox,
+		model.QuotaPolicy{Scope: model.QuotaPolicyScopeGlobal, MaxComputers: 2, Mode: model.QuotaPolicyModeEnforce},
+	)
+	employees := repos.Employees.(*mockEmployeeRepository)
+
+	repo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		if len(employees.locked) != 1 || employees.locked[0] != "ABC" {
+			t.Errorf("Expected employee ABC to be locked before counting, got %v", employees.locked)
+		}
+		return []model.Computer{createTestComputer()}, nil
+	}
+
+	if err := applyQuotaPolicy(context.Background(), repos, "ABC"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(employees.locked) != 1 {
+		t.Errorf("Expected the employee to be locked once, got %v", employees.locked)
+	}
+}
+
+func TestApplyQuotaPolicy_DepartmentOverridesGlobal(t *testing.T) {
+	_, repo, outbox := createTestService()
+
+	repo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		return []model.Computer{createTestComputer(), createTestComputer(), createTestComputer(), createTestComputer()}, nil
+	}
+
+	repos := createTestRepositories(repo, outbox,
+		model.QuotaPolicy{Scope: model.QuotaPolicyScopeGlobal, MaxComputers: 3, Mode: model.QuotaPolicyModeEnforce},
+		model.QuotaPolicy{Scope: model.QuotaPolicyScopeDepartment, Target: "Engineering", MaxComputers: 5, Mode: model.QuotaPolicyModeWarn},
+	)
+	repos.Employees = &mockEmployeeRepository{
+		GetEmployeeByAbbreviationFunc: func(ctx context.Context, abbreviation string) (*model.Employee, error) {
+			return &model.Employee{Abbreviation: abbreviation, Department: "Engineering", Active: true}, nil
+		},
+	}
+
+	if err := applyQuotaPolicy(context.Background(), repos, "ABC"); err != nil {
+		t.Errorf("Expected the department policy to allow 4 computers, got %v", err)
+	}
+	if len(outbox.messages) > 0 {
+		t.Error("Expected no notification below the department threshold")
+	}
+}
+
+func TestAssignComputerToEmployee_EnforcedQuotaRejected(t *testing.T) {
+	svc, repo, _, _ := createTestServiceWithEvents()
+	svc.tx.(*mockTransactor).repos.Policies = &mockPolicyRepository{policies: []model.QuotaPolicy{
+		{Scope: model.QuotaPolicyScopeGlobal, MaxComputers: 1, Mode: model.QuotaPolicyModeEnforce},
+	}}
+
+	computer := createTestComputer()
+	computer.EmployeeAbbreviation = ""
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		return &computer, nil
+	}
+	repo.GetComputersByEmployeeFunc = func(ctx context.Context, emp string) ([]model.Computer, error) {
+		return []model.Computer{createTestComputer(), computer}, nil
+	}
+
+	_, err := svc.AssignComputerToEmployee(context.Background(), computer.ID, "ABC")
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		t.Fatalf("Expected AppError, got %v", err)
+	}
+	if appErr.GetHTTPStatus() != 409 {
+		t.Errorf("Expected 409 status, got %d", appErr.GetHTTPStatus())
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DispatcherConfig controls how the outbox dispatcher polls and retries
+type DispatcherConfig struct {
+	// PollInterval is how often the outbox is checked for due messages
+	PollInterval time.Duration
+	// BatchSize is the maximum number of messages claimed per poll
+	BatchSize int
+	// MaxAttempts is the number of failed deliveries after which a message is dead-lettered
+	MaxAttempts int
+	// RetryBackoff is the delay after the first failure; it doubles with every further failure
+	RetryBackoff time.Duration
+	// MaxRetryBackoff caps the delay between attempts
+	MaxRetryBackoff time.Duration
+	// DeliveryTimeout bounds a single delivery, including the notifier's own retries
+	DeliveryTimeout time.Duration
+}
+
+// DefaultDispatcherConfig returns the default dispatcher configuration
+func DefaultDispatcherConfig() DispatcherConfig {
+	return DispatcherConfig{
+		PollInterval:    time.Second,
+		BatchSize:       50,
+		MaxAttempts:     5,
+		RetryBackoff:    30 * time.Second,
+		MaxRetryBackoff: time.Hour,
+		DeliveryTimeout: time.Minute,
+	}
+}
+
+// OutboxDispatcher delivers notifications from the outbox in the background
+type OutboxDispatcher struct {
+	outbox   repository.OutboxRepository
+	notifier NotificationService
+	config   DispatcherConfig
+	logger   *slog.Logger
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+	cancel    context.CancelFunc
+}
+
+// NewOutboxDispatcher creates a new outbox dispatcher
+func NewOutboxDispatcher(outbox repository.OutboxRepository, notifier NotificationService, config DispatcherConfig, logger *slog.Logger) *OutboxDispatcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &OutboxDispatcher{
+		outbox:   outbox,
+		notifier: notifier,
+		config:   config,
+		logger:   logger,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start begins polling the outbox in a background goroutine
+func (d *OutboxDispatcher) Start() {
+	d.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		d.cancel = cancel
+		go d.run(ctx)
+	})
+}
+
+// Shutdown stops polling and drains the messages that are already due. If ctx expires
+// first, in-flight deliveries are cancelled; their claims lapse and they are retried on
+// the next start.
+func (d *OutboxDispatcher) Shutdown(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stop) })
+
+	// Nothing to drain if the dispatcher was never started
+	d.startOnce.Do(func() { close(d.done) })
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		if d.cancel != nil {
+			d.cancel()
+		}
+		<-d.done
+		return ctx.Err()
+	}
+}
+
+// run polls the outbox until Shutdown is called, then drains it once more
+func (d *OutboxDispatcher) run(ctx context.Context) {
+	defer close(d.done)
+	defer d.cancel()
+
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			d.logger.Info("Notification dispatcher draining outbox")
+			d.dispatchAll(ctx)
+			d.logger.Info("Notification dispatcher stopped")
+			return
+		case <-ticker.C:
+			d.dispatchAll(ctx)
+		}
+	}
+}
+
+// dispatchAll delivers batches until no due messages remain
+func (d *OutboxDispatcher) dispatchAll(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := d.DispatchPending(ctx)
+		if err != nil {
+			d.logger.ErrorContext(ctx, "Failed to dispatch notifications", "error", err)
+			return
+		}
+		if claimed < d.config.BatchSize {
+			return
+		}
+	}
+}
+
+// DispatchPending claims one batch of due messages and attempts to deliver each of them.
+// It returns the number of messages claimed.
+func (d *OutboxDispatcher) DispatchPending(ctx context.Context) (int, error) {
+	// Messages of a batch are delivered one after another, so claims outlive the delivery of the
+	// whole batch plus one spare timeout; a slow notifier then cannot cause duplicate sends
+	lease := time.Duration(d.config.BatchSize+1) * d.config.DeliveryTimeout
+
+	messages, err := d.outbox.ClaimDue(ctx, d.config.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		d.deliver(ctx, message)
+	}
+
+	return len(messages), nil
+}
+
+// deliver sends a single message and records the outcome
+func (d *OutboxDispatcher) deliver(ctx context.Context, message model.OutboxMessage) {
+	logger := d.logger.With("notification_id", message.ID, "type", message.NotificationType)
+
+	var notification ComputerNotification
+	if err := json.Unmarshal(message.Payload, &notification); err != nil {
+		d.markDead(ctx, logger, message, fmt.Errorf("invalid payload: %w", err))
+		return
+	}
+	if notification.EmployeeAbbreviation != "" {
+		logger = logger.With("employee", notification.EmployeeAbbreviation)
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, d.config.DeliveryTimeout)
+	err := d.notifier.SendComputerNotification(sendCtx, notification)
+	cancel()
+
+	if err == nil {
+		if err := d.outbox.MarkDelivered(ctx, message.ID); err != nil {
+			logger.ErrorContext(ctx, "Failed to mark notification as delivered", "error", err)
+		}
+		return
+	}
+
+	// Shutdown interrupted the delivery; leave the claim to lapse so it is retried
+	if ctx.Err() != nil {
+		return
+	}
+
+	attempts := message.Attempts + 1
+	if attempts >= d.config.MaxAttempts {
+		d.markDead(ctx, logger, message, err)
+		return
+	}
+
+	nextAttemptAt := time.Now().Add(d.retryBackoff(attempts))
+	if err := d.outbox.MarkRetry(ctx, message.ID, err.Error(), nextAttemptAt); err != nil {
+		logger.ErrorContext(ctx, "Failed to schedule notification retry", "error", err)
+		return
+	}
+
+	logger.WarnContext(ctx, "Notification failed, retrying", "attempt", attempts, "max_attempts", d.config.MaxAttempts,
+		"next_attempt_at", nextAttemptAt.Format(time.RFC3339), "error", err)
+}
+
+// markDead moves a message to the dead-letter state
+func (d *OutboxDispatcher) markDead(ctx context.Context, logger *slog.Logger, message model.OutboxMessage, cause error) {
+	if err := d.outbox.MarkDead(ctx, message.ID, cause.Error()); err != nil {
+		logger.ErrorContext(ctx, "Failed to dead-letter notification", "error", err)
+		return
+	}
+
+	logger.ErrorContext(ctx, "Notification moved to dead letter", "attempts", message.Attempts+1, "error", cause)
+}
+
+// retryBackoff returns the exponential delay before the given attempt is retried
+func (d *OutboxDispatcher) retryBackoff(attempts int) time.Duration {
+	backoff := d.config.RetryBackoff
+	for i := 1; i < attempts && backoff < d.config.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > d.config.MaxRetryBackoff {
+		backoff = d.config.MaxRetryBackoff
+	}
+	return backoff
+}
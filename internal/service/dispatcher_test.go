@@ -0,0 +1,228 @@
+package service
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockNotificationService records notifications delivered by the dispatcher
+type mockNotificationService struct {
+	mu            sync.Mutex
+	notifications []ComputerNotification
+	err           error
+}
+
+func (m *mockNotificationService) SendComputerNotification(ctx context.Context, notification ComputerNotification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.notifications = append(m.notifications, notification)
+	return nil
+}
+
+func (m *mockNotificationService) sent() []ComputerNotification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ComputerNotification(nil), m.notifications...)
+}
+
+// memoryOutbox is an in-memory outbox that tracks message state transitions
+type memoryOutbox struct {
+	mockOutboxRepository
+
+	mu      sync.Mutex
+	pending []model.OutboxMessage
+	status  map[int64]model.OutboxStatus
+	retries map[int64]time.Time
+	leases  []time.Duration
+}
+
+func newMemoryOutbox(messages ...model.OutboxMessage) *memoryOutbox {
+	return &memoryOutbox{
+		pending: messages,
+		status:  make(map[int64]model.OutboxStatus),
+		retries: make(map[int64]time.Time),
+	}
+}
+
+func (m *memoryOutbox) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leases = append(m.leases, lease)
+	if limit > len(m.pending) {
+		limit = len(m.pending)
+	}
+	claimed := m.pending[:limit]
+	m.pending = m.pending[limit:]
+	return claimed, nil
+}
+
+func (m *memoryOutbox) MarkDelivered(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status[id] = model.OutboxStatusDelivered
+	return nil
+}
+
+func (m *memoryOutbox) MarkRetry(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status[id] = model.OutboxStatusPending
+	m.retries[id] = nextAttemptAt
+	return nil
+}
+
+func (m *memoryOutbox) MarkDead(ctx context.Context, id int64, lastError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status[id] = model.OutboxStatusDead
+	return nil
+}
+
+func (m *memoryOutbox) statusOf(id int64) model.OutboxStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status[id]
+}
+
+func newTestOutboxMessage(t *testing.T, id int64, attempts int) model.OutboxMessage {
+	t.Helper()
+	payload, err := json.Marshal(ComputerNotification{Type: NotificationTypeComputerCreated, EmployeeAbbreviation: "ABC", Message: "created"})
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+	return model.OutboxMessage{ID: id, NotificationType: string(NotificationTypeComputerCreated), Payload: payload, Attempts: attempts}
+}
+
+func createTestDispatcher(outbox *memoryOutbox, notifier *mockNotificationService) *OutboxDispatcher {
+	config := DefaultDispatcherConfig()
+	config.PollInterval = time.Hour // Tests drive delivery explicitly
+	config.BatchSize = 2
+	config.MaxAttempts = 3
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+	return NewOutboxDispatcher(outbox, notifier, config, logger)
+}
+
+func TestDispatchPending_Delivers(t *testing.T) {
+	outbox := newMemoryOutbox(newTestOutboxMessage(t, 1, 0))
+	notifier := &mockNotificationService{}
+	dispatcher := createTestDispatcher(outbox, notifier)
+
+	claimed, err := dispatcher.DispatchPending(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if claimed != 1 {
+		t.Errorf("Expected 1 claimed message, got %d", claimed)
+	}
+	if sent := notifier.sent(); len(sent) != 1 || sent[0].EmployeeAbbreviation != "ABC" {
+		t.Errorf("Expected notification for ABC to be sent, got %v", sent)
+	}
+	if status := outbox.statusOf(1); status != model.OutboxStatusDelivered {
+		t.Errorf("Expected message to be delivered, got %q", status)
+	}
+}
+
+func TestDispatchPending_LeaseCoversBatch(t *testing.T) {
+	outbox := newMemoryOutbox(newTestOutboxMessage(t, 1, 0), newTestOutboxMessage(t, 2, 0))
+	dispatcher := createTestDispatcher(outbox, &mockNotificationService{})
+
+	if _, err := dispatcher.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Both messages may take the full delivery timeout, one after another
+	minimum := time.Duration(dispatcher.config.BatchSize) * dispatcher.config.DeliveryTimeout
+	if len(outbox.leases) != 1 || outbox.leases[0] <= minimum {
+		t.Errorf("Expected a lease longer than %s, got %v", minimum, outbox.leases)
+	}
+}
+
+func TestDispatchPending_SchedulesRetry(t *testing.T) {
+	outbox := newMemoryOutbox(newTestOutboxMessage(t, 1, 0))
+	notifier := &mockNotificationService{err: errors.New("service unavailable")}
+	dispatcher := createTestDispatcher(outbox, notifier)
+
+	before := time.Now()
+	if _, err := dispatcher.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if status := outbox.statusOf(1); status != model.OutboxStatusPending {
+		t.Errorf("Expected message to stay pending, got %q", status)
+	}
+	if next := outbox.retries[1]; next.Before(before.Add(dispatcher.config.RetryBackoff)) {
+		t.Errorf("Expected retry after the backoff, got %s", next)
+	}
+}
+
+func TestDispatchPending_DeadLettersAfterMaxAttempts(t *testing.T) {
+	outbox := newMemoryOutbox(newTestOutboxMessage(t, 1, 2))
+	notifier := &mockNotificationService{err: errors.New("service unavailable")}
+	dispatcher := createTestDispatcher(outbox, notifier)
+
+	if _, err := dispatcher.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if status := outbox.statusOf(1); status != model.OutboxStatusDead {
+		t.Errorf("Expected message to be dead-lettered, got %q", status)
+	}
+}
+
+func TestDispatchPending_InvalidPayload(t *testing.T) {
+	outbox := newMemoryOutbox(model.OutboxMessage{ID: 1, Payload: []byte("not json")})
+	notifier := &mockNotificationService{}
+	dispatcher := createTestDispatcher(outbox, notifier)
+
+	if _, err := dispatcher.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if status := outbox.statusOf(1); status != model.OutboxStatusDead {
+		t.Errorf("Expected message to be dead-lettered, got %q", status)
+	}
+	if len(notifier.sent()) != 0 {
+		t.Error("Expected no notification to be sent")
+	}
+}
+
+func TestOutboxDispatcher_ShutdownDrains(t *testing.T) {
+	outbox := newMemoryOutbox(newTestOutboxMessage(t, 1, 0), newTestOutboxMessage(t, 2, 0), newTestOutboxMessage(t, 3, 0))
+	notifier := &mockNotificationService{}
+	dispatcher := createTestDispatcher(outbox, notifier)
+
+	dispatcher.Start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := dispatcher.Shutdown(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(notifier.sent()) != 3 {
+		t.Errorf("Expected all 3 notifications to be delivered on shutdown, got %d", len(notifier.sent()))
+	}
+}
+
+func TestRetryBackoff_Capped(t *testing.T) {
+	dispatcher := createTestDispatcher(newMemoryOutbox(), &mockNotificationService{})
+
+	if got := dispatcher.retryBackoff(1); got != 30*time.Second {
+		t.Errorf("Expected first retry after 30s, got %s", got)
+	}
+	if got := dispatcher.retryBackoff(2); got != time.Minute {
+		t.Errorf("Expected second retry after 1m, got %s", got)
+	}
+	if got := dispatcher.retryBackoff(20); got != time.Hour {
+		t.Errorf("Expected backoff to be capped at 1h, got %s", got)
+	}
+}
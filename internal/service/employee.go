@@ -0,0 +1,125 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/validation"
+	"context"
+	stderrors "errors"
+	"log/slog"
+)
+
+// EmployeeService handles business logic for employee operations
+type EmployeeService struct {
+	repo   repository.EmployeeRepository
+	logger *slog.Logger
+}
+
+// NewEmployeeService creates a new employee service
+func NewEmployeeService(repo repository.EmployeeRepository, logger *slog.Logger) *EmployeeService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &EmployeeService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreateEmployee creates a new, active employee
+func (s *EmployeeService) CreateEmployee(ctx context.Context, employee model.Employee) (*model.Employee, error) {
+	if validationErrors := validation.ValidateEmployeeInput(&employee); len(validationErrors) > 0 {
+		return nil, validationErrorFromList(validationErrors)
+	}
+
+	// New employees always start out active
+	employee.Active = true
+
+	if err := s.repo.CreateEmployee(ctx, employee); err != nil {
+		return nil, mapEmployeeRepositoryError(err, "failed to create employee")
+	}
+
+	created, err := s.repo.GetEmployeeByAbbreviation(ctx, employee.Abbreviation)
+	if err != nil {
+		return nil, mapEmployeeRepositoryError(err, "failed to retrieve created employee")
+	}
+
+	s.logger.InfoContext(ctx, "Employee created", "employee", employee.Abbreviation)
+
+	return created, nil
+}
+
+// GetAllEmployees retrieves employees with pagination
+func (s *EmployeeService) GetAllEmployees(ctx context.Context, params repository.PaginationParams) (*repository.EmployeePaginatedResult, error) {
+	result, err := s.repo.GetAllEmployeesPaginated(ctx, params)
+	if err != nil {
+		return nil, mapEmployeeRepositoryError(err, "failed to retrieve employees")
+	}
+
+	return result, nil
+}
+
+// GetEmployee retrieves an employee by abbreviation
+func (s *EmployeeService) GetEmployee(ctx context.Context, abbreviation string) (*model.Employee, error) {
+	if err := validation.ValidateEmployeeAbbreviation(abbreviation); err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+
+	employee, err := s.repo.GetEmployeeByAbbreviation(ctx, abbreviation)
+	if err != nil {
+		return nil, mapEmployeeRepositoryError(err, "failed to retrieve employee")
+	}
+
+	return employee, nil
+}
+
+// UpdateEmployee replaces the mutable fields of an employee. Setting Active to false marks the
+// employee as having left the company.
+func (s *EmployeeService) UpdateEmployee(ctx context.Context, abbreviation string, updates model.Employee) (*model.Employee, error) {
+	// The abbreviation is the identifier and cannot be changed through an update
+	updates.Abbreviation = abbreviation
+	if validationErrors := validation.ValidateEmployeeInput(&updates); len(validationErrors) > 0 {
+		return nil, validationErrorFromList(validationErrors)
+	}
+
+	if err := s.repo.UpdateEmployee(ctx, abbreviation, updates); err != nil {
+		return nil, mapEmployeeRepositoryError(err, "failed to update employee")
+	}
+
+	updated, err := s.repo.GetEmployeeByAbbreviation(ctx, abbreviation)
+	if err != nil {
+		return nil, mapEmployeeRepositoryError(err, "failed to retrieve updated employee")
+	}
+
+	s.logger.InfoContext(ctx, "Employee updated", "employee", abbreviation, "active", updated.Active)
+
+	return updated, nil
+}
+
+// DeleteEmployee deletes an employee that has no computers assigned
+func (s *EmployeeService) DeleteEmployee(ctx context.Context, abbreviation string) error {
+	if err := validation.ValidateEmployeeAbbreviation(abbreviation); err != nil {
+		return errors.ValidationError(err.Error())
+	}
+
+	if err := s.repo.DeleteEmployee(ctx, abbreviation); err != nil {
+		return mapEmployeeRepositoryError(err, "failed to delete employee")
+	}
+
+	s.logger.InfoContext(ctx, "Employee deleted", "employee", abbreviation)
+
+	return nil
+}
+
+// mapEmployeeRepositoryError translates employee repository errors into application errors
+func mapEmployeeRepositoryError(err error, message string) error {
+	switch {
+	case stderrors.Is(err, repository.ErrDuplicateEmployee):
+		return errors.AlreadyExistsError("Employee with this abbreviation")
+	case stderrors.Is(err, repository.ErrEmployeeInUse):
+		return errors.NewAppError(errors.ErrorCodeConflict, "Employee still has computers assigned")
+	default:
+		return mapRepositoryError(err, message)
+	}
+}
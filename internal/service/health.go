@@ -0,0 +1,245 @@
+package service
+
+import (
+	"computer-management-api/internal/repository"
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Health statuses of a component and of the service as a whole
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+)
+
+// Components checked for readiness
+const (
+	HealthComponentDatabase     = "database"
+	HealthComponentNotification = "notification_service"
+	HealthComponentOutbox       = "outbox"
+	HealthComponentServer       = "server"
+)
+
+// ComponentHealth is the result of checking one dependency. Only critical components that
+// are down make the service unready. Error describes the failure without the internal details
+// of the cause, since probes are not authenticated; the cause is logged instead.
+type ComponentHealth struct {
+	Status    string                 `json:"status"`
+	Critical  bool                   `json:"critical"`
+	LatencyMS int64                  `json:"latency_ms"`
+	Error     string                 `json:"error,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+
+	cause error
+}
+
+// HealthReport is the readiness of the service with a breakdown per component
+type HealthReport struct {
+	Status     string                     `json:"status"`
+	Timestamp  time.Time                  `json:"timestamp"`
+	Components map[string]ComponentHealth `json:"components"`
+}
+
+// Ready reports whether the service should receive traffic
+func (r *HealthReport) Ready() bool {
+	return r.Status != HealthStatusDown
+}
+
+// DatabasePool is the connection pool checked for readiness; *sql.DB implements it
+type DatabasePool interface {
+	PingContext(ctx context.Context) error
+	Stats() sql.DBStats
+}
+
+// NotifierHealthChecker reports whether the notification service is reachable
+type NotifierHealthChecker interface {
+	IsHealthy(ctx context.Context) bool
+}
+
+// HealthConfig controls the readiness checks
+type HealthConfig struct {
+	// Timeout bounds each dependency check
+	Timeout time.Duration
+}
+
+// DefaultHealthConfig returns the default readiness check configuration
+func DefaultHealthConfig() HealthConfig {
+	return HealthConfig{Timeout: 2 * time.Second}
+}
+
+// HealthService checks whether the service and its dependencies can serve traffic
+type HealthService struct {
+	db       DatabasePool
+	notifier NotifierHealthChecker
+	outbox   repository.OutboxRepository
+	config   HealthConfig
+	logger   *slog.Logger
+
+	shuttingDown atomic.Bool
+	lastStatus   atomic.Value // string
+}
+
+// NewHealthService creates a new health service. The notifier and outbox are optional; a nil
+// one is not checked.
+func NewHealthService(db DatabasePool, notifier NotifierHealthChecker, outbox repository.OutboxRepository, config HealthConfig, logger *slog.Logger) *HealthService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultHealthConfig().Timeout
+	}
+	return &HealthService{
+		db:       db,
+		notifier: notifier,
+		outbox:   outbox,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// BeginShutdown makes readiness fail from now on, so load balancers stop routing requests to
+// the server before it stops accepting them
+func (s *HealthService) BeginShutdown() {
+	if !s.shuttingDown.Swap(true) {
+		s.logger.Info("Readiness disabled for shutdown")
+	}
+}
+
+// Readiness checks the dependencies concurrently, each within the configured timeout. The
+// service is down when a critical dependency is down or it is shutting down, and degraded
+// when any other check failed.
+func (s *HealthService) Readiness(ctx context.Context) *HealthReport {
+	report := &HealthReport{
+		Status:     HealthStatusUp,
+		Timestamp:  time.Now().UTC(),
+		Components: make(map[string]ComponentHealth),
+	}
+
+	if s.shuttingDown.Load() {
+		report.Status = HealthStatusDown
+		report.Components[HealthComponentServer] = ComponentHealth{Status: HealthStatusDown, Critical: true, Error: "server is shutting down"}
+		return report
+	}
+
+	checks := map[string]func(context.Context) ComponentHealth{
+		HealthComponentDatabase: s.checkDatabase,
+	}
+	if s.notifier != nil {
+		checks[HealthComponentNotification] = s.checkNotifier
+	}
+	if s.outbox != nil {
+		checks[HealthComponentOutbox] = s.checkOutbox
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(context.Context) ComponentHealth) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			result := check(checkCtx)
+			result.LatencyMS = time.Since(start).Milliseconds()
+
+			mu.Lock()
+			report.Components[name] = result
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	for _, component := range report.Components {
+		switch {
+		case component.Status == HealthStatusDown && component.Critical:
+			report.Status = HealthStatusDown
+		case component.Status != HealthStatusUp && report.Status == HealthStatusUp:
+			report.Status = HealthStatusDegraded
+		}
+	}
+
+	s.logTransition(ctx, report)
+	return report
+}
+
+// logTransition logs when the readiness status changes rather than on every probe
+func (s *HealthService) logTransition(ctx context.Context, report *HealthReport) {
+	previous, _ := s.lastStatus.Swap(report.Status).(string)
+	if previous == report.Status || (previous == "" && report.Status == HealthStatusUp) {
+		return
+	}
+
+	attrs := []interface{}{"status", report.Status, "previous_status", previous}
+	for name, component := range report.Components {
+		if component.cause != nil {
+			attrs = append(attrs, name, component.cause)
+		} else if component.Error != "" {
+			attrs = append(attrs, name, component.Error)
+		}
+	}
+	if report.Status == HealthStatusUp {
+		s.logger.InfoContext(ctx, "Readiness changed", attrs...)
+	} else {
+		s.logger.WarnContext(ctx, "Readiness changed", attrs...)
+	}
+}
+
+// checkDatabase pings the database and reports the connection pool usage. A pool with every
+// connection in use is degraded: requests wait for a connection.
+func (s *HealthService) checkDatabase(ctx context.Context) ComponentHealth {
+	result := ComponentHealth{Status: HealthStatusUp, Critical: true}
+
+	stats := s.db.Stats()
+	result.Details = map[string]interface{}{
+		"open_connections": stats.OpenConnections,
+		"in_use":           stats.InUse,
+		"idle":             stats.Idle,
+		"max_open":         stats.MaxOpenConnections,
+		"wait_count":       stats.WaitCount,
+		"wait_duration_ms": stats.WaitDuration.Milliseconds(),
+	}
+	if stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections {
+		result.Status = HealthStatusDegraded
+		result.Error = "connection pool is saturated"
+	}
+
+	if err := s.db.PingContext(ctx); err != nil {
+		result.Status = HealthStatusDown
+		result.Error = "database is unreachable"
+		result.cause = err
+	}
+	return result
+}
+
+// checkNotifier checks that the notification service is reachable. Notifications wait in the
+// outbox while it is not, so it is not critical.
+func (s *HealthService) checkNotifier(ctx context.Context) ComponentHealth {
+	if !s.notifier.IsHealthy(ctx) {
+		return ComponentHealth{Status: HealthStatusDown, Error: "notification service is unreachable"}
+	}
+	return ComponentHealth{Status: HealthStatusUp}
+}
+
+// checkOutbox reports how many notifications wait for delivery and for how long
+func (s *HealthService) checkOutbox(ctx context.Context) ComponentHealth {
+	backlog, err := s.outbox.Backlog(ctx)
+	if err != nil {
+		return ComponentHealth{Status: HealthStatusDown, Error: "failed to read the outbox", cause: err}
+	}
+
+	result := ComponentHealth{
+		Status:  HealthStatusUp,
+		Details: map[string]interface{}{"pending": backlog.Pending},
+	}
+	if backlog.OldestPendingAt != nil {
+		result.Details["oldest_pending_age_seconds"] = int64(time.Since(*backlog.OldestPendingAt).Seconds())
+	}
+	return result
+}
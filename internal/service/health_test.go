@@ -0,0 +1,168 @@
+package service
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockDatabasePool reports fixed pool statistics and pings until told to fail
+type mockDatabasePool struct {
+	stats   sql.DBStats
+	pingErr error
+	delay   time.Duration
+}
+
+func (m *mockDatabasePool) PingContext(ctx context.Context) error {
+	select {
+	case <-time.After(m.delay):
+		return m.pingErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *mockDatabasePool) Stats() sql.DBStats {
+	return m.stats
+}
+
+type mockNotifierHealth bool
+
+func (m mockNotifierHealth) IsHealthy(ctx context.Context) bool {
+	return bool(m)
+}
+
+// backlogOutbox reports a fixed backlog
+type backlogOutbox struct {
+	mockOutboxRepository
+
+	backlog *model.OutboxBacklog
+	err     error
+}
+
+func (m *backlogOutbox) Backlog(ctx context.Context) (*model.OutboxBacklog, error) {
+	return m.backlog, m.err
+}
+
+func TestHealthService_Readiness(t *testing.T) {
+	oldest := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		db       *mockDatabasePool
+		notifier mockNotifierHealth
+		outbox   *backlogOutbox
+		status   string
+		check    func(t *testing.T, report *HealthReport)
+	}{
+		{
+			name:     "all up",
+			db:       &mockDatabasePool{stats: sql.DBStats{MaxOpenConnections: 10, OpenConnections: 2, InUse: 1, Idle: 1}},
+			notifier: true,
+			outbox:   &backlogOutbox{backlog: &model.OutboxBacklog{Pending: 4, OldestPendingAt: &oldest}},
+			status:   HealthStatusUp,
+			check: func(t *testing.T, report *HealthReport) {
+				details := report.Components[HealthComponentOutbox].Details
+				if details["pending"] != 4 || details["oldest_pending_age_seconds"].(int64) < 60 {
+					t.Errorf("Expected the outbox backlog, got %v", details)
+				}
+				if report.Components[HealthComponentDatabase].Details["in_use"] != 1 {
+					t.Errorf("Expected the pool statistics, got %v", report.Components[HealthComponentDatabase].Details)
+				}
+			},
+		},
+		{
+			name:     "database down",
+			db:       &mockDatabasePool{pingErr: errors.New("dial tcp 10.0.0.5:5432: connection refused")},
+			notifier: true,
+			outbox:   &backlogOutbox{backlog: &model.OutboxBacklog{}},
+			status:   HealthStatusDown,
+			check: func(t *testing.T, report *HealthReport) {
+				if err := report.Components[HealthComponentDatabase].Error; strings.Contains(err, "10.0.0.5") {
+					t.Errorf("Expected the cause to be hidden, got %q", err)
+				}
+			},
+		},
+		{
+			name:     "database ping times out",
+			db:       &mockDatabasePool{delay: time.Second},
+			notifier: true,
+			outbox:   &backlogOutbox{backlog: &model.OutboxBacklog{}},
+			status:   HealthStatusDown,
+		},
+		{
+			name:     "pool saturated",
+			db:       &mockDatabasePool{stats: sql.DBStats{MaxOpenConnections: 2, OpenConnections: 2, InUse: 2, WaitCount: 7}},
+			notifier: true,
+			outbox:   &backlogOutbox{backlog: &model.OutboxBacklog{}},
+			status:   HealthStatusDegraded,
+		},
+		{
+			name:     "notification service down",
+			db:       &mockDatabasePool{},
+			notifier: false,
+			outbox:   &backlogOutbox{backlog: &model.OutboxBacklog{}},
+			status:   HealthStatusDegraded,
+		},
+		{
+			name:     "outbox unreadable",
+			db:       &mockDatabasePool{},
+			notifier: true,
+			outbox:   &backlogOutbox{err: errors.New("permission denied")},
+			status:   HealthStatusDegraded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			svc := NewHealthService(tt.db, tt.notifier, tt.outbox, HealthConfig{Timeout: 50 * time.Millisecond}, slog.New(slog.NewTextHandler(&logs, nil)))
+
+			report := svc.Readiness(context.Background())
+			if report.Status != tt.status {
+				t.Fatalf("Expected status %s, got %s: %+v", tt.status, report.Status, report.Components)
+			}
+			if report.Ready() != (tt.status != HealthStatusDown) {
+				t.Errorf("Expected ready to be %v", tt.status != HealthStatusDown)
+			}
+			if len(report.Components) != 3 {
+				t.Errorf("Expected 3 components, got %v", report.Components)
+			}
+			if tt.status != HealthStatusUp && !strings.Contains(logs.String(), "Readiness changed") {
+				t.Errorf("Expected the status change to be logged, got %q", logs.String())
+			}
+			if tt.check != nil {
+				tt.check(t, report)
+			}
+		})
+	}
+}
+
+func TestHealthService_OptionalComponents(t *testing.T) {
+	svc := NewHealthService(&mockDatabasePool{}, nil, nil, DefaultHealthConfig(), nil)
+
+	report := svc.Readiness(context.Background())
+	if report.Status != HealthStatusUp || len(report.Components) != 1 {
+		t.Errorf("Expected only the database to be checked, got %+v", report)
+	}
+}
+
+func TestHealthService_BeginShutdown(t *testing.T) {
+	svc := NewHealthService(&mockDatabasePool{}, mockNotifierHealth(true), nil, DefaultHealthConfig(), slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	if !svc.Readiness(context.Background()).Ready() {
+		t.Fatal("Expected the service to be ready before shutdown")
+	}
+
+	svc.BeginShutdown()
+
+	report := svc.Readiness(context.Background())
+	if report.Ready() || report.Components[HealthComponentServer].Status != HealthStatusDown {
+		t.Errorf("Expected readiness to fail during shutdown, got %+v", report)
+	}
+}
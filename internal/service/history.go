@@ -0,0 +1,85 @@
+package service
+
+import (
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/validation"
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+// HistoryService answers audit questions from the computer event trail
+type HistoryService struct {
+	events    repository.EventRepository
+	computers repository.ComputerRepository
+	employees repository.EmployeeRepository
+	logger    *slog.Logger
+}
+
+// NewHistoryService creates a new history service
+func NewHistoryService(events repository.EventRepository, computers repository.ComputerRepository, employees repository.EmployeeRepository, logger *slog.Logger) *HistoryService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &HistoryService{
+		events:    events,
+		computers: computers,
+		employees: employees,
+		logger:    logger,
+	}
+}
+
+// GetComputerHistory retrieves the audit trail of a computer, newest first.
+// Deleted computers keep their history; only computers that never existed are reported as not found.
+func (s *HistoryService) GetComputerHistory(ctx context.Context, computerID uuid.UUID, params repository.PaginationParams) (*repository.EventPaginatedResult, error) {
+	result, err := s.events.GetEventsByComputer(ctx, computerID, params)
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to retrieve computer history")
+	}
+
+	if result.TotalCount == 0 {
+		if _, err := s.computers.GetComputerByID(ctx, computerID); err != nil {
+			return nil, mapRepositoryError(err, "failed to retrieve computer")
+		}
+	}
+
+	return result, nil
+}
+
+// GetEmployeeHistory retrieves every assignment change involving an employee, newest first.
+// Deleted employees keep their history; only employees that never existed are reported as not found.
+func (s *HistoryService) GetEmployeeHistory(ctx context.Context, employeeAbbrev string, params repository.PaginationParams) (*repository.EventPaginatedResult, error) {
+	if err := validation.ValidateEmployeeAbbreviation(employeeAbbrev); err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+
+	result, err := s.events.GetEventsByEmployee(ctx, employeeAbbrev, params)
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to retrieve employee history")
+	}
+
+	if result.TotalCount == 0 {
+		if _, err := s.employees.GetEmployeeByAbbreviation(ctx, employeeAbbrev); err != nil {
+			return nil, mapRepositoryError(err, "failed to retrieve employee")
+		}
+	}
+
+	return result, nil
+}
+
+// SearchHistory retrieves the events of all computers matching a filter, newest first, including
+// those of computers that have since been deleted.
+func (s *HistoryService) SearchHistory(ctx context.Context, filter repository.EventFilter, params repository.PaginationParams) (*repository.EventPaginatedResult, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return nil, errors.ValidationError("from must not be after to")
+	}
+
+	result, err := s.events.SearchEvents(ctx, filter, params)
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to search history")
+	}
+
+	return result, nil
+}
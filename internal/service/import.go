@@ -0,0 +1,331 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/validation"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// MaxImportRows limits the number of computers in a single import
+const MaxImportRows = 5000
+
+// ImportMode selects how an import treats computers whose MAC address is already registered
+type ImportMode string
+
+const (
+	// ImportModeCreate rejects rows whose MAC address is already registered
+	ImportModeCreate ImportMode = "create"
+	// ImportModeUpsert updates the computer registered with the row's MAC address
+	ImportModeUpsert ImportMode = "upsert"
+)
+
+// ImportAction is what an import does with a row
+type ImportAction string
+
+const (
+	ImportActionCreate    ImportAction = "create"
+	ImportActionUpdate    ImportAction = "update"
+	ImportActionUnchanged ImportAction = "unchanged"
+)
+
+// ImportRow is a computer read from an import file
+type ImportRow struct {
+	Line     int // Line of the row in the file, used in the report
+	Computer model.Computer
+	Error    string // Set when the row could not be parsed
+}
+
+// ImportOptions controls how computers are imported
+type ImportOptions struct {
+	Mode   ImportMode
+	DryRun bool // Validate and report without committing any change
+}
+
+// ImportRowResult reports the outcome of a single row
+type ImportRowResult struct {
+	Line       int          `json:"line"`
+	MACAddress string       `json:"mac_address,omitempty"`
+	Action     ImportAction `json:"action,omitempty"`
+	ComputerID *uuid.UUID   `json:"computer_id,omitempty"`
+	Errors     []string     `json:"errors,omitempty"`
+}
+
+// ImportReport summarizes an import. Rows are only applied if none of them failed; until then
+// the counts describe what the valid rows would do.
+type ImportReport struct {
+	Mode      ImportMode        `json:"mode"`
+	DryRun    bool              `json:"dry_run"`
+	Applied   bool              `json:"applied"`
+	Total     int               `json:"total"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Failed    int               `json:"failed"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+// errDryRun rolls back the transaction of a dry run after every change was applied
+var errDryRun = stderrors.New("dry run")
+
+// importPlan is a validated row and the computer it replaces, if any
+type importPlan struct {
+	result   *ImportRowResult
+	computer model.Computer
+	existing *model.Computer
+}
+
+// ImportComputers validates every row and applies them in a single transaction. Rows are
+// validated like single creations, MAC addresses must be unique within the file, and existing
+// MAC addresses are rejected unless the mode is ImportModeUpsert. If any row is invalid nothing
+// is written and the report lists the problems of each row. A dry run applies the rows in a
+// transaction that is rolled back, so quota policies and IP address conflicts are checked too.
+func (s *ComputerService) ImportComputers(ctx context.Context, rows []ImportRow, opts ImportOptions) (*ImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = ImportModeCreate
+	}
+	if opts.Mode != ImportModeCreate && opts.Mode != ImportModeUpsert {
+		return nil, errors.ValidationError("import mode must be create or upsert")
+	}
+	if len(rows) == 0 {
+		return nil, errors.ValidationError("import contains no computers")
+	}
+	if len(rows) > MaxImportRows {
+		return nil, errors.ValidationError(fmt.Sprintf("import cannot contain more than %d computers", MaxImportRows))
+	}
+
+	report := &ImportReport{
+		Mode:   opts.Mode,
+		DryRun: opts.DryRun,
+		Total:  len(rows),
+		Rows:   make([]ImportRowResult, len(rows)),
+	}
+
+	plans, err := s.planImport(ctx, rows, opts.Mode, report)
+	if err != nil {
+		return nil, err
+	}
+	if report.Failed > 0 {
+		s.logger.WarnContext(ctx, "Import rejected", "failed", report.Failed, "total", report.Total)
+		return report, nil
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		employees := make(map[string]bool)
+		for _, plan := range plans {
+			if err := applyImportPlan(ctx, repos, plan); err != nil {
+				if appErr, ok := errors.AsAppError(err); ok {
+					return appErr.WithDetail("line", plan.result.Line)
+				}
+				return fmt.Errorf("line %d: %w", plan.result.Line, err)
+			}
+			if plan.result.Action == ImportActionUnchanged || plan.computer.EmployeeAbbreviation == "" {
+				continue
+			}
+			if plan.existing == nil || plan.existing.EmployeeAbbreviation != plan.computer.EmployeeAbbreviation {
+				employees[plan.computer.EmployeeAbbreviation] = true
+			}
+		}
+
+		// Quotas are evaluated once every row is in place, in a stable order
+		abbreviations := make([]string, 0, len(employees))
+		for abbrev := range employees {
+			abbreviations = append(abbreviations, abbrev)
+		}
+		sort.Strings(abbreviations)
+		for _, abbrev := range abbreviations {
+			if err := applyQuotaPolicy(ctx, repos, abbrev); err != nil {
+				return err
+			}
+		}
+
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !stderrors.Is(err, errDryRun) {
+		return nil, mapRepositoryError(err, "failed to import computers")
+	}
+
+	report.Applied = !opts.DryRun
+	s.logger.InfoContext(ctx, "Import completed", "created", report.Created, "updated", report.Updated,
+		"unchanged", report.Unchanged, "dry_run", opts.DryRun)
+
+	return report, nil
+}
+
+// planImport validates the rows and decides what to do with each of them, recording the outcome
+// in the report. Only database failures are returned as errors.
+func (s *ComputerService) planImport(ctx context.Context, rows []ImportRow, mode ImportMode, report *ImportReport) ([]importPlan, error) {
+	plans := make([]importPlan, 0, len(rows))
+	firstLine := make(map[string]int, len(rows))
+	macAddresses := make([]string, 0, len(rows))
+
+	for i, row := range rows {
+		result := &report.Rows[i]
+		result.Line = row.Line
+		result.MACAddress = row.Computer.MACAddress
+
+		if row.Error != "" {
+			result.Errors = []string{row.Error}
+			continue
+		}
+
+		computer := row.Computer
+		validationErrors := append(validation.ValidateComputerInput(&computer), s.hostnameErrors(&computer)...)
+		validationErrors = append(validationErrors, macAddressErrors(s.RejectSuspiciousMACs, computer.MACAddress)...)
+		if len(validationErrors) > 0 {
+			result.Errors = validationErrors
+			continue
+		}
+		result.MACAddress = computer.MACAddress
+
+		if line, seen := firstLine[computer.MACAddress]; seen {
+			result.Errors = []string{fmt.Sprintf("duplicate MAC address %s, first used on line %d", computer.MACAddress, line)}
+			continue
+		}
+		firstLine[computer.MACAddress] = row.Line
+		macAddresses = append(macAddresses, computer.MACAddress)
+
+		plans = append(plans, importPlan{result: result, computer: computer})
+	}
+
+	var existing []model.Computer
+	if len(macAddresses) > 0 {
+		var err error
+		if existing, err = s.repo.GetComputersByMACs(ctx, macAddresses); err != nil {
+			return nil, mapRepositoryError(err, "failed to check existing computers")
+		}
+	}
+	byMAC := make(map[string]*model.Computer, len(existing))
+	for i := range existing {
+		byMAC[existing[i].MACAddress] = &existing[i]
+	}
+
+	employeeErrors := make(map[string]error)
+	valid := plans[:0]
+	for _, plan := range plans {
+		plan.existing = byMAC[plan.computer.MACAddress]
+		if err := s.planImportRow(ctx, &plan, mode, employeeErrors); err != nil {
+			appErr, ok := errors.AsAppError(err)
+			if !ok || (appErr.Code != errors.ErrorCodeNotFound && appErr.Code != errors.ErrorCodeConflict && appErr.Code != errors.ErrorCodeAlreadyExists) {
+				return nil, err
+			}
+			plan.result.Action, plan.result.ComputerID = "", nil
+			plan.result.Errors = append(plan.result.Errors, appErr.Message)
+			continue
+		}
+		valid = append(valid, plan)
+	}
+
+	for _, result := range report.Rows {
+		switch {
+		case len(result.Errors) > 0:
+			report.Failed++
+		case result.Action == ImportActionCreate:
+			report.Created++
+		case result.Action == ImportActionUpdate:
+			report.Updated++
+		case result.Action == ImportActionUnchanged:
+			report.Unchanged++
+		}
+	}
+
+	return valid, nil
+}
+
+// planImportRow decides whether a validated row creates or updates a computer and checks the
+// employee it is assigned to. Employee lookups are cached across rows.
+func (s *ComputerService) planImportRow(ctx context.Context, plan *importPlan, mode ImportMode, employeeErrors map[string]error) error {
+	if plan.existing == nil {
+		if plan.computer.ID == uuid.Nil {
+			plan.computer.ID = uuid.New()
+		}
+		plan.result.Action = ImportActionCreate
+	} else {
+		if mode != ImportModeUpsert {
+			return errors.AlreadyExistsError("Computer with this MAC address")
+		}
+		plan.computer.ID = plan.existing.ID
+		plan.computer.CreatedAt = plan.existing.CreatedAt
+		plan.result.Action = ImportActionUpdate
+		if sameComputerFields(*plan.existing, plan.computer) {
+			plan.result.Action = ImportActionUnchanged
+		}
+	}
+	id := plan.computer.ID
+	plan.result.ComputerID = &id
+
+	// Like single updates, only a change of assignment requires the employee to be active
+	abbrev := plan.computer.EmployeeAbbreviation
+	if abbrev == "" || (plan.existing != nil && plan.existing.EmployeeAbbreviation == abbrev) {
+		return nil
+	}
+	err, checked := employeeErrors[abbrev]
+	if !checked {
+		err = s.validateAssignableEmployee(ctx, abbrev)
+		employeeErrors[abbrev] = err
+	}
+	return err
+}
+
+// applyImportPlan writes a planned row along with its audit event and notification
+func applyImportPlan(ctx context.Context, repos repository.Repositories, plan importPlan) error {
+	computer := plan.computer
+	switch plan.result.Action {
+	case ImportActionCreate:
+		if err := reserveIPAddress(ctx, repos.Subnets, computer.IPAddress, computer.ID); err != nil {
+			return err
+		}
+		if err := repos.Computers.CreateComputer(ctx, computer); err != nil {
+			return err
+		}
+		if err := repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventCreated, nil, &computer)); err != nil {
+			return err
+		}
+		if computer.EmployeeAbbreviation == "" {
+			return nil
+		}
+		return enqueueNotification(ctx, repos, newCreationNotification(computer))
+
+	case ImportActionUpdate:
+		if computer.IPAddress != plan.existing.IPAddress {
+			if err := reserveIPAddress(ctx, repos.Subnets, computer.IPAddress, computer.ID); err != nil {
+				return err
+			}
+		}
+		if err := repos.Computers.UpdateComputer(ctx, computer.ID, computer); err != nil {
+			return err
+		}
+		updated, err := repos.Computers.GetComputerByID(ctx, computer.ID)
+		if err != nil {
+			return err
+		}
+		if err := repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventUpdated, plan.existing, updated)); err != nil {
+			return err
+		}
+		if plan.existing.EmployeeAbbreviation == updated.EmployeeAbbreviation {
+			return nil
+		}
+		return enqueueNotification(ctx, repos, newUpdateNotification(*plan.existing, *updated))
+
+	default:
+		return nil
+	}
+}
+
+// sameComputerFields reports whether two computers have the same user-editable fields
+func sameComputerFields(a, b model.Computer) bool {
+	return a.MACAddress == b.MACAddress &&
+		a.ComputerName == b.ComputerName &&
+		a.IPAddress == b.IPAddress &&
+		a.EmployeeAbbreviation == b.EmployeeAbbreviation &&
+		a.Description == b.Description
+}
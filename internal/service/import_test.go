@@ -0,0 +1,168 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	apperrors "computer-management-api/pkg/errors"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestImportComputers_InvalidRowsRejectImport(t *testing.T) {
+	svc, repo, events, _ := createTestServiceWithEvents()
+
+	existing := createTestComputer()
+	existing.MACAddress = "AA:BB:CC:DD:EE:FF"
+	repo.GetComputersByMACsFunc = func(ctx context.Context, macAddresses []string) ([]model.Computer, error) {
+		return []model.Computer{existing}, nil
+	}
+	repo.CreateComputerFunc = func(ctx context.Context, computer model.Computer) error {
+		t.Error("Expected no computer to be created")
+		return nil
+	}
+
+	rows := []ImportRow{
+		{Line: 2, Computer: model.Computer{MACAddress: "00-1b-44-11-3a-b7", ComputerName: "PC-1", IPAddress: "10.0.0.1"}},
+		{Line: 3, Computer: model.Computer{MACAddress: "not-a-mac", ComputerName: "PC-2", IPAddress: "10.0.0.2"}},
+		{Line: 4, Computer: model.Computer{MACAddress: "00:1B:44:11:3A:B7", ComputerName: "PC-3", IPAddress: "10.0.0.3"}},
+		{Line: 5, Computer: model.Computer{MACAddress: "aa:bb:cc:dd:ee:ff", ComputerName: "PC-4", IPAddress: "10.0.0.4"}},
+		{Line: 6, Error: "expected 3 fields, got 2"},
+	}
+
+	report, err := svc.ImportComputers(context.Background(), rows, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Applied || report.Failed != 4 || report.Created != 1 {
+		t.Errorf("Expected 4 failed rows and nothing applied, got %+v", report)
+	}
+	for _, line := range []int{1, 2, 3, 4} {
+		if len(report.Rows[line].Errors) == 0 {
+			t.Errorf("Expected an error for line %d", report.Rows[line].Line)
+		}
+	}
+	if report.Rows[0].MACAddress != "00:1B:44:11:3A:B7" || report.Rows[0].Action != ImportActionCreate {
+		t.Errorf("Expected the first row to create a normalized MAC, got %+v", report.Rows[0])
+	}
+	if len(events.events) != 0 {
+		t.Errorf("Expected no audit events, got %d", len(events.events))
+	}
+}
+
+func TestImportComputers_Upsert(t *testing.T) {
+	svc, repo, events, outbox := createTestServiceWithEvents()
+
+	changed := createTestComputer()
+	changed.MACAddress = "00:1B:44:11:3A:B7"
+	unchanged := createTestComputer()
+	unchanged.MACAddress = "AA:BB:CC:DD:EE:FF"
+	unchanged.EmployeeAbbreviation = ""
+
+	repo.GetComputersByMACsFunc = func(ctx context.Context, macAddresses []string) ([]model.Computer, error) {
+		return []model.Computer{changed, unchanged}, nil
+	}
+	var updatedIDs, createdIDs []uuid.UUID
+	repo.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, computer model.Computer) error {
+		updatedIDs = append(updatedIDs, id)
+		return nil
+	}
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		updated := changed
+		updated.EmployeeAbbreviation = "DEF"
+		return &updated, nil
+	}
+	repo.CreateComputerFunc = func(ctx context.Context, computer model.Computer) error {
+		createdIDs = append(createdIDs, computer.ID)
+		return nil
+	}
+
+	rows := []ImportRow{
+		{Line: 1, Computer: model.Computer{MACAddress: changed.MACAddress, ComputerName: changed.ComputerName, IPAddress: changed.IPAddress, EmployeeAbbreviation: "DEF"}},
+		{Line: 2, Computer: model.Computer{MACAddress: unchanged.MACAddress, ComputerName: unchanged.ComputerName, IPAddress: unchanged.IPAddress}},
+		{Line: 3, Computer: model.Computer{MACAddress: "11:22:33:44:55:66", ComputerName: "PC-NEW", IPAddress: "10.0.0.9"}},
+	}
+
+	report, err := svc.ImportComputers(context.Background(), rows, ImportOptions{Mode: ImportModeUpsert})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !report.Applied || report.Created != 1 || report.Updated != 1 || report.Unchanged != 1 {
+		t.Errorf("Expected one creation, update and unchanged row, got %+v", report)
+	}
+	if len(updatedIDs) != 1 || updatedIDs[0] != changed.ID {
+		t.Errorf("Expected only %s to be updated, got %v", changed.ID, updatedIDs)
+	}
+	if len(createdIDs) != 1 || *report.Rows[2].ComputerID != createdIDs[0] {
+		t.Errorf("Expected the created ID to be reported, got %v", createdIDs)
+	}
+	if len(events.events) != 2 {
+		t.Errorf("Expected 2 audit events, got %d", len(events.events))
+	}
+	if len(outbox.messages) != 1 || outbox.messages[0].NotificationType != string(NotificationTypeComputerUpdated) {
+		t.Errorf("Expected a reassignment notification, got %v", outbox.messages)
+	}
+}
+
+func TestImportComputers_DryRun(t *testing.T) {
+	svc, _, events, _ := createTestServiceWithEvents()
+
+	rows := []ImportRow{{Line: 1, Computer: model.Computer{MACAddress: "11:22:33:44:55:66", ComputerName: "PC-NEW", IPAddress: "10.0.0.9"}}}
+
+	report, err := svc.ImportComputers(context.Background(), rows, ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Applied || !report.DryRun || report.Created != 1 {
+		t.Errorf("Expected a validated but unapplied report, got %+v", report)
+	}
+	// The mock transactor cannot roll back, but the rows must have been run through it
+	if len(events.events) != 1 {
+		t.Errorf("Expected the dry run to exercise the transaction, got %d events", len(events.events))
+	}
+}
+
+func TestImportComputers_InvalidRequest(t *testing.T) {
+	svc, _, _ := createTestService()
+
+	tests := []struct {
+		name string
+		rows []ImportRow
+		opts ImportOptions
+	}{
+		{"no rows", nil, ImportOptions{}},
+		{"unknown mode", []ImportRow{{Line: 1}}, ImportOptions{Mode: "replace"}},
+		{"too many rows", make([]ImportRow, MaxImportRows+1), ImportOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ImportComputers(context.Background(), tt.rows, tt.opts)
+			appErr, ok := apperrors.AsAppError(err)
+			if !ok || appErr.Code != apperrors.ErrorCodeValidation {
+				t.Errorf("Expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestImportComputers_IPAddressConflict(t *testing.T) {
+	svc, _, _, _ := createTestServiceWithEvents()
+	svc.tx.(*mockTransactor).repos.Subnets = &mockSubnetRepository{
+		assigned: []model.IPAddressAssignment{{Address: "10.0.0.2", ComputerID: uuid.New()}},
+	}
+
+	rows := []ImportRow{
+		{Line: 2, Computer: model.Computer{MACAddress: "00:1B:44:11:3A:B7", ComputerName: "PC-1", IPAddress: "10.0.0.1"}},
+		{Line: 3, Computer: model.Computer{MACAddress: "AA:BB:CC:DD:EE:FF", ComputerName: "PC-2", IPAddress: "10.0.0.2"}},
+	}
+
+	_, err := svc.ImportComputers(context.Background(), rows, ImportOptions{})
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok || appErr.Code != apperrors.ErrorCodeConflict {
+		t.Fatalf("Expected conflict error, got %v", err)
+	}
+	if appErr.Details["line"] != 3 {
+		t.Errorf("Expected the conflict to name line 3, got %v", appErr.Details["line"])
+	}
+}
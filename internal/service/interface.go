@@ -0,0 +1,117 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/netconfig"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ComputerServiceInterface defines the business operations available to the HTTP layer.
+// Every mutation of computer data goes through this interface so that validation,
+// normalization and notification rules live in a single place.
+type ComputerServiceInterface interface {
+	// Computer CRUD operations
+	CreateComputer(ctx context.Context, computer model.Computer) (*model.Computer, error)
+	GetAllComputers(ctx context.Context, filter repository.ComputerFilter, params repository.PaginationParams) (*repository.PaginatedResult, error)
+	GetComputerByID(ctx context.Context, id uuid.UUID) (*model.Computer, error)
+	UpdateComputer(ctx context.Context, id uuid.UUID, updates model.Computer) (*model.Computer, error)
+	PatchComputer(ctx context.Context, id uuid.UUID, mediaType string, document []byte) (*model.Computer, error)
+	DeleteComputer(ctx context.Context, id uuid.UUID) error
+	ImportComputers(ctx context.Context, rows []ImportRow, opts ImportOptions) (*ImportReport, error)
+	ExportComputers(ctx context.Context, filter repository.ComputerFilter, fn func(model.Computer) error) error
+
+	// Employee-specific operations
+	GetComputersByEmployee(ctx context.Context, employeeAbbrev string, params repository.PaginationParams) (*repository.PaginatedResult, error)
+	AssignComputerToEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbrev string) (*model.Computer, error)
+	RemoveComputerFromEmployee(ctx context.Context, computerID uuid.UUID, employeeAbbrev string) (*model.Computer, error)
+}
+
+// EmployeeServiceInterface defines the employee management operations available to the HTTP layer.
+type EmployeeServiceInterface interface {
+	CreateEmployee(ctx context.Context, employee model.Employee) (*model.Employee, error)
+	GetAllEmployees(ctx context.Context, params repository.PaginationParams) (*repository.EmployeePaginatedResult, error)
+	GetEmployee(ctx context.Context, abbreviation string) (*model.Employee, error)
+	UpdateEmployee(ctx context.Context, abbreviation string, updates model.Employee) (*model.Employee, error)
+	DeleteEmployee(ctx context.Context, abbreviation string) error
+}
+
+// NetworkInterfaceServiceInterface defines the network interface operations available to the HTTP layer.
+type NetworkInterfaceServiceInterface interface {
+	GetInterfaces(ctx context.Context, computerID uuid.UUID) ([]model.NetworkInterface, error)
+	GetInterface(ctx context.Context, computerID, id uuid.UUID) (*model.NetworkInterface, error)
+	GetInterfaceByMAC(ctx context.Context, macAddress string) (*NetworkInterfaceLookup, error)
+	CreateInterface(ctx context.Context, computerID uuid.UUID, iface model.NetworkInterface) (*model.NetworkInterface, error)
+	UpdateInterface(ctx context.Context, computerID, id uuid.UUID, updates model.NetworkInterface) (*model.NetworkInterface, error)
+	DeleteInterface(ctx context.Context, computerID, id uuid.UUID) error
+}
+
+// NetworkConfigServiceInterface defines the inventory the DHCP and DNS configuration handlers render.
+type NetworkConfigServiceInterface interface {
+	GetHosts(ctx context.Context) ([]netconfig.Host, error)
+	GetLastDeletion(ctx context.Context) (time.Time, error)
+}
+
+// SubnetServiceInterface defines the subnet and address allocation operations available to the HTTP layer.
+type SubnetServiceInterface interface {
+	CreateSubnet(ctx context.Context, subnet model.Subnet) (*model.Subnet, error)
+	GetSubnets(ctx context.Context) ([]model.Subnet, error)
+	GetSubnet(ctx context.Context, id uuid.UUID) (*model.Subnet, error)
+	UpdateSubnet(ctx context.Context, id uuid.UUID, updates model.Subnet) (*model.Subnet, error)
+	DeleteSubnet(ctx context.Context, id uuid.UUID) error
+	GetUtilization(ctx context.Context, id uuid.UUID) (*model.SubnetUtilization, error)
+	GetAllUtilization(ctx context.Context) ([]model.SubnetUtilization, error)
+	AllocateAddress(ctx context.Context, subnetID, computerID uuid.UUID) (*model.Computer, error)
+}
+
+// WakeServiceInterface defines the Wake-on-LAN operations available to the HTTP layer.
+type WakeServiceInterface interface {
+	Wake(ctx context.Context, id uuid.UUID) (*model.Wake, error)
+}
+
+// HistoryServiceInterface defines the audit trail queries available to the HTTP layer.
+type HistoryServiceInterface interface {
+	GetComputerHistory(ctx context.Context, computerID uuid.UUID, params repository.PaginationParams) (*repository.EventPaginatedResult, error)
+	GetEmployeeHistory(ctx context.Context, employeeAbbrev string, params repository.PaginationParams) (*repository.EventPaginatedResult, error)
+	SearchHistory(ctx context.Context, filter repository.EventFilter, params repository.PaginationParams) (*repository.EventPaginatedResult, error)
+}
+
+// PolicyServiceInterface defines the quota policy management operations available to the HTTP layer.
+type PolicyServiceInterface interface {
+	CreatePolicy(ctx context.Context, policy model.QuotaPolicy) (*model.QuotaPolicy, error)
+	GetAllPolicies(ctx context.Context, params repository.PaginationParams) (*repository.PolicyPaginatedResult, error)
+	GetPolicy(ctx context.Context, id int64) (*model.QuotaPolicy, error)
+	UpdatePolicy(ctx context.Context, id int64, updates model.QuotaPolicy) (*model.QuotaPolicy, error)
+	DeletePolicy(ctx context.Context, id int64) error
+	GetEffectivePolicy(ctx context.Context, employeeAbbrev string) (*model.QuotaPolicy, error)
+}
+
+// APIKeyServiceInterface defines the API key management operations available to the HTTP layer.
+type APIKeyServiceInterface interface {
+	IssueAPIKey(ctx context.Context, key model.APIKey) (*IssuedAPIKey, error)
+	GetAllAPIKeys(ctx context.Context) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	Authenticate(ctx context.Context, secret string) (*model.APIKey, error)
+}
+
+// HealthServiceInterface defines the readiness checks available to the HTTP layer.
+type HealthServiceInterface interface {
+	Readiness(ctx context.Context) *HealthReport
+}
+
+// Ensure services implement their interfaces at compile time
+var (
+	_ ComputerServiceInterface         = (*ComputerService)(nil)
+	_ EmployeeServiceInterface         = (*EmployeeService)(nil)
+	_ NetworkInterfaceServiceInterface = (*NetworkInterfaceService)(nil)
+	_ SubnetServiceInterface           = (*SubnetService)(nil)
+	_ NetworkConfigServiceInterface    = (*NetworkConfigService)(nil)
+	_ WakeServiceInterface             = (*WakeService)(nil)
+	_ HistoryServiceInterface          = (*HistoryService)(nil)
+	_ PolicyServiceInterface           = (*PolicyService)(nil)
+	_ APIKeyServiceInterface           = (*APIKeyService)(nil)
+	_ HealthServiceInterface           = (*HealthService)(nil)
+)
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"context"
+	"log/slog"
+)
+
+// InventoryService summarizes the computer inventory for monitoring
+type InventoryService struct {
+	repo   repository.InventoryRepository
+	logger *slog.Logger
+}
+
+// NewInventoryService creates a new inventory service
+func NewInventoryService(repo repository.InventoryRepository, logger *slog.Logger) *InventoryService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &InventoryService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetInventoryStats counts the computers, the assigned ones and the employees over their quota.
+// Employees without a quota policy are measured against DefaultMaxComputersPerEmployee.
+func (s *InventoryService) GetInventoryStats(ctx context.Context) (*model.InventoryStats, error) {
+	stats, err := s.repo.GetInventoryStats(ctx, DefaultMaxComputersPerEmployee)
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to get inventory stats")
+	}
+	return stats, nil
+}
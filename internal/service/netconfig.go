@@ -0,0 +1,109 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/netconfig"
+	"computer-management-api/pkg/validation"
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NetworkConfigService provides the computers and their network interfaces to the DHCP and DNS
+// configuration generators
+type NetworkConfigService struct {
+	computers  repository.ComputerRepository
+	interfaces repository.NetworkInterfaceRepository
+	events     repository.EventRepository
+	logger     *slog.Logger
+}
+
+// NewNetworkConfigService creates a new network configuration service
+func NewNetworkConfigService(computers repository.ComputerRepository, interfaces repository.NetworkInterfaceRepository, events repository.EventRepository, logger *slog.Logger) *NetworkConfigService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &NetworkConfigService{
+		computers:  computers,
+		interfaces: interfaces,
+		events:     events,
+		logger:     logger,
+	}
+}
+
+// GetHosts returns every computer with its network interfaces. Computers whose names are not DNS
+// hostnames, which may have been stored before hostnames were required, are left out and logged.
+func (s *NetworkConfigService) GetHosts(ctx context.Context) ([]netconfig.Host, error) {
+	interfaces, err := s.interfaces.GetAllInterfaces(ctx)
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to retrieve network interfaces")
+	}
+	byComputer := make(map[uuid.UUID][]model.NetworkInterface)
+	for _, iface := range interfaces {
+		byComputer[iface.ComputerID] = append(byComputer[iface.ComputerID], iface)
+	}
+
+	var hosts []netconfig.Host
+	var skipped []string
+	err = s.computers.StreamComputers(ctx, repository.ComputerFilter{}, func(c model.Computer) error {
+		if err := validation.ValidateHostname(c.ComputerName); err != nil {
+			skipped = append(skipped, c.ComputerName)
+			return nil
+		}
+		hosts = append(hosts, newHost(c, byComputer[c.ID]))
+		return nil
+	})
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to retrieve computers")
+	}
+
+	if len(skipped) > 0 {
+		s.logger.WarnContext(ctx, "Computers without a DNS hostname left out of the network configuration",
+			"count", len(skipped), "computer_names", skipped)
+	}
+	s.logger.DebugContext(ctx, "Retrieved network configuration hosts", "count", len(hosts))
+
+	return hosts, nil
+}
+
+// GetLastDeletion returns when a computer was last deleted, from the audit trail, or the zero
+// time if none ever was
+func (s *NetworkConfigService) GetLastDeletion(ctx context.Context) (time.Time, error) {
+	event, err := s.events.GetLatestEventByType(ctx, model.ComputerEventDeleted)
+	if stderrors.Is(err, repository.ErrEventNotFound) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, mapRepositoryError(err, "failed to retrieve the last deletion")
+	}
+	return event.OccurredAt, nil
+}
+
+// newHost converts a computer and its interfaces. Addresses are canonical, so they always parse.
+func newHost(c model.Computer, interfaces []model.NetworkInterface) netconfig.Host {
+	host := netconfig.Host{
+		Name:       c.ComputerName,
+		Interfaces: make([]netconfig.Interface, 0, len(interfaces)),
+		UpdatedAt:  c.UpdatedAt,
+	}
+	for _, iface := range interfaces {
+		converted := netconfig.Interface{MACAddress: iface.MACAddress, Primary: iface.Primary}
+		if iface.IPv4Address != "" {
+			converted.IPv4Address, _ = netip.ParseAddr(iface.IPv4Address)
+		}
+		if iface.IPv6Address != "" {
+			converted.IPv6Address, _ = netip.ParseAddr(iface.IPv6Address)
+		}
+		host.Interfaces = append(host.Interfaces, converted)
+
+		if iface.UpdatedAt.After(host.UpdatedAt) {
+			host.UpdatedAt = iface.UpdatedAt
+		}
+	}
+	return host
+}
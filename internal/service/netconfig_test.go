@@ -0,0 +1,86 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestGetHosts(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	laptop := model.Computer{ID: uuid.New(), ComputerName: "LAPTOP-01", MACAddress: "00:1B:44:11:3A:B7", IPAddress: "2001:db8::1"}
+	legacy := model.Computer{ID: uuid.New(), ComputerName: "Reception PC", MACAddress: "00:1B:44:11:3A:B8", IPAddress: "192.168.1.101"}
+	for _, c := range []model.Computer{laptop, legacy} {
+		if err := store.Computers().CreateComputer(ctx, c); err != nil {
+			t.Fatalf("Failed to create computer: %v", err)
+		}
+	}
+	dock := model.NetworkInterface{ID: uuid.New(), ComputerID: laptop.ID, MACAddress: "00:1B:44:11:3A:C0", Type: model.NetworkInterfaceTypeDock, IPv4Address: "192.168.1.150"}
+	if err := store.Interfaces().CreateInterface(ctx, dock); err != nil {
+		t.Fatalf("Failed to create interface: %v", err)
+	}
+	stored, err := store.Computers().GetComputerByID(ctx, laptop.ID)
+	if err != nil {
+		t.Fatalf("Failed to get computer: %v", err)
+	}
+	storedDock, err := store.Interfaces().GetInterfaceByID(ctx, laptop.ID, dock.ID)
+	if err != nil {
+		t.Fatalf("Failed to get interface: %v", err)
+	}
+
+	hosts, err := NewNetworkConfigService(store.Computers(), store.Interfaces(), &mockEventRepository{}, nil).GetHosts(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(hosts) != 1 {
+		t.Fatalf("Expected only the computer named as a hostname, got %+v", hosts)
+	}
+	host := hosts[0]
+	if host.Name != "LAPTOP-01" || len(host.Interfaces) != 2 {
+		t.Fatalf("Unexpected host %+v", host)
+	}
+	primary, secondary := host.Interfaces[0], host.Interfaces[1]
+	if !primary.Primary || primary.IPv6Address.String() != "2001:db8::1" || primary.IPv4Address.IsValid() {
+		t.Errorf("Unexpected primary interface %+v", primary)
+	}
+	if secondary.Primary || secondary.MACAddress != "00:1B:44:11:3A:C0" || secondary.IPv4Address.String() != "192.168.1.150" {
+		t.Errorf("Unexpected secondary interface %+v", secondary)
+	}
+
+	latest := stored.UpdatedAt
+	if storedDock.UpdatedAt.After(latest) {
+		latest = storedDock.UpdatedAt
+	}
+	if !host.UpdatedAt.Equal(latest) {
+		t.Errorf("Expected the latest change %v, got %v", latest, host.UpdatedAt)
+	}
+}
+
+func TestGetLastDeletion(t *testing.T) {
+	ctx := context.Background()
+	events := &mockEventRepository{}
+	svc := NewNetworkConfigService(nil, nil, events, nil)
+
+	deletedAt, err := svc.GetLastDeletion(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !deletedAt.IsZero() {
+		t.Errorf("Expected no deletion, got %v", deletedAt)
+	}
+
+	deleted := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	events.events = []model.ComputerEvent{
+		{ComputerID: uuid.New(), EventType: model.ComputerEventDeleted, OccurredAt: deleted},
+		{ComputerID: uuid.New(), EventType: model.ComputerEventUpdated, OccurredAt: deleted.Add(time.Hour)},
+	}
+	if deletedAt, err = svc.GetLastDeletion(ctx); err != nil || !deletedAt.Equal(deleted) {
+		t.Errorf("Expected the deletion at %v, got %v: %v", deleted, deletedAt, err)
+	}
+}
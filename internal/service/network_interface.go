@@ -0,0 +1,302 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/oui"
+	"computer-management-api/pkg/validation"
+	"context"
+	stderrors "errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+// NetworkInterfaceService handles business logic for the network interfaces of computers. The
+// primary interface of a computer is its MAC and IP address, so changing which interface is
+// primary, or the addresses of the primary interface, updates the computer as well. Changes to
+// interfaces are changes to their computer and only apply while it has a version the request is
+// conditional on.
+type NetworkInterfaceService struct {
+	computers  repository.ComputerRepository
+	interfaces repository.NetworkInterfaceRepository
+	tx         repository.Transactor
+	logger     *slog.Logger
+
+	// Vendors identifies the organizations MAC addresses are registered to
+	Vendors VendorRegistry
+
+	// RejectSuspiciousMACs rejects multicast and locally administered MAC addresses, which are
+	// otherwise accepted and flagged with warnings
+	RejectSuspiciousMACs bool
+}
+
+// NetworkInterfaceLookup is a network interface together with the computer it belongs to
+type NetworkInterfaceLookup struct {
+	Interface model.NetworkInterface `json:"interface"`
+	Computer  model.Computer         `json:"computer"`
+}
+
+// NewNetworkInterfaceService creates a new network interface service
+func NewNetworkInterfaceService(computers repository.ComputerRepository, interfaces repository.NetworkInterfaceRepository, tx repository.Transactor, logger *slog.Logger) *NetworkInterfaceService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &NetworkInterfaceService{
+		computers:  computers,
+		interfaces: interfaces,
+		tx:         tx,
+		logger:     logger,
+		Vendors:    oui.Embedded(),
+	}
+}
+
+// GetInterfaces retrieves the network interfaces of a computer, the primary one first
+func (s *NetworkInterfaceService) GetInterfaces(ctx context.Context, computerID uuid.UUID) ([]model.NetworkInterface, error) {
+	if _, err := s.computers.GetComputerByID(ctx, computerID); err != nil {
+		return nil, mapInterfaceRepositoryError(err, "failed to retrieve computer")
+	}
+
+	interfaces, err := s.interfaces.GetInterfacesByComputer(ctx, computerID)
+	if err != nil {
+		return nil, mapInterfaceRepositoryError(err, "failed to retrieve network interfaces")
+	}
+	if interfaces == nil {
+		interfaces = []model.NetworkInterface{}
+	}
+	for i := range interfaces {
+		describeInterface(s.Vendors, &interfaces[i])
+	}
+
+	return interfaces, nil
+}
+
+// GetInterface retrieves a network interface of a computer
+func (s *NetworkInterfaceService) GetInterface(ctx context.Context, computerID, id uuid.UUID) (*model.NetworkInterface, error) {
+	iface, err := s.interfaces.GetInterfaceByID(ctx, computerID, id)
+	if err != nil {
+		return nil, mapInterfaceRepositoryError(err, "failed to retrieve network interface")
+	}
+	describeInterface(s.Vendors, iface)
+
+	return iface, nil
+}
+
+// GetInterfaceByMAC retrieves the network interface with a MAC address and the computer it belongs to
+func (s *NetworkInterfaceService) GetInterfaceByMAC(ctx context.Context, macAddress string) (*NetworkInterfaceLookup, error) {
+	normalizedMAC, err := validation.ValidateMAC(macAddress)
+	if err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+
+	iface, err := s.interfaces.GetInterfaceByMAC(ctx, normalizedMAC)
+	if err != nil {
+		return nil, mapInterfaceRepositoryError(err, "failed to retrieve network interface")
+	}
+
+	computer, err := s.computers.GetComputerByID(ctx, iface.ComputerID)
+	if err != nil {
+		return nil, mapInterfaceRepositoryError(err, "failed to retrieve computer")
+	}
+	describeInterface(s.Vendors, iface)
+	describeComputer(s.Vendors, computer)
+
+	return &NetworkInterfaceLookup{Interface: *iface, Computer: *computer}, nil
+}
+
+// CreateInterface adds a network interface to a computer. An interface created as primary
+// replaces the computer's MAC and IP address.
+func (s *NetworkInterfaceService) CreateInterface(ctx context.Context, computerID uuid.UUID, iface model.NetworkInterface) (*model.NetworkInterface, error) {
+	if err := validateNetworkInterface(&iface, s.RejectSuspiciousMACs); err != nil {
+		return nil, err
+	}
+
+	iface.ID = uuid.New()
+	iface.ComputerID = computerID
+
+	var created *model.NetworkInterface
+	err := s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		computer, err := lockComputer(ctx, repos, computerID)
+		if err != nil {
+			return err
+		}
+		if err := reserveInterfaceAddresses(ctx, repos, iface, model.NetworkInterface{}); err != nil {
+			return err
+		}
+		if err := repos.Interfaces.CreateInterface(ctx, iface); err != nil {
+			return err
+		}
+		if iface.Primary {
+			if err := promoteInterface(ctx, repos, computer, iface); err != nil {
+				return err
+			}
+		}
+
+		created, err = repos.Interfaces.GetInterfaceByID(ctx, computerID, iface.ID)
+		return err
+	})
+	if err != nil {
+		return nil, mapInterfaceRepositoryError(err, "failed to create network interface")
+	}
+
+	s.logger.InfoContext(ctx, "Network interface created", "computer_id", computerID, "interface_id", created.ID,
+		"mac_address", created.MACAddress, "primary", created.Primary)
+
+	describeInterface(s.Vendors, created)
+	if len(created.MACWarnings) > 0 {
+		s.logger.WarnContext(ctx, "Network interface registered with a suspicious MAC address", "computer_id", computerID,
+			"interface_id", created.ID, "warnings", created.MACWarnings)
+	}
+
+	return created, nil
+}
+
+// UpdateInterface replaces the addresses and type of a network interface and, if requested,
+// makes it the primary interface. The primary interface cannot be demoted directly; another
+// interface has to be made primary instead.
+func (s *NetworkInterfaceService) UpdateInterface(ctx context.Context, computerID, id uuid.UUID, updates model.NetworkInterface) (*model.NetworkInterface, error) {
+	if err := validateNetworkInterface(&updates, s.RejectSuspiciousMACs); err != nil {
+		return nil, err
+	}
+
+	updates.ID = id
+	updates.ComputerID = computerID
+
+	var updated *model.NetworkInterface
+	err := s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		computer, err := lockComputer(ctx, repos, computerID)
+		if err != nil {
+			return err
+		}
+		existing, err := repos.Interfaces.GetInterfaceByID(ctx, computerID, id)
+		if err != nil {
+			return err
+		}
+		if err := reserveInterfaceAddresses(ctx, repos, updates, *existing); err != nil {
+			return err
+		}
+		if existing.Primary && !updates.Primary {
+			return errors.NewAppError(errors.ErrorCodeConflict, "The primary network interface cannot be demoted; make another interface primary instead")
+		}
+
+		if err := repos.Interfaces.UpdateInterface(ctx, updates); err != nil {
+			return err
+		}
+		if updates.Primary {
+			if err := promoteInterface(ctx, repos, computer, updates); err != nil {
+				return err
+			}
+		}
+
+		updated, err = repos.Interfaces.GetInterfaceByID(ctx, computerID, id)
+		return err
+	})
+	if err != nil {
+		return nil, mapInterfaceRepositoryError(err, "failed to update network interface")
+	}
+
+	s.logger.InfoContext(ctx, "Network interface updated", "computer_id", computerID, "interface_id", id)
+
+	describeInterface(s.Vendors, updated)
+
+	return updated, nil
+}
+
+// DeleteInterface deletes a secondary network interface of a computer
+func (s *NetworkInterfaceService) DeleteInterface(ctx context.Context, computerID, id uuid.UUID) error {
+	err := s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		if _, err := lockComputer(ctx, repos, computerID); err != nil {
+			return err
+		}
+		existing, err := repos.Interfaces.GetInterfaceByID(ctx, computerID, id)
+		if err != nil {
+			return err
+		}
+		if existing.Primary {
+			return errors.NewAppError(errors.ErrorCodeConflict, "The primary network interface cannot be deleted; make another interface primary first")
+		}
+		return repos.Interfaces.DeleteInterface(ctx, computerID, id)
+	})
+	if err != nil {
+		return mapInterfaceRepositoryError(err, "failed to delete network interface")
+	}
+
+	s.logger.InfoContext(ctx, "Network interface deleted", "computer_id", computerID, "interface_id", id)
+
+	return nil
+}
+
+// validateNetworkInterface validates field formats and normalizes the addresses. The primary
+// interface provides the computer's IP address, so it needs an IPv4 or IPv6 address.
+func validateNetworkInterface(iface *model.NetworkInterface, rejectSuspiciousMACs bool) error {
+	validationErrors := validation.ValidateNetworkInterfaceInput(iface)
+	validationErrors = append(validationErrors, macAddressErrors(rejectSuspiciousMACs, iface.MACAddress)...)
+	if iface.Primary && iface.IPv4Address == "" && iface.IPv6Address == "" {
+		validationErrors = append(validationErrors, "the primary interface requires an IPv4 or IPv6 address")
+	}
+	if len(validationErrors) > 0 {
+		return validationErrorFromList(validationErrors)
+	}
+	return nil
+}
+
+// reserveInterfaceAddresses checks that the IP addresses of an interface that differ from those
+// it had before are free to use for its computer
+func reserveInterfaceAddresses(ctx context.Context, repos repository.Repositories, iface, before model.NetworkInterface) error {
+	if iface.IPv4Address != "" && iface.IPv4Address != before.IPv4Address {
+		if err := reserveIPAddress(ctx, repos.Subnets, iface.IPv4Address, iface.ComputerID); err != nil {
+			return err
+		}
+	}
+	if iface.IPv6Address != "" && iface.IPv6Address != before.IPv6Address {
+		return reserveIPAddress(ctx, repos.Subnets, iface.IPv6Address, iface.ComputerID)
+	}
+	return nil
+}
+
+// promoteInterface makes iface the primary interface of computer and gives the computer its
+// MAC and IP address, recording the change in the audit trail
+func promoteInterface(ctx context.Context, repos repository.Repositories, computer *model.Computer, iface model.NetworkInterface) error {
+	if err := repos.Interfaces.SetPrimaryInterface(ctx, computer.ID, iface.ID); err != nil {
+		return err
+	}
+	address := primaryAddress(computer, iface)
+	if computer.MACAddress == iface.MACAddress && computer.IPAddress == address {
+		return nil
+	}
+
+	changed := *computer
+	changed.MACAddress = iface.MACAddress
+	changed.IPAddress = address
+	if err := repos.Computers.UpdateComputer(ctx, computer.ID, changed); err != nil {
+		return err
+	}
+
+	updated, err := repos.Computers.GetComputerByID(ctx, computer.ID)
+	if err != nil {
+		return err
+	}
+	return repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventUpdated, computer, updated))
+}
+
+// primaryAddress returns the address a computer takes from its primary interface: the one of the
+// family the computer already uses if the interface has it, or else the interface's other address
+func primaryAddress(computer *model.Computer, iface model.NetworkInterface) string {
+	if iface.IPv6Address != "" && (iface.IPv4Address == "" || !validation.IsIPv4(computer.IPAddress)) {
+		return iface.IPv6Address
+	}
+	return iface.IPv4Address
+}
+
+// mapInterfaceRepositoryError translates network interface repository errors into application errors
+func mapInterfaceRepositoryError(err error, message string) error {
+	switch {
+	case stderrors.Is(err, repository.ErrInterfaceNotFound):
+		return errors.NotFoundError("Network interface")
+	case stderrors.Is(err, repository.ErrDuplicateMAC):
+		return errors.AlreadyExistsError("Network interface with this MAC address")
+	default:
+		return mapRepositoryError(err, message)
+	}
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	apperrors "computer-management-api/pkg/errors"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// mockNetworkInterfaceRepository keeps network interfaces in memory
+type mockNetworkInterfaceRepository struct {
+	repository.NetworkInterfaceRepository
+
+	interfaces map[uuid.UUID]model.NetworkInterface
+}
+
+func (m *mockNetworkInterfaceRepository) CreateInterface(ctx context.Context, iface model.NetworkInterface) error {
+	iface.Primary = false
+	m.interfaces[iface.ID] = iface
+	return nil
+}
+
+func (m *mockNetworkInterfaceRepository) GetInterfacesByComputer(ctx context.Context, computerID uuid.UUID) ([]model.NetworkInterface, error) {
+	var interfaces []model.NetworkInterface
+	for _, iface := range m.interfaces {
+		if iface.ComputerID == computerID {
+			interfaces = append(interfaces, iface)
+		}
+	}
+	return interfaces, nil
+}
+
+func (m *mockNetworkInterfaceRepository) GetInterfaceByID(ctx context.Context, computerID, id uuid.UUID) (*model.NetworkInterface, error) {
+	iface, ok := m.interfaces[id]
+	if !ok || iface.ComputerID != computerID {
+		return nil, repository.ErrInterfaceNotFound
+	}
+	return &iface, nil
+}
+
+func (m *mockNetworkInterfaceRepository) GetInterfaceByMAC(ctx context.Context, macAddress string) (*model.NetworkInterface, error) {
+	for _, iface := range m.interfaces {
+		if iface.MACAddress == macAddress {
+			return &iface, nil
+		}
+	}
+	return nil, repository.ErrInterfaceNotFound
+}
+
+func (m *mockNetworkInterfaceRepository) UpdateInterface(ctx context.Context, iface model.NetworkInterface) error {
+	existing, ok := m.interfaces[iface.ID]
+	if !ok {
+		return repository.ErrInterfaceNotFound
+	}
+	iface.Primary = existing.Primary
+	m.interfaces[iface.ID] = iface
+	return nil
+}
+
+func (m *mockNetworkInterfaceRepository) SetPrimaryInterface(ctx context.Context, computerID, id uuid.UUID) error {
+	if _, ok := m.interfaces[id]; !ok {
+		return repository.ErrInterfaceNotFound
+	}
+	for interfaceID, iface := range m.interfaces {
+		if iface.ComputerID == computerID {
+			iface.Primary = interfaceID == id
+			m.interfaces[interfaceID] = iface
+		}
+	}
+	return nil
+}
+
+func (m *mockNetworkInterfaceRepository) DeleteInterface(ctx context.Context, computerID, id uuid.UUID) error {
+	delete(m.interfaces, id)
+	return nil
+}
+
+// createTestInterfaceComputer returns a test computer as stored, with its MAC address normalized
+func createTestInterfaceComputer() model.Computer {
+	computer := createTestComputer()
+	computer.MACAddress = "00:1B:44:11:3A:B7"
+	return computer
+}
+
+// createTestInterfaceService returns a service for a computer whose primary interface has its MAC
+// and IP address
+func createTestInterfaceService(computer model.Computer) (*NetworkInterfaceService, *mockComputerRepository, *mockNetworkInterfaceRepository, *mockEventRepository) {
+	stored := computer
+	repo := &mockComputerRepository{}
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		if id != stored.ID {
+			return nil, repository.ErrComputerNotFound
+		}
+		c := stored
+		return &c, nil
+	}
+	repo.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, c model.Computer) error {
+		stored = c
+		stored.Version++
+		return nil
+	}
+
+	primary := model.NetworkInterface{ID: uuid.New(), ComputerID: computer.ID, MACAddress: computer.MACAddress,
+		Type: model.NetworkInterfaceTypeEthernet, IPv4Address: computer.IPAddress, Primary: true}
+	interfaces := &mockNetworkInterfaceRepository{interfaces: map[uuid.UUID]model.NetworkInterface{primary.ID: primary}}
+	events := &mockEventRepository{}
+
+	tx := &mockTransactor{repos: repository.Repositories{Computers: repo, Events: events, Interfaces: interfaces, Subnets: &mockSubnetRepository{}}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+	return NewNetworkInterfaceService(repo, interfaces, tx, logger), repo, interfaces, events
+}
+
+// primaryOf returns the primary interface held by the mock repository
+func primaryOf(t *testing.T, interfaces *mockNetworkInterfaceRepository) model.NetworkInterface {
+	t.Helper()
+	for _, iface := range interfaces.interfaces {
+		if iface.Primary {
+			return iface
+		}
+	}
+	t.Fatal("No primary interface")
+	return model.NetworkInterface{}
+}
+
+func TestCreateInterface_SecondaryLeavesComputerUnchanged(t *testing.T) {
+	computer := createTestInterfaceComputer()
+	svc, repo, _, events := createTestInterfaceService(computer)
+
+	repo.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, c model.Computer) error {
+		t.Error("Expected the computer to stay unchanged")
+		return nil
+	}
+
+	created, err := svc.CreateInterface(context.Background(), computer.ID, model.NetworkInterface{MACAddress: "aa-bb-cc-dd-ee-ff", Type: model.NetworkInterfaceTypeWiFi})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created.MACAddress != "AA:BB:CC:DD:EE:FF" || created.Primary || created.ComputerID != computer.ID {
+		t.Errorf("Unexpected interface %+v", created)
+	}
+	if len(events.events) != 0 {
+		t.Errorf("Expected no audit events, got %d", len(events.events))
+	}
+}
+
+func TestCreateInterface_PrimaryUpdatesComputer(t *testing.T) {
+	computer := createTestInterfaceComputer()
+	svc, repo, interfaces, events := createTestInterfaceService(computer)
+
+	created, err := svc.CreateInterface(context.Background(), computer.ID, model.NetworkInterface{
+		MACAddress: "AA:BB:CC:DD:EE:FF", Type: model.NetworkInterfaceTypeDock, IPv4Address: "10.0.0.5", Primary: true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !created.Primary || primaryOf(t, interfaces).ID != created.ID {
+		t.Error("Expected the new interface to be primary")
+	}
+
+	updated, _ := repo.GetComputerByID(context.Background(), computer.ID)
+	if updated.MACAddress != "AA:BB:CC:DD:EE:FF" || updated.IPAddress != "10.0.0.5" {
+		t.Errorf("Expected the computer to take the primary interface's addresses, got %s %s", updated.MACAddress, updated.IPAddress)
+	}
+	if len(events.events) != 1 || events.events[0].EventType != model.ComputerEventUpdated {
+		t.Fatalf("Expected an updated event, got %+v", events.events)
+	}
+	if events.events[0].OldValue.MACAddress != computer.MACAddress {
+		t.Errorf("Expected the event to record the former MAC address, got %s", events.events[0].OldValue.MACAddress)
+	}
+}
+
+func TestCreateInterface_PrimaryRequiresIPAddress(t *testing.T) {
+	computer := createTestInterfaceComputer()
+	svc, _, _, _ := createTestInterfaceService(computer)
+
+	_, err := svc.CreateInterface(context.Background(), computer.ID, model.NetworkInterface{
+		MACAddress: "AA:BB:CC:DD:EE:FF", Primary: true,
+	})
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeValidation {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+}
+
+func TestCreateInterface_PrimaryKeepsAddressFamily(t *testing.T) {
+	computer := createTestInterfaceComputer()
+	computer.IPAddress = "2001:db8::10"
+	svc, repo, _, _ := createTestInterfaceService(computer)
+
+	_, err := svc.CreateInterface(context.Background(), computer.ID, model.NetworkInterface{
+		MACAddress: "AA:BB:CC:DD:EE:FF", IPv4Address: "10.0.0.5", IPv6Address: "2001:DB8::20", Primary: true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	updated, _ := repo.GetComputerByID(context.Background(), computer.ID)
+	if updated.IPAddress != "2001:db8::20" {
+		t.Errorf("Expected the computer to keep using IPv6, got %s", updated.IPAddress)
+	}
+}
+
+func TestCreateInterface_UnknownComputer(t *testing.T) {
+	svc, _, _, _ := createTestInterfaceService(createTestInterfaceComputer())
+
+	_, err := svc.CreateInterface(context.Background(), uuid.New(), model.NetworkInterface{MACAddress: "AA:BB:CC:DD:EE:FF"})
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeNotFound {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestUpdateInterface_PrimarySyncsComputer(t *testing.T) {
+	computer := createTestInterfaceComputer()
+	svc, repo, interfaces, _ := createTestInterfaceService(computer)
+	primary := primaryOf(t, interfaces)
+
+	_, err := svc.UpdateInterface(context.Background(), computer.ID, primary.ID, model.NetworkInterface{
+		MACAddress: primary.MACAddress, IPv4Address: "192.168.1.200", Primary: true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	updated, _ := repo.GetComputerByID(context.Background(), computer.ID)
+	if updated.IPAddress != "192.168.1.200" {
+		t.Errorf("Expected the computer's IP address to follow its primary interface, got %s", updated.IPAddress)
+	}
+}
+
+func TestUpdateInterface_CannotDemotePrimary(t *testing.T) {
+	computer := createTestInterfaceComputer()
+	svc, _, interfaces, _ := createTestInterfaceService(computer)
+	primary := primaryOf(t, interfaces)
+
+	_, err := svc.UpdateInterface(context.Background(), computer.ID, primary.ID, model.NetworkInterface{
+		MACAddress: primary.MACAddress, IPv4Address: primary.IPv4Address,
+	})
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeConflict {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+}
+
+func TestDeleteInterface_PrimaryRejected(t *testing.T) {
+	computer := createTestInterfaceComputer()
+	svc, _, interfaces, _ := createTestInterfaceService(computer)
+	primary := primaryOf(t, interfaces)
+
+	err := svc.DeleteInterface(context.Background(), computer.ID, primary.ID)
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeConflict {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+	if _, ok := interfaces.interfaces[primary.ID]; !ok {
+		t.Error("Expected the primary interface to be kept")
+	}
+}
+
+func TestGetInterfaceByMAC_ReturnsComputer(t *testing.T) {
+	computer := createTestInterfaceComputer()
+	svc, _, _, _ := createTestInterfaceService(computer)
+
+	lookup, err := svc.GetInterfaceByMAC(context.Background(), "00-1b-44-11-3a-b7")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lookup.Computer.ID != computer.ID || !lookup.Interface.Primary {
+		t.Errorf("Unexpected lookup %+v", lookup)
+	}
+
+	_, err = svc.GetInterfaceByMAC(context.Background(), "AA:BB:CC:DD:EE:FF")
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeNotFound {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	_, err = svc.GetInterfaceByMAC(context.Background(), "invalid")
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeValidation {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+}
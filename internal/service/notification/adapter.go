@@ -26,21 +26,20 @@ func (a *ServiceAdapter) SendComputerNotification(ctx context.Context, computerN
 		Level:                mapNotificationLevel(computerNotification.Type),
 		EmployeeAbbreviation: computerNotification.EmployeeAbbreviation,
 		Message:              computerNotification.Message,
-		Metadata:             computerNotification.Metadata,
+	}
+
+	// Copy metadata so the caller's map is never mutated
+	clientNotification.Metadata = make(map[string]string, len(computerNotification.Metadata)+3)
+	for key, value := range computerNotification.Metadata {
+		clientNotification.Metadata[key] = value
 	}
 
 	// Add computer-specific metadata
 	if computerNotification.ComputerName != "" {
-		if clientNotification.Metadata == nil {
-			clientNotification.Metadata = make(map[string]string)
-		}
 		clientNotification.Metadata["computer_name"] = computerNotification.ComputerName
 	}
 
 	if computerNotification.ComputerCount > 0 {
-		if clientNotification.Metadata == nil {
-			clientNotification.Metadata = make(map[string]string)
-		}
 		clientNotification.Metadata["computer_count"] = fmt.Sprintf("%d", computerNotification.ComputerCount)
 	}
 
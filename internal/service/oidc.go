@@ -0,0 +1,109 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/jwt"
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"strings"
+)
+
+// UserActorPrefix precedes the name of a signed-in user in the audit trail
+const UserActorPrefix = "user:"
+
+// OIDCConfig controls how the claims of identity provider tokens are mapped to principals
+type OIDCConfig struct {
+	// RolesClaim is the dot-separated path of the claim listing the user's roles or groups
+	RolesClaim string
+	// RoleMapping maps claim values to roles; values that are role names map to themselves
+	RoleMapping map[string]model.Role
+	// EmployeeClaim holds the employee abbreviation of users with the employee role
+	EmployeeClaim string
+	// UsernameClaim names the user in the audit trail; the subject is used when it is missing
+	UsernameClaim string
+}
+
+// OIDCAuthenticator verifies bearer tokens issued by the OpenID Connect identity provider and
+// maps their claims to roles
+type OIDCAuthenticator struct {
+	validator *jwt.Validator
+	config    OIDCConfig
+	logger    *slog.Logger
+}
+
+// NewOIDCAuthenticator creates a new authenticator for tokens accepted by validator
+func NewOIDCAuthenticator(validator *jwt.Validator, config OIDCConfig, logger *slog.Logger) *OIDCAuthenticator {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &OIDCAuthenticator{
+		validator: validator,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// VerifyToken verifies a token and returns the principal it identifies. Invalid tokens are
+// rejected with an unauthorized error. A valid token without known roles yields a principal
+// without scopes, which is authenticated but may not do anything.
+func (a *OIDCAuthenticator) VerifyToken(ctx context.Context, token string) (*model.Principal, error) {
+	claims, err := a.validator.Verify(ctx, token)
+	if err != nil {
+		return nil, mapTokenError(err)
+	}
+
+	subject := claims.String("sub")
+	if subject == "" {
+		return nil, errors.UnauthorizedError("Invalid token: missing subject")
+	}
+	name := claims.String(a.config.UsernameClaim)
+	if name == "" {
+		name = subject
+	}
+
+	principal := &model.Principal{Actor: UserActorPrefix + name}
+	seen := make(map[model.Scope]bool)
+	for _, value := range claims.Strings(a.config.RolesClaim) {
+		role, ok := a.config.RoleMapping[value]
+		if !ok {
+			role = model.Role(value)
+		}
+		scopes, known := model.RoleScopes[role]
+		if !known || principal.HasRole(role) {
+			continue
+		}
+
+		principal.Roles = append(principal.Roles, role)
+		for _, scope := range scopes {
+			if !seen[scope] {
+				seen[scope] = true
+				principal.Scopes = append(principal.Scopes, scope)
+			}
+		}
+	}
+
+	if principal.HasRole(model.RoleEmployee) {
+		principal.EmployeeAbbreviation = strings.ToUpper(strings.TrimSpace(claims.String(a.config.EmployeeClaim)))
+		if principal.EmployeeAbbreviation == "" {
+			a.logger.WarnContext(ctx, "Token has the employee role but no employee claim", "user", name, "claim", a.config.EmployeeClaim)
+		}
+	}
+
+	return principal, nil
+}
+
+// mapTokenError translates token verification errors into application errors. Failures to
+// obtain the identity provider's keys are not the client's fault.
+func mapTokenError(err error) error {
+	for _, tokenErr := range []error{
+		jwt.ErrMalformed, jwt.ErrUnsupportedAlgorithm, jwt.ErrUnknownKey, jwt.ErrInvalidSignature,
+		jwt.ErrExpired, jwt.ErrNotYetValid, jwt.ErrInvalidIssuer, jwt.ErrInvalidAudience,
+	} {
+		if stderrors.Is(err, tokenErr) {
+			return errors.UnauthorizedError("Invalid token: " + strings.TrimPrefix(tokenErr.Error(), "jwt: "))
+		}
+	}
+	return errors.ExternalServiceError("identity provider", err)
+}
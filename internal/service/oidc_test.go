@@ -0,0 +1,100 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	apperrors "computer-management-api/pkg/errors"
+	"computer-management-api/pkg/jwt"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+)
+
+// staticKey serves a single public key to the validator
+type staticKey struct {
+	key crypto.PublicKey
+}
+
+func (s staticKey) PublicKey(ctx context.Context, kid, alg string) (crypto.PublicKey, error) {
+	if kid != "test" {
+		return nil, jwt.ErrUnknownKey
+	}
+	return s.key, nil
+}
+
+func TestOIDCAuthenticator_VerifyToken(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator := NewOIDCAuthenticator(
+		&jwt.Validator{Keys: staticKey{&key.PublicKey}, Issuer: "https://idp.example.com"},
+		OIDCConfig{
+			RolesClaim:    "realm_access.roles",
+			RoleMapping:   map[string]model.Role{"helpdesk": model.RoleITAdmin},
+			EmployeeClaim: "employee_abbreviation",
+			UsernameClaim: "preferred_username",
+		},
+		nil,
+	)
+	token := func(claims map[string]interface{}) string {
+		claims["iss"] = "https://idp.example.com"
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+		token, err := jwt.Sign("ES256", "test", key, claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	t.Run("mapped and direct roles", func(t *testing.T) {
+		principal, err := authenticator.VerifyToken(context.Background(), token(map[string]interface{}{
+			"sub":                "123",
+			"preferred_username": "jdoe",
+			"realm_access":       map[string]interface{}{"roles": []string{"viewer", "helpdesk", "offline_access"}},
+		}))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if principal.Actor != "user:jdoe" {
+			t.Errorf("Expected actor user:jdoe, got %s", principal.Actor)
+		}
+		if !principal.HasRole(model.RoleViewer) || !principal.HasRole(model.RoleITAdmin) || len(principal.Roles) != 2 {
+			t.Errorf("Expected the viewer and it-admin roles, got %v", principal.Roles)
+		}
+		if len(principal.Scopes) != len(model.AllScopes) {
+			t.Errorf("Expected each scope once, got %v", principal.Scopes)
+		}
+	})
+
+	t.Run("employee", func(t *testing.T) {
+		principal, err := authenticator.VerifyToken(context.Background(), token(map[string]interface{}{
+			"sub":                   "456",
+			"employee_abbreviation": "abc",
+			"realm_access":          map[string]interface{}{"roles": []string{"employee"}},
+		}))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if principal.Actor != "user:456" || principal.EmployeeAbbreviation != "ABC" || len(principal.Scopes) != 0 {
+			t.Errorf("Expected an employee principal for ABC without scopes, got %+v", principal)
+		}
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		for name, tokenString := range map[string]string{
+			"missing subject": token(map[string]interface{}{"preferred_username": "jdoe"}),
+			"malformed":       "not-a-token",
+		} {
+			_, err := authenticator.VerifyToken(context.Background(), tokenString)
+			var appErr *apperrors.AppError
+			if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrorCodeUnauthorized {
+				t.Errorf("%s: expected an unauthorized error, got %v", name, err)
+			}
+		}
+	})
+}
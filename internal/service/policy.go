@@ -0,0 +1,222 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/validation"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+)
+
+// DefaultMaxComputersPerEmployee is the warning threshold used when no global quota policy is configured
+const DefaultMaxComputersPerEmployee = 3
+
+// PolicyService handles business logic for computer quota policies
+type PolicyService struct {
+	repo      repository.PolicyRepository
+	employees repository.EmployeeRepository
+	logger    *slog.Logger
+}
+
+// NewPolicyService creates a new policy service
+func NewPolicyService(repo repository.PolicyRepository, employees repository.EmployeeRepository, logger *slog.Logger) *PolicyService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &PolicyService{
+		repo:      repo,
+		employees: employees,
+		logger:    logger,
+	}
+}
+
+// CreatePolicy creates a new quota policy
+func (s *PolicyService) CreatePolicy(ctx context.Context, policy model.QuotaPolicy) (*model.QuotaPolicy, error) {
+	if err := s.validatePolicy(ctx, &policy); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreatePolicy(ctx, policy)
+	if err != nil {
+		return nil, mapPolicyRepositoryError(err, "failed to create quota policy")
+	}
+
+	created, err := s.repo.GetPolicyByID(ctx, id)
+	if err != nil {
+		return nil, mapPolicyRepositoryError(err, "failed to retrieve created quota policy")
+	}
+
+	s.logger.InfoContext(ctx, "Quota policy created", "policy_id", created.ID, "scope", created.Scope, "target", created.Target,
+		"max_computers", created.MaxComputers, "mode", created.Mode)
+
+	return created, nil
+}
+
+// GetAllPolicies retrieves quota policies with pagination
+func (s *PolicyService) GetAllPolicies(ctx context.Context, params repository.PaginationParams) (*repository.PolicyPaginatedResult, error) {
+	result, err := s.repo.GetAllPoliciesPaginated(ctx, params)
+	if err != nil {
+		return nil, mapPolicyRepositoryError(err, "failed to retrieve quota policies")
+	}
+
+	return result, nil
+}
+
+// GetPolicy retrieves a quota policy by ID
+func (s *PolicyService) GetPolicy(ctx context.Context, id int64) (*model.QuotaPolicy, error) {
+	policy, err := s.repo.GetPolicyByID(ctx, id)
+	if err != nil {
+		return nil, mapPolicyRepositoryError(err, "failed to retrieve quota policy")
+	}
+
+	return policy, nil
+}
+
+// UpdatePolicy replaces a quota policy
+func (s *PolicyService) UpdatePolicy(ctx context.Context, id int64, updates model.QuotaPolicy) (*model.QuotaPolicy, error) {
+	if err := s.validatePolicy(ctx, &updates); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdatePolicy(ctx, id, updates); err != nil {
+		return nil, mapPolicyRepositoryError(err, "failed to update quota policy")
+	}
+
+	updated, err := s.repo.GetPolicyByID(ctx, id)
+	if err != nil {
+		return nil, mapPolicyRepositoryError(err, "failed to retrieve updated quota policy")
+	}
+
+	s.logger.InfoContext(ctx, "Quota policy updated", "policy_id", id, "max_computers", updated.MaxComputers, "mode", updated.Mode)
+
+	return updated, nil
+}
+
+// DeletePolicy deletes a quota policy. Employees it covered fall back to the next most specific policy.
+func (s *PolicyService) DeletePolicy(ctx context.Context, id int64) error {
+	if err := s.repo.DeletePolicy(ctx, id); err != nil {
+		return mapPolicyRepositoryError(err, "failed to delete quota policy")
+	}
+
+	s.logger.InfoContext(ctx, "Quota policy deleted", "policy_id", id)
+
+	return nil
+}
+
+// GetEffectivePolicy returns the quota policy that applies to an employee
+func (s *PolicyService) GetEffectivePolicy(ctx context.Context, employeeAbbrev string) (*model.QuotaPolicy, error) {
+	if err := validation.ValidateEmployeeAbbreviation(employeeAbbrev); err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+
+	employee, err := s.employees.GetEmployeeByAbbreviation(ctx, employeeAbbrev)
+	if err != nil {
+		return nil, mapPolicyRepositoryError(err, "failed to resolve quota policy")
+	}
+	policy, err := resolveQuotaPolicy(ctx, s.repo, employee)
+	if err != nil {
+		return nil, mapPolicyRepositoryError(err, "failed to resolve quota policy")
+	}
+
+	return policy, nil
+}
+
+// validatePolicy validates a policy and ensures employee overrides refer to a known employee
+func (s *PolicyService) validatePolicy(ctx context.Context, policy *model.QuotaPolicy) error {
+	if validationErrors := validation.ValidateQuotaPolicyInput(policy); len(validationErrors) > 0 {
+		return validationErrorFromList(validationErrors)
+	}
+
+	if policy.Scope == model.QuotaPolicyScopeEmployee {
+		if _, err := s.employees.GetEmployeeByAbbreviation(ctx, policy.Target); err != nil {
+			return mapRepositoryError(err, "failed to retrieve employee")
+		}
+	}
+
+	return nil
+}
+
+// resolveQuotaPolicy returns the most specific policy for an employee: their own override,
+// then their department's, then the global policy, then the built-in default
+func resolveQuotaPolicy(ctx context.Context, policies repository.PolicyRepository, employee *model.Employee) (*model.QuotaPolicy, error) {
+	applicable, err := policies.GetApplicablePolicies(ctx, employee.Abbreviation, employee.Department)
+	if err != nil {
+		return nil, err
+	}
+
+	// Policies are returned most general first
+	if len(applicable) > 0 {
+		return &applicable[len(applicable)-1], nil
+	}
+
+	return &model.QuotaPolicy{
+		Scope:        model.QuotaPolicyScopeGlobal,
+		MaxComputers: DefaultMaxComputersPerEmployee,
+		Mode:         model.QuotaPolicyModeWarn,
+	}, nil
+}
+
+// applyQuotaPolicy evaluates the employee's quota policy after a change. Computers are counted
+// inside the transaction so the change being made is included, after locking the employee so
+// concurrent assignments are counted one after the other. An enforced quota rejects the change,
+// a warning quota queues a threshold notification once it is reached.
+func applyQuotaPolicy(ctx context.Context, repos repository.Repositories, employeeAbbrev string) error {
+	if employeeAbbrev == "" {
+		return nil
+	}
+
+	employee, err := repos.Employees.GetEmployeeByAbbreviationForUpdate(ctx, employeeAbbrev)
+	if err != nil {
+		return err
+	}
+	policy, err := resolveQuotaPolicy(ctx, repos.Policies, employee)
+	if err != nil {
+		return err
+	}
+
+	computers, err := repos.Computers.GetComputersByEmployee(ctx, employeeAbbrev)
+	if err != nil {
+		return err
+	}
+
+	if policy.Mode == model.QuotaPolicyModeEnforce {
+		if len(computers) <= policy.MaxComputers {
+			return nil
+		}
+		return errors.NewAppError(errors.ErrorCodeConflict,
+			fmt.Sprintf("Employee %s cannot be assigned more than %d computers", employeeAbbrev, policy.MaxComputers)).
+			WithDetail("max_computers", policy.MaxComputers).
+			WithDetail("policy_scope", string(policy.Scope))
+	}
+
+	if len(computers) < policy.MaxComputers {
+		return nil
+	}
+
+	return enqueueNotification(ctx, repos, ComputerNotification{
+		Type:                 NotificationTypeThresholdExceeded,
+		EmployeeAbbreviation: employeeAbbrev,
+		ComputerCount:        len(computers),
+		Message:              fmt.Sprintf("Employee %s has %d computers assigned (threshold: %d)", employeeAbbrev, len(computers), policy.MaxComputers),
+		Metadata: map[string]string{
+			"threshold":    fmt.Sprintf("%d", policy.MaxComputers),
+			"count":        fmt.Sprintf("%d", len(computers)),
+			"policy_scope": string(policy.Scope),
+		},
+	})
+}
+
+// mapPolicyRepositoryError translates quota policy repository errors into application errors
+func mapPolicyRepositoryError(err error, message string) error {
+	switch {
+	case stderrors.Is(err, repository.ErrPolicyNotFound):
+		return errors.NotFoundError("Quota policy")
+	case stderrors.Is(err, repository.ErrDuplicatePolicy):
+		return errors.AlreadyExistsError("Quota policy for this scope and target")
+	default:
+		return mapRepositoryError(err, message)
+	}
+}
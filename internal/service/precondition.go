@@ -0,0 +1,59 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/errors"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type expectedVersionsContextKey struct{}
+
+// WithExpectedVersions returns a context whose changes to a computer only apply while the
+// computer's version is one of versions. Without any versions no change applies at all.
+func WithExpectedVersions(ctx context.Context, versions ...int64) context.Context {
+	if versions == nil {
+		versions = []int64{}
+	}
+	return context.WithValue(ctx, expectedVersionsContextKey{}, versions)
+}
+
+// ExpectedVersionsFromContext returns the versions a change is conditional on and whether it is conditional
+func ExpectedVersionsFromContext(ctx context.Context) ([]int64, bool) {
+	versions, ok := ctx.Value(expectedVersionsContextKey{}).([]int64)
+	return versions, ok
+}
+
+// checkComputerVersion locks the computer for the current transaction and rejects the change
+// if the request was conditional on a version the computer no longer has
+func checkComputerVersion(ctx context.Context, repos repository.Repositories, id uuid.UUID) error {
+	if _, conditional := ExpectedVersionsFromContext(ctx); !conditional {
+		return nil
+	}
+	_, err := lockComputer(ctx, repos, id)
+	return err
+}
+
+// lockComputer locks the computer for the current transaction, whether or not the request is
+// conditional, and rejects the change if it was conditional on a version the computer no longer
+// has. Concurrent writers cannot change the returned row until the transaction ends, so changes
+// are decided on it.
+func lockComputer(ctx context.Context, repos repository.Repositories, id uuid.UUID) (*model.Computer, error) {
+	computer, err := repos.Computers.GetComputerByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, conditional := ExpectedVersionsFromContext(ctx)
+	if !conditional {
+		return computer, nil
+	}
+	for _, version := range versions {
+		if computer.Version == version {
+			return computer, nil
+		}
+	}
+	return nil, errors.PreconditionFailedError("Computer").WithDetail("current_version", computer.Version)
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/oui"
+	"computer-management-api/pkg/validation"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"net/netip"
+
+	"github.com/google/uuid"
+)
+
+// SubnetService handles business logic for subnets and the addresses assigned from them.
+// Changes to the addresses of a subnet lock the subnet first, so concurrent allocations and
+// assignments from the same subnet are serialized and cannot hand out an address twice.
+type SubnetService struct {
+	repo   repository.SubnetRepository
+	tx     repository.Transactor
+	logger *slog.Logger
+
+	// Vendors identifies the organizations the MAC addresses of computers are registered to
+	Vendors VendorRegistry
+}
+
+// NewSubnetService creates a new subnet service
+func NewSubnetService(repo repository.SubnetRepository, tx repository.Transactor, logger *slog.Logger) *SubnetService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SubnetService{
+		repo:    repo,
+		tx:      tx,
+		logger:  logger,
+		Vendors: oui.Embedded(),
+	}
+}
+
+// CreateSubnet creates a new subnet
+func (s *SubnetService) CreateSubnet(ctx context.Context, subnet model.Subnet) (*model.Subnet, error) {
+	if validationErrors := validation.ValidateSubnetInput(&subnet); len(validationErrors) > 0 {
+		return nil, validationErrorFromList(validationErrors)
+	}
+
+	subnet.ID = uuid.New()
+	if err := s.repo.CreateSubnet(ctx, subnet); err != nil {
+		return nil, mapSubnetRepositoryError(err, "failed to create subnet")
+	}
+
+	created, err := s.repo.GetSubnetByID(ctx, subnet.ID)
+	if err != nil {
+		return nil, mapSubnetRepositoryError(err, "failed to retrieve created subnet")
+	}
+
+	s.logger.InfoContext(ctx, "Subnet created", "subnet_id", created.ID, "cidr", created.CIDR)
+
+	return created, nil
+}
+
+// GetSubnets retrieves all subnets ordered by network address
+func (s *SubnetService) GetSubnets(ctx context.Context) ([]model.Subnet, error) {
+	subnets, err := s.repo.GetSubnets(ctx)
+	if err != nil {
+		return nil, mapSubnetRepositoryError(err, "failed to retrieve subnets")
+	}
+	if subnets == nil {
+		subnets = []model.Subnet{}
+	}
+
+	return subnets, nil
+}
+
+// GetSubnet retrieves a subnet by ID
+func (s *SubnetService) GetSubnet(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+	subnet, err := s.repo.GetSubnetByID(ctx, id)
+	if err != nil {
+		return nil, mapSubnetRepositoryError(err, "failed to retrieve subnet")
+	}
+
+	return subnet, nil
+}
+
+// UpdateSubnet replaces a subnet. Addresses already assigned are left unchanged, even if the
+// new network no longer contains them.
+func (s *SubnetService) UpdateSubnet(ctx context.Context, id uuid.UUID, updates model.Subnet) (*model.Subnet, error) {
+	if validationErrors := validation.ValidateSubnetInput(&updates); len(validationErrors) > 0 {
+		return nil, validationErrorFromList(validationErrors)
+	}
+
+	updates.ID = id
+	if err := s.repo.UpdateSubnet(ctx, updates); err != nil {
+		return nil, mapSubnetRepositoryError(err, "failed to update subnet")
+	}
+
+	updated, err := s.repo.GetSubnetByID(ctx, id)
+	if err != nil {
+		return nil, mapSubnetRepositoryError(err, "failed to retrieve updated subnet")
+	}
+
+	s.logger.InfoContext(ctx, "Subnet updated", "subnet_id", id, "cidr", updated.CIDR)
+
+	return updated, nil
+}
+
+// DeleteSubnet deletes a subnet. The addresses assigned from it are left unchanged.
+func (s *SubnetService) DeleteSubnet(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.DeleteSubnet(ctx, id); err != nil {
+		return mapSubnetRepositoryError(err, "failed to delete subnet")
+	}
+
+	s.logger.InfoContext(ctx, "Subnet deleted", "subnet_id", id)
+
+	return nil
+}
+
+// GetUtilization reports how many addresses of a subnet are assigned
+func (s *SubnetService) GetUtilization(ctx context.Context, id uuid.UUID) (*model.SubnetUtilization, error) {
+	subnet, err := s.repo.GetSubnetByID(ctx, id)
+	if err != nil {
+		return nil, mapSubnetRepositoryError(err, "failed to retrieve subnet")
+	}
+
+	utilization, err := s.utilization(ctx, *subnet)
+	if err != nil {
+		return nil, err
+	}
+	return &utilization, nil
+}
+
+// GetAllUtilization reports how many addresses of every subnet are assigned
+func (s *SubnetService) GetAllUtilization(ctx context.Context) ([]model.SubnetUtilization, error) {
+	subnets, err := s.GetSubnets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	utilizations := make([]model.SubnetUtilization, 0, len(subnets))
+	for _, subnet := range subnets {
+		utilization, err := s.utilization(ctx, subnet)
+		if err != nil {
+			return nil, err
+		}
+		utilizations = append(utilizations, utilization)
+	}
+	return utilizations, nil
+}
+
+// utilization counts the usable and assigned addresses of a subnet
+func (s *SubnetService) utilization(ctx context.Context, subnet model.Subnet) (model.SubnetUtilization, error) {
+	assigned, err := s.repo.GetAssignedAddresses(ctx, subnet.CIDR)
+	if err != nil {
+		return model.SubnetUtilization{}, mapSubnetRepositoryError(err, "failed to retrieve assigned addresses")
+	}
+
+	network := netip.MustParsePrefix(subnet.CIDR)
+	first, last := validation.HostRange(network)
+	capacity := new(big.Int).Sub(addressValue(last), addressValue(first))
+	capacity.Add(capacity, big.NewInt(1))
+	if subnet.Gateway != "" {
+		capacity.Sub(capacity, big.NewInt(1))
+	}
+
+	used := 0
+	for address := range usedAddresses(subnet, assigned) {
+		if !address.Less(first) && !last.Less(address) {
+			used++
+		}
+	}
+
+	free := new(big.Int).Sub(capacity, big.NewInt(int64(used)))
+	percent := 0.0
+	if capacity.Sign() > 0 {
+		ratio, _ := new(big.Float).Quo(big.NewFloat(float64(used)), new(big.Float).SetInt(capacity)).Float64()
+		percent = math.Round(ratio*10000) / 100
+	}
+
+	return model.SubnetUtilization{
+		Subnet:             subnet,
+		Capacity:           capacity,
+		Used:               used,
+		Free:               free,
+		UtilizationPercent: percent,
+	}, nil
+}
+
+// AllocateAddress assigns the lowest free address of a subnet to a computer, replacing its IP
+// address, and records the change in the audit trail. A computer that already has an address
+// in the subnet keeps it, so retrying an allocation is safe. Like every change to a computer, the
+// allocation only applies while the computer has a version the request is conditional on.
+func (s *SubnetService) AllocateAddress(ctx context.Context, subnetID, computerID uuid.UUID) (*model.Computer, error) {
+	var allocated *model.Computer
+	err := s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		// The computer is locked before its subnet, in the same order as other changes to computers
+		computer, err := lockComputer(ctx, repos, computerID)
+		if err != nil {
+			return err
+		}
+		subnet, err := repos.Subnets.GetSubnetByIDForUpdate(ctx, subnetID)
+		if err != nil {
+			return err
+		}
+
+		network := netip.MustParsePrefix(subnet.CIDR)
+		if current, err := netip.ParseAddr(computer.IPAddress); err == nil && network.Contains(current) {
+			allocated = computer
+			return nil
+		}
+
+		assigned, err := repos.Subnets.GetAssignedAddresses(ctx, subnet.CIDR)
+		if err != nil {
+			return err
+		}
+		address, ok := nextFreeAddress(*subnet, usedAddresses(*subnet, assigned))
+		if !ok {
+			return errors.NewAppError(errors.ErrorCodeConflict, fmt.Sprintf("Subnet %s has no free addresses", subnet.CIDR)).
+				WithDetail("subnet_id", subnet.ID)
+		}
+
+		changed := *computer
+		changed.IPAddress = address.String()
+		if err := repos.Computers.UpdateComputer(ctx, computerID, changed); err != nil {
+			return err
+		}
+		if allocated, err = repos.Computers.GetComputerByID(ctx, computerID); err != nil {
+			return err
+		}
+		return repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventUpdated, computer, allocated))
+	})
+	if err != nil {
+		return nil, mapSubnetRepositoryError(err, "failed to allocate address")
+	}
+
+	s.logger.InfoContext(ctx, "Address allocated", "subnet_id", subnetID, "computer_id", computerID, "ip_address", allocated.IPAddress)
+
+	describeComputer(s.Vendors, allocated)
+
+	return allocated, nil
+}
+
+// usedAddresses returns the assigned addresses of a subnet other than its gateway, which is
+// reserved rather than used
+func usedAddresses(subnet model.Subnet, assigned []model.IPAddressAssignment) map[netip.Addr]bool {
+	used := make(map[netip.Addr]bool, len(assigned)+1)
+	for _, a := range assigned {
+		if address, err := netip.ParseAddr(a.Address); err == nil {
+			used[address] = true
+		}
+	}
+	if gateway, err := netip.ParseAddr(subnet.Gateway); err == nil {
+		delete(used, gateway)
+	}
+	return used
+}
+
+// nextFreeAddress returns the lowest host address of a subnet that is neither used nor the gateway
+func nextFreeAddress(subnet model.Subnet, used map[netip.Addr]bool) (netip.Addr, bool) {
+	gateway, _ := netip.ParseAddr(subnet.Gateway)
+	first, last := validation.HostRange(netip.MustParsePrefix(subnet.CIDR))
+	for address := first; address.IsValid() && !last.Less(address); address = address.Next() {
+		if !used[address] && address != gateway {
+			return address, true
+		}
+	}
+	return netip.Addr{}, false
+}
+
+// addressValue returns an address as an integer
+func addressValue(address netip.Addr) *big.Int {
+	return new(big.Int).SetBytes(address.AsSlice())
+}
+
+// reserveIPAddress checks that a computer can use an IP address. Once any subnet is defined, the
+// address must be a host address of one of them other than its gateway, and no other computer
+// may use it on any of its network interfaces. The subnet, or the address itself while no
+// subnet is defined, is locked until the transaction ends, so the check holds until the address
+// is written. Subnets are locked before computers.
+func reserveIPAddress(ctx context.Context, subnets repository.SubnetRepository, ip string, computerID uuid.UUID) error {
+	address, err := netip.ParseAddr(ip)
+	if err != nil {
+		return errors.ValidationError(fmt.Sprintf("invalid IP address: %s", ip))
+	}
+
+	subnet, err := subnets.GetSubnetContainingForUpdate(ctx, ip)
+	switch {
+	case stderrors.Is(err, repository.ErrSubnetNotFound):
+		count, err := subnets.CountSubnets(ctx)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.ValidationError(fmt.Sprintf("IP address %s does not belong to any subnet", ip))
+		}
+		if err := subnets.LockAddress(ctx, address.String()); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		first, last := validation.HostRange(netip.MustParsePrefix(subnet.CIDR))
+		if address.Less(first) || last.Less(address) {
+			return errors.ValidationError(fmt.Sprintf("IP address %s is not a host address of subnet %s", ip, subnet.CIDR))
+		}
+		if ip == subnet.Gateway {
+			return errors.NewAppError(errors.ErrorCodeConflict, fmt.Sprintf("IP address %s is the gateway of subnet %s", ip, subnet.CIDR)).
+				WithDetail("subnet_id", subnet.ID)
+		}
+	}
+
+	assigned, err := subnets.GetAssignedAddresses(ctx, netip.PrefixFrom(address, address.BitLen()).String())
+	if err != nil {
+		return err
+	}
+	for _, a := range assigned {
+		if a.ComputerID != computerID {
+			return errors.NewAppError(errors.ErrorCodeConflict, fmt.Sprintf("IP address %s is already assigned to another computer", ip)).
+				WithDetail("computer_id", a.ComputerID)
+		}
+	}
+	return nil
+}
+
+// mapSubnetRepositoryError translates subnet repository errors into application errors
+func mapSubnetRepositoryError(err error, message string) error {
+	switch {
+	case stderrors.Is(err, repository.ErrSubnetNotFound):
+		return errors.NotFoundError("Subnet")
+	case stderrors.Is(err, repository.ErrSubnetOverlap):
+		return errors.NewAppError(errors.ErrorCodeConflict, "Subnet overlaps an existing subnet")
+	default:
+		return mapRepositoryError(err, message)
+	}
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	apperrors "computer-management-api/pkg/errors"
+	"context"
+	"log/slog"
+	"net/netip"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// mockSubnetRepository keeps subnets and assigned addresses in memory
+type mockSubnetRepository struct {
+	subnets  []model.Subnet
+	assigned []model.IPAddressAssignment
+	locked   []string
+}
+
+func (m *mockSubnetRepository) CreateSubnet(ctx context.Context, subnet model.Subnet) error {
+	for _, s := range m.subnets {
+		if netip.MustParsePrefix(s.CIDR).Overlaps(netip.MustParsePrefix(subnet.CIDR)) {
+			return repository.ErrSubnetOverlap
+		}
+	}
+	m.subnets = append(m.subnets, subnet)
+	return nil
+}
+
+func (m *mockSubnetRepository) GetSubnets(ctx context.Context) ([]model.Subnet, error) {
+	return m.subnets, nil
+}
+
+func (m *mockSubnetRepository) GetSubnetByID(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+	for _, s := range m.subnets {
+		if s.ID == id {
+			return &s, nil
+		}
+	}
+	return nil, repository.ErrSubnetNotFound
+}
+
+func (m *mockSubnetRepository) GetSubnetByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Subnet, error) {
+	return m.GetSubnetByID(ctx, id)
+}
+
+func (m *mockSubnetRepository) GetSubnetContaining(ctx context.Context, ip string) (*model.Subnet, error) {
+	for _, s := range m.subnets {
+		if netip.MustParsePrefix(s.CIDR).Contains(netip.MustParseAddr(ip)) {
+			return &s, nil
+		}
+	}
+	return nil, repository.ErrSubnetNotFound
+}
+
+func (m *mockSubnetRepository) GetSubnetContainingForUpdate(ctx context.Context, ip string) (*model.Subnet, error) {
+	return m.GetSubnetContaining(ctx, ip)
+}
+
+func (m *mockSubnetRepository) CountSubnets(ctx context.Context) (int, error) {
+	return len(m.subnets), nil
+}
+
+func (m *mockSubnetRepository) LockAddress(ctx context.Context, ip string) error {
+	m.locked = append(m.locked, ip)
+	return nil
+}
+
+func (m *mockSubnetRepository) UpdateSubnet(ctx context.Context, subnet model.Subnet) error {
+	for i, s := range m.subnets {
+		if s.ID == subnet.ID {
+			m.subnets[i] = subnet
+			return nil
+		}
+	}
+	return repository.ErrSubnetNotFound
+}
+
+func (m *mockSubnetRepository) DeleteSubnet(ctx context.Context, id uuid.UUID) error {
+	for i, s := range m.subnets {
+		if s.ID == id {
+			m.subnets = append(m.subnets[:i], m.subnets[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrSubnetNotFound
+}
+
+func (m *mockSubnetRepository) GetAssignedAddresses(ctx context.Context, network string) ([]model.IPAddressAssignment, error) {
+	var assigned []model.IPAddressAssignment
+	for _, a := range m.assigned {
+		if netip.MustParsePrefix(network).Contains(netip.MustParseAddr(a.Address)) {
+			assigned = append(assigned, a)
+		}
+	}
+	return assigned, nil
+}
+
+// createTestSubnetService returns a service over a single subnet and a computer outside of it
+func createTestSubnetService(subnet model.Subnet) (*SubnetService, *mockSubnetRepository, *mockComputerRepository, model.Computer) {
+	computer := createTestComputer()
+	computer.IPAddress = "172.16.0.10"
+
+	repo := &mockComputerRepository{}
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		if id != computer.ID {
+			return nil, repository.ErrComputerNotFound
+		}
+		c := computer
+		return &c, nil
+	}
+	repo.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, c model.Computer) error {
+		computer = c
+		return nil
+	}
+
+	subnets := &mockSubnetRepository{subnets: []model.Subnet{subnet}}
+	tx := &mockTransactor{repos: repository.Repositories{Computers: repo, Events: &mockEventRepository{}, Subnets: subnets}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+	return NewSubnetService(subnets, tx, logger), subnets, repo, computer
+}
+
+func TestCreateSubnet_NormalizesCIDR(t *testing.T) {
+	svc, _, _, _ := createTestSubnetService(model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24"})
+
+	created, err := svc.CreateSubnet(context.Background(), model.Subnet{CIDR: "2001:DB8::/64", Gateway: "2001:DB8::1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created.ID == uuid.Nil || created.CIDR != "2001:db8::/64" || created.Gateway != "2001:db8::1" {
+		t.Errorf("Unexpected subnet %+v", created)
+	}
+}
+
+func TestCreateSubnet_Overlap(t *testing.T) {
+	svc, _, _, _ := createTestSubnetService(model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/16"})
+
+	_, err := svc.CreateSubnet(context.Background(), model.Subnet{CIDR: "10.0.5.0/24"})
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeConflict {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+}
+
+func TestCreateSubnet_HostBitsSet(t *testing.T) {
+	svc, _, _, _ := createTestSubnetService(model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24"})
+
+	_, err := svc.CreateSubnet(context.Background(), model.Subnet{CIDR: "192.168.1.1/24"})
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeValidation {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+}
+
+func TestGetUtilization_CountsHostAddresses(t *testing.T) {
+	subnet := model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/29", Gateway: "10.0.0.1"}
+	svc, subnets, _, _ := createTestSubnetService(subnet)
+	subnets.assigned = []model.IPAddressAssignment{
+		{Address: "10.0.0.1", ComputerID: uuid.New()}, // The gateway is reserved, not used
+		{Address: "10.0.0.2", ComputerID: uuid.New()},
+		{Address: "10.0.0.3", ComputerID: uuid.New()},
+	}
+
+	utilization, err := svc.GetUtilization(context.Background(), subnet.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Eight addresses less the network, broadcast and gateway address
+	if utilization.Capacity.Int64() != 5 || utilization.Used != 2 || utilization.Free.Int64() != 3 {
+		t.Errorf("Unexpected utilization %+v", utilization)
+	}
+	if utilization.UtilizationPercent != 40 {
+		t.Errorf("Expected 40%% utilization, got %v", utilization.UtilizationPercent)
+	}
+}
+
+func TestGetUtilization_IPv6Capacity(t *testing.T) {
+	subnet := model.Subnet{ID: uuid.New(), CIDR: "2001:db8::/64"}
+	svc, _, _, _ := createTestSubnetService(subnet)
+
+	utilization, err := svc.GetUtilization(context.Background(), subnet.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// 2^64 addresses less the Subnet-Router anycast address
+	if utilization.Capacity.String() != "18446744073709551615" || utilization.UtilizationPercent != 0 {
+		t.Errorf("Unexpected utilization %+v", utilization)
+	}
+}
+
+func TestAllocateAddress_SkipsGatewayAndUsedAddresses(t *testing.T) {
+	subnet := model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"}
+	svc, subnets, _, computer := createTestSubnetService(subnet)
+	subnets.assigned = []model.IPAddressAssignment{
+		{Address: "10.0.0.2", ComputerID: uuid.New()},
+		{Address: "10.0.0.4", ComputerID: uuid.New()},
+	}
+
+	allocated, err := svc.AllocateAddress(context.Background(), subnet.ID, computer.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if allocated.IPAddress != "10.0.0.3" {
+		t.Errorf("Expected 10.0.0.3, got %s", allocated.IPAddress)
+	}
+}
+
+func TestAllocateAddress_KeepsAddressInSubnet(t *testing.T) {
+	subnet := model.Subnet{ID: uuid.New(), CIDR: "172.16.0.0/24"}
+	svc, _, repo, computer := createTestSubnetService(subnet)
+	repo.UpdateComputerFunc = func(ctx context.Context, id uuid.UUID, c model.Computer) error {
+		t.Error("Expected the computer to keep its address")
+		return nil
+	}
+
+	allocated, err := svc.AllocateAddress(context.Background(), subnet.ID, computer.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if allocated.IPAddress != computer.IPAddress {
+		t.Errorf("Expected %s, got %s", computer.IPAddress, allocated.IPAddress)
+	}
+}
+
+func TestAllocateAddress_SubnetFull(t *testing.T) {
+	subnet := model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/30", Gateway: "10.0.0.1"}
+	svc, subnets, _, computer := createTestSubnetService(subnet)
+	subnets.assigned = []model.IPAddressAssignment{{Address: "10.0.0.2", ComputerID: uuid.New()}}
+
+	_, err := svc.AllocateAddress(context.Background(), subnet.ID, computer.ID)
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeConflict {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+}
+
+func TestAllocateAddress_UnknownSubnet(t *testing.T) {
+	svc, _, _, computer := createTestSubnetService(model.Subnet{ID: uuid.New(), CIDR: "10.0.0.0/24"})
+
+	_, err := svc.AllocateAddress(context.Background(), uuid.New(), computer.ID)
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeNotFound {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestReserveIPAddress(t *testing.T) {
+	computerID := uuid.New()
+	subnets := &mockSubnetRepository{
+		subnets:  []model.Subnet{{ID: uuid.New(), CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"}},
+		assigned: []model.IPAddressAssignment{{Address: "10.0.0.5", ComputerID: uuid.New()}, {Address: "10.0.0.6", ComputerID: computerID}},
+	}
+
+	tests := []struct {
+		name         string
+		ip           string
+		expectedCode apperrors.ErrorCode
+	}{
+		{name: "Free address", ip: "10.0.0.7"},
+		{name: "Own address", ip: "10.0.0.6"},
+		{name: "Taken by another computer", ip: "10.0.0.5", expectedCode: apperrors.ErrorCodeConflict},
+		{name: "Gateway", ip: "10.0.0.1", expectedCode: apperrors.ErrorCodeConflict},
+		{name: "Broadcast address", ip: "10.0.0.255", expectedCode: apperrors.ErrorCodeValidation},
+		{name: "Outside every subnet", ip: "192.168.1.1", expectedCode: apperrors.ErrorCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := reserveIPAddress(context.Background(), subnets, tt.ip, computerID)
+			if tt.expectedCode == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != tt.expectedCode {
+				t.Errorf("Expected %s error, got %v", tt.expectedCode, err)
+			}
+		})
+	}
+
+	// The subnet lock covers its addresses
+	if len(subnets.locked) != 0 {
+		t.Errorf("Expected no address locks, got %v", subnets.locked)
+	}
+}
+
+func TestReserveIPAddress_WithoutSubnets(t *testing.T) {
+	subnets := &mockSubnetRepository{assigned: []model.IPAddressAssignment{{Address: "10.0.0.5", ComputerID: uuid.New()}}}
+
+	if err := reserveIPAddress(context.Background(), subnets, "192.168.1.1", uuid.New()); err != nil {
+		t.Errorf("Expected any address to be accepted without subnets, got %v", err)
+	}
+	err := reserveIPAddress(context.Background(), subnets, "10.0.0.5", uuid.New())
+	if appErr, ok := apperrors.AsAppError(err); !ok || appErr.Code != apperrors.ErrorCodeConflict {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+
+	// Without a subnet to lock, the address itself is locked in its canonical form
+	if err := reserveIPAddress(context.Background(), subnets, "2001:DB8:0::5", uuid.New()); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	expected := []string{"192.168.1.1", "10.0.0.5", "2001:db8::5"}
+	if len(subnets.locked) != len(expected) {
+		t.Fatalf("Expected the addresses %v to be locked, got %v", expected, subnets.locked)
+	}
+	for i, ip := range expected {
+		if subnets.locked[i] != ip {
+			t.Errorf("Expected %s to be locked, got %s", ip, subnets.locked[i])
+		}
+	}
+}
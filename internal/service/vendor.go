@@ -0,0 +1,65 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/oui"
+	"computer-management-api/pkg/validation"
+)
+
+// VendorRegistry identifies the organization a MAC address is registered to
+type VendorRegistry interface {
+	// Lookup returns the organization a MAC address is assigned to, or an empty string
+	Lookup(mac string) string
+	// Prefixes returns the MAC address prefixes of the organizations whose name contains vendor
+	Prefixes(vendor string) []string
+}
+
+// Ensure the registries implement VendorRegistry
+var (
+	_ VendorRegistry = (*oui.Registry)(nil)
+	_ VendorRegistry = (*oui.FileRegistry)(nil)
+)
+
+// describeComputer sets the fields derived from a computer's MAC address
+func describeComputer(vendors VendorRegistry, computer *model.Computer) {
+	computer.Vendor = vendors.Lookup(computer.MACAddress)
+	computer.MACWarnings = validation.MACAddressWarnings(computer.MACAddress)
+}
+
+// describeComputers sets the fields derived from the MAC addresses of computers
+func describeComputers(vendors VendorRegistry, computers []model.Computer) {
+	for i := range computers {
+		describeComputer(vendors, &computers[i])
+	}
+}
+
+// describeInterface sets the fields derived from a network interface's MAC address
+func describeInterface(vendors VendorRegistry, iface *model.NetworkInterface) {
+	iface.Vendor = vendors.Lookup(iface.MACAddress)
+	iface.MACWarnings = validation.MACAddressWarnings(iface.MACAddress)
+}
+
+// macAddressErrors returns the warnings about a valid MAC address as validation errors when
+// reject is set, so multicast and locally administered addresses are refused
+func macAddressErrors(reject bool, mac string) []string {
+	if !reject {
+		return nil
+	}
+	if _, err := validation.ValidateMAC(mac); err != nil {
+		return nil
+	}
+	return validation.MACAddressWarnings(mac)
+}
+
+// resolveVendorFilter narrows a filter by vendor to the MAC address prefixes registered to it.
+// A vendor without prefixes matches no computers.
+func resolveVendorFilter(vendors VendorRegistry, filter *repository.ComputerFilter) {
+	if filter.Vendor == "" {
+		return
+	}
+	filter.MACPrefixes = vendors.Prefixes(filter.Vendor)
+	if filter.MACPrefixes == nil {
+		filter.MACPrefixes = []string{}
+	}
+}
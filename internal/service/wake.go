@@ -0,0 +1,138 @@
+package service
+
+import (
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	"computer-management-api/pkg/errors"
+	"computer-management-api/pkg/validation"
+	"computer-management-api/pkg/wol"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultWakeInterval is how long a computer cannot be woken again by default
+const DefaultWakeInterval = time.Minute
+
+// WakeService wakes computers with Wake-on-LAN magic packets, which are sent to the broadcast
+// address of the subnet a computer's IPv4 address belongs to. Every wake-up is recorded in the
+// audit trail, and a computer can be woken at most once per MinInterval, which is enforced
+// from the audit trail so that it holds across every instance of the API.
+type WakeService struct {
+	tx     repository.Transactor
+	logger *slog.Logger
+
+	// Port is the UDP port magic packets are sent to
+	Port int
+	// MinInterval is how long a computer cannot be woken again after a magic packet was sent to it
+	MinInterval time.Duration
+}
+
+// NewWakeService creates a new Wake-on-LAN service
+func NewWakeService(tx repository.Transactor, logger *slog.Logger) *WakeService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &WakeService{
+		tx:          tx,
+		logger:      logger,
+		Port:        wol.DefaultPort,
+		MinInterval: DefaultWakeInterval,
+	}
+}
+
+// Wake records the wake-up of a computer in the audit trail and then sends it a magic packet.
+// The computer is locked while its last wake-up is checked and the new one is recorded, and the
+// packet is only sent once that transaction has committed. A wake-up whose packet could not be
+// sent stays recorded and counts towards the rate limit.
+func (s *WakeService) Wake(ctx context.Context, id uuid.UUID) (*model.Wake, error) {
+	var computer *model.Computer
+	var broadcast netip.Addr
+	err := s.tx.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		var err error
+		if computer, err = repos.Computers.GetComputerByIDForUpdate(ctx, id); err != nil {
+			return err
+		}
+
+		last, err := repos.Events.GetLatestEvent(ctx, id, model.ComputerEventWoken)
+		if err != nil && !stderrors.Is(err, repository.ErrEventNotFound) {
+			return err
+		}
+		if last != nil {
+			if elapsed := time.Since(last.OccurredAt); elapsed < s.MinInterval {
+				retryAfter := s.MinInterval - elapsed
+				return errors.NewAppError(errors.ErrorCodeRateLimit, fmt.Sprintf("Computer was woken less than %s ago", s.MinInterval)).
+					WithDetail("computer_id", id).
+					WithDetail("retry_after_seconds", int(math.Ceil(retryAfter.Seconds())))
+			}
+		}
+
+		if broadcast, err = wakeBroadcastAddress(ctx, repos, computer); err != nil {
+			return err
+		}
+		return repos.Events.RecordEvent(ctx, newComputerEvent(ctx, model.ComputerEventWoken, nil, computer))
+	})
+	if err != nil {
+		return nil, mapRepositoryError(err, "failed to wake computer")
+	}
+
+	wake := &model.Wake{
+		ComputerID:       computer.ID,
+		MACAddress:       computer.MACAddress,
+		BroadcastAddress: broadcast.String(),
+		Port:             s.Port,
+		Actor:            ActorFromContext(ctx),
+		SentAt:           time.Now(),
+	}
+	if err := wol.Send(ctx, computer.MACAddress, netip.AddrPortFrom(broadcast, uint16(s.Port))); err != nil {
+		return nil, errors.NewAppErrorWithCause(errors.ErrorCodeExternalService, "Failed to send the magic packet", err)
+	}
+
+	s.logger.InfoContext(ctx, "Computer woken", "computer_id", id, "mac_address", wake.MACAddress,
+		"broadcast_address", wake.BroadcastAddress, "port", wake.Port, "actor", wake.Actor)
+
+	return wake, nil
+}
+
+// wakeBroadcastAddress returns the address magic packets for a computer are sent to: the
+// broadcast address of the subnet its IPv4 address belongs to. Computers whose IP address is an
+// IPv6 address are found by the IPv4 address of their primary interface.
+func wakeBroadcastAddress(ctx context.Context, repos repository.Repositories, computer *model.Computer) (netip.Addr, error) {
+	ip := computer.IPAddress
+	if address, err := netip.ParseAddr(ip); err != nil || !address.Is4() {
+		interfaces, err := repos.Interfaces.GetInterfacesByComputer(ctx, computer.ID)
+		if err != nil {
+			return netip.Addr{}, err
+		}
+		ip = ""
+		for _, iface := range interfaces {
+			if iface.Primary {
+				ip = iface.IPv4Address
+			}
+		}
+		if ip == "" {
+			return netip.Addr{}, errors.NewAppError(errors.ErrorCodeConflict, "Computer has no IPv4 address to find its broadcast address by").
+				WithDetail("computer_id", computer.ID)
+		}
+	}
+
+	subnet, err := repos.Subnets.GetSubnetContaining(ctx, ip)
+	if stderrors.Is(err, repository.ErrSubnetNotFound) {
+		return netip.Addr{}, errors.NewAppError(errors.ErrorCodeConflict, fmt.Sprintf("IP address %s of the computer does not belong to any subnet", ip)).
+			WithDetail("computer_id", computer.ID)
+	}
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	if subnet.BroadcastAddress != "" {
+		return netip.ParseAddr(subnet.BroadcastAddress)
+	}
+	return validation.LastAddress(netip.MustParsePrefix(subnet.CIDR)), nil
+}
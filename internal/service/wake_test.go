@@ -0,0 +1,215 @@
+package service
+
+import (
+	"bytes"
+	"computer-management-api/internal/model"
+	"computer-management-api/internal/repository"
+	apperrors "computer-management-api/pkg/errors"
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// listenUDP stands in for the network with a UDP socket on the loopback interface
+func listenUDP(t *testing.T) *net.UDPConn {
+	t.Helper()
+
+	listener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	return listener
+}
+
+// receive returns the next datagram received by a listener
+func receive(t *testing.T, listener *net.UDPConn) []byte {
+	t.Helper()
+
+	if err := listener.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("Failed to set deadline: %v", err)
+	}
+	buf := make([]byte, 1024)
+	n, err := listener.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to receive the magic packet: %v", err)
+	}
+	return buf[:n]
+}
+
+// createTestWakeService returns a service over a computer in a subnet whose broadcast address
+// is a listener on the loopback interface
+func createTestWakeService(t *testing.T) (*WakeService, *mockSubnetRepository, *mockEventRepository, model.Computer, *net.UDPConn) {
+	t.Helper()
+
+	listener := listenUDP(t)
+	computer := createTestComputer()
+	repo := &mockComputerRepository{}
+	repo.GetComputerByIDFunc = func(ctx context.Context, id uuid.UUID) (*model.Computer, error) {
+		if id != computer.ID {
+			return nil, repository.ErrComputerNotFound
+		}
+		c := computer
+		return &c, nil
+	}
+
+	subnets := &mockSubnetRepository{subnets: []model.Subnet{{ID: uuid.New(), CIDR: "192.168.1.0/24", BroadcastAddress: "127.0.0.1"}}}
+	events := &mockEventRepository{}
+	tx := &mockTransactor{repos: repository.Repositories{Computers: repo, Events: events, Subnets: subnets}}
+	logger := slog.New(slog.NewTextHandler(bytes.NewBuffer([]byte{}), nil)) // Silent logger for tests
+
+	svc := NewWakeService(tx, logger)
+	svc.Port = listener.LocalAddr().(*net.UDPAddr).Port
+	return svc, subnets, events, computer, listener
+}
+
+func TestWake_SendsMagicPacket(t *testing.T) {
+	svc, _, events, computer, listener := createTestWakeService(t)
+
+	wake, err := svc.Wake(WithActor(context.Background(), "helpdesk"), computer.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if wake.BroadcastAddress != "127.0.0.1" || wake.Port != svc.Port || wake.Actor != "helpdesk" {
+		t.Errorf("Unexpected wake %+v", wake)
+	}
+
+	packet := receive(t, listener)
+	mac := []byte{0x00, 0x1B, 0x44, 0x11, 0x3A, 0xB7}
+	if len(packet) != 102 || !bytes.Equal(packet[:6], bytes.Repeat([]byte{0xFF}, 6)) || !bytes.Equal(packet[96:], mac) {
+		t.Errorf("Unexpected magic packet % X", packet)
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.EventType != model.ComputerEventWoken || event.ComputerID != computer.ID || event.Actor != "helpdesk" {
+		t.Errorf("Unexpected event %+v", event)
+	}
+}
+
+func TestWake_RateLimited(t *testing.T) {
+	svc, _, events, computer, listener := createTestWakeService(t)
+
+	if _, err := svc.Wake(context.Background(), computer.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	receive(t, listener)
+
+	_, err := svc.Wake(context.Background(), computer.ID)
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok || appErr.Code != apperrors.ErrorCodeRateLimit {
+		t.Fatalf("Expected a rate limit error, got %v", err)
+	}
+	if appErr.Details["retry_after_seconds"] != 60 {
+		t.Errorf("Expected to retry after 60 seconds, got %v", appErr.Details["retry_after_seconds"])
+	}
+	if len(events.events) != 1 {
+		t.Errorf("Expected only the first wake-up to be recorded, got %d events", len(events.events))
+	}
+
+	// The limit comes from the audit trail, so it also holds for other instances of the API
+	other := NewWakeService(svc.tx, svc.logger)
+	if _, err := other.Wake(context.Background(), computer.ID); !isAppError(err, apperrors.ErrorCodeRateLimit) {
+		t.Errorf("Expected a rate limit error from another instance, got %v", err)
+	}
+
+	// Other computers are limited separately, and the limit ends after the interval
+	if _, err := svc.Wake(context.Background(), uuid.New()); !isAppError(err, apperrors.ErrorCodeNotFound) {
+		t.Errorf("Expected not found for another computer, got %v", err)
+	}
+	svc.MinInterval = 0
+	if _, err := svc.Wake(context.Background(), computer.ID); err != nil {
+		t.Errorf("Unexpected error after the interval: %v", err)
+	}
+}
+
+func TestWake_NotInSubnet(t *testing.T) {
+	svc, subnets, events, computer, listener := createTestWakeService(t)
+	subnets.subnets[0].CIDR = "10.0.0.0/8"
+
+	_, err := svc.Wake(context.Background(), computer.ID)
+	if !isAppError(err, apperrors.ErrorCodeConflict) {
+		t.Fatalf("Expected a conflict, got %v", err)
+	}
+	if len(events.events) != 0 {
+		t.Errorf("Expected no events, got %d", len(events.events))
+	}
+
+	// A failed attempt does not count towards the rate limit
+	subnets.subnets[0].CIDR = "192.168.1.0/24"
+	if _, err := svc.Wake(context.Background(), computer.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	receive(t, listener)
+}
+
+func TestWake_SendFailed(t *testing.T) {
+	svc, subnets, events, computer, _ := createTestWakeService(t)
+	subnets.subnets[0].BroadcastAddress = "::1" // Magic packets are only sent over IPv4
+
+	_, err := svc.Wake(context.Background(), computer.ID)
+	if !isAppError(err, apperrors.ErrorCodeExternalService) {
+		t.Fatalf("Expected an external service error, got %v", err)
+	}
+
+	// The packet is sent once the wake-up is committed, so the failed attempt stays recorded
+	if len(events.events) != 1 || events.events[0].EventType != model.ComputerEventWoken {
+		t.Errorf("Expected the wake-up to be recorded, got %+v", events.events)
+	}
+	if _, err := svc.Wake(context.Background(), computer.ID); !isAppError(err, apperrors.ErrorCodeRateLimit) {
+		t.Errorf("Expected a rate limit error, got %v", err)
+	}
+}
+
+func TestWakeBroadcastAddress(t *testing.T) {
+	computer := createTestComputer()
+	primary := model.NetworkInterface{ID: uuid.New(), ComputerID: computer.ID, IPv4Address: "192.168.1.100", IPv6Address: "2001:db8::100", Primary: true}
+	repos := repository.Repositories{
+		Interfaces: &mockNetworkInterfaceRepository{interfaces: map[uuid.UUID]model.NetworkInterface{primary.ID: primary}},
+		Subnets:    &mockSubnetRepository{subnets: []model.Subnet{{ID: uuid.New(), CIDR: "192.168.1.0/25"}}},
+	}
+
+	tests := []struct {
+		name      string
+		ipAddress string
+		expected  string
+	}{
+		{name: "Directed broadcast address", ipAddress: "192.168.1.100", expected: "192.168.1.127"},
+		{name: "IPv4 address of the primary interface", ipAddress: "2001:db8::100", expected: "192.168.1.127"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := computer
+			c.IPAddress = tt.ipAddress
+			broadcast, err := wakeBroadcastAddress(context.Background(), repos, &c)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if broadcast.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, broadcast)
+			}
+		})
+	}
+
+	// Without an IPv4 address there is no broadcast address
+	primary.IPv4Address = ""
+	repos.Interfaces = &mockNetworkInterfaceRepository{interfaces: map[uuid.UUID]model.NetworkInterface{primary.ID: primary}}
+	c := computer
+	c.IPAddress = "2001:db8::100"
+	if _, err := wakeBroadcastAddress(context.Background(), repos, &c); !isAppError(err, apperrors.ErrorCodeConflict) {
+		t.Errorf("Expected a conflict, got %v", err)
+	}
+}
+
+// isAppError reports whether err is an application error with a code
+func isAppError(err error, code apperrors.ErrorCode) bool {
+	appErr, ok := apperrors.AsAppError(err)
+	return ok && appErr.Code == code
+}
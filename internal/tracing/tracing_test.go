@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"computer-management-api/internal/config"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup_FileExporter(t *testing.T) {
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+
+	path := filepath.Join(t.TempDir(), "spans.json")
+	shutdown, err := Setup(context.Background(), config.TracingConfig{
+		Exporter:    "file",
+		FilePath:    path,
+		ServiceName: "test-service",
+		SampleRatio: 1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "test-span")
+	traceID := TraceID(ctx)
+	span.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("Failed to shut down tracing: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read spans: %v", err)
+	}
+	for _, expected := range []string{`"Name":"test-span"`, traceID, "test-service"} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected %s in the exported spans, got %s", expected, data)
+		}
+	}
+}
+
+func TestSetup_None(t *testing.T) {
+	shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: "none"})
+	if err != nil {
+		t.Fatalf("Failed to set up tracing: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if traceID := TraceID(context.Background()); traceID != "" {
+		t.Errorf("Expected no trace ID outside a trace, got %s", traceID)
+	}
+}
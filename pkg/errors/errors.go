@@ -20,6 +20,10 @@ const (
 	ErrorCodeForbidden     ErrorCode = "FORBIDDEN"
 	ErrorCodeConflict      ErrorCode = "CONFLICT"
 
+	// Conditional request errors
+	ErrorCodePreconditionFailed   ErrorCode = "PRECONDITION_FAILED"
+	ErrorCodePreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
+
 	// Technical errors
 	ErrorCodeInternal        ErrorCode = "INTERNAL_ERROR"
 	ErrorCodeDatabase        ErrorCode = "DATABASE_ERROR"
@@ -42,6 +46,7 @@ type AppError struct {
 	Cause      error                  `json:"-"`
 	Timestamp  time.Time              `json:"timestamp"`
 	RequestID  string                 `json:"request_id,omitempty"`
+	TraceID    string                 `json:"trace_id,omitempty"`
 	StackTrace string                 `json:"-"` // Don't expose in JSON
 }
 
@@ -60,13 +65,17 @@ func (e *AppError) Unwrap() error {
 
 // ToJSON converts the error to JSON for API responses
 func (e *AppError) ToJSON() []byte {
-	data, _ := json.Marshal(map[string]interface{}{
+	response := map[string]interface{}{
 		"error":      e.Message,
 		"code":       e.Code,
 		"details":    e.Details,
 		"timestamp":  e.Timestamp,
 		"request_id": e.RequestID,
-	})
+	}
+	if e.TraceID != "" {
+		response["trace_id"] = e.TraceID
+	}
+	data, _ := json.Marshal(response)
 	return data
 }
 
@@ -80,6 +89,10 @@ func (e *AppError) GetHTTPStatus() int {
 		return http.StatusNotFound
 	case ErrorCodeAlreadyExists, ErrorCodeConflict:
 		return http.StatusConflict
+	case ErrorCodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case ErrorCodePreconditionRequired:
+		return http.StatusPreconditionRequired
 	case ErrorCodeUnauthorized:
 		return http.StatusUnauthorized
 	case ErrorCodeForbidden:
@@ -133,6 +146,12 @@ func (e *AppError) WithRequestID(requestID string) *AppError {
 	return e
 }
 
+// WithTraceID adds the trace ID of the request to the error
+func (e *AppError) WithTraceID(traceID string) *AppError {
+	e.TraceID = traceID
+	return e
+}
+
 // getStackTrace captures the current stack trace
 func getStackTrace() string {
 	buf := make([]byte, 2048)
@@ -166,6 +185,26 @@ func AlreadyExistsError(resource string) *AppError {
 	return NewAppError(ErrorCodeAlreadyExists, fmt.Sprintf("%s already exists", resource))
 }
 
+// PreconditionFailedError creates an error for a conditional request whose resource has changed
+func PreconditionFailedError(resource string) *AppError {
+	return NewAppError(ErrorCodePreconditionFailed, fmt.Sprintf("%s has been modified since it was retrieved", resource))
+}
+
+// PreconditionRequiredError creates an error for an unconditional request that must be conditional
+func PreconditionRequiredError(header string) *AppError {
+	return NewAppError(ErrorCodePreconditionRequired, fmt.Sprintf("%s header is required", header))
+}
+
+// UnauthorizedError creates an error for a request without valid credentials
+func UnauthorizedError(message string) *AppError {
+	return NewAppError(ErrorCodeUnauthorized, message)
+}
+
+// ForbiddenError creates an error for credentials that do not grant the required permission
+func ForbiddenError(message string) *AppError {
+	return NewAppError(ErrorCodeForbidden, message)
+}
+
 // DatabaseError creates a database error
 func DatabaseError(message string, cause error) *AppError {
 	return NewAppErrorWithCause(ErrorCodeDatabase, message, cause)
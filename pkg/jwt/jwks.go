@@ -0,0 +1,270 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxKeySetSize limits the size of a JSON Web Key Set
+const maxKeySetSize = 1 << 20
+
+// jsonWebKey is a public key of a JSON Web Key Set
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+
+	// RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// Elliptic curve keys
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+// verificationKey is a parsed signature verification key
+type verificationKey struct {
+	id        string
+	algorithm string // Empty when the key may be used with any algorithm of its type
+	key       crypto.PublicKey
+}
+
+// KeySet is a parsed JSON Web Key Set
+type KeySet struct {
+	keys []verificationKey
+}
+
+// ParseKeySet parses a JSON Web Key Set. Keys for encryption and of unsupported types are
+// skipped, but a set without any usable key is an error.
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("jwt: invalid key set: %w", err)
+	}
+
+	set := &KeySet{}
+	for i, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var key crypto.PublicKey
+		var err error
+		switch jwk.KeyType {
+		case "RSA":
+			key, err = parseRSAKey(jwk)
+		case "EC":
+			key, err = parseECKey(jwk)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("jwt: invalid key %d (%s): %w", i, jwk.KeyID, err)
+		}
+		set.keys = append(set.keys, verificationKey{id: jwk.KeyID, algorithm: jwk.Algorithm, key: key})
+	}
+
+	if len(set.keys) == 0 {
+		return nil, errors.New("jwt: key set contains no signature verification keys")
+	}
+	return set, nil
+}
+
+// LoadKeySetFile reads a JSON Web Key Set from a file
+func LoadKeySetFile(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: failed to read key set: %w", err)
+	}
+	return ParseKeySet(data)
+}
+
+// PublicKey returns the key with the given ID. Tokens without a key ID can only be verified
+// by a set with a single key.
+func (s *KeySet) PublicKey(ctx context.Context, kid, alg string) (crypto.PublicKey, error) {
+	if kid == "" {
+		if len(s.keys) == 1 && (s.keys[0].algorithm == "" || s.keys[0].algorithm == alg) {
+			return s.keys[0].key, nil
+		}
+		return nil, ErrUnknownKey
+	}
+
+	for _, key := range s.keys {
+		if key.id == kid && (key.algorithm == "" || key.algorithm == alg) {
+			return key.key, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// parseRSAKey parses the modulus and exponent of an RSA key
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	if n.BitLen() < 2048 {
+		return nil, errors.New("RSA keys must have at least 2048 bits")
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid RSA exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// parseECKey parses the curve point of an elliptic curve key and checks that it is on the curve
+func parseECKey(jwk jsonWebKey) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	var validator ecdh.Curve
+	switch jwk.Curve {
+	case "P-256":
+		curve, validator = elliptic.P256(), ecdh.P256()
+	case "P-384":
+		curve, validator = elliptic.P384(), ecdh.P384()
+	case "P-521":
+		curve, validator = elliptic.P521(), ecdh.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", jwk.Curve)
+	}
+
+	x, err := decodeBigInt(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeBigInt(jwk.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the point with its uncompressed encoding: 0x04 || X || Y
+	size := (curve.Params().BitSize + 7) / 8
+	if len(x.Bytes()) > size || len(y.Bytes()) > size {
+		return nil, errors.New("invalid curve point")
+	}
+	point := make([]byte, 1+2*size)
+	point[0] = 4
+	x.FillBytes(point[1 : 1+size])
+	y.FillBytes(point[1+size:])
+	if _, err := validator.NewPublicKey(point); err != nil {
+		return nil, errors.New("invalid curve point")
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// decodeBigInt decodes a base64url encoded unsigned big-endian integer
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// minRefreshInterval limits how often an unknown key ID can trigger a refresh of a remote set
+const minRefreshInterval = time.Minute
+
+// RemoteKeySet fetches a JSON Web Key Set from a URL, typically the identity provider's
+// jwks_uri. The set is refreshed periodically and when a token names an unknown key, so keys
+// rotated by the provider are picked up.
+type RemoteKeySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+
+	mu          sync.Mutex
+	set         *KeySet
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// NewRemoteKeySet creates a key set that is fetched from url with client. Nothing is fetched
+// until the first key is needed.
+func NewRemoteKeySet(url string, client *http.Client, refreshInterval time.Duration) *RemoteKeySet {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &RemoteKeySet{
+		url:             url,
+		client:          client,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// PublicKey returns the key with the given ID, fetching the key set if needed
+func (s *RemoteKeySet) PublicKey(ctx context.Context, kid, alg string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.set == nil || now.Sub(s.fetchedAt) >= s.refreshInterval {
+		if err := s.refresh(ctx, now); err != nil && s.set == nil {
+			return nil, err
+		}
+	}
+
+	key, err := s.set.PublicKey(ctx, kid, alg)
+	if errors.Is(err, ErrUnknownKey) && now.Sub(s.attemptedAt) >= minRefreshInterval {
+		if err := s.refresh(ctx, now); err != nil {
+			return nil, err
+		}
+		return s.set.PublicKey(ctx, kid, alg)
+	}
+	return key, err
+}
+
+// refresh fetches the key set. The previous set is kept if fetching fails.
+func (s *RemoteKeySet) refresh(ctx context.Context, now time.Time) error {
+	s.attemptedAt = now
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("jwt: failed to fetch key set: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("jwt: failed to fetch key set: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwt: failed to fetch key set: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxKeySetSize))
+	if err != nil {
+		return fmt.Errorf("jwt: failed to fetch key set: %w", err)
+	}
+
+	set, err := ParseKeySet(data)
+	if err != nil {
+		return err
+	}
+	s.set = set
+	s.fetchedAt = now
+	return nil
+}
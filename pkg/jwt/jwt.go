@@ -0,0 +1,316 @@
+// Package jwt verifies JSON Web Tokens signed with RSA or ECDSA keys, as issued by OpenID
+// Connect identity providers. Keys are read from JSON Web Key Sets (RFC 7517).
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers SHA-256 for crypto.Hash
+	_ "crypto/sha512" // Registers SHA-384 and SHA-512 for crypto.Hash
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Errors returned when a token is rejected
+var (
+	ErrMalformed            = errors.New("jwt: malformed token")
+	ErrUnsupportedAlgorithm = errors.New("jwt: unsupported signing algorithm")
+	ErrUnknownKey           = errors.New("jwt: unknown signing key")
+	ErrInvalidSignature     = errors.New("jwt: invalid signature")
+	ErrExpired              = errors.New("jwt: token has expired")
+	ErrNotYetValid          = errors.New("jwt: token is not valid yet")
+	ErrInvalidIssuer        = errors.New("jwt: invalid issuer")
+	ErrInvalidAudience      = errors.New("jwt: invalid audience")
+)
+
+// algorithm describes a supported JWS signing algorithm
+type algorithm struct {
+	hash crypto.Hash
+	kty  string // Key type the algorithm requires
+	pss  bool
+}
+
+// algorithms are the supported asymmetric signing algorithms. Symmetric algorithms and "none"
+// are deliberately absent.
+var algorithms = map[string]algorithm{
+	"RS256": {crypto.SHA256, "RSA", false},
+	"RS384": {crypto.SHA384, "RSA", false},
+	"RS512": {crypto.SHA512, "RSA", false},
+	"PS256": {crypto.SHA256, "RSA", true},
+	"PS384": {crypto.SHA384, "RSA", true},
+	"PS512": {crypto.SHA512, "RSA", true},
+	"ES256": {crypto.SHA256, "EC", false},
+	"ES384": {crypto.SHA384, "EC", false},
+	"ES512": {crypto.SHA512, "EC", false},
+}
+
+// Header is the JOSE header of a token
+type Header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid,omitempty"`
+	Type      string `json:"typ,omitempty"`
+}
+
+// KeyProvider returns the public key a token was signed with
+type KeyProvider interface {
+	PublicKey(ctx context.Context, kid, alg string) (crypto.PublicKey, error)
+}
+
+// Validator verifies tokens and their registered claims
+type Validator struct {
+	Keys KeyProvider
+	// Issuer must equal the iss claim when set
+	Issuer string
+	// Audience must be one of the aud claim's values when set
+	Audience string
+	// Leeway tolerates clock skew when checking exp and nbf
+	Leeway time.Duration
+	// Now returns the current time; time.Now when nil
+	Now func() time.Time
+}
+
+// Verify checks the signature, expiry, issuer and audience of a compact serialized token and
+// returns its claims. Tokens without an expiry are rejected.
+func (v *Validator) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var header Header
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	alg, ok := algorithms[header.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	key, err := v.Keys.PublicKey(ctx, header.KeyID, header.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// validateClaims checks the registered time, issuer and audience claims
+func (v *Validator) validateClaims(claims Claims) error {
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+
+	expiresAt, ok := claims.Time("exp")
+	if !ok {
+		return fmt.Errorf("%w: missing exp claim", ErrMalformed)
+	}
+	if !now.Before(expiresAt.Add(v.Leeway)) {
+		return ErrExpired
+	}
+	if notBefore, ok := claims.Time("nbf"); ok && now.Add(v.Leeway).Before(notBefore) {
+		return ErrNotYetValid
+	}
+
+	if v.Issuer != "" && claims.String("iss") != v.Issuer {
+		return ErrInvalidIssuer
+	}
+	if v.Audience != "" {
+		for _, audience := range claims.Strings("aud") {
+			if audience == v.Audience {
+				return nil
+			}
+		}
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
+// verifySignature checks a signature made with alg by the holder of key
+func verifySignature(alg algorithm, key crypto.PublicKey, signed, signature []byte) error {
+	hasher := alg.hash.New()
+	hasher.Write(signed)
+	digest := hasher.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if alg.kty != "RSA" {
+			return ErrUnknownKey
+		}
+		var err error
+		if alg.pss {
+			err = rsa.VerifyPSS(k, alg.hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			err = rsa.VerifyPKCS1v15(k, alg.hash, digest, signature)
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	case *ecdsa.PublicKey:
+		if alg.kty != "EC" {
+			return ErrUnknownKey
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	default:
+		return ErrUnknownKey
+	}
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformed
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return ErrMalformed
+	}
+	return nil
+}
+
+// Claims are the claims of a verified token
+type Claims map[string]interface{}
+
+// Lookup returns the claim at a dot-separated path, such as realm_access.roles
+func (c Claims) Lookup(path string) (interface{}, bool) {
+	var value interface{} = map[string]interface{}(c)
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// String returns a string claim, or "" if it is missing or not a string
+func (c Claims) String(path string) string {
+	value, _ := c.Lookup(path)
+	s, _ := value.(string)
+	return s
+}
+
+// Strings returns a claim that is a string or an array of strings, such as aud
+func (c Claims) Strings(path string) []string {
+	value, _ := c.Lookup(path)
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// Time returns a NumericDate claim such as exp
+func (c Claims) Time(path string) (time.Time, bool) {
+	value, _ := c.Lookup(path)
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), true
+}
+
+// Sign creates a compact serialized token with the given claims, signed by key with alg. It
+// allows tests and tools to issue tokens that Verify accepts.
+func Sign(alg, kid string, key crypto.Signer, claims interface{}) (string, error) {
+	a, ok := algorithms[alg]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, alg)
+	}
+
+	header, err := json.Marshal(Header{Algorithm: alg, KeyID: kid, Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	hasher := a.hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if a.kty != "RSA" {
+			return "", ErrUnknownKey
+		}
+		if a.pss {
+			signature, err = rsa.SignPSS(rand.Reader, k, a.hash, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, k, a.hash, digest)
+		}
+	case *ecdsa.PrivateKey:
+		if a.kty != "EC" {
+			return "", ErrUnknownKey
+		}
+		var r, s *big.Int
+		if r, s, err = ecdsa.Sign(rand.Reader, k, digest); err == nil {
+			size := (k.Curve.Params().BitSize + 7) / 8
+			signature = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+		}
+	default:
+		return "", ErrUnknownKey
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
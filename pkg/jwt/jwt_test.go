@@ -0,0 +1,190 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var (
+	testRSAKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	testECKey, _  = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+)
+
+// testKeySet returns a key set with the test RSA key as "rsa-1" and the test EC key as "ec-1"
+func testKeySet(t *testing.T) []byte {
+	t.Helper()
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "alg": "RS256", "n": encode(testRSAKey.N.Bytes()), "e": encode(big.NewInt(int64(testRSAKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": encode(testECKey.X.FillBytes(make([]byte, 32))), "y": encode(testECKey.Y.FillBytes(make([]byte, 32)))},
+			{"kty": "oct", "kid": "secret", "k": "c2VjcmV0"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// sign creates a token signed with the test key for alg, or an unsigned one for other algorithms
+func sign(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	var key crypto.Signer = testRSAKey
+	switch alg {
+	case "ES256":
+		key = testECKey
+	case "RS256":
+	default:
+		header, _ := json.Marshal(Header{Algorithm: alg, KeyID: kid})
+		payload, _ := json.Marshal(claims)
+		return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + ".c2ln"
+	}
+
+	token, err := Sign(alg, kid, key, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestValidator_Verify(t *testing.T) {
+	keys, err := ParseKeySet(testKeySet(t))
+	if err != nil {
+		t.Fatalf("Failed to parse key set: %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	validator := &Validator{Keys: keys, Issuer: "https://idp.example.com", Audience: "computer-api", Leeway: time.Minute, Now: func() time.Time { return now }}
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{"iss": "https://idp.example.com", "aud": []string{"other", "computer-api"}, "sub": "jdoe", "exp": now.Add(time.Hour).Unix()}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	valid := sign(t, "RS256", "rsa-1", claims(nil))
+	tampered := strings.Split(valid, ".")
+	tampered[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","exp":9999999999}`))
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"RSA", valid, nil},
+		{"ECDSA", sign(t, "ES256", "ec-1", claims(nil)), nil},
+		{"within leeway", sign(t, "RS256", "rsa-1", claims(map[string]interface{}{"exp": now.Add(-30 * time.Second).Unix()})), nil},
+		{"expired", sign(t, "RS256", "rsa-1", claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})), ErrExpired},
+		{"missing expiry", sign(t, "RS256", "rsa-1", claims(map[string]interface{}{"exp": nil})), ErrMalformed},
+		{"not yet valid", sign(t, "RS256", "rsa-1", claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})), ErrNotYetValid},
+		{"wrong issuer", sign(t, "RS256", "rsa-1", claims(map[string]interface{}{"iss": "https://evil.example.com"})), ErrInvalidIssuer},
+		{"wrong audience", sign(t, "RS256", "rsa-1", claims(map[string]interface{}{"aud": "other"})), ErrInvalidAudience},
+		{"unknown key", sign(t, "RS256", "rsa-2", claims(nil)), ErrUnknownKey},
+		{"key used with another algorithm", sign(t, "ES256", "rsa-1", claims(nil)), ErrUnknownKey},
+		{"none algorithm", sign(t, "none", "", claims(nil)), ErrUnsupportedAlgorithm},
+		{"symmetric algorithm", sign(t, "HS256", "secret", claims(nil)), ErrUnsupportedAlgorithm},
+		{"tampered payload", strings.Join(tampered, "."), ErrInvalidSignature},
+		{"malformed", "not-a-token", ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := validator.Verify(context.Background(), tt.token)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if tt.err == nil && claims.String("sub") != "jdoe" {
+				t.Errorf("Expected the subject claim, got %v", claims)
+			}
+		})
+	}
+}
+
+func TestClaims(t *testing.T) {
+	claims := Claims{
+		"roles":        []interface{}{"viewer", 7, "it-admin"},
+		"realm_access": map[string]interface{}{"roles": []interface{}{"employee"}},
+		"group":        "staff",
+		"exp":          json.Number("1700000000.5"),
+	}
+
+	if roles := claims.Strings("roles"); len(roles) != 2 || roles[1] != "it-admin" {
+		t.Errorf("Expected the string roles, got %v", roles)
+	}
+	if roles := claims.Strings("realm_access.roles"); len(roles) != 1 || roles[0] != "employee" {
+		t.Errorf("Expected the nested roles, got %v", roles)
+	}
+	if groups := claims.Strings("group"); len(groups) != 1 {
+		t.Errorf("Expected a single string as a list, got %v", groups)
+	}
+	if claims.String("realm_access.missing") != "" || claims.Strings("group.name") != nil {
+		t.Error("Expected missing claims to be empty")
+	}
+	if exp, ok := claims.Time("exp"); !ok || exp.UnixMilli() != 1700000000500 {
+		t.Errorf("Expected a fractional NumericDate, got %v", exp)
+	}
+}
+
+func TestParseKeySet_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not JSON":        `keys`,
+		"no usable keys":  `{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`,
+		"short RSA key":   `{"keys": [{"kty": "RSA", "n": "AQAB", "e": "AQAB"}]}`,
+		"point off curve": `{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`,
+	}
+
+	for name, data := range tests {
+		if _, err := ParseKeySet([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	var requests int32
+	keySet := []byte(`{"keys": []}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write(keySet)
+	}))
+	defer server.Close()
+
+	remote := NewRemoteKeySet(server.URL, server.Client(), time.Hour)
+
+	// The provider has not published a usable key yet
+	if _, err := remote.PublicKey(context.Background(), "rsa-1", "RS256"); err == nil {
+		t.Fatal("Expected an error for an empty key set")
+	}
+
+	keySet = testKeySet(t)
+	remote.attemptedAt = time.Time{} // Allow another fetch right away
+	if _, err := remote.PublicKey(context.Background(), "rsa-1", "RS256"); err != nil {
+		t.Fatalf("Expected the key after it was published, got %v", err)
+	}
+	if _, err := remote.PublicKey(context.Background(), "ec-1", "ES256"); err != nil {
+		t.Fatalf("Expected the cached key set to be used, got %v", err)
+	}
+
+	// Unknown keys trigger at most one refresh per interval
+	remote.PublicKey(context.Background(), "rotated", "RS256")
+	remote.PublicKey(context.Background(), "rotated", "RS256")
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("Expected 2 key set requests, got %d", n)
+	}
+}
@@ -0,0 +1,421 @@
+// Package netconfig renders static DHCP reservations and DNS zone files from an inventory of
+// hosts. The output depends only on the hosts, not on their order or the time it is rendered,
+// so generated files can be kept in version control and diffed.
+package netconfig
+
+import (
+	"bufio"
+	"computer-management-api/pkg/validation"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Media types of the rendered files
+const (
+	TextMediaType = "text/plain; charset=utf-8"
+	JSONMediaType = "application/json"
+)
+
+// header is the first line of the files that allow comments
+const header = "Generated from the computer inventory. Do not edit."
+
+// SOA timers of zone files, in seconds
+const (
+	soaRefresh     = 3600
+	soaRetry       = 900
+	soaExpire      = 1209600
+	soaNegativeTTL = 300
+)
+
+// Suffixes of reverse DNS zones
+const (
+	ipv4ReverseSuffix = ".in-addr.arpa"
+	ipv6ReverseSuffix = ".ip6.arpa"
+)
+
+// ErrUnknownFormat is returned for DHCP formats other than the supported ones
+var ErrUnknownFormat = errors.New("netconfig: unknown DHCP format")
+
+// Host is a computer with its network interfaces. Name must be a DNS-safe hostname.
+type Host struct {
+	Name       string
+	Interfaces []Interface
+	// UpdatedAt is the time of the latest change to the host or its interfaces
+	UpdatedAt time.Time
+}
+
+// Interface is a network interface of a host. Addresses that are not set are the zero Addr.
+type Interface struct {
+	MACAddress  string
+	IPv4Address netip.Addr
+	IPv6Address netip.Addr
+	Primary     bool
+}
+
+// primary returns the primary interface of a host
+func (h Host) primary() (Interface, bool) {
+	for _, iface := range h.Interfaces {
+		if iface.Primary {
+			return iface, true
+		}
+	}
+	return Interface{}, false
+}
+
+// DHCPFormat is a DHCP server configuration format
+type DHCPFormat string
+
+const (
+	// DHCPFormatISC renders ISC dhcpd host declarations
+	DHCPFormatISC DHCPFormat = "isc"
+	// DHCPFormatDnsmasq renders dnsmasq dhcp-host options
+	DHCPFormatDnsmasq DHCPFormat = "dnsmasq"
+	// DHCPFormatKea renders a Kea DHCPv4 reservations list
+	DHCPFormatKea DHCPFormat = "kea"
+)
+
+// DHCPFormats are the supported DHCP formats
+var DHCPFormats = []DHCPFormat{DHCPFormatISC, DHCPFormatDnsmasq, DHCPFormatKea}
+
+// MediaType returns the media type of files in the format
+func (f DHCPFormat) MediaType() string {
+	if f == DHCPFormatKea {
+		return JSONMediaType
+	}
+	return TextMediaType
+}
+
+// Filename returns the name of the file the format is downloaded as
+func (f DHCPFormat) Filename() string {
+	switch f {
+	case DHCPFormatISC:
+		return "dhcpd-hosts.conf"
+	case DHCPFormatDnsmasq:
+		return "dnsmasq-hosts.conf"
+	default:
+		return "kea-reservations.json"
+	}
+}
+
+// reservation is a static DHCPv4 lease of an interface
+type reservation struct {
+	name       string
+	hostname   string
+	macAddress string
+	ipAddress  netip.Addr
+}
+
+// reservations returns a reservation for every interface with an IPv4 address, ordered by
+// address. Only the primary interface carries the hostname. Reservation names are the hostname
+// for primary interfaces of uniquely named hosts and otherwise also contain the MAC address.
+func reservations(hosts []Host) []reservation {
+	names := make(map[string]int, len(hosts))
+	for _, h := range hosts {
+		names[strings.ToLower(h.Name)]++
+	}
+
+	var result []reservation
+	for _, h := range hosts {
+		hostname := strings.ToLower(h.Name)
+		for _, iface := range h.Interfaces {
+			if !iface.IPv4Address.IsValid() {
+				continue
+			}
+			mac := strings.ToLower(iface.MACAddress)
+			r := reservation{
+				name:       hostname + "-" + strings.ReplaceAll(mac, ":", ""),
+				macAddress: mac,
+				ipAddress:  iface.IPv4Address,
+			}
+			if iface.Primary {
+				r.hostname = hostname
+				if names[hostname] == 1 {
+					r.name = hostname
+				}
+			}
+			result = append(result, r)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if c := result[i].ipAddress.Compare(result[j].ipAddress); c != 0 {
+			return c < 0
+		}
+		return result[i].macAddress < result[j].macAddress
+	})
+	return result
+}
+
+// WriteDHCP writes a DHCPv4 reservation for every interface of the hosts with an IPv4 address
+func WriteDHCP(w io.Writer, format DHCPFormat, hosts []Host) error {
+	switch format {
+	case DHCPFormatISC:
+		return writeISC(w, reservations(hosts))
+	case DHCPFormatDnsmasq:
+		return writeDnsmasq(w, reservations(hosts))
+	case DHCPFormatKea:
+		return writeKea(w, reservations(hosts))
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// writeISC writes ISC dhcpd host declarations
+func writeISC(w io.Writer, reservations []reservation) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# %s\n", header)
+	for _, r := range reservations {
+		fmt.Fprintf(bw, "\nhost %s {\n", r.name)
+		fmt.Fprintf(bw, "\thardware ethernet %s;\n", r.macAddress)
+		fmt.Fprintf(bw, "\tfixed-address %s;\n", r.ipAddress)
+		if r.hostname != "" {
+			fmt.Fprintf(bw, "\toption host-name \"%s\";\n", r.hostname)
+		}
+		fmt.Fprint(bw, "}\n")
+	}
+	return bw.Flush()
+}
+
+// writeDnsmasq writes dnsmasq dhcp-host options
+func writeDnsmasq(w io.Writer, reservations []reservation) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# %s\n", header)
+	for _, r := range reservations {
+		fmt.Fprintf(bw, "dhcp-host=%s,%s", r.macAddress, r.ipAddress)
+		if r.hostname != "" {
+			fmt.Fprintf(bw, ",%s", r.hostname)
+		}
+		fmt.Fprint(bw, "\n")
+	}
+	return bw.Flush()
+}
+
+// keaReservation is a host reservation of the Kea DHCPv4 server
+type keaReservation struct {
+	HWAddress string `json:"hw-address"`
+	IPAddress string `json:"ip-address"`
+	Hostname  string `json:"hostname,omitempty"`
+}
+
+// writeKea writes a Kea DHCPv4 reservations list, to be included in a subnet4 or the global scope
+func writeKea(w io.Writer, reservations []reservation) error {
+	document := struct {
+		Reservations []keaReservation `json:"reservations"`
+	}{Reservations: make([]keaReservation, 0, len(reservations))}
+
+	for _, r := range reservations {
+		document.Reservations = append(document.Reservations, keaReservation{
+			HWAddress: r.macAddress,
+			IPAddress: r.ipAddress.String(),
+			Hostname:  r.hostname,
+		})
+	}
+
+	encoded, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(encoded, '\n'))
+	return err
+}
+
+// Zone describes a DNS zone file. Forward zones get A and AAAA records of the hosts, reverse
+// zones (below in-addr.arpa or ip6.arpa) PTR records of the addresses they contain.
+type Zone struct {
+	// Name is the zone apex, such as example.com or 1.168.192.in-addr.arpa
+	Name string
+	// Domain is the forward zone the PTR records of a reverse zone point into
+	Domain string
+	// NameServer and Hostmaster are the primary name server and contact of the SOA record,
+	// relative to the zone unless they end with a dot
+	NameServer string
+	Hostmaster string
+	// TTL is the default TTL of the records
+	TTL time.Duration
+	// Serial is the SOA serial; Serial(hosts, RemovedAt) is used when it is 0
+	Serial uint32
+	// RemovedAt is when a host was last removed, which advances the derived serial
+	RemovedAt time.Time
+}
+
+// ParseZoneName validates a zone name and returns it in lower case without a trailing dot
+func ParseZoneName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if err := validation.ValidateDomainName(name); err != nil {
+		return "", err
+	}
+	if _, reverse, err := reverseZonePrefix(name); reverse && err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// IsReverseZone reports whether a zone name is below in-addr.arpa or ip6.arpa
+func IsReverseZone(name string) bool {
+	_, reverse, _ := reverseZonePrefix(name)
+	return reverse
+}
+
+// reverseZonePrefix returns the network a reverse zone is for. Only zones on octet and nibble
+// boundaries are supported.
+func reverseZonePrefix(name string) (netip.Prefix, bool, error) {
+	switch {
+	case strings.HasSuffix(name, ipv4ReverseSuffix):
+		labels := strings.Split(strings.TrimSuffix(name, ipv4ReverseSuffix), ".")
+		if len(labels) > 4 {
+			return netip.Prefix{}, true, fmt.Errorf("reverse zone %s has more than 4 octets", name)
+		}
+		var octets [4]byte
+		for i, label := range labels {
+			value, err := strconv.ParseUint(label, 10, 8)
+			if err != nil || strconv.FormatUint(value, 10) != label {
+				return netip.Prefix{}, true, fmt.Errorf("reverse zone %s has an invalid octet %q", name, label)
+			}
+			octets[len(labels)-1-i] = byte(value)
+		}
+		return netip.PrefixFrom(netip.AddrFrom4(octets), 8*len(labels)), true, nil
+
+	case strings.HasSuffix(name, ipv6ReverseSuffix):
+		labels := strings.Split(strings.TrimSuffix(name, ipv6ReverseSuffix), ".")
+		if len(labels) > 32 {
+			return netip.Prefix{}, true, fmt.Errorf("reverse zone %s has more than 32 nibbles", name)
+		}
+		var bytes [16]byte
+		for i, label := range labels {
+			value, err := strconv.ParseUint(label, 16, 4)
+			if err != nil || len(label) != 1 {
+				return netip.Prefix{}, true, fmt.Errorf("reverse zone %s has an invalid nibble %q", name, label)
+			}
+			nibble := len(labels) - 1 - i
+			bytes[nibble/2] |= byte(value) << (4 * (1 - nibble%2))
+		}
+		return netip.PrefixFrom(netip.AddrFrom16(bytes), 4*len(labels)), true, nil
+
+	default:
+		return netip.Prefix{}, false, nil
+	}
+}
+
+// reverseName returns the labels of the reverse DNS name of an address, least significant first
+func reverseName(address netip.Addr) []string {
+	raw := address.AsSlice()
+	var labels []string
+	for i := len(raw) - 1; i >= 0; i-- {
+		if address.Is4() {
+			labels = append(labels, strconv.Itoa(int(raw[i])))
+		} else {
+			labels = append(labels, strconv.FormatUint(uint64(raw[i]&0x0f), 16), strconv.FormatUint(uint64(raw[i]>>4), 16))
+		}
+	}
+	return labels
+}
+
+// Serial returns a SOA serial derived from the latest change to the hosts or removal of a host,
+// at removedAt: its Unix time, or 1 without either.
+func Serial(hosts []Host, removedAt time.Time) uint32 {
+	latest := removedAt
+	for _, h := range hosts {
+		if h.UpdatedAt.After(latest) {
+			latest = h.UpdatedAt
+		}
+	}
+	if latest.Unix() <= 0 {
+		return 1
+	}
+	return uint32(latest.Unix())
+}
+
+// record is a resource record of a zone file, with its owner relative to the zone
+type record struct {
+	owner   string
+	address netip.Addr
+	rrType  string
+	data    string
+}
+
+// WriteZone writes a BIND zone file with the SOA and NS records of the zone and the address or
+// pointer records of the primary interfaces of the hosts
+func WriteZone(w io.Writer, zone Zone, hosts []Host) error {
+	name, err := ParseZoneName(zone.Name)
+	if err != nil {
+		return err
+	}
+	network, reverse, _ := reverseZonePrefix(name)
+	domain := strings.ToLower(strings.TrimSuffix(zone.Domain, "."))
+	if reverse && domain == "" {
+		return fmt.Errorf("reverse zone %s requires a domain for its PTR records", name)
+	}
+
+	var records []record
+	for _, h := range hosts {
+		iface, ok := h.primary()
+		if !ok {
+			continue
+		}
+		hostname := strings.ToLower(h.Name)
+		for _, address := range []netip.Addr{iface.IPv4Address, iface.IPv6Address} {
+			switch {
+			case !address.IsValid():
+			case reverse && network.Contains(address):
+				labels := reverseName(address)
+				owner := strings.Join(labels[:len(labels)-network.Bits()/bitsPerLabel(address)], ".")
+				if owner == "" {
+					owner = "@"
+				}
+				records = append(records, record{owner: owner, address: address, rrType: "PTR", data: hostname + "." + domain + "."})
+			case !reverse && address.Is4():
+				records = append(records, record{owner: hostname, address: address, rrType: "A", data: address.String()})
+			case !reverse:
+				records = append(records, record{owner: hostname, address: address, rrType: "AAAA", data: address.String()})
+			}
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !reverse && records[i].owner != records[j].owner {
+			return records[i].owner < records[j].owner
+		}
+		if c := records[i].address.Compare(records[j].address); c != 0 {
+			return c < 0
+		}
+		return records[i].data < records[j].data
+	})
+
+	serial := zone.Serial
+	if serial == 0 {
+		serial = Serial(hosts, zone.RemovedAt)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "; %s\n", header)
+	fmt.Fprintf(bw, "$ORIGIN %s.\n", name)
+	fmt.Fprintf(bw, "$TTL %d\n", int64(zone.TTL/time.Second))
+	fmt.Fprintf(bw, "@\tIN\tSOA\t%s %s (\n", zone.NameServer, zone.Hostmaster)
+	fmt.Fprintf(bw, "\t\t\t%d\t; serial\n", serial)
+	fmt.Fprintf(bw, "\t\t\t%d\t; refresh\n", soaRefresh)
+	fmt.Fprintf(bw, "\t\t\t%d\t; retry\n", soaRetry)
+	fmt.Fprintf(bw, "\t\t\t%d\t; expire\n", soaExpire)
+	fmt.Fprintf(bw, "\t\t\t%d )\t; negative caching TTL\n", soaNegativeTTL)
+	fmt.Fprintf(bw, "@\tIN\tNS\t%s\n", zone.NameServer)
+	if len(records) > 0 {
+		fmt.Fprint(bw, "\n")
+	}
+	for _, r := range records {
+		fmt.Fprintf(bw, "%s\tIN\t%s\t%s\n", r.owner, r.rrType, r.data)
+	}
+	return bw.Flush()
+}
+
+// bitsPerLabel returns the number of address bits in a label of the reverse name of an address
+func bitsPerLabel(address netip.Addr) int {
+	if address.Is4() {
+		return 8
+	}
+	return 4
+}
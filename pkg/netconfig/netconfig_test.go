@@ -0,0 +1,287 @@
+package netconfig
+
+import (
+	"bytes"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+// testHosts are a dual-stack laptop with a Wi-Fi interface and two desktops that share a name
+func testHosts() []Host {
+	return []Host{
+		{
+			Name: "Laptop-01",
+			Interfaces: []Interface{
+				{MACAddress: "AA:BB:CC:DD:EE:01", IPv4Address: netip.MustParseAddr("192.168.1.20"), IPv6Address: netip.MustParseAddr("2001:db8::20"), Primary: true},
+				{MACAddress: "AA:BB:CC:DD:EE:02", IPv4Address: netip.MustParseAddr("192.168.1.21")},
+				{MACAddress: "AA:BB:CC:DD:EE:03"},
+			},
+			UpdatedAt: time.Unix(1700000000, 0),
+		},
+		{
+			Name:       "desktop",
+			Interfaces: []Interface{{MACAddress: "AA:BB:CC:DD:EE:10", IPv4Address: netip.MustParseAddr("192.168.1.10"), Primary: true}},
+			UpdatedAt:  time.Unix(1700000500, 0),
+		},
+		{
+			Name:       "DESKTOP",
+			Interfaces: []Interface{{MACAddress: "AA:BB:CC:DD:EE:11", IPv4Address: netip.MustParseAddr("192.168.2.10"), Primary: true}},
+			UpdatedAt:  time.Unix(1600000000, 0),
+		},
+	}
+}
+
+// render writes hosts with fn and returns the output
+func render(t *testing.T, fn func(w *bytes.Buffer) error) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := fn(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestWriteDHCP(t *testing.T) {
+	tests := []struct {
+		format   DHCPFormat
+		expected string
+	}{
+		{
+			format: DHCPFormatISC,
+			expected: `# Generated from the computer inventory. Do not edit.
+
+host desktop-aabbccddee10 {
+	hardware ethernet aa:bb:cc:dd:ee:10;
+	fixed-address 192.168.1.10;
+	option host-name "desktop";
+}
+
+host laptop-01 {
+	hardware ethernet aa:bb:cc:dd:ee:01;
+	fixed-address 192.168.1.20;
+	option host-name "laptop-01";
+}
+
+host laptop-01-aabbccddee02 {
+	hardware ethernet aa:bb:cc:dd:ee:02;
+	fixed-address 192.168.1.21;
+}
+
+host desktop-aabbccddee11 {
+	hardware ethernet aa:bb:cc:dd:ee:11;
+	fixed-address 192.168.2.10;
+	option host-name "desktop";
+}
+`,
+		},
+		{
+			format: DHCPFormatDnsmasq,
+			expected: `# Generated from the computer inventory. Do not edit.
+dhcp-host=aa:bb:cc:dd:ee:10,192.168.1.10,desktop
+dhcp-host=aa:bb:cc:dd:ee:01,192.168.1.20,laptop-01
+dhcp-host=aa:bb:cc:dd:ee:02,192.168.1.21
+dhcp-host=aa:bb:cc:dd:ee:11,192.168.2.10,desktop
+`,
+		},
+		{
+			format: DHCPFormatKea,
+			expected: `{
+  "reservations": [
+    {
+      "hw-address": "aa:bb:cc:dd:ee:10",
+      "ip-address": "192.168.1.10",
+      "hostname": "desktop"
+    },
+    {
+      "hw-address": "aa:bb:cc:dd:ee:01",
+      "ip-address": "192.168.1.20",
+      "hostname": "laptop-01"
+    },
+    {
+      "hw-address": "aa:bb:cc:dd:ee:02",
+      "ip-address": "192.168.1.21"
+    },
+    {
+      "hw-address": "aa:bb:cc:dd:ee:11",
+      "ip-address": "192.168.2.10",
+      "hostname": "desktop"
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			output := render(t, func(w *bytes.Buffer) error { return WriteDHCP(w, tt.format, testHosts()) })
+			if output != tt.expected {
+				t.Errorf("Unexpected output:\n%s\nExpected:\n%s", output, tt.expected)
+			}
+
+			// The order of the hosts does not matter
+			hosts := testHosts()
+			hosts[0], hosts[2] = hosts[2], hosts[0]
+			if reordered := render(t, func(w *bytes.Buffer) error { return WriteDHCP(w, tt.format, hosts) }); reordered != output {
+				t.Errorf("Output depends on the order of the hosts:\n%s", reordered)
+			}
+		})
+	}
+}
+
+func TestWriteDHCP_Empty(t *testing.T) {
+	output := render(t, func(w *bytes.Buffer) error { return WriteDHCP(w, DHCPFormatKea, nil) })
+	if output != "{\n  \"reservations\": []\n}\n" {
+		t.Errorf("Unexpected output: %s", output)
+	}
+}
+
+func TestWriteDHCP_UnknownFormat(t *testing.T) {
+	err := WriteDHCP(&bytes.Buffer{}, DHCPFormat("bootp"), nil)
+	if !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestWriteZone_Forward(t *testing.T) {
+	zone := Zone{Name: "Example.COM.", NameServer: "ns1", Hostmaster: "hostmaster", TTL: time.Hour}
+	output := render(t, func(w *bytes.Buffer) error { return WriteZone(w, zone, testHosts()) })
+
+	expected := `; Generated from the computer inventory. Do not edit.
+$ORIGIN example.com.
+$TTL 3600
+@	IN	SOA	ns1 hostmaster (
+			1700000500	; serial
+			3600	; refresh
+			900	; retry
+			1209600	; expire
+			300 )	; negative caching TTL
+@	IN	NS	ns1
+
+desktop	IN	A	192.168.1.10
+desktop	IN	A	192.168.2.10
+laptop-01	IN	A	192.168.1.20
+laptop-01	IN	AAAA	2001:db8::20
+`
+	if output != expected {
+		t.Errorf("Unexpected output:\n%s\nExpected:\n%s", output, expected)
+	}
+}
+
+func TestWriteZone_Reverse(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{
+			name:     "1.168.192.in-addr.arpa",
+			expected: "10\tIN\tPTR\tdesktop.example.com.\n20\tIN\tPTR\tlaptop-01.example.com.\n",
+		},
+		{
+			name:     "168.192.in-addr.arpa",
+			expected: "10.1\tIN\tPTR\tdesktop.example.com.\n20.1\tIN\tPTR\tlaptop-01.example.com.\n10.2\tIN\tPTR\tdesktop.example.com.\n",
+		},
+		{
+			name:     "8.b.d.0.1.0.0.2.ip6.arpa",
+			expected: "0.2.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0\tIN\tPTR\tlaptop-01.example.com.\n",
+		},
+		{
+			name:     "20.1.168.192.in-addr.arpa",
+			expected: "@\tIN\tPTR\tlaptop-01.example.com.\n",
+		},
+		{
+			name:     "10.in-addr.arpa",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone := Zone{Name: tt.name, Domain: "example.com", NameServer: "ns1.example.com.", Hostmaster: "hostmaster.example.com.", TTL: time.Hour, Serial: 7}
+			output := render(t, func(w *bytes.Buffer) error { return WriteZone(w, zone, testHosts()) })
+
+			prefix := "; Generated from the computer inventory. Do not edit.\n$ORIGIN " + tt.name + ".\n$TTL 3600\n" +
+				"@\tIN\tSOA\tns1.example.com. hostmaster.example.com. (\n\t\t\t7\t; serial\n"
+			if len(output) < len(prefix) || output[:len(prefix)] != prefix {
+				t.Fatalf("Unexpected header:\n%s", output)
+			}
+			suffix := "@\tIN\tNS\tns1.example.com.\n"
+			if tt.expected != "" {
+				suffix += "\n" + tt.expected
+			}
+			if len(output) < len(suffix) || output[len(output)-len(suffix):] != suffix {
+				t.Errorf("Unexpected records:\n%s\nExpected:\n%s", output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteZone_ReverseRequiresDomain(t *testing.T) {
+	err := WriteZone(&bytes.Buffer{}, Zone{Name: "1.168.192.in-addr.arpa", NameServer: "ns1", Hostmaster: "hostmaster", TTL: time.Hour}, testHosts())
+	if err == nil {
+		t.Error("Expected an error for a reverse zone without a domain")
+	}
+}
+
+func TestParseZoneName(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    string
+		reverse     bool
+		expectError bool
+	}{
+		{name: "Example.com.", expected: "example.com"},
+		{name: "1.168.192.in-addr.arpa", expected: "1.168.192.in-addr.arpa", reverse: true},
+		{name: "8.b.d.0.1.0.0.2.ip6.arpa", expected: "8.b.d.0.1.0.0.2.ip6.arpa", reverse: true},
+		{name: "256.168.192.in-addr.arpa", expectError: true},
+		{name: "01.168.192.in-addr.arpa", expectError: true},
+		{name: "1.1.1.1.1.in-addr.arpa", expectError: true},
+		{name: "ab.8.b.d.0.1.0.0.2.ip6.arpa", expectError: true},
+		{name: "exa_mple.com", expectError: true},
+		{name: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := ParseZoneName(tt.name)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for zone %q, got %q", tt.name, name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if name != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, name)
+			}
+			if IsReverseZone(name) != tt.reverse {
+				t.Errorf("Expected reverse %v for %q", tt.reverse, name)
+			}
+		})
+	}
+}
+
+func TestSerial(t *testing.T) {
+	if serial := Serial(nil, time.Time{}); serial != 1 {
+		t.Errorf("Expected serial 1 without hosts, got %d", serial)
+	}
+	if serial := Serial(testHosts(), time.Time{}); serial != 1700000500 {
+		t.Errorf("Expected the latest change as serial, got %d", serial)
+	}
+	if serial := Serial(testHosts(), time.Unix(1700000400, 0)); serial != 1700000500 {
+		t.Errorf("Expected an earlier removal to keep the serial, got %d", serial)
+	}
+
+	// Removing a host advances the serial, even when no host is left
+	if serial := Serial(testHosts(), time.Unix(1700000900, 0)); serial != 1700000900 {
+		t.Errorf("Expected the removal as serial, got %d", serial)
+	}
+	if serial := Serial(nil, time.Unix(1700000900, 0)); serial != 1700000900 {
+		t.Errorf("Expected the removal as serial without hosts, got %d", serial)
+	}
+}
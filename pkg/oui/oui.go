@@ -0,0 +1,305 @@
+// Package oui identifies the organization a MAC address was assigned to from the IEEE
+// Registration Authority's public listings of MA-L (OUI), MA-M and MA-S assignments.
+package oui
+
+import (
+	"compress/gzip"
+	"embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// embeddedListings holds the listings published at https://standards-oui.ieee.org, compressed
+// with gzip. scripts/update-oui.sh downloads them again; deployments that need newer
+// assignments between releases load the listings with LoadFiles.
+//
+//go:embed ieee/*.csv.gz
+var embeddedListings embed.FS
+
+// assignmentLengths are the lengths in hex digits of MA-S, MA-M and MA-L assignments, the most
+// specific first
+var assignmentLengths = []int{9, 7, 6}
+
+// Registry maps MAC address prefixes to the organizations they are assigned to
+type Registry struct {
+	organizations map[string]string // Organization by assignment of 6, 7 or 9 uppercase hex digits
+}
+
+// Parse reads a registry in the CSV format of the IEEE listings, whose columns are the
+// registry, the assignment and the organization name. A header row is skipped.
+func Parse(r io.Reader) (*Registry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	registry := &Registry{organizations: make(map[string]string)}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return registry, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("oui: %w", err)
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("oui: line %d: expected at least 3 fields, got %d", line, len(record))
+		}
+
+		assignment := strings.ToUpper(strings.TrimSpace(record[1]))
+		if line == 1 && strings.EqualFold(assignment, "Assignment") {
+			continue
+		}
+		if !isAssignment(assignment) {
+			return nil, fmt.Errorf("oui: line %d: invalid assignment %q", line, record[1])
+		}
+		registry.organizations[assignment] = strings.TrimSpace(record[2])
+	}
+}
+
+// Embedded returns the registry compiled into the binary
+var Embedded = sync.OnceValue(func() *Registry {
+	registry, err := parseListings(embeddedListings, "ieee")
+	if err != nil {
+		panic(err)
+	}
+	return registry
+})
+
+// parseListings reads a registry from every gzip-compressed listing in a directory
+func parseListings(fsys fs.FS, dir string) (*Registry, error) {
+	names, err := fs.Glob(fsys, dir+"/*.csv.gz")
+	if err != nil {
+		return nil, fmt.Errorf("oui: %w", err)
+	}
+
+	registry := &Registry{organizations: make(map[string]string)}
+	for _, name := range names {
+		loaded, err := parseCompressed(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("%w in %s", err, name)
+		}
+		registry.merge(loaded)
+	}
+	return registry, nil
+}
+
+// parseCompressed reads a registry from a gzip-compressed listing
+func parseCompressed(fsys fs.FS, name string) (*Registry, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("oui: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("oui: %w", err)
+	}
+	defer reader.Close()
+
+	return Parse(reader)
+}
+
+// merge adds the assignments of another registry, replacing the organizations of assignments
+// both hold
+func (r *Registry) merge(other *Registry) {
+	for assignment, organization := range other.organizations {
+		r.organizations[assignment] = organization
+	}
+}
+
+// Len returns the number of assignments in the registry
+func (r *Registry) Len() int {
+	return len(r.organizations)
+}
+
+// Lookup returns the organization the most specific assignment containing a MAC address
+// belongs to, or an empty string if the address is unassigned or invalid
+func (r *Registry) Lookup(mac string) string {
+	digits := strings.ToUpper(strings.NewReplacer(":", "", "-", "", ".", "").Replace(mac))
+	if len(digits) != 12 || !isHex(digits) {
+		return ""
+	}
+	for _, length := range assignmentLengths {
+		if organization, ok := r.organizations[digits[:length]]; ok {
+			return organization
+		}
+	}
+	return ""
+}
+
+// Prefixes returns the normalized MAC address prefixes, such as "00:1B:44" or "70:B3:D5:1",
+// assigned to organizations whose name contains vendor, ignoring case. The result is empty but
+// not nil if no organization matches.
+func (r *Registry) Prefixes(vendor string) []string {
+	vendor = strings.ToLower(strings.TrimSpace(vendor))
+	prefixes := []string{}
+	if vendor == "" {
+		return prefixes
+	}
+	for assignment, organization := range r.organizations {
+		if strings.Contains(strings.ToLower(organization), vendor) {
+			prefixes = append(prefixes, macPrefix(assignment))
+		}
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// FileRegistry is a registry loaded from IEEE CSV files, such as oui.csv, mam.csv and
+// oui36.csv for the MA-L, MA-M and MA-S assignments. The files are checked for changes at most
+// once per refresh interval and loaded again when they were modified, so listings downloaded in
+// place are picked up without a restart.
+type FileRegistry struct {
+	paths           []string
+	refreshInterval time.Duration
+
+	// OnReloadError is called when modified files cannot be loaded; the previous registry stays in use
+	OnReloadError func(error)
+
+	mu        sync.Mutex
+	registry  *Registry
+	modTimes  []time.Time
+	checkedAt time.Time
+}
+
+// LoadFiles loads a registry from the given files, which are checked for changes every
+// refreshInterval. A refresh interval of zero never checks.
+func LoadFiles(refreshInterval time.Duration, paths ...string) (*FileRegistry, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("oui: no registry files")
+	}
+	f := &FileRegistry{paths: paths, refreshInterval: refreshInterval, checkedAt: time.Now()}
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Len returns the number of assignments in the registry
+func (f *FileRegistry) Len() int {
+	return f.current().Len()
+}
+
+// Lookup returns the organization a MAC address is assigned to; see Registry.Lookup
+func (f *FileRegistry) Lookup(mac string) string {
+	return f.current().Lookup(mac)
+}
+
+// Prefixes returns the MAC address prefixes assigned to a vendor; see Registry.Prefixes
+func (f *FileRegistry) Prefixes(vendor string) []string {
+	return f.current().Prefixes(vendor)
+}
+
+// current returns the registry, loading the files again first if they are due to be checked
+// and were modified
+func (f *FileRegistry) current() *Registry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if f.refreshInterval > 0 && now.Sub(f.checkedAt) >= f.refreshInterval {
+		f.checkedAt = now
+		if err := f.reload(); err != nil && f.OnReloadError != nil {
+			f.OnReloadError(err)
+		}
+	}
+	return f.registry
+}
+
+// reload loads the files unless none was modified since they were last loaded. The caller holds
+// the lock or has not shared the registry yet.
+func (f *FileRegistry) reload() error {
+	modTimes := make([]time.Time, len(f.paths))
+	for i, path := range f.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("oui: %w", err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	if f.registry != nil && sameTimes(modTimes, f.modTimes) {
+		return nil
+	}
+
+	registry := &Registry{organizations: make(map[string]string)}
+	for _, path := range f.paths {
+		loaded, err := parseFile(path)
+		if err != nil {
+			return err
+		}
+		registry.merge(loaded)
+	}
+
+	f.registry = registry
+	f.modTimes = modTimes
+	return nil
+}
+
+// parseFile reads a registry from a file
+func parseFile(path string) (*Registry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("oui: %w", err)
+	}
+	defer file.Close()
+
+	registry, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s", err, path)
+	}
+	return registry, nil
+}
+
+// sameTimes reports whether two lists hold the same instants
+func sameTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAssignment reports whether value is an MA-L, MA-M or MA-S assignment in hex digits
+func isAssignment(value string) bool {
+	for _, length := range assignmentLengths {
+		if len(value) == length {
+			return isHex(value)
+		}
+	}
+	return false
+}
+
+// isHex reports whether value consists of uppercase hex digits
+func isHex(value string) bool {
+	for _, c := range value {
+		if (c < '0' || c > '9') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// macPrefix formats an assignment as a normalized MAC address prefix, e.g. "001B44" as "00:1B:44"
+func macPrefix(assignment string) string {
+	var b strings.Builder
+	for i := 0; i < len(assignment); i += 2 {
+		if i > 0 {
+			b.WriteByte(':')
+		}
+		b.WriteString(assignment[i:min(i+2, len(assignment))])
+	}
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package oui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// testRegistry is an excerpt of the IEEE listings with an MA-L block whose MA-M and MA-S
+// assignments belong to other organizations
+const testRegistry = "\ufeffRegistry,Assignment,Organization Name,Organization Address\n" +
+	"MA-L,70B3D5,IEEE Registration Authority,\"445 Hoes Lane Piscataway NJ US 08554 \"\n" +
+	"MA-M,70B3D51,Example Sensors Ltd,\"1 Example Road Springfield US\"\n" +
+	"MA-S,70B3D5F2A,Example Instruments GmbH ,Musterstrasse 1 Berlin DE\n" +
+	"MA-L,0050C2,\"Example Systems, Inc.\",\n"
+
+func TestParse(t *testing.T) {
+	registry, err := Parse(strings.NewReader(testRegistry))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if registry.Len() != 4 {
+		t.Errorf("Expected 4 assignments, got %d", registry.Len())
+	}
+
+	tests := []struct {
+		mac      string
+		expected string
+	}{
+		{"70:B3:D5:1A:BC:DE", "Example Sensors Ltd"},
+		{"70:b3:d5:f2:a0:01", "Example Instruments GmbH"},
+		{"70-B3-D5-F2-B0-01", "IEEE Registration Authority"},
+		{"0050.c211.2233", "Example Systems, Inc."},
+		{"00:1B:44:11:3A:B7", ""},
+		{"70:B3:D5", ""},
+		{"not a mac", ""},
+	}
+	for _, tt := range tests {
+		if vendor := registry.Lookup(tt.mac); vendor != tt.expected {
+			t.Errorf("Lookup(%q) = %q, expected %q", tt.mac, vendor, tt.expected)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, data := range []string{
+		"MA-L,70B3D,Too Short,\n",
+		"MA-L,70B3DX,Not Hex,\n",
+		"MA-L,70B3D5\n",
+	} {
+		if _, err := Parse(strings.NewReader(data)); err == nil {
+			t.Errorf("Expected an error for %q", data)
+		}
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+	registry, err := Parse(strings.NewReader(testRegistry))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		vendor   string
+		expected []string
+	}{
+		{"example", []string{"00:50:C2", "70:B3:D5:1", "70:B3:D5:F2:A"}},
+		{" INSTRUMENTS ", []string{"70:B3:D5:F2:A"}},
+		{"unknown", []string{}},
+		{"", []string{}},
+	}
+	for _, tt := range tests {
+		if prefixes := registry.Prefixes(tt.vendor); !reflect.DeepEqual(prefixes, tt.expected) {
+			t.Errorf("Prefixes(%q) = %v, expected %v", tt.vendor, prefixes, tt.expected)
+		}
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	registry := Embedded()
+	if registry.Len() < 30000 {
+		t.Fatalf("Expected the embedded registry to hold the complete listings, got %d assignments", registry.Len())
+	}
+	if vendor := registry.Lookup("00:50:56:12:34:56"); vendor != "VMware, Inc." {
+		t.Errorf("Expected VMware, Inc., got %q", vendor)
+	}
+	if vendor := registry.Lookup("28:6F:B9:00:00:01"); vendor != "Nokia Shanghai Bell Co., Ltd." {
+		t.Errorf("Expected Nokia Shanghai Bell Co., Ltd., got %q", vendor)
+	}
+}
+
+// compress returns a listing compressed with gzip
+func compress(t *testing.T, listing string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(listing)); err != nil {
+		t.Fatalf("Failed to compress listing: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to compress listing: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseListings(t *testing.T) {
+	fsys := fstest.MapFS{
+		"ieee/oui.csv.gz":   {Data: compress(t, "Registry,Assignment,Organization Name,Organization Address\nMA-L,70B3D5,IEEE Registration Authority,\n")},
+		"ieee/mam.csv.gz":   {Data: compress(t, "Registry,Assignment,Organization Name,Organization Address\nMA-M,70B3D51,Example Sensors Ltd,\n")},
+		"ieee/oui36.csv.gz": {Data: compress(t, "Registry,Assignment,Organization Name,Organization Address\nMA-S,70B3D5F2A,Example Instruments GmbH,\n")},
+		"ieee/README":       {Data: []byte("Not a listing")},
+	}
+
+	registry, err := parseListings(fsys, "ieee")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if registry.Len() != 3 {
+		t.Errorf("Expected 3 assignments, got %d", registry.Len())
+	}
+	if vendor := registry.Lookup("70:B3:D5:F2:A0:01"); vendor != "Example Instruments GmbH" {
+		t.Errorf("Expected the MA-S assignment, got %q", vendor)
+	}
+	if vendor := registry.Lookup("70:B3:D5:1A:BC:DE"); vendor != "Example Sensors Ltd" {
+		t.Errorf("Expected the MA-M assignment, got %q", vendor)
+	}
+
+	// A listing that is not compressed is rejected
+	fsys["ieee/broken.csv.gz"] = &fstest.MapFile{Data: []byte(testRegistry)}
+	if _, err := parseListings(fsys, "ieee"); err == nil {
+		t.Error("Expected an error for an uncompressed listing")
+	}
+}
+
+func TestFileRegistry_Reload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "oui.csv")
+	if err := os.WriteFile(path, []byte(testRegistry), 0o600); err != nil {
+		t.Fatalf("Failed to write registry: %v", err)
+	}
+
+	registry, err := LoadFiles(time.Nanosecond, path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var reloadErr error
+	registry.OnReloadError = func(err error) { reloadErr = err }
+	if vendor := registry.Lookup("00:50:C2:00:00:01"); vendor != "Example Systems, Inc." {
+		t.Errorf("Expected Example Systems, Inc., got %q", vendor)
+	}
+
+	// A modified file is loaded again
+	if err := os.WriteFile(path, []byte("MA-L,0050C2,Renamed Systems,\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write registry: %v", err)
+	}
+	modified := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatalf("Failed to touch registry: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	if vendor := registry.Lookup("00:50:C2:00:00:01"); vendor != "Renamed Systems" {
+		t.Errorf("Expected the reloaded vendor, got %q", vendor)
+	}
+
+	// A broken file keeps the previous registry in use
+	if err := os.WriteFile(path, []byte("MA-L,broken,Broken,\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write registry: %v", err)
+	}
+	modified = modified.Add(time.Minute)
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatalf("Failed to touch registry: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	if vendor := registry.Lookup("00:50:C2:00:00:01"); vendor != "Renamed Systems" {
+		t.Errorf("Expected the previous vendor to be kept, got %q", vendor)
+	}
+	if reloadErr == nil {
+		t.Error("Expected the reload error to be reported")
+	}
+}
+
+func TestLoadFiles_Missing(t *testing.T) {
+	if _, err := LoadFiles(time.Hour, filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
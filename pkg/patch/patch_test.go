@@ -0,0 +1,115 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// assertJSONEqual compares two JSON documents structurally
+func assertJSONEqual(t *testing.T, expected string, actual []byte) {
+	t.Helper()
+
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatalf("Invalid expected JSON: %v", err)
+	}
+	if err := json.Unmarshal(actual, &got); err != nil {
+		t.Fatalf("Invalid actual JSON: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected %s, got %s", expected, actual)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// Test cases from RFC 7396 appendix A
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{"Replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"Remove member with null", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"Remove one of many", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"Replace array", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"Nested objects", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"Non-object patch replaces document", `{"a":"foo"}`, `["c"]`, `["c"]`},
+		{"Empty patch leaves document unchanged", `{"a":"b"}`, `{}`, `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MergePatch([]byte(tt.document), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			assertJSONEqual(t, tt.expected, result)
+		})
+	}
+}
+
+func TestMergePatch_InvalidPatch(t *testing.T) {
+	_, err := MergePatch([]byte(`{}`), []byte(`{"a":`))
+	if !errors.Is(err, ErrInvalidPatch) {
+		t.Errorf("Expected ErrInvalidPatch, got %v", err)
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{"Add member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`},
+		{"Insert array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"Append array element", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"qux"}]`, `{"foo":["bar","qux"]}`},
+		{"Remove member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"Remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"Replace value with null", `{"baz":"qux"}`, `[{"op":"replace","path":"/baz","value":null}]`, `{"baz":null}`},
+		{"Move value", `{"foo":{"bar":"baz"},"qux":{}}`, `[{"op":"move","from":"/foo/bar","path":"/qux/thud"}]`, `{"foo":{},"qux":{"thud":"baz"}}`},
+		{"Copy value", `{"foo":"bar"}`, `[{"op":"copy","from":"/foo","path":"/baz"}]`, `{"foo":"bar","baz":"bar"}`},
+		{"Escaped pointer", `{"a/b":1,"m~n":2}`, `[{"op":"remove","path":"/a~1b"},{"op":"remove","path":"/m~0n"}]`, `{}`},
+		{"Passing test", `{"foo":"bar"}`, `[{"op":"test","path":"/foo","value":"bar"},{"op":"replace","path":"/foo","value":"baz"}]`, `{"foo":"baz"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := JSONPatch([]byte(tt.document), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			assertJSONEqual(t, tt.expected, result)
+		})
+	}
+}
+
+func TestJSONPatch_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		expected error
+	}{
+		{"Failed test", `[{"op":"test","path":"/foo","value":"baz"}]`, ErrTestFailed},
+		{"Replace missing member", `[{"op":"replace","path":"/missing","value":1}]`, ErrPathNotFound},
+		{"Remove missing member", `[{"op":"remove","path":"/missing"}]`, ErrPathNotFound},
+		{"Unknown operation", `[{"op":"merge","path":"/foo"}]`, ErrInvalidPatch},
+		{"Missing value", `[{"op":"add","path":"/foo"}]`, ErrInvalidPatch},
+		{"Relative path", `[{"op":"remove","path":"foo"}]`, ErrInvalidPatch},
+		{"Not an array", `{"op":"remove","path":"/foo"}`, ErrInvalidPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := JSONPatch([]byte(`{"foo":"bar"}`), []byte(tt.patch))
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
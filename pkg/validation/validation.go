@@ -3,7 +3,10 @@ package validation
 import (
 	"fmt"
 	"net"
+	"net/mail"
+	"net/netip"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"computer-management-api/internal/model"
@@ -16,7 +19,8 @@ const (
 
 // Employee validation constants
 const (
-	EmployeeAbbrevExactLength = 3 // Employee abbreviation must be exactly 3 characters
+	EmployeeAbbrevExactLength = 3   // Employee abbreviation must be exactly 3 characters
+	EmployeeFieldMaxLength    = 255 // Maximum length of employee name, email and department
 )
 
 // ValidateMAC validates a MAC address format and returns normalized version
@@ -36,12 +40,76 @@ func ValidateMAC(mac string) (string, error) {
 	return normalized, nil
 }
 
-// ValidateIP validates an IP address format (IPv4 or IPv6)
-func ValidateIP(ip string) error {
-	if net.ParseIP(ip) == nil {
-		return fmt.Errorf("invalid IP address format: %s", ip)
+// ValidateMACPrefix validates the leading octets of a MAC address and returns the normalized prefix
+func ValidateMACPrefix(prefix string) (string, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(prefix, " ", ""), "-", ":"))
+
+	prefixRegex := regexp.MustCompile(`^[0-9A-F]{1,2}(:[0-9A-F]{2}){0,5}:?$`)
+	if len(normalized) > MACAddressLength || !prefixRegex.MatchString(normalized) {
+		return "", fmt.Errorf("invalid MAC address prefix: %s", prefix)
 	}
-	return nil
+
+	return normalized, nil
+}
+
+// MACAddressWarnings returns the reasons a normalized MAC address is unlikely to be the address
+// an adapter was manufactured with: multicast addresses are never assigned to adapters, and
+// locally administered ones are set in software, for example randomized for privacy or spoofed.
+func MACAddressWarnings(mac string) []string {
+	firstOctet, err := strconv.ParseUint(mac[:min(2, len(mac))], 16, 8)
+	if err != nil {
+		return nil
+	}
+
+	var warnings []string
+	if firstOctet&0x01 != 0 {
+		warnings = append(warnings, fmt.Sprintf("MAC address %s is a multicast address", mac))
+	}
+	if firstOctet&0x02 != 0 {
+		warnings = append(warnings, fmt.Sprintf("MAC address %s is locally administered and may be randomized or spoofed", mac))
+	}
+	return warnings
+}
+
+// ValidateSubnet parses a network in CIDR notation. A bare IP address is treated as a single-host network.
+func ValidateSubnet(subnet string) (*net.IPNet, error) {
+	if !strings.Contains(subnet, "/") {
+		ip := net.ParseIP(subnet)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid subnet: %s", subnet)
+		}
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet: %s", subnet)
+	}
+	return network, nil
+}
+
+// ValidateIP validates an IP address (IPv4 or IPv6) and returns its canonical form: IPv6
+// addresses compressed and in lowercase, IPv4-mapped IPv6 addresses as plain IPv4. Zones such
+// as "%eth0" only mean something on the host that uses them and are rejected.
+func ValidateIP(ip string) (string, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return "", fmt.Errorf("invalid IP address format: %s", ip)
+	}
+	if addr.Zone() != "" {
+		return "", fmt.Errorf("IP address cannot have a zone: %s", ip)
+	}
+
+	return addr.Unmap().String(), nil
+}
+
+// IsIPv4 reports whether a canonical IP address, as returned by ValidateIP, is an IPv4 address
+func IsIPv4(ip string) bool {
+	return !strings.Contains(ip, ":")
 }
 
 // ValidateEmployeeAbbreviation validates employee abbreviation
@@ -76,6 +144,32 @@ func ValidateComputerName(name string) error {
 	return nil
 }
 
+// dnsLabelRegex matches a DNS label as allowed in hostnames (RFC 1123): letters, digits and
+// hyphens, neither starting nor ending with a hyphen
+var dnsLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// ValidateHostname validates that a computer name can be used as a DNS hostname: a single label
+// of at most 63 letters, digits and hyphens that does not start or end with a hyphen
+func ValidateHostname(name string) error {
+	if !dnsLabelRegex.MatchString(name) {
+		return fmt.Errorf("computer name must be a DNS hostname of at most 63 letters, digits and hyphens, not starting or ending with a hyphen: %s", name)
+	}
+	return nil
+}
+
+// ValidateDomainName validates a DNS domain name without a trailing dot, such as example.com
+func ValidateDomainName(name string) error {
+	if name == "" || len(name) > 253 {
+		return fmt.Errorf("invalid domain name: %s", name)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if !dnsLabelRegex.MatchString(label) {
+			return fmt.Errorf("invalid domain name: %s", name)
+		}
+	}
+	return nil
+}
+
 // ValidateRequired checks if a string field is not empty
 func ValidateRequired(fieldName, value string) error {
 	if strings.TrimSpace(value) == "" {
@@ -84,9 +178,11 @@ func ValidateRequired(fieldName, value string) error {
 	return nil
 }
 
-// ValidateComputerInput validates all required fields for creating a new computer
+// ValidateComputerInput validates all required fields for creating a new computer. The fields
+// derived from the MAC address are cleared, as they are never taken from input.
 func ValidateComputerInput(computer *model.Computer) []string {
 	var errors []string
+	computer.Vendor, computer.MACWarnings = "", nil
 
 	// Validate computer name
 	if err := ValidateComputerName(computer.ComputerName); err != nil {
@@ -101,9 +197,12 @@ func ValidateComputerInput(computer *model.Computer) []string {
 		computer.MACAddress = normalizedMAC // Update with normalized version
 	}
 
-	// Validate IP address
-	if err := ValidateIP(computer.IPAddress); err != nil {
+	// Validate IP address and get canonical version
+	normalizedIP, err := ValidateIP(computer.IPAddress)
+	if err != nil {
 		errors = append(errors, err.Error())
+	} else {
+		computer.IPAddress = normalizedIP
 	}
 
 	// Validate employee abbreviation (optional field)
@@ -120,3 +219,250 @@ func ValidateComputerInputForUpdate(computer *model.Computer) []string {
 	// This can be modified later if update has different requirements
 	return ValidateComputerInput(computer)
 }
+
+// ValidateEmail validates an email address
+func ValidateEmail(email string) error {
+	if email == "" {
+		return nil // Optional field
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return fmt.Errorf("invalid email address format: %s", email)
+	}
+
+	return nil
+}
+
+// ValidateEmployeeInput validates all fields of an employee
+func ValidateEmployeeInput(employee *model.Employee) []string {
+	var errors []string
+
+	// Abbreviation is the employee's identifier and therefore required
+	if employee.Abbreviation == "" {
+		errors = append(errors, "employee abbreviation is required")
+	} else if err := ValidateEmployeeAbbreviation(employee.Abbreviation); err != nil {
+		errors = append(errors, err.Error())
+	}
+
+	if err := ValidateRequired("employee name", employee.Name); err != nil {
+		errors = append(errors, err.Error())
+	}
+
+	if err := ValidateEmail(employee.Email); err != nil {
+		errors = append(errors, err.Error())
+	}
+
+	if len(employee.Name) > EmployeeFieldMaxLength || len(employee.Email) > EmployeeFieldMaxLength || len(employee.Department) > EmployeeFieldMaxLength {
+		errors = append(errors, fmt.Sprintf("employee name, email and department cannot exceed %d characters", EmployeeFieldMaxLength))
+	}
+
+	return errors
+}
+
+// ValidateQuotaPolicyInput validates a quota policy and defaults its mode to warn
+func ValidateQuotaPolicyInput(policy *model.QuotaPolicy) []string {
+	var errors []string
+
+	if policy.Mode == "" {
+		policy.Mode = model.QuotaPolicyModeWarn
+	}
+
+	switch policy.Scope {
+	case model.QuotaPolicyScopeGlobal:
+		if policy.Target != "" {
+			errors = append(errors, "global quota policy cannot have a target")
+		}
+	case model.QuotaPolicyScopeDepartment:
+		if err := ValidateRequired("department", policy.Target); err != nil {
+			errors = append(errors, err.Error())
+		} else if len(policy.Target) > EmployeeFieldMaxLength {
+			errors = append(errors, fmt.Sprintf("department cannot exceed %d characters", EmployeeFieldMaxLength))
+		}
+	case model.QuotaPolicyScopeEmployee:
+		if policy.Target == "" {
+			errors = append(errors, "employee abbreviation is required")
+		} else if err := ValidateEmployeeAbbreviation(policy.Target); err != nil {
+			errors = append(errors, err.Error())
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("scope must be one of %s, %s or %s",
+			model.QuotaPolicyScopeGlobal, model.QuotaPolicyScopeDepartment, model.QuotaPolicyScopeEmployee))
+	}
+
+	if policy.MaxComputers < 0 {
+		errors = append(errors, "max computers cannot be negative")
+	}
+
+	if policy.Mode != model.QuotaPolicyModeWarn && policy.Mode != model.QuotaPolicyModeEnforce {
+		errors = append(errors, fmt.Sprintf("mode must be either %s or %s", model.QuotaPolicyModeWarn, model.QuotaPolicyModeEnforce))
+	}
+
+	return errors
+}
+
+// ValidateNetworkInterfaceInput validates a network interface, normalizes its MAC and IP addresses
+// and defaults its type to ethernet. Both IP addresses are optional but must be of their family.
+// The fields derived from the MAC address are cleared, as they are never taken from input.
+func ValidateNetworkInterfaceInput(iface *model.NetworkInterface) []string {
+	var errors []string
+	iface.Vendor, iface.MACWarnings = "", nil
+
+	normalizedMAC, err := ValidateMAC(iface.MACAddress)
+	if err != nil {
+		errors = append(errors, err.Error())
+	} else {
+		iface.MACAddress = normalizedMAC
+	}
+
+	if iface.Type == "" {
+		iface.Type = model.NetworkInterfaceTypeEthernet
+	}
+	switch iface.Type {
+	case model.NetworkInterfaceTypeEthernet, model.NetworkInterfaceTypeWiFi, model.NetworkInterfaceTypeDock, model.NetworkInterfaceTypeOther:
+	default:
+		errors = append(errors, fmt.Sprintf("type must be one of %s, %s, %s or %s", model.NetworkInterfaceTypeEthernet,
+			model.NetworkInterfaceTypeWiFi, model.NetworkInterfaceTypeDock, model.NetworkInterfaceTypeOther))
+	}
+
+	if iface.IPv4Address != "" {
+		if ip, err := ValidateIP(iface.IPv4Address); err != nil || !IsIPv4(ip) {
+			errors = append(errors, fmt.Sprintf("invalid IPv4 address: %s", iface.IPv4Address))
+		} else {
+			iface.IPv4Address = ip
+		}
+	}
+	if iface.IPv6Address != "" {
+		if ip, err := ValidateIP(iface.IPv6Address); err != nil || IsIPv4(ip) {
+			errors = append(errors, fmt.Sprintf("invalid IPv6 address: %s", iface.IPv6Address))
+		} else {
+			iface.IPv6Address = ip
+		}
+	}
+
+	return errors
+}
+
+// Subnet validation constants
+const (
+	VLANMin                 = 1
+	VLANMax                 = 4094
+	SubnetDescriptionMaxLen = 255
+)
+
+// ValidateSubnetInput validates a subnet and normalizes its CIDR, gateway and broadcast address.
+// The CIDR must be a network address, without host bits set, and the gateway a host address
+// within it. A broadcast address can only be set for IPv4 subnets, but may lie outside them.
+func ValidateSubnetInput(subnet *model.Subnet) []string {
+	var errors []string
+
+	network, err := netip.ParsePrefix(strings.TrimSpace(subnet.CIDR))
+	switch {
+	case err != nil || network.Addr().Is4In6():
+		errors = append(errors, fmt.Sprintf("invalid CIDR: %s", subnet.CIDR))
+	case network != network.Masked():
+		errors = append(errors, fmt.Sprintf("CIDR %s has host bits set; the network is %s", subnet.CIDR, network.Masked()))
+	default:
+		subnet.CIDR = network.String()
+	}
+
+	if subnet.Gateway != "" {
+		gateway, err := ValidateIP(subnet.Gateway)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("invalid gateway: %s", subnet.Gateway))
+		} else if len(errors) == 0 {
+			first, last := HostRange(network)
+			if addr := netip.MustParseAddr(gateway); addr.Less(first) || last.Less(addr) || addr.BitLen() != first.BitLen() {
+				errors = append(errors, fmt.Sprintf("gateway %s is not a host address of %s", gateway, subnet.CIDR))
+			}
+			subnet.Gateway = gateway
+		}
+	}
+
+	if subnet.BroadcastAddress != "" {
+		broadcast, err := ValidateIP(subnet.BroadcastAddress)
+		switch {
+		case err != nil:
+			errors = append(errors, fmt.Sprintf("invalid broadcast address: %s", subnet.BroadcastAddress))
+		case !netip.MustParseAddr(broadcast).Is4():
+			errors = append(errors, fmt.Sprintf("broadcast address %s is not an IPv4 address", broadcast))
+		case len(errors) == 0 && !network.Addr().Is4():
+			errors = append(errors, fmt.Sprintf("broadcast address requires an IPv4 subnet, not %s", subnet.CIDR))
+		default:
+			subnet.BroadcastAddress = broadcast
+		}
+	}
+
+	if subnet.VLAN != nil && (*subnet.VLAN < VLANMin || *subnet.VLAN > VLANMax) {
+		errors = append(errors, fmt.Sprintf("VLAN must be between %d and %d", VLANMin, VLANMax))
+	}
+
+	if len(subnet.Description) > SubnetDescriptionMaxLen {
+		errors = append(errors, fmt.Sprintf("description cannot exceed %d characters", SubnetDescriptionMaxLen))
+	}
+
+	return errors
+}
+
+// HostRange returns the first and last address of a network that can be assigned to a host:
+// all but the network and broadcast address of IPv4 networks larger than a /31, and all but
+// the Subnet-Router anycast address of IPv6 networks larger than a /127.
+func HostRange(network netip.Prefix) (netip.Addr, netip.Addr) {
+	first := network.Masked().Addr()
+	last := LastAddress(network)
+
+	if first.BitLen()-network.Bits() > 1 {
+		first = first.Next()
+		if first.Is4() {
+			last = last.Prev()
+		}
+	}
+	return first, last
+}
+
+// LastAddress returns the last address of a network, which is the directed broadcast address
+// of IPv4 networks
+func LastAddress(network netip.Prefix) netip.Addr {
+	bytes := network.Masked().Addr().AsSlice()
+	for i := network.Bits(); i < len(bytes)*8; i++ {
+		bytes[i/8] |= 0x80 >> (i % 8)
+	}
+	last, _ := netip.AddrFromSlice(bytes)
+	return last
+}
+
+// ValidateAPIKeyInput validates the name and scopes of a new API key and removes duplicate scopes
+func ValidateAPIKeyInput(key *model.APIKey) []string {
+	var errors []string
+
+	key.Name = strings.TrimSpace(key.Name)
+	if err := ValidateRequired("name", key.Name); err != nil {
+		errors = append(errors, err.Error())
+	} else if len(key.Name) > EmployeeFieldMaxLength {
+		errors = append(errors, fmt.Sprintf("name cannot exceed %d characters", EmployeeFieldMaxLength))
+	}
+
+	known := make(map[model.Scope]bool, len(model.AllScopes))
+	for _, scope := range model.AllScopes {
+		known[scope] = true
+	}
+
+	if len(key.Scopes) == 0 {
+		errors = append(errors, "at least one scope is required")
+	}
+	scopes := key.Scopes[:0]
+	seen := make(map[model.Scope]bool, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		if !known[scope] {
+			errors = append(errors, fmt.Sprintf("unknown scope %q", scope))
+			continue
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	key.Scopes = scopes
+
+	return errors
+}
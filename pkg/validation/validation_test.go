@@ -2,6 +2,8 @@ package validation
 
 import (
 	"computer-management-api/internal/model"
+	"net/netip"
+	"strings"
 	"testing"
 )
 
@@ -72,21 +74,137 @@ func TestValidateMAC(t *testing.T) {
 	}
 }
 
+func TestValidateMACPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		expectError bool
+		expected    string
+	}{
+		{name: "Single octet", prefix: "aa", expected: "AA"},
+		{name: "Partial octet", prefix: "a", expected: "A"},
+		{name: "OUI with hyphens", prefix: "00-1b-44", expected: "00:1B:44"},
+		{name: "Trailing separator", prefix: "00:1B:", expected: "00:1B:"},
+		{name: "Full address", prefix: "AA:BB:CC:DD:EE:FF", expected: "AA:BB:CC:DD:EE:FF"},
+		{name: "Wildcard characters", prefix: "AA%", expectError: true},
+		{name: "Invalid characters", prefix: "ZZ", expectError: true},
+		{name: "Too long", prefix: "AA:BB:CC:DD:EE:FF:00", expectError: true},
+		{name: "Empty", prefix: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateMACPrefix(tt.prefix)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for MAC prefix %s, but got none", tt.prefix)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Unexpected error for MAC prefix %s: %v", tt.prefix, err)
+				}
+				if result != tt.expected {
+					t.Errorf("Expected normalized MAC prefix %s, got %s", tt.expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestMACAddressWarnings(t *testing.T) {
+	tests := []struct {
+		mac      string
+		expected []string
+	}{
+		{"00:1B:44:11:3A:B7", nil},
+		{"01:00:5E:00:00:FB", []string{"multicast"}},
+		{"02:42:AC:11:00:02", []string{"locally administered"}},
+		{"FF:FF:FF:FF:FF:FF", []string{"multicast", "locally administered"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mac, func(t *testing.T) {
+			warnings := MACAddressWarnings(tt.mac)
+			if len(warnings) != len(tt.expected) {
+				t.Fatalf("Expected %d warnings, got %v", len(tt.expected), warnings)
+			}
+			for i, expected := range tt.expected {
+				if !strings.Contains(warnings[i], expected) {
+					t.Errorf("Expected warning about %s, got %q", expected, warnings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSubnet(t *testing.T) {
+	tests := []struct {
+		name        string
+		subnet      string
+		expectError bool
+		expected    string
+	}{
+		{name: "IPv4 network", subnet: "192.168.1.0/24", expected: "192.168.1.0/24"},
+		{name: "Host bits are masked", subnet: "192.168.1.77/24", expected: "192.168.1.0/24"},
+		{name: "Bare IPv4 address", subnet: "10.0.0.5", expected: "10.0.0.5/32"},
+		{name: "IPv6 network", subnet: "2001:db8::/32", expected: "2001:db8::/32"},
+		{name: "Invalid prefix length", subnet: "10.0.0.0/33", expectError: true},
+		{name: "Not a network", subnet: "not-a-subnet", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateSubnet(tt.subnet)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for subnet %s, but got none", tt.subnet)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Unexpected error for subnet %s: %v", tt.subnet, err)
+				}
+				if result.String() != tt.expected {
+					t.Errorf("Expected subnet %s, got %s", tt.expected, result)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateIP(t *testing.T) {
 	tests := []struct {
 		name        string
 		ip          string
 		expectError bool
+		expected    string
 	}{
 		{
-			name:        "Valid IPv4",
-			ip:          "192.168.1.1",
-			expectError: false,
+			name:     "Valid IPv4",
+			ip:       "192.168.1.1",
+			expected: "192.168.1.1",
 		},
 		{
-			name:        "Valid IPv6",
-			ip:          "2001:0db8:85a3:0000:0000:8a2e:0370:7334",
-			expectError: false,
+			name:     "Valid IPv6 is compressed",
+			ip:       "2001:0DB8:85a3:0000:0000:8a2e:0370:7334",
+			expected: "2001:db8:85a3::8a2e:370:7334",
+		},
+		{
+			name:     "IPv4-mapped IPv6 becomes IPv4",
+			ip:       "::ffff:10.0.0.1",
+			expected: "10.0.0.1",
+		},
+		{
+			name:        "Zone is rejected",
+			ip:          "fe80::1%eth0",
+			expectError: true,
+		},
+		{
+			name:        "CIDR is not an address",
+			ip:          "10.0.0.0/8",
+			expectError: true,
 		},
 		{
 			name:        "Invalid IP",
@@ -102,7 +220,7 @@ func TestValidateIP(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateIP(tt.ip)
+			result, err := ValidateIP(tt.ip)
 
 			if tt.expectError {
 				if err == nil {
@@ -112,6 +230,9 @@ func TestValidateIP(t *testing.T) {
 				if err != nil {
 					t.Errorf("Unexpected error for IP %s: %v", tt.ip, err)
 				}
+				if result != tt.expected {
+					t.Errorf("Expected IP %s, got %s", tt.expected, result)
+				}
 			}
 		})
 	}
@@ -217,6 +338,67 @@ func TestValidateComputerName(t *testing.T) {
 	}
 }
 
+func TestValidateHostname(t *testing.T) {
+	tests := []struct {
+		hostname    string
+		expectError bool
+	}{
+		{"TEST-001", false},
+		{"pc1", false},
+		{"a", false},
+		{strings.Repeat("a", 63), false},
+		{strings.Repeat("a", 64), true},
+		{"", true},
+		{"-pc", true},
+		{"pc-", true},
+		{"pc 01", true},
+		{"pc_01", true},
+		{"pc.example.com", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			err := ValidateHostname(tt.hostname)
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for hostname '%s', but got none", tt.hostname)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error for hostname '%s': %v", tt.hostname, err)
+			}
+		})
+	}
+}
+
+func TestValidateDomainName(t *testing.T) {
+	tests := []struct {
+		domain      string
+		expectError bool
+	}{
+		{"example.com", false},
+		{"office.example.com", false},
+		{"1.168.192.in-addr.arpa", false},
+		{"localdomain", false},
+		{"", true},
+		{"example..com", true},
+		{".example.com", true},
+		{"example.com.", true},
+		{"exa_mple.com", true},
+		{strings.Repeat("a.", 127) + "a", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			err := ValidateDomainName(tt.domain)
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for domain '%s', but got none", tt.domain)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error for domain '%s': %v", tt.domain, err)
+			}
+		})
+	}
+}
+
 func TestValidateComputerInput(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -274,3 +456,371 @@ func TestValidateComputerInput(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEmployeeInput(t *testing.T) {
+	tests := []struct {
+		name           string
+		employee       model.Employee
+		expectedErrors int
+	}{
+		{
+			name: "Valid employee",
+			employee: model.Employee{
+				Abbreviation: "JDO",
+				Name:         "John Doe",
+				Email:        "john.doe@example.com",
+				Department:   "IT",
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "Valid employee without email",
+			employee: model.Employee{
+				Abbreviation: "JDO",
+				Name:         "John Doe",
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "Missing abbreviation",
+			employee: model.Employee{
+				Name: "John Doe",
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "Invalid email",
+			employee: model.Employee{
+				Abbreviation: "JDO",
+				Name:         "John Doe",
+				Email:        "John Doe <john.doe@example.com>",
+			},
+			expectedErrors: 1,
+		},
+		{
+			name: "Multiple validation errors",
+			employee: model.Employee{
+				Abbreviation: "TOOLONG",
+				Email:        "not-an-email",
+			},
+			expectedErrors: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := ValidateEmployeeInput(&tt.employee)
+
+			if len(errors) != tt.expectedErrors {
+				t.Errorf("Expected %d errors, got %d: %v", tt.expectedErrors, len(errors), errors)
+			}
+		})
+	}
+}
+
+func TestValidateQuotaPolicyInput(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         model.QuotaPolicy
+		expectedErrors int
+	}{
+		{
+			name:           "Valid global policy",
+			policy:         model.QuotaPolicy{Scope: model.QuotaPolicyScopeGlobal, MaxComputers: 3},
+			expectedErrors: 0,
+		},
+		{
+			name:           "Valid department policy",
+			policy:         model.QuotaPolicy{Scope: model.QuotaPolicyScopeDepartment, Target: "Engineering", MaxComputers: 5, Mode: model.QuotaPolicyModeEnforce},
+			expectedErrors: 0,
+		},
+		{
+			name:           "Valid employee policy",
+			policy:         model.QuotaPolicy{Scope: model.QuotaPolicyScopeEmployee, Target: "JDO", MaxComputers: 0, Mode: model.QuotaPolicyModeEnforce},
+			expectedErrors: 0,
+		},
+		{
+			name:           "Global policy with target",
+			policy:         model.QuotaPolicy{Scope: model.QuotaPolicyScopeGlobal, Target: "Engineering", MaxComputers: 3},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Department policy without target",
+			policy:         model.QuotaPolicy{Scope: model.QuotaPolicyScopeDepartment, MaxComputers: 3},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Employee policy with invalid abbreviation",
+			policy:         model.QuotaPolicy{Scope: model.QuotaPolicyScopeEmployee, Target: "TOOLONG", MaxComputers: 3},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Multiple validation errors",
+			policy:         model.QuotaPolicy{Scope: "team", MaxComputers: -1, Mode: "block"},
+			expectedErrors: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := ValidateQuotaPolicyInput(&tt.policy)
+
+			if len(errors) != tt.expectedErrors {
+				t.Errorf("Expected %d errors, got %d: %v", tt.expectedErrors, len(errors), errors)
+			}
+			if tt.policy.Mode == "" {
+				t.Error("Expected mode to be defaulted")
+			}
+		})
+	}
+}
+
+func TestValidateNetworkInterfaceInput(t *testing.T) {
+	tests := []struct {
+		name           string
+		iface          model.NetworkInterface
+		expectedErrors int
+		expectedIPv4   string
+		expectedIPv6   string
+	}{
+		{
+			name:           "Valid interface without addresses",
+			iface:          model.NetworkInterface{MACAddress: "00:1b:44:11:3a:b7"},
+			expectedErrors: 0,
+		},
+		{
+			name:           "Valid interface with both addresses",
+			iface:          model.NetworkInterface{MACAddress: "00-1B-44-11-3A-B7", Type: model.NetworkInterfaceTypeWiFi, IPv4Address: "10.0.0.1", IPv6Address: "2001:DB8:0:0::1"},
+			expectedErrors: 0,
+			expectedIPv4:   "10.0.0.1",
+			expectedIPv6:   "2001:db8::1",
+		},
+		{
+			name:           "IPv6 address as IPv4",
+			iface:          model.NetworkInterface{MACAddress: "00:1B:44:11:3A:B7", IPv4Address: "2001:db8::1"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "IPv4 address as IPv6",
+			iface:          model.NetworkInterface{MACAddress: "00:1B:44:11:3A:B7", IPv6Address: "10.0.0.1"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "IPv4-mapped IPv6 address as IPv4",
+			iface:          model.NetworkInterface{MACAddress: "00:1B:44:11:3A:B7", IPv4Address: "::ffff:10.0.0.1"},
+			expectedErrors: 0,
+			expectedIPv4:   "10.0.0.1",
+		},
+		{
+			name:           "IPv6 address with zone",
+			iface:          model.NetworkInterface{MACAddress: "00:1B:44:11:3A:B7", IPv6Address: "fe80::1%en0"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Multiple validation errors",
+			iface:          model.NetworkInterface{MACAddress: "invalid", Type: "bluetooth", IPv4Address: "300.0.0.1"},
+			expectedErrors: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := ValidateNetworkInterfaceInput(&tt.iface)
+
+			if len(errors) != tt.expectedErrors {
+				t.Errorf("Expected %d errors, got %d: %v", tt.expectedErrors, len(errors), errors)
+			}
+			if tt.iface.Type == "" {
+				t.Error("Expected type to be defaulted")
+			}
+			if tt.expectedErrors == 0 && tt.iface.MACAddress != "00:1B:44:11:3A:B7" {
+				t.Errorf("Expected normalized MAC address, got %s", tt.iface.MACAddress)
+			}
+			if tt.expectedIPv4 != "" && tt.iface.IPv4Address != tt.expectedIPv4 {
+				t.Errorf("Expected IPv4 address %s, got %s", tt.expectedIPv4, tt.iface.IPv4Address)
+			}
+			if tt.expectedIPv6 != "" && tt.iface.IPv6Address != tt.expectedIPv6 {
+				t.Errorf("Expected IPv6 address %s, got %s", tt.expectedIPv6, tt.iface.IPv6Address)
+			}
+		})
+	}
+}
+
+func TestValidateSubnetInput(t *testing.T) {
+	vlan := func(v int) *int { return &v }
+
+	tests := []struct {
+		name            string
+		subnet          model.Subnet
+		expectedErrors  int
+		expectedCIDR    string
+		expectedGateway string
+	}{
+		{
+			name:           "Valid IPv4 subnet",
+			subnet:         model.Subnet{CIDR: "10.0.0.0/24", Gateway: "10.0.0.1", VLAN: vlan(100)},
+			expectedErrors: 0,
+			expectedCIDR:   "10.0.0.0/24",
+		},
+		{
+			name:            "IPv6 subnet is canonicalized",
+			subnet:          model.Subnet{CIDR: "2001:DB8:0:0::/64", Gateway: "2001:DB8::1"},
+			expectedErrors:  0,
+			expectedCIDR:    "2001:db8::/64",
+			expectedGateway: "2001:db8::1",
+		},
+		{
+			name:           "Host bits set",
+			subnet:         model.Subnet{CIDR: "10.0.0.5/24"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Not a CIDR",
+			subnet:         model.Subnet{CIDR: "10.0.0.0"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Gateway outside the subnet",
+			subnet:         model.Subnet{CIDR: "10.0.0.0/24", Gateway: "10.0.1.1"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Gateway is the broadcast address",
+			subnet:         model.Subnet{CIDR: "10.0.0.0/24", Gateway: "10.0.0.255"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Gateway of the other family",
+			subnet:         model.Subnet{CIDR: "10.0.0.0/24", Gateway: "2001:db8::1"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Broadcast address outside the subnet",
+			subnet:         model.Subnet{CIDR: "10.0.0.0/24", BroadcastAddress: " 255.255.255.255 "},
+			expectedErrors: 0,
+		},
+		{
+			name:           "IPv6 broadcast address",
+			subnet:         model.Subnet{CIDR: "10.0.0.0/24", BroadcastAddress: "2001:db8::ff"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Broadcast address of an IPv6 subnet",
+			subnet:         model.Subnet{CIDR: "2001:db8::/64", BroadcastAddress: "10.0.0.255"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "VLAN out of range",
+			subnet:         model.Subnet{CIDR: "10.0.0.0/24", VLAN: vlan(4095)},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Multiple validation errors",
+			subnet:         model.Subnet{CIDR: "invalid", Gateway: "invalid", VLAN: vlan(0)},
+			expectedErrors: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := ValidateSubnetInput(&tt.subnet)
+
+			if len(errors) != tt.expectedErrors {
+				t.Errorf("Expected %d errors, got %d: %v", tt.expectedErrors, len(errors), errors)
+			}
+			if tt.expectedCIDR != "" && tt.subnet.CIDR != tt.expectedCIDR {
+				t.Errorf("Expected CIDR %s, got %s", tt.expectedCIDR, tt.subnet.CIDR)
+			}
+			if tt.expectedGateway != "" && tt.subnet.Gateway != tt.expectedGateway {
+				t.Errorf("Expected gateway %s, got %s", tt.expectedGateway, tt.subnet.Gateway)
+			}
+			if tt.expectedErrors == 0 && strings.TrimSpace(tt.subnet.BroadcastAddress) != tt.subnet.BroadcastAddress {
+				t.Errorf("Expected a normalized broadcast address, got %q", tt.subnet.BroadcastAddress)
+			}
+		})
+	}
+}
+
+func TestHostRange(t *testing.T) {
+	tests := []struct {
+		network string
+		first   string
+		last    string
+	}{
+		{"10.0.0.0/24", "10.0.0.1", "10.0.0.254"},
+		{"10.0.0.0/31", "10.0.0.0", "10.0.0.1"},
+		{"10.0.0.7/32", "10.0.0.7", "10.0.0.7"},
+		{"2001:db8::/64", "2001:db8::1", "2001:db8::ffff:ffff:ffff:ffff"},
+		{"2001:db8::/127", "2001:db8::", "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.network, func(t *testing.T) {
+			first, last := HostRange(netip.MustParsePrefix(tt.network))
+			if first.String() != tt.first || last.String() != tt.last {
+				t.Errorf("Expected %s - %s, got %s - %s", tt.first, tt.last, first, last)
+			}
+		})
+	}
+}
+
+func TestLastAddress(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.0/24":   "10.0.0.255",
+		"10.0.0.64/26":  "10.0.0.127",
+		"10.0.0.7/32":   "10.0.0.7",
+		"2001:db8::/64": "2001:db8::ffff:ffff:ffff:ffff",
+	}
+
+	for network, expected := range tests {
+		if last := LastAddress(netip.MustParsePrefix(network)); last.String() != expected {
+			t.Errorf("LastAddress(%s) = %s, expected %s", network, last, expected)
+		}
+	}
+}
+
+func TestValidateAPIKeyInput(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            model.APIKey
+		expectedErrors int
+		expectedScopes int
+	}{
+		{
+			name:           "Valid key",
+			key:            model.APIKey{Name: "inventory-sync", Scopes: []model.Scope{model.ScopeComputersRead, model.ScopeComputersWrite}},
+			expectedErrors: 0,
+			expectedScopes: 2,
+		},
+		{
+			name:           "Duplicate scopes are removed",
+			key:            model.APIKey{Name: "reporting", Scopes: []model.Scope{model.ScopeComputersRead, model.ScopeComputersRead}},
+			expectedErrors: 0,
+			expectedScopes: 1,
+		},
+		{
+			name:           "Missing name and scopes",
+			key:            model.APIKey{Name: "  "},
+			expectedErrors: 2,
+		},
+		{
+			name:           "Unknown scope",
+			key:            model.APIKey{Name: "reporting", Scopes: []model.Scope{"computers:delete", model.ScopePoliciesRead}},
+			expectedErrors: 1,
+			expectedScopes: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := ValidateAPIKeyInput(&tt.key)
+
+			if len(errors) != tt.expectedErrors {
+				t.Errorf("Expected %d errors, got %d: %v", tt.expectedErrors, len(errors), errors)
+			}
+			if len(tt.key.Scopes) != tt.expectedScopes {
+				t.Errorf("Expected %d scopes, got %v", tt.expectedScopes, tt.key.Scopes)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package wol
+
+import "syscall"
+
+// enableBroadcast leaves the socket unchanged on platforms without the Unix socket options;
+// magic packets are still sent to unicast addresses and relays
+func enableBroadcast(network, address string, c syscall.RawConn) error {
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package wol
+
+import "syscall"
+
+// enableBroadcast allows a socket to send to broadcast addresses, which the kernel refuses
+// otherwise
+func enableBroadcast(network, address string, c syscall.RawConn) error {
+	var sockErr error
+	err := c.Control(func(fd uintptr) {
+		sockErr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_BROADCAST, 1)
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
@@ -0,0 +1,64 @@
+// Package wol wakes computers over the network by sending Wake-on-LAN magic packets, which a
+// network adapter in standby recognizes by its own MAC address.
+package wol
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// Ports magic packets are commonly sent to. Adapters accept them on any port; these are the
+// discard and echo services, which hosts that are awake ignore.
+const (
+	DefaultPort = 9
+	EchoPort    = 7
+)
+
+// MagicPacket returns the magic packet waking the adapter with a MAC address: six bytes of 0xFF
+// followed by sixteen repetitions of the address
+func MagicPacket(mac string) ([]byte, error) {
+	hardwareAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		return nil, fmt.Errorf("wol: %w", err)
+	}
+	if len(hardwareAddr) != 6 {
+		return nil, fmt.Errorf("wol: %s is not an EUI-48 MAC address", mac)
+	}
+
+	packet := make([]byte, 0, 6+16*6)
+	for i := 0; i < 6; i++ {
+		packet = append(packet, 0xFF)
+	}
+	for i := 0; i < 16; i++ {
+		packet = append(packet, hardwareAddr...)
+	}
+	return packet, nil
+}
+
+// Send sends the magic packet for a MAC address in a UDP datagram to addr, usually the
+// broadcast address of the network the adapter is attached to
+func Send(ctx context.Context, mac string, addr netip.AddrPort) error {
+	packet, err := MagicPacket(mac)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Control: enableBroadcast}
+	conn, err := dialer.DialContext(ctx, "udp4", addr.String())
+	if err != nil {
+		return fmt.Errorf("wol: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return fmt.Errorf("wol: %w", err)
+		}
+	}
+	if _, err := conn.Write(packet); err != nil {
+		return fmt.Errorf("wol: %w", err)
+	}
+	return nil
+}
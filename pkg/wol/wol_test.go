@@ -0,0 +1,65 @@
+package wol
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestMagicPacket(t *testing.T) {
+	packet, err := MagicPacket("00:1b:44:11:3a:b7")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(packet) != 102 {
+		t.Fatalf("Expected 102 bytes, got %d", len(packet))
+	}
+	if !bytes.Equal(packet[:6], []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}) {
+		t.Errorf("Unexpected synchronization stream % X", packet[:6])
+	}
+	mac := []byte{0x00, 0x1B, 0x44, 0x11, 0x3A, 0xB7}
+	for i := 6; i < len(packet); i += 6 {
+		if !bytes.Equal(packet[i:i+6], mac) {
+			t.Fatalf("Unexpected repetition at offset %d: % X", i, packet[i:i+6])
+		}
+	}
+}
+
+func TestMagicPacket_Invalid(t *testing.T) {
+	for _, mac := range []string{"not a mac", "00:1B:44:11:3A", "02:00:5e:10:00:00:00:01"} {
+		if _, err := MagicPacket(mac); err == nil {
+			t.Errorf("Expected an error for %q", mac)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	listener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	addr := listener.LocalAddr().(*net.UDPAddr).AddrPort()
+	if err := Send(ctx, "00-1B-44-11-3A-B7", netip.AddrPortFrom(addr.Addr(), addr.Port())); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := listener.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("Failed to set deadline: %v", err)
+	}
+	buf := make([]byte, 1024)
+	n, err := listener.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to receive the magic packet: %v", err)
+	}
+	expected, _ := MagicPacket("00:1B:44:11:3A:B7")
+	if !bytes.Equal(buf[:n], expected) {
+		t.Errorf("Unexpected packet % X", buf[:n])
+	}
+}
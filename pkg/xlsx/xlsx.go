@@ -0,0 +1,201 @@
+// Package xlsx streams single-sheet Office Open XML spreadsheets. Rows are written to the
+// underlying writer as they are added, so sheets of any size can be produced in constant memory.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// MediaType is the media type of XLSX workbooks
+const MediaType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// ErrClosed is returned when rows are written to a closed workbook
+var ErrClosed = errors.New("xlsx: workbook is closed")
+
+// Static parts of the workbook. Cell style 1 formats timestamps as dates.
+const (
+	contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+
+	relsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+
+	workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	stylesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+		`</styleSheet>`
+
+	sheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	sheetFooter = `</sheetData></worksheet>`
+)
+
+// excelEpoch is day zero of spreadsheet date serial numbers
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Writer streams the rows of a single worksheet. The static parts of the workbook are written
+// when it is created and the worksheet is completed by Close.
+type Writer struct {
+	zip    *zip.Writer
+	sheet  io.Writer
+	row    int
+	buf    bytes.Buffer
+	closed bool
+}
+
+// NewWriter starts a workbook with a single sheet of the given name on w
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	var name bytes.Buffer
+	if err := xml.EscapeText(&name, []byte(sheetName)); err != nil {
+		return nil, err
+	}
+
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", relsXML},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, name.String())},
+		{"xl/styles.xml", stylesXML},
+	}
+	for _, part := range parts {
+		pw, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(pw, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, sheetHeader); err != nil {
+		return nil, err
+	}
+
+	return &Writer{zip: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends a row to the sheet. Strings, integers, floats, booleans and times are
+// supported, times are written in UTC. nil and zero times leave the cell empty and other values
+// are formatted with %v.
+func (w *Writer) WriteRow(values ...interface{}) error {
+	if w.closed {
+		return ErrClosed
+	}
+
+	w.row++
+	w.buf.Reset()
+	fmt.Fprintf(&w.buf, `<row r="%d">`, w.row)
+	for i, value := range values {
+		ref := columnName(i) + strconv.Itoa(w.row)
+		if err := writeCell(&w.buf, ref, value); err != nil {
+			return err
+		}
+	}
+	w.buf.WriteString(`</row>`)
+
+	_, err := w.sheet.Write(w.buf.Bytes())
+	return err
+}
+
+// Flush flushes buffered data to the underlying writer
+func (w *Writer) Flush() error {
+	return w.zip.Flush()
+}
+
+// Close completes the worksheet and the workbook. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+
+	if _, err := io.WriteString(w.sheet, sheetFooter); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
+
+// writeCell writes a single cell with the given reference
+func writeCell(buf *bytes.Buffer, ref string, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+		fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		if err := xml.EscapeText(buf, []byte(v)); err != nil {
+			return err
+		}
+		buf.WriteString(`</t></is></c>`)
+	case int:
+		fmt.Fprintf(buf, `<c r="%s"><v>%d</v></c>`, ref, v)
+	case int64:
+		fmt.Fprintf(buf, `<c r="%s"><v>%d</v></c>`, ref, v)
+	case float64:
+		fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'g', -1, 64))
+	case bool:
+		b := 0
+		if v {
+			b = 1
+		}
+		fmt.Fprintf(buf, `<c r="%s" t="b"><v>%d</v></c>`, ref, b)
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		serial := v.UTC().Sub(excelEpoch).Hours() / 24
+		fmt.Fprintf(buf, `<c r="%s" s="1"><v>%s</v></c>`, ref, strconv.FormatFloat(serial, 'f', -1, 64))
+	default:
+		return writeCell(buf, ref, fmt.Sprintf("%v", v))
+	}
+	return nil
+}
+
+// columnName returns the letters of a zero-based column index, e.g. 0 is A and 27 is AB
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
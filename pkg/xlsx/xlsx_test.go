@@ -0,0 +1,124 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// sheet is the subset of a worksheet the tests inspect
+type sheet struct {
+	Rows []struct {
+		Ref   string `xml:"r,attr"`
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Style  string `xml:"s,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readSheet opens a workbook and decodes its worksheet, checking that every part is present
+func readSheet(t *testing.T, data []byte) sheet {
+	t.Helper()
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Invalid zip archive: %v", err)
+	}
+
+	files := make(map[string][]byte)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file.Name, err)
+		}
+		files[file.Name] = content
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		content, ok := files[name]
+		if !ok {
+			t.Fatalf("Missing part %s", name)
+		}
+		if err := xml.Unmarshal(content, new(interface{})); err != nil {
+			t.Errorf("Part %s is not well-formed XML: %v", name, err)
+		}
+	}
+	if !bytes.Contains(files["xl/workbook.xml"], []byte(`name="Q&amp;A"`)) {
+		t.Errorf("Expected the escaped sheet name, got %s", files["xl/workbook.xml"])
+	}
+
+	var s sheet
+	if err := xml.Unmarshal(files["xl/worksheets/sheet1.xml"], &s); err != nil {
+		t.Fatalf("Invalid worksheet: %v", err)
+	}
+	return s
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Q&A")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := w.WriteRow("name", "count", "created"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	created := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	if err := w.WriteRow("<PC & \"1\">", int64(3), created, nil, "", true, 1.5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := w.WriteRow("late"); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+
+	s := readSheet(t, buf.Bytes())
+	if len(s.Rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(s.Rows))
+	}
+
+	cells := s.Rows[1].Cells
+	if len(cells) != 5 {
+		t.Fatalf("Expected empty values to be skipped, got %d cells", len(cells))
+	}
+	if cells[0].Ref != "A2" || cells[0].Type != "inlineStr" || cells[0].Inline != `<PC & "1">` {
+		t.Errorf("Unexpected string cell %+v", cells[0])
+	}
+	if cells[1].Ref != "B2" || cells[1].Value != "3" {
+		t.Errorf("Unexpected number cell %+v", cells[1])
+	}
+	if cells[2].Ref != "C2" || cells[2].Style != "1" || cells[2].Value != "45293.5" {
+		t.Errorf("Unexpected date cell %+v", cells[2])
+	}
+	if cells[3].Ref != "F2" || cells[3].Type != "b" || cells[3].Value != "1" {
+		t.Errorf("Unexpected boolean cell %+v", cells[3])
+	}
+	if cells[4].Ref != "G2" || cells[4].Value != "1.5" {
+		t.Errorf("Unexpected float cell %+v", cells[4])
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for index, expected := range tests {
+		if got := columnName(index); got != expected {
+			t.Errorf("columnName(%d) = %s, expected %s", index, got, expected)
+		}
+	}
+}
@@ -37,6 +37,20 @@ if [ $attempt -eq $max_attempts ]; then
     exit 1
 fi
 
+# Apply the schema migrations
+echo "📜 Applying database migrations..."
+if ! DB_DRIVER=postgres \
+    DB_HOST="${TEST_DB_HOST:-127.0.0.1}" \
+    DB_PORT="${TEST_DB_PORT:-5452}" \
+    DB_USER="${TEST_DB_USER:-postgres}" \
+    DB_PASSWORD="${TEST_DB_PASSWORD:-postgres}" \
+    DB_NAME="${TEST_DB_NAME:-postgres}" \
+    NOTIFIER_URL=http://localhost:8081 \
+    go run ./cmd/api migrate up; then
+    echo "❌ Failed to apply database migrations"
+    exit 1
+fi
+
 # Check notification service
 echo "📧 Checking notification service..."
 max_attempts=15
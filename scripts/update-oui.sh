@@ -0,0 +1,25 @@
+#!/bin/bash
+
+# OUI Registry Update Script
+# This script downloads the IEEE MA-L, MA-M and MA-S listings and compresses them into the
+# registry embedded in pkg/oui
+
+set -e
+
+base_url="${OUI_BASE_URL:-https://standards-oui.ieee.org}"
+target="$(cd "$(dirname "$0")/.." && pwd)/pkg/oui/ieee"
+tmp="$(mktemp -d)"
+trap 'rm -rf "$tmp"' EXIT
+
+for listing in oui/oui.csv oui28/mam.csv oui36/oui36.csv; do
+    name="$(basename "$listing")"
+    echo "📥 Downloading $name..."
+    if ! curl -fsSL -o "$tmp/$name" "$base_url/$listing"; then
+        echo "❌ Failed to download $base_url/$listing"
+        exit 1
+    fi
+    tr -d '\r' < "$tmp/$name" | gzip -9n > "$tmp/$name.gz"
+done
+
+mv "$tmp"/*.csv.gz "$target/"
+echo "✅ Updated the embedded OUI registry in $target"